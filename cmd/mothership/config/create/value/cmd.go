@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kyma-incubator/reconciler/pkg/kv"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/spf13/cobra"
 )
@@ -51,12 +52,12 @@ func Run(o *Options, val string) error {
 		Value:      val,
 		Username:   "!TODO!", //FIXME
 	})
-	if err != nil {
+	if err != nil && !kv.IsTriggerError(err) {
 		return err
 	}
 
-	fmt.Printf("Value '%s' created (bucket: %s / key: %s - version %d)\n", value.Value, value.Bucket, value.Key, value.KeyVersion)
-	return nil
+	fmt.Printf("Value '%s' created (bucket: %s / key: %s - version %d)\n", value.Masked(), value.Bucket, value.Key, value.KeyVersion)
+	return err
 }
 
 func getKey(o *Options) (*model.KeyEntity, error) {
@@ -86,7 +86,7 @@ func renderKeysWithValues(o *Options, keys []*model.KeyEntity) error {
 			if _, ok := kvPairs[value.Bucket]; !ok {
 				kvPairs[value.Bucket] = []interface{}{}
 			}
-			kvPairs[value.Bucket] = append(kvPairs[value.Bucket], value.Value)
+			kvPairs[value.Bucket] = append(kvPairs[value.Bucket], value.Masked())
 		}
 		if err := formatter.AddRow(key.Key, key.DataType, key.Encrypted, key.Username,
 			key.Created.Format(time.RFC822Z), key.Validator, key.Trigger, key.Version, kvPairs); err != nil {
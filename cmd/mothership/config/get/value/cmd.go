@@ -26,6 +26,7 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd.Flags().BoolVar(&o.History, "history", false, "Show history of configuration value")
 	cmd.Flags().StringVar(&o.Key, "key", "", "Key name")
 	cmd.Flags().Int64Var(&o.KeyVersion, "key-version", 0, "Key version")
+	cmd.Flags().BoolVar(&o.Reveal, "reveal", false, "Show values of encrypted keys in plain text")
 
 	return cmd
 }
@@ -65,7 +66,11 @@ func renderValues(o *Options, values []*model.ValueEntity) error {
 		return err
 	}
 	for _, value := range values {
-		if err := formatter.AddRow(value.Bucket, value.Value, value.DataType, value.Username,
+		plainValue := value.Masked()
+		if o.Reveal {
+			plainValue = value.Value
+		}
+		if err := formatter.AddRow(value.Bucket, plainValue, value.DataType, value.Username,
 			value.Created.Format(time.RFC822Z), value.Version); err != nil {
 			return err
 		}
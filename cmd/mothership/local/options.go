@@ -43,13 +43,15 @@ func (o *Options) Kubeconfig() string {
 	return o.kubeconfig
 }
 
-func componentsFromFile(path string) ([][]string, []string, error) {
+//componentsFromFile returns the pre-components, the components and the declared dependencies of the components
+func componentsFromFile(path string) ([][]string, []string, map[string][]string, error) {
 	var preComps []string
 	var defaultComps []string
+	dependencies := make(map[string][]string)
 
 	compList, err := components.NewComponentList(path)
 	if err != nil {
-		return [][]string{preComps}, defaultComps, err
+		return [][]string{preComps}, defaultComps, dependencies, err
 	}
 
 	for _, c := range compList.Prerequisites {
@@ -59,7 +61,12 @@ func componentsFromFile(path string) ([][]string, []string, error) {
 	for _, c := range compList.Components {
 		defaultComps = append(defaultComps, fmt.Sprintf("{%s,%s,%s,%s}", c.Name, c.Namespace, c.URL, c.Version))
 	}
-	return [][]string{preComps}, defaultComps, nil
+	for _, c := range append(compList.Prerequisites, compList.Components...) {
+		if c.DependsOn != nil {
+			dependencies[c.Name] = c.DependsOn
+		}
+	}
+	return [][]string{preComps}, defaultComps, dependencies, nil
 }
 
 func componentsFromStrings(list []string, values []string) ([]*keb.Component, error) {
@@ -119,6 +126,7 @@ func setURLRepository(url string) string {
 
 func (o *Options) Components(defaultComponentsFile string) ([][]string, []*keb.Component, error) {
 	var preComps [][]string
+	var dependencies map[string][]string
 
 	comps := o.components
 	if len(o.components) == 0 {
@@ -127,7 +135,7 @@ func (o *Options) Components(defaultComponentsFile string) ([][]string, []*keb.C
 			cFile = defaultComponentsFile
 		}
 		var err error
-		preComps, comps, err = componentsFromFile(cFile)
+		preComps, comps, dependencies, err = componentsFromFile(cFile)
 		if err != nil {
 			return preComps, nil, err
 		}
@@ -137,6 +145,11 @@ func (o *Options) Components(defaultComponentsFile string) ([][]string, []*keb.C
 	if err != nil {
 		return preComps, nil, err
 	}
+	for _, comp := range mergedComps {
+		if dependsOn, ok := dependencies[comp.Component]; ok {
+			comp.DependsOn = &dependsOn
+		}
+	}
 
 	return preComps, mergedComps, err
 }
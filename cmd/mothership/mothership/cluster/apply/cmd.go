@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	statusCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/cluster/status"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Create or update a cluster.",
+		Long:  `Add a cluster to the inventory of the mothership reconciler or update its configuration. The cluster is read from a JSON or YAML file.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return Run(cmd.Context(), o)
+		},
+	}
+	cmd.Flags().StringVarP(&o.File, "file", "f", "", "File containing the cluster")
+	cmd.Flags().BoolVar(&o.Wait, "wait", false, "Wait until the reconciliation of the cluster is finished")
+	cmd.Flags().DurationVar(&o.WaitTimeout, "wait-timeout", 30*time.Minute, "Maximum time to wait for the reconciliation of the cluster")
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options) error {
+	cluster, err := o.cluster()
+	if err != nil {
+		return err
+	}
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	resp, err := mothership.CreateOrUpdateCluster(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	if o.Wait {
+		waitCtx, cancel := context.WithTimeout(ctx, o.WaitTimeout)
+		defer cancel()
+		o.Logger().Infof("Waiting until cluster '%s' is reconciled", cluster.RuntimeID)
+		resp, err = mothership.WaitForStatus(waitCtx, cluster.RuntimeID, 0, keb.StatusReady)
+		if resp != nil {
+			if renderErr := statusCmd.Render(o.OutputFormat, resp, os.Stdout); renderErr != nil {
+				return renderErr
+			}
+		}
+		return err
+	}
+	return statusCmd.Render(o.OutputFormat, resp, os.Stdout)
+}
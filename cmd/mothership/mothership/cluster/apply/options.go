@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/cmd/mothership/mothership/remote"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+type Options struct {
+	*cli.Options
+	Remote      *remote.Options
+	File        string
+	Wait        bool
+	WaitTimeout time.Duration
+}
+
+func NewOptions(o *cli.Options, remoteOpts *remote.Options) *Options {
+	return &Options{Options: o, Remote: remoteOpts}
+}
+
+func (o *Options) validate() error {
+	if o.File == "" {
+		return fmt.Errorf("file containing the cluster has to be provided")
+	}
+	if o.Wait && o.WaitTimeout <= 0 {
+		return fmt.Errorf("wait timeout '%s' has to be positive", o.WaitTimeout)
+	}
+	return nil
+}
+
+//cluster reads the cluster from the file (JSON or YAML)
+func (o *Options) cluster() (*keb.Cluster, error) {
+	data, err := ioutil.ReadFile(o.File)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to read cluster file '%s'", o.File))
+	}
+	cluster := &keb.Cluster{}
+	if err := yaml.Unmarshal(data, cluster); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to unmarshal cluster file '%s'", o.File))
+	}
+	if cluster.RuntimeID == "" {
+		return nil, fmt.Errorf("cluster file '%s' does not define a runtime ID", o.File)
+	}
+	return cluster, nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCluster(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+		return file
+	}
+
+	t.Run("Read JSON cluster", func(t *testing.T) {
+		o := NewOptions(nil, nil)
+		o.File = writeFile("cluster.json", `{"runtimeID":"runtime","kymaConfig":{"version":"2.0.0","profile":"evaluation"}}`)
+		cluster, err := o.cluster()
+		require.NoError(t, err)
+		require.Equal(t, "runtime", cluster.RuntimeID)
+		require.Equal(t, "2.0.0", cluster.KymaConfig.Version)
+	})
+
+	t.Run("Read YAML cluster", func(t *testing.T) {
+		o := NewOptions(nil, nil)
+		o.File = writeFile("cluster.yaml", "runtimeID: runtime\nkymaConfig:\n  version: 2.0.0\n")
+		cluster, err := o.cluster()
+		require.NoError(t, err)
+		require.Equal(t, "runtime", cluster.RuntimeID)
+		require.Equal(t, "2.0.0", cluster.KymaConfig.Version)
+	})
+
+	t.Run("Cluster without runtime ID", func(t *testing.T) {
+		o := NewOptions(nil, nil)
+		o.File = writeFile("invalid.json", `{"kymaConfig":{"version":"2.0.0"}}`)
+		_, err := o.cluster()
+		require.Error(t, err)
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		o := NewOptions(nil, nil)
+		require.Error(t, o.validate())
+		o.File = filepath.Join(dir, "missing.json")
+		_, err := o.cluster()
+		require.Error(t, err)
+	})
+}
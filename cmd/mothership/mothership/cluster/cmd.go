@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	applyCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/cluster/apply"
+	configCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/cluster/config"
+	diffCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/cluster/config/diff"
+	getCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/cluster/config/get"
+	deleteCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/cluster/delete"
+	reconcileCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/cluster/reconcile"
+	statusCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/cluster/status"
+	statusChangesCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/cluster/statuschanges"
+	"github.com/kyma-incubator/reconciler/cmd/mothership/mothership/remote"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *cli.Options, remoteOpts *remote.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Manage clusters of the mothership reconciler",
+	}
+
+	cmd.PersistentFlags().StringVarP(&o.OutputFormat, "output-format", "o", "table",
+		fmt.Sprintf("Define output formatting. Supported options are '%s'.", strings.Join(cli.SupportedOutputFormats, "', '")))
+	remoteOpts.AddFlags(cmd.PersistentFlags())
+
+	cmd.AddCommand(applyCmd.NewCmd(applyCmd.NewOptions(o, remoteOpts)))
+	cmd.AddCommand(deleteCmd.NewCmd(deleteCmd.NewOptions(o, remoteOpts)))
+	cmd.AddCommand(reconcileCmd.NewCmd(reconcileCmd.NewOptions(o, remoteOpts)))
+	cmd.AddCommand(statusCmd.NewCmd(statusCmd.NewOptions(o, remoteOpts)))
+	cmd.AddCommand(statusChangesCmd.NewCmd(statusChangesCmd.NewOptions(o, remoteOpts)))
+
+	//register config commands
+	configCommand := configCmd.NewCmd(o)
+	cmd.AddCommand(configCommand)
+	configCommand.AddCommand(getCmd.NewCmd(getCmd.NewOptions(o, remoteOpts)))
+	configCommand.AddCommand(diffCmd.NewCmd(diffCmd.NewOptions(o)))
+
+	return cmd
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff RUNTIME_ID FROM_VERSION TO_VERSION",
+		Short: "Show the changes between two configuration versions of a cluster.",
+		Long:  `Show the changes of Kyma version, profile, components and KV-bucket values between two configuration versions of a cluster. Values of secrets and encrypted keys are masked.`,
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.parseArgs(args); err != nil {
+				return err
+			}
+			if err := o.InitApplicationRegistry(true); err != nil {
+				return err
+			}
+			return Run(o)
+		},
+	}
+	return cmd
+}
+
+func Run(o *Options) error {
+	inventory := o.Registry.Inventory()
+	fromState, err := inventory.Get(o.RuntimeID, o.FromVersion)
+	if err != nil {
+		return err
+	}
+	toState, err := inventory.Get(o.RuntimeID, o.ToVersion)
+	if err != nil {
+		return err
+	}
+	encryptedKeys, err := o.Registry.KVRepository().EncryptedKeys()
+	if err != nil {
+		return err
+	}
+	return render(o.OutputFormat,
+		converters.ConvertConfigDiff(fromState.Configuration, toState.Configuration, encryptedKeys), os.Stdout)
+}
+
+func render(outputFormat string, diff keb.HTTPClusterConfigDiffResponse, writer io.Writer) error {
+	formatter, err := cli.NewOutputFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.Header("Component", "Change", "Field", "From", "To"); err != nil {
+		return err
+	}
+
+	addValueChange := func(component string, field string, change *keb.ValueChange) error {
+		if change == nil {
+			return nil
+		}
+		return formatter.AddRow(component, keb.ConfigChangeTypeChanged, field, change.From, change.To)
+	}
+	if err := addValueChange("", "kymaVersion", diff.KymaVersion); err != nil {
+		return err
+	}
+	if err := addValueChange("", "kymaProfile", diff.KymaProfile); err != nil {
+		return err
+	}
+	for _, value := range diff.BucketValues {
+		if err := formatter.AddRow("", value.Type, fmt.Sprintf("bucketValues.%s", value.Key),
+			valueString(value.From), valueString(value.To)); err != nil {
+			return err
+		}
+	}
+	for _, component := range diff.Components {
+		if component.Type != keb.ConfigChangeTypeChanged {
+			if err := formatter.AddRow(component.Component, component.Type, "component", "", ""); err != nil {
+				return err
+			}
+		}
+		if err := addValueChange(component.Component, "version", component.Version); err != nil {
+			return err
+		}
+		if err := addValueChange(component.Component, "namespace", component.Namespace); err != nil {
+			return err
+		}
+		if err := addValueChange(component.Component, "url", component.Url); err != nil {
+			return err
+		}
+		for _, config := range component.Configuration {
+			if err := formatter.AddRow(component.Component, config.Type, fmt.Sprintf("configuration.%s", config.Key),
+				valueString(config.From), valueString(config.To)); err != nil {
+				return err
+			}
+		}
+	}
+	return formatter.Output(writer)
+}
+
+func valueString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
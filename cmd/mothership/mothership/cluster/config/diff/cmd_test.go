@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	diff := keb.HTTPClusterConfigDiffResponse{
+		KymaVersion: &keb.ValueChange{From: "2.0.0", To: "2.0.1"},
+		Components: []keb.ComponentChange{
+			{Component: "istio", Type: keb.ConfigChangeTypeChanged, Configuration: []keb.ConfigurationChange{
+				{Key: "password", Type: keb.ConfigChangeTypeChanged, Secret: true, From: "*****", To: "*****"},
+			}},
+			{Component: "monitoring", Type: keb.ConfigChangeTypeAdded, Configuration: []keb.ConfigurationChange{}},
+		},
+		BucketValues: []keb.ConfigurationChange{
+			{Key: "global.domain", Type: keb.ConfigChangeTypeChanged, From: "old.com", To: "new.com"},
+		},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, render("json", diff, &buffer))
+
+	var rows []map[string]string
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &rows))
+	require.Equal(t, []map[string]string{
+		{"component": "", "change": "changed", "field": "kymaVersion", "from": "2.0.0", "to": "2.0.1"},
+		{"component": "", "change": "changed", "field": "bucketValues.global.domain", "from": "old.com", "to": "new.com"},
+		{"component": "istio", "change": "changed", "field": "configuration.password", "from": "*****", "to": "*****"},
+		{"component": "monitoring", "change": "added", "field": "component", "from": "", "to": ""},
+	}, rows)
+
+}
+
+func TestParseArgs(t *testing.T) {
+	o := NewOptions(nil)
+	require.Error(t, o.parseArgs([]string{"runtime", "1", "x"}))
+	require.Error(t, o.parseArgs([]string{"runtime", "x", "2"}))
+	require.NoError(t, o.parseArgs([]string{"runtime", "1", "2"}))
+	require.Equal(t, "runtime", o.RuntimeID)
+	require.Equal(t, int64(1), o.FromVersion)
+	require.Equal(t, int64(2), o.ToVersion)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+)
+
+type Options struct {
+	*cli.Options
+	RuntimeID   string
+	FromVersion int64
+	ToVersion   int64
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{Options: o}
+}
+
+func (o *Options) parseArgs(args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("runtime ID and the two configuration versions have to be provided")
+	}
+	o.RuntimeID = args[0]
+	var err error
+	if o.FromVersion, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+		return fmt.Errorf("configuration version '%s' is not a number", args[1])
+	}
+	if o.ToVersion, err = strconv.ParseInt(args[2], 10, 64); err != nil {
+		return fmt.Errorf("configuration version '%s' is not a number", args[2])
+	}
+	return nil
+}
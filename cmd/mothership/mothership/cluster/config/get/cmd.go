@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+const secretMask = "*****"
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get RUNTIME_ID VERSION",
+		Short: "Show a configuration version of a cluster.",
+		Long:  `Show the Kyma version, profile and components of a configuration version of a cluster. Values of secrets are masked.`,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.parseArgs(args); err != nil {
+				return err
+			}
+			return Run(cmd.Context(), o)
+		},
+	}
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options) error {
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	config, err := mothership.GetClusterConfig(ctx, o.RuntimeID, o.ConfigVersion)
+	if err != nil {
+		return err
+	}
+	return render(o.OutputFormat, config, os.Stdout)
+}
+
+func render(outputFormat string, config *keb.KymaConfig, writer io.Writer) error {
+	formatter, err := cli.NewOutputFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.Header("Kyma Version", "Kyma Profile", "Component", "Version", "Namespace", "Configuration"); err != nil {
+		return err
+	}
+	for _, component := range config.Components {
+		configuration := make(map[string]interface{}, len(component.Configuration))
+		for _, entry := range component.Configuration {
+			if entry.Secret {
+				configuration[entry.Key] = secretMask
+			} else {
+				configuration[entry.Key] = entry.Value
+			}
+		}
+		if err := formatter.AddRow(config.Version, config.Profile, component.Component,
+			component.Version, component.Namespace, configuration); err != nil {
+			return err
+		}
+	}
+	return formatter.Output(writer)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	config := &keb.KymaConfig{
+		Version: "2.0.0",
+		Profile: "evaluation",
+		Components: []keb.Component{
+			{Component: "istio", Version: "1.11.0", Namespace: "istio-system", Configuration: []keb.Configuration{
+				{Key: "password", Secret: true, Value: "secret"},
+				{Key: "replicas", Value: "2"},
+			}},
+		},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, render("json", config, &buffer))
+
+	var rows []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &rows))
+	require.Equal(t, []map[string]interface{}{
+		{
+			"kymaVersion":   "2.0.0",
+			"kymaProfile":   "evaluation",
+			"component":     "istio",
+			"version":       "1.11.0",
+			"namespace":     "istio-system",
+			"configuration": map[string]interface{}{"password": "*****", "replicas": "2"},
+		},
+	}, rows)
+}
+
+func TestParseArgs(t *testing.T) {
+	o := NewOptions(nil, nil)
+	require.Error(t, o.parseArgs([]string{"runtime"}))
+	require.Error(t, o.parseArgs([]string{"runtime", "x"}))
+	require.NoError(t, o.parseArgs([]string{"runtime", "2"}))
+	require.Equal(t, "runtime", o.RuntimeID)
+	require.Equal(t, int64(2), o.ConfigVersion)
+}
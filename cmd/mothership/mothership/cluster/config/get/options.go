@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kyma-incubator/reconciler/cmd/mothership/mothership/remote"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+)
+
+type Options struct {
+	*cli.Options
+	Remote        *remote.Options
+	RuntimeID     string
+	ConfigVersion int64
+}
+
+func NewOptions(o *cli.Options, remoteOpts *remote.Options) *Options {
+	return &Options{Options: o, Remote: remoteOpts}
+}
+
+func (o *Options) parseArgs(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("runtime ID and configuration version have to be provided")
+	}
+	o.RuntimeID = args[0]
+	var err error
+	if o.ConfigVersion, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+		return fmt.Errorf("configuration version '%s' is not a number", args[1])
+	}
+	return nil
+}
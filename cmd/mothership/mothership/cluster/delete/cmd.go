@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	statusCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/cluster/status"
+	"github.com/kyma-incubator/reconciler/pkg/client"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete RUNTIME_ID",
+		Short: "Delete a cluster.",
+		Long:  `Mark a cluster for deletion: the mothership reconciler removes Kyma from the cluster and drops it from its inventory.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.parseArgs(args); err != nil {
+				return err
+			}
+			return Run(cmd.Context(), o)
+		},
+	}
+	cmd.Flags().BoolVar(&o.Wait, "wait", false, "Wait until the cluster is deleted")
+	cmd.Flags().DurationVar(&o.WaitTimeout, "wait-timeout", 30*time.Minute, "Maximum time to wait for the deletion of the cluster")
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options) error {
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	resp, err := mothership.DeleteCluster(ctx, o.RuntimeID)
+	if err != nil {
+		return err
+	}
+	if o.Wait {
+		waitCtx, cancel := context.WithTimeout(ctx, o.WaitTimeout)
+		defer cancel()
+		o.Logger().Infof("Waiting until cluster '%s' is deleted", o.RuntimeID)
+		resp, err = mothership.WaitForStatus(waitCtx, o.RuntimeID, 0, keb.StatusDeleted)
+		if client.IsNotFoundError(err) {
+			//cluster was already removed from the inventory
+			o.Logger().Infof("Cluster '%s' is deleted", o.RuntimeID)
+			return nil
+		}
+		if resp != nil {
+			if renderErr := statusCmd.Render(o.OutputFormat, resp, os.Stdout); renderErr != nil {
+				return renderErr
+			}
+		}
+		return err
+	}
+	return statusCmd.Render(o.OutputFormat, resp, os.Stdout)
+}
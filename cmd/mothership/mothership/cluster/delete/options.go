@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/cmd/mothership/mothership/remote"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+)
+
+type Options struct {
+	*cli.Options
+	Remote      *remote.Options
+	RuntimeID   string
+	Wait        bool
+	WaitTimeout time.Duration
+}
+
+func NewOptions(o *cli.Options, remoteOpts *remote.Options) *Options {
+	return &Options{Options: o, Remote: remoteOpts}
+}
+
+func (o *Options) parseArgs(args []string) error {
+	if len(args) != 1 || args[0] == "" {
+		return fmt.Errorf("runtime ID has to be provided")
+	}
+	o.RuntimeID = args[0]
+	if o.Wait && o.WaitTimeout <= 0 {
+		return fmt.Errorf("wait timeout '%s' has to be positive", o.WaitTimeout)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArgs(t *testing.T) {
+	o := NewOptions(nil, nil)
+	require.Error(t, o.parseArgs([]string{}))
+	require.NoError(t, o.parseArgs([]string{"runtime"}))
+	require.Equal(t, "runtime", o.RuntimeID)
+
+	o.Wait = true
+	require.Error(t, o.parseArgs([]string{"runtime"}))
+	o.WaitTimeout = time.Minute
+	require.NoError(t, o.parseArgs([]string{"runtime"}))
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	infoCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/reconciliation/info"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reconcile RUNTIME_ID",
+		Short: "Reconcile a cluster immediately.",
+		Long: `Start a reconciliation of the latest cluster configuration without waiting for the reconcile interval.
+The reconciliation can be limited to a subset of components: their dependencies are reconciled as well.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.parseArgs(args); err != nil {
+				return err
+			}
+			return Run(cmd.Context(), o)
+		},
+	}
+	cmd.Flags().StringSliceVar(&o.Components, "component", []string{}, "Component to reconcile (can be repeated, all components are reconciled if omitted)")
+	cmd.Flags().StringVar(&o.Reason, "reason", "", "Reason of the reconciliation")
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options) error {
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	request := &keb.ReconcileRequest{Reason: o.Reason}
+	if len(o.Components) > 0 {
+		request.Components = &o.Components
+	}
+	info, err := mothership.ReconcileCluster(ctx, o.RuntimeID, request)
+	if err != nil {
+		return err
+	}
+	return infoCmd.Render(o.OutputFormat, info, os.Stdout)
+}
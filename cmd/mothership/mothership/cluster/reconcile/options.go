@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/cmd/mothership/mothership/remote"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+)
+
+type Options struct {
+	*cli.Options
+	Remote     *remote.Options
+	RuntimeID  string
+	Components []string
+	Reason     string
+}
+
+func NewOptions(o *cli.Options, remoteOpts *remote.Options) *Options {
+	return &Options{Options: o, Remote: remoteOpts}
+}
+
+func (o *Options) parseArgs(args []string) error {
+	if len(args) != 1 || args[0] == "" {
+		return fmt.Errorf("runtime ID has to be provided")
+	}
+	o.RuntimeID = args[0]
+	if strings.TrimSpace(o.Reason) == "" {
+		return fmt.Errorf("reason of the reconciliation has to be provided")
+	}
+	for _, component := range o.Components {
+		if component == "" {
+			return fmt.Errorf("component names cannot be empty")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArgs(t *testing.T) {
+	o := NewOptions(nil, nil)
+	require.Error(t, o.parseArgs([]string{}))
+	require.Error(t, o.parseArgs([]string{"runtime"}))
+
+	o.Reason = "hotfix"
+	require.NoError(t, o.parseArgs([]string{"runtime"}))
+	require.Equal(t, "runtime", o.RuntimeID)
+
+	o.Components = []string{"istio", ""}
+	require.Error(t, o.parseArgs([]string{"runtime"}))
+	o.Components = []string{"istio"}
+	require.NoError(t, o.parseArgs([]string{"runtime"}))
+}
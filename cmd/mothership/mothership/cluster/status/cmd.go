@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status RUNTIME_ID",
+		Short: "Show the status of a cluster.",
+		Long:  `Show the status of the latest configuration of a cluster or of a particular configuration version.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.parseArgs(args); err != nil {
+				return err
+			}
+			return Run(cmd.Context(), o)
+		},
+	}
+	cmd.Flags().Int64Var(&o.ConfigVersion, "config-version", 0, "Configuration version of the cluster (default is the latest version)")
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options) error {
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	var resp *keb.HTTPClusterResponse
+	if o.ConfigVersion > 0 {
+		resp, err = mothership.GetClusterConfigStatus(ctx, o.RuntimeID, o.ConfigVersion)
+	} else {
+		resp, err = mothership.GetClusterStatus(ctx, o.RuntimeID)
+	}
+	if err != nil {
+		return err
+	}
+	return Render(o.OutputFormat, resp, os.Stdout)
+}
+
+//Render writes the status of a cluster in the requested output format
+func Render(outputFormat string, resp *keb.HTTPClusterResponse, writer io.Writer) error {
+	formatter, err := cli.NewOutputFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.Header("Cluster", "Cluster Version", "Configuration Version", "Status", "Failures"); err != nil {
+		return err
+	}
+	failures := []string{}
+	if resp.Failures != nil {
+		for _, failure := range *resp.Failures {
+			failures = append(failures, failure.Component+": "+failure.Reason)
+		}
+	}
+	if err := formatter.AddRow(resp.Cluster, resp.ClusterVersion, resp.ConfigurationVersion, resp.Status, failures); err != nil {
+		return err
+	}
+	return formatter.Output(writer)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	resp := &keb.HTTPClusterResponse{
+		Cluster:              "runtime",
+		ClusterVersion:       1,
+		ConfigurationVersion: 2,
+		Status:               keb.StatusError,
+		Failures:             &[]keb.Failure{{Component: "istio", Reason: "timeout"}},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, Render("json", resp, &buffer))
+
+	var rows []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &rows))
+	require.Equal(t, []map[string]interface{}{
+		{
+			"cluster":              "runtime",
+			"clusterVersion":       float64(1),
+			"configurationVersion": float64(2),
+			"status":               "error",
+			"failures":             []interface{}{"istio: timeout"},
+		},
+	}, rows)
+}
+
+func TestParseArgs(t *testing.T) {
+	o := NewOptions(nil, nil)
+	require.Error(t, o.parseArgs([]string{}))
+	require.Error(t, o.parseArgs([]string{""}))
+	o.ConfigVersion = -1
+	require.Error(t, o.parseArgs([]string{"runtime"}))
+	o.ConfigVersion = 2
+	require.NoError(t, o.parseArgs([]string{"runtime"}))
+	require.Equal(t, "runtime", o.RuntimeID)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/cmd/mothership/mothership/remote"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+)
+
+type Options struct {
+	*cli.Options
+	Remote        *remote.Options
+	RuntimeID     string
+	ConfigVersion int64
+}
+
+func NewOptions(o *cli.Options, remoteOpts *remote.Options) *Options {
+	return &Options{Options: o, Remote: remoteOpts}
+}
+
+func (o *Options) parseArgs(args []string) error {
+	if len(args) != 1 || args[0] == "" {
+		return fmt.Errorf("runtime ID has to be provided")
+	}
+	o.RuntimeID = args[0]
+	if o.ConfigVersion < 0 {
+		return fmt.Errorf("configuration version '%d' is invalid", o.ConfigVersion)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status-changes RUNTIME_ID",
+		Short: "Show the status changes of a cluster.",
+		Long:  `Show the status changes of a cluster within an offset (e.g. 1h or 24h).`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.parseArgs(args); err != nil {
+				return err
+			}
+			return Run(cmd.Context(), o)
+		},
+	}
+	cmd.Flags().DurationVar(&o.Offset, "offset", 0, "Offset of the status changes (default is the offset of the mothership reconciler)")
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options) error {
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	resp, err := mothership.GetClusterStatusChanges(ctx, o.RuntimeID, o.Offset)
+	if err != nil {
+		return err
+	}
+	return render(o.OutputFormat, resp, os.Stdout)
+}
+
+func render(outputFormat string, resp *keb.HTTPClusterStatusResponse, writer io.Writer) error {
+	formatter, err := cli.NewOutputFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.Header("Started", "Duration", "Status"); err != nil {
+		return err
+	}
+	for _, change := range resp.StatusChanges {
+		//the mothership reports the duration in nanoseconds
+		if err := formatter.AddRow(change.Started.Format(time.RFC3339), time.Duration(change.Duration).String(), change.Status); err != nil {
+			return err
+		}
+	}
+	return formatter.Output(writer)
+}
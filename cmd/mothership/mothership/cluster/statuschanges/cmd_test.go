@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	started := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	resp := &keb.HTTPClusterStatusResponse{
+		StatusChanges: []keb.StatusChange{
+			{Started: started, Duration: int64(90 * time.Second), Status: keb.StatusReconciling},
+			{Started: started.Add(90 * time.Second), Duration: int64(time.Minute), Status: keb.StatusReady},
+		},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, render("json", resp, &buffer))
+
+	var rows []map[string]string
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &rows))
+	require.Equal(t, []map[string]string{
+		{"started": "2021-10-01T12:00:00Z", "duration": "1m30s", "status": "reconciling"},
+		{"started": "2021-10-01T12:01:30Z", "duration": "1m0s", "status": "ready"},
+	}, rows)
+}
+
+func TestParseArgs(t *testing.T) {
+	o := NewOptions(nil, nil)
+	require.Error(t, o.parseArgs([]string{}))
+	o.Offset = -time.Hour
+	require.Error(t, o.parseArgs([]string{"runtime"}))
+	o.Offset = time.Hour
+	require.NoError(t, o.parseArgs([]string{"runtime"}))
+	require.Equal(t, "runtime", o.RuntimeID)
+}
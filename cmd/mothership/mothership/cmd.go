@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	clusterCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/cluster"
+	dbCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/db"
+	rekeyCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/db/rekey"
 	installCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/install"
+	reconciliationCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/reconciliation"
+	"github.com/kyma-incubator/reconciler/cmd/mothership/mothership/remote"
 	startCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/start"
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	"github.com/spf13/cobra"
@@ -17,5 +22,15 @@ func NewCmd(o *cli.Options) *cobra.Command {
 	cmd.AddCommand(startCmd.NewCmd(startCmd.NewOptions(o)))
 	cmd.AddCommand(installCmd.NewCmd(installCmd.NewOptions(o)))
 
+	//register commands which call the REST API of a running mothership reconciler
+	remoteOpts := remote.NewOptions()
+	cmd.AddCommand(clusterCmd.NewCmd(o, remoteOpts))
+	cmd.AddCommand(reconciliationCmd.NewCmd(o, remoteOpts))
+
+	//register db commands
+	dbCommand := dbCmd.NewCmd(o)
+	cmd.AddCommand(dbCommand)
+	dbCommand.AddCommand(rekeyCmd.NewCmd(rekeyCmd.NewOptions(o)))
+
 	return cmd
 }
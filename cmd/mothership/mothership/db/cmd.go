@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Maintain the database of the mothership reconciler",
+	}
+	return cmd
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+//rekeyEntities are the entities with encrypted columns and the unique field used for ordering their rows.
+//Entities which encrypt fields depending on a flag define these fields and the condition of the encrypted rows.
+var rekeyEntities = []struct {
+	entity  db.DatabaseEntity
+	idField string
+	fields  []string
+	where   map[string]interface{}
+}{
+	{entity: &model.ClusterEntity{}, idField: "Version"},
+	{entity: &model.ClusterConfigurationEntity{}, idField: "Version"},
+	{entity: &model.WebhookEntity{}, idField: "ID"},
+	{entity: &model.OperationEntity{}, idField: "CorrelationID"},
+	{entity: &model.TaskEntity{}, idField: "CorrelationID"},
+	{entity: &model.ValueEntity{}, idField: "Version", fields: []string{"Value"}, where: map[string]interface{}{"Encrypted": true}},
+}
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rekey",
+		Short: "Re-encrypt all encrypted database columns with the active encryption key.",
+		Long: `Re-encrypt all encrypted database columns with the active encryption key after a key rotation.
+The previous keys have to be configured as decryption keys ('db.encryption.decryptionKeyFiles').
+Rows are processed in batches, each batch in its own transaction. An interrupted run can be restarted:
+values which are already encrypted with the active key are skipped.
+Values of the configuration store which are stored in plain text although their key requires encryption
+are encrypted before.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.InitApplicationRegistry(true); err != nil {
+				return err
+			}
+			return Run(o)
+		},
+	}
+	cmd.Flags().IntVar(&o.BatchSize, "batch-size", db.DefaultRekeyBatchSize, "Number of rows which are re-encrypted within one transaction")
+	return cmd
+}
+
+func Run(o *Options) error {
+	conn := o.Registry.Connnection()
+	o.Logger().Infof("Re-encrypting database with active key '%s' (keyring: %v)",
+		conn.Encryptor().KeyID(), conn.Encryptor().KeyIDs())
+
+	rekeyer, err := db.NewRekeyer(conn, o.BatchSize, o.Logger())
+	if err != nil {
+		return err
+	}
+
+	//values of encrypted keys can exist in plain text if they were created before the encryption of keys was supported
+	encrypted, err := o.Registry.KVRepository().EncryptPlaintextValues()
+	if err != nil {
+		return err
+	}
+	o.Logger().Infof("Encrypted %d plain text values of encrypted configuration keys", encrypted)
+
+	var undecryptable int64
+	for _, rekeyEntity := range rekeyEntities {
+		progressFct := func(progress *db.RekeyProgress) {
+			o.Logger().Infof("Table '%s': %d rows processed, %d values re-encrypted",
+				progress.Table, progress.Rows, progress.Rekeyed)
+		}
+		var progress *db.RekeyProgress
+		if len(rekeyEntity.fields) > 0 {
+			progress, err = rekeyer.RekeyFields(rekeyEntity.entity, rekeyEntity.idField, rekeyEntity.fields, rekeyEntity.where, progressFct)
+		} else {
+			progress, err = rekeyer.Rekey(rekeyEntity.entity, rekeyEntity.idField, progressFct)
+		}
+		if err != nil {
+			return err
+		}
+		o.Logger().Infof("Table '%s' finished: %d rows processed, %d values re-encrypted, %d values not decryptable",
+			progress.Table, progress.Rows, progress.Rekeyed, progress.Undecryptable)
+		undecryptable += progress.Undecryptable
+	}
+
+	if undecryptable > 0 {
+		return fmt.Errorf("%d values could not be decrypted: configure the missing keys as decryption keys and re-run the command",
+			undecryptable)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+type Options struct {
+	*cli.Options
+	BatchSize int
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o, db.DefaultRekeyBatchSize}
+}
+
+func (o *Options) Validate() error {
+	if o.BatchSize <= 0 {
+		return fmt.Errorf("batch size has to be > 0 (was %d)", o.BatchSize)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	controlCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/reconciliation/control"
+	infoCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/reconciliation/info"
+	listCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/reconciliation/list"
+	resolveOperationCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/reconciliation/resolveoperation"
+	stopOperationCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/reconciliation/stopoperation"
+	"github.com/kyma-incubator/reconciler/cmd/mothership/mothership/remote"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *cli.Options, remoteOpts *remote.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reconciliation",
+		Short: "Manage reconciliations of the mothership reconciler",
+	}
+
+	cmd.PersistentFlags().StringVarP(&o.OutputFormat, "output-format", "o", "table",
+		fmt.Sprintf("Define output formatting. Supported options are '%s'.", strings.Join(cli.SupportedOutputFormats, "', '")))
+	remoteOpts.AddFlags(cmd.PersistentFlags())
+
+	cmd.AddCommand(listCmd.NewCmd(listCmd.NewOptions(o, remoteOpts)))
+	cmd.AddCommand(infoCmd.NewCmd(infoCmd.NewOptions(o, remoteOpts)))
+	cmd.AddCommand(controlCmd.NewPauseCmd(controlCmd.NewOptions(o, remoteOpts)))
+	cmd.AddCommand(controlCmd.NewResumeCmd(controlCmd.NewOptions(o, remoteOpts)))
+	cmd.AddCommand(controlCmd.NewCancelCmd(controlCmd.NewOptions(o, remoteOpts)))
+	cmd.AddCommand(stopOperationCmd.NewCmd(stopOperationCmd.NewOptions(o, remoteOpts)))
+	cmd.AddCommand(resolveOperationCmd.NewRetryCmd(resolveOperationCmd.NewOptions(o, remoteOpts)))
+	cmd.AddCommand(resolveOperationCmd.NewSkipCmd(resolveOperationCmd.NewOptions(o, remoteOpts)))
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	infoCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/reconciliation/info"
+	"github.com/kyma-incubator/reconciler/pkg/client"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+type action func(mothership *client.Client, ctx context.Context, schedulingID string) (*keb.HTTPReconciliationInfo, error)
+
+func NewPauseCmd(o *Options) *cobra.Command {
+	return newCmd(o, "pause", "Pause a reconciliation.",
+		`Hold back the operations of a running reconciliation which are not started yet. Running operations are not interrupted.`,
+		(*client.Client).PauseReconciliation)
+}
+
+func NewResumeCmd(o *Options) *cobra.Command {
+	return newCmd(o, "resume", "Resume a paused reconciliation.",
+		`Continue the processing of the operations of a paused reconciliation.`,
+		(*client.Client).ResumeReconciliation)
+}
+
+func NewCancelCmd(o *Options) *cobra.Command {
+	return newCmd(o, "cancel", "Cancel a reconciliation.",
+		`Stop a running reconciliation: operations which are not started yet will not be processed.`,
+		(*client.Client).CancelReconciliation)
+}
+
+func newCmd(o *Options, use, short, long string, fct action) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use + " SCHEDULING_ID",
+		Short: short,
+		Long:  long,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.parseArgs(args); err != nil {
+				return err
+			}
+			return Run(cmd.Context(), o, fct)
+		},
+	}
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options, fct action) error {
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	info, err := fct(mothership, ctx, o.SchedulingID)
+	if err != nil {
+		return err
+	}
+	return infoCmd.Render(o.OutputFormat, info, os.Stdout)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info SCHEDULING_ID",
+		Short: "Show a reconciliation.",
+		Long:  `Show the status of a reconciliation and of its operations.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.parseArgs(args); err != nil {
+				return err
+			}
+			return Run(cmd.Context(), o)
+		},
+	}
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options) error {
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	info, err := mothership.GetReconciliation(ctx, o.SchedulingID)
+	if err != nil {
+		return err
+	}
+	return Render(o.OutputFormat, info, os.Stdout)
+}
+
+//Render writes a reconciliation (one row per operation) in the requested output format
+func Render(outputFormat string, info *keb.HTTPReconciliationInfo, writer io.Writer) error {
+	formatter, err := cli.NewOutputFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.Header("Runtime ID", "Status", "Paused", "Component", "Correlation ID", "Priority", "State", "Reason"); err != nil {
+		return err
+	}
+	if len(info.Operations) == 0 {
+		if err := formatter.AddRow(info.RuntimeID, info.Status, info.Paused, "", "", "", "", ""); err != nil {
+			return err
+		}
+	}
+	for _, op := range info.Operations {
+		if err := formatter.AddRow(info.RuntimeID, info.Status, info.Paused,
+			op.Component, op.CorrelationID, op.Priority, op.State, op.Reason); err != nil {
+			return err
+		}
+	}
+	return formatter.Output(writer)
+}
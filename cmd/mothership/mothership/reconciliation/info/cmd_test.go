@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	t.Run("Reconciliation with operations", func(t *testing.T) {
+		info := &keb.HTTPReconciliationInfo{
+			RuntimeID: "runtime",
+			Status:    keb.StatusReconciling,
+			Paused:    true,
+			Operations: []keb.Operation{
+				{Component: "istio", CorrelationID: "correlation", Priority: 1, State: "done"},
+			},
+		}
+
+		var buffer bytes.Buffer
+		require.NoError(t, Render("json", info, &buffer))
+
+		var rows []map[string]interface{}
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &rows))
+		require.Equal(t, []map[string]interface{}{
+			{
+				"runtimeID":     "runtime",
+				"status":        "reconciling",
+				"paused":        true,
+				"component":     "istio",
+				"correlationID": "correlation",
+				"priority":      float64(1),
+				"state":         "done",
+				"reason":        "",
+			},
+		}, rows)
+	})
+
+	t.Run("Reconciliation without operations", func(t *testing.T) {
+		info := &keb.HTTPReconciliationInfo{RuntimeID: "runtime", Status: keb.StatusReady}
+
+		var buffer bytes.Buffer
+		require.NoError(t, Render("json", info, &buffer))
+
+		var rows []map[string]interface{}
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &rows))
+		require.Len(t, rows, 1)
+		require.Equal(t, "runtime", rows[0]["runtimeID"])
+		require.Equal(t, "", rows[0]["component"])
+	})
+}
+
+func TestParseArgs(t *testing.T) {
+	o := NewOptions(nil, nil)
+	require.Error(t, o.parseArgs([]string{}))
+	require.NoError(t, o.parseArgs([]string{"scheduling"}))
+	require.Equal(t, "scheduling", o.SchedulingID)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List reconciliations.",
+		Long:  `List the reconciliations of the mothership reconciler filtered by runtime, status and creation time.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Run(cmd.Context(), o)
+		},
+	}
+	cmd.Flags().StringSliceVar(&o.RuntimeIDs, "runtime-id", nil, "Show only reconciliations of these runtimes")
+	cmd.Flags().StringSliceVar(&o.Statuses, "status", nil, "Show only reconciliations with these statuses")
+	cmd.Flags().StringVar(&o.Before, "before", "", "Show only reconciliations created before this RFC3339 timestamp")
+	cmd.Flags().StringVar(&o.After, "after", "", "Show only reconciliations created after this RFC3339 timestamp")
+	cmd.Flags().IntVar(&o.Last, "last", 0, "Show only the last N reconciliations")
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options) error {
+	params, err := o.params()
+	if err != nil {
+		return err
+	}
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	reconciliations, err := mothership.ListReconciliations(ctx, params)
+	if err != nil {
+		return err
+	}
+	return render(o.OutputFormat, reconciliations, os.Stdout)
+}
+
+func render(outputFormat string, reconciliations keb.HTTPReconcilerStatus, writer io.Writer) error {
+	formatter, err := cli.NewOutputFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+	if err := formatter.Header("Scheduling ID", "Runtime ID", "Status", "Created", "Updated"); err != nil {
+		return err
+	}
+	for _, reconciliation := range reconciliations {
+		if err := formatter.AddRow(reconciliation.SchedulingID, reconciliation.RuntimeID, reconciliation.Status,
+			reconciliation.Created.Format(time.RFC3339), reconciliation.Updated.Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	return formatter.Output(writer)
+}
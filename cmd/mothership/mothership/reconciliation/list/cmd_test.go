@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	created := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	reconciliations := keb.HTTPReconcilerStatus{
+		{SchedulingID: "scheduling", RuntimeID: "runtime", Status: keb.StatusReady, Created: created, Updated: created.Add(time.Minute)},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, render("json", reconciliations, &buffer))
+
+	var rows []map[string]string
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &rows))
+	require.Equal(t, []map[string]string{
+		{
+			"schedulingID": "scheduling",
+			"runtimeID":    "runtime",
+			"status":       "ready",
+			"created":      "2021-10-01T12:00:00Z",
+			"updated":      "2021-10-01T12:01:00Z",
+		},
+	}, rows)
+}
+
+func TestParams(t *testing.T) {
+	t.Run("Without filters", func(t *testing.T) {
+		params, err := NewOptions(nil, nil).params()
+		require.NoError(t, err)
+		require.Equal(t, &keb.GetReconciliationsParams{}, params)
+	})
+
+	t.Run("With filters", func(t *testing.T) {
+		o := NewOptions(nil, nil)
+		o.RuntimeIDs = []string{"runtime1", "runtime2"}
+		o.Statuses = []string{"ready"}
+		o.After = "2021-10-01T12:00:00Z"
+		o.Last = 5
+		params, err := o.params()
+		require.NoError(t, err)
+		require.Equal(t, []string{"runtime1", "runtime2"}, *params.RuntimeID)
+		require.Equal(t, []keb.Status{keb.StatusReady}, *params.Status)
+		require.Equal(t, time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC), *params.After)
+		require.Nil(t, params.Before)
+		require.Equal(t, 5, *params.Last)
+	})
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		o := NewOptions(nil, nil)
+		o.Before = "yesterday"
+		_, err := o.params()
+		require.Error(t, err)
+
+		o = NewOptions(nil, nil)
+		o.Last = -1
+		_, err = o.params()
+		require.Error(t, err)
+	})
+}
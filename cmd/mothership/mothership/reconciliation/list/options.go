@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/cmd/mothership/mothership/remote"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+type Options struct {
+	*cli.Options
+	Remote     *remote.Options
+	RuntimeIDs []string
+	Statuses   []string
+	Before     string
+	After      string
+	Last       int
+}
+
+func NewOptions(o *cli.Options, remoteOpts *remote.Options) *Options {
+	return &Options{Options: o, Remote: remoteOpts}
+}
+
+//params converts the filter flags to the query parameters of the mothership API
+func (o *Options) params() (*keb.GetReconciliationsParams, error) {
+	params := &keb.GetReconciliationsParams{}
+	if len(o.RuntimeIDs) > 0 {
+		runtimeIDs := o.RuntimeIDs
+		params.RuntimeID = &runtimeIDs
+	}
+	if len(o.Statuses) > 0 {
+		statuses := []keb.Status{}
+		for _, status := range o.Statuses {
+			statuses = append(statuses, keb.Status(status))
+		}
+		params.Status = &statuses
+	}
+	var err error
+	if params.Before, err = parseTime("before", o.Before); err != nil {
+		return nil, err
+	}
+	if params.After, err = parseTime("after", o.After); err != nil {
+		return nil, err
+	}
+	if o.Last < 0 {
+		return nil, fmt.Errorf("last '%d' cannot be negative", o.Last)
+	}
+	if o.Last > 0 {
+		last := o.Last
+		params.Last = &last
+	}
+	return params, nil
+}
+
+func parseTime(flag, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	result, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s '%s' is not a RFC3339 timestamp", flag, value)
+	}
+	return &result, nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	infoCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership/reconciliation/info"
+	"github.com/spf13/cobra"
+)
+
+func NewRetryCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retry-operation SCHEDULING_ID CORRELATION_ID",
+		Short: "Retry a failed operation of a reconciliation.",
+		Long: `Reset a failed operation so that it gets processed again by its component reconciler.
+A finished reconciliation is reopened if it is still the latest reconciliation of the cluster.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.parseArgs(args, false); err != nil {
+				return err
+			}
+			return RunRetry(cmd.Context(), o)
+		},
+	}
+	return cmd
+}
+
+func NewSkipCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "skip-operation SCHEDULING_ID CORRELATION_ID",
+		Short: "Skip a failed operation of a reconciliation.",
+		Long: `Mark a failed operation as skipped so that the reconciliation continues with the remaining operations.
+A finished reconciliation is reopened if it is still the latest reconciliation of the cluster.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.parseArgs(args, true); err != nil {
+				return err
+			}
+			return RunSkip(cmd.Context(), o)
+		},
+	}
+	cmd.Flags().StringVar(&o.Reason, "reason", "", "Reason for skipping the operation")
+	return cmd
+}
+
+func RunRetry(ctx context.Context, o *Options) error {
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	info, err := mothership.RetryOperation(ctx, o.SchedulingID, o.CorrelationID)
+	if err != nil {
+		return err
+	}
+	return infoCmd.Render(o.OutputFormat, info, os.Stdout)
+}
+
+func RunSkip(ctx context.Context, o *Options) error {
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	info, err := mothership.SkipOperation(ctx, o.SchedulingID, o.CorrelationID, o.Reason)
+	if err != nil {
+		return err
+	}
+	return infoCmd.Render(o.OutputFormat, info, os.Stdout)
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArgs(t *testing.T) {
+	t.Run("Retry", func(t *testing.T) {
+		o := NewOptions(nil, nil)
+		require.Error(t, o.parseArgs([]string{"scheduling"}, false))
+		require.NoError(t, o.parseArgs([]string{"scheduling", "correlation"}, false))
+		require.Equal(t, "scheduling", o.SchedulingID)
+		require.Equal(t, "correlation", o.CorrelationID)
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		o := NewOptions(nil, nil)
+		require.Error(t, o.parseArgs([]string{"scheduling", "correlation"}, true))
+		o.Reason = "broken chart"
+		require.NoError(t, o.parseArgs([]string{"scheduling", "correlation"}, true))
+	})
+}
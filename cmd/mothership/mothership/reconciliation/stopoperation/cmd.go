@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stop-operation SCHEDULING_ID CORRELATION_ID",
+		Short: "Stop an operation of a reconciliation.",
+		Long:  `Prevent that an operation which was not started yet gets processed by a component reconciler.`,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.parseArgs(args); err != nil {
+				return err
+			}
+			return Run(cmd.Context(), o)
+		},
+	}
+	cmd.Flags().StringVar(&o.Reason, "reason", "", "Reason for stopping the operation")
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options) error {
+	mothership, err := o.Remote.Client()
+	if err != nil {
+		return err
+	}
+	if err := mothership.StopOperation(ctx, o.SchedulingID, o.CorrelationID, o.Reason); err != nil {
+		return err
+	}
+	o.Logger().Infof("Operation '%s' of reconciliation '%s' stopped", o.CorrelationID, o.SchedulingID)
+	return nil
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/cmd/mothership/mothership/remote"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+)
+
+type Options struct {
+	*cli.Options
+	Remote        *remote.Options
+	SchedulingID  string
+	CorrelationID string
+	Reason        string
+}
+
+func NewOptions(o *cli.Options, remoteOpts *remote.Options) *Options {
+	return &Options{Options: o, Remote: remoteOpts}
+}
+
+func (o *Options) parseArgs(args []string) error {
+	if len(args) != 2 || args[0] == "" || args[1] == "" {
+		return fmt.Errorf("scheduling ID and correlation ID have to be provided")
+	}
+	o.SchedulingID = args[0]
+	o.CorrelationID = args[1]
+	if o.Reason == "" {
+		return fmt.Errorf("reason for stopping the operation has to be provided")
+	}
+	return nil
+}
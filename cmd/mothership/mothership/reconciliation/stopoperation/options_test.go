@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArgs(t *testing.T) {
+	o := NewOptions(nil, nil)
+	require.Error(t, o.parseArgs([]string{"scheduling"}))
+	require.Error(t, o.parseArgs([]string{"scheduling", "correlation"}))
+	o.Reason = "not required"
+	require.NoError(t, o.parseArgs([]string{"scheduling", "correlation"}))
+	require.Equal(t, "scheduling", o.SchedulingID)
+	require.Equal(t, "correlation", o.CorrelationID)
+}
@@ -0,0 +1,53 @@
+package remote
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/client"
+	"github.com/spf13/pflag"
+)
+
+const (
+	defaultURL = "http://localhost:8080"
+	//TokenEnv is the environment variable used as fallback for the bearer token
+	TokenEnv = "MOTHERSHIP_TOKEN"
+)
+
+//Options defines the connection to a running mothership reconciler
+type Options struct {
+	URL      string
+	Token    string
+	Timeout  time.Duration
+	Attempts uint
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+//AddFlags registers the connection flags (typically as persistent flags of a command group)
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.URL, "url", defaultURL, "URL of the mothership reconciler")
+	flags.StringVar(&o.Token, "token", "",
+		"Bearer token used to authenticate against the mothership reconciler (default is the value of $"+TokenEnv+")")
+	flags.DurationVar(&o.Timeout, "timeout", 30*time.Second, "Timeout of a single request to the mothership reconciler")
+	flags.UintVar(&o.Attempts, "attempts", client.DefaultAttempts, "Attempts of a request before giving up")
+}
+
+//Client returns a client of the mothership reconciler configured by the options
+func (o *Options) Client() (*client.Client, error) {
+	token := o.Token
+	if token == "" {
+		token = os.Getenv(TokenEnv)
+	}
+	return client.NewClient(&client.Config{
+		URL:      o.URL,
+		Token:    token,
+		Attempts: o.Attempts,
+		HTTPClient: &http.Client{
+			Timeout: o.Timeout,
+		},
+	})
+}
@@ -81,6 +81,9 @@ type data struct {
 	User            string `json:"user"`
 	Tenant          string `json:"tenant"`
 	IP              string `json:"ip"`
+	Status          int    `json:"status,omitempty"` //HTTP status of rejected requests
+	Reason          string `json:"reason,omitempty"` //reason why a request was rejected
+	Event           string `json:"event,omitempty"`  //action which was applied by an accepted request
 }
 
 func auditLogRequest(w http.ResponseWriter, r *http.Request, l *zap.Logger, o *Options) {
@@ -119,6 +122,9 @@ func auditLogRequest(w http.ResponseWriter, r *http.Request, l *zap.Logger, o *O
 	if user != "" {
 		logData.User = user
 	}
+	if identity := identityFromContext(r); identity != nil {
+		logData.User = identity.Subject //verified by the authenticator
+	}
 
 	// log request body if needed.
 	if r.Method == "POST" || r.Method == "PUT" {
@@ -139,13 +145,60 @@ func auditLogRequest(w http.ResponseWriter, r *http.Request, l *zap.Logger, o *O
 	}
 	logData.IP = ip
 
-	data, err := json.Marshal(logData)
-	if err != nil {
+	if err := writeAuditLog(l, o, logData); err != nil {
 		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
 			Error: errors.Wrap(err, "Failed to marshal auditlog JSON payload").Error(),
 		})
+	}
+}
+
+//auditLogDenial records a request which was rejected by the authorizer
+func auditLogDenial(r *http.Request, l *zap.Logger, o *Options, status int, reason string) {
+	logData := newAuditLogData(r, o)
+	logData.Status = status
+	logData.Reason = reason
+	if err := writeAuditLog(l, o, logData); err != nil {
+		o.Logger().Errorf("Failed to write audit log for rejected request: %s", err)
+	}
+}
+
+//auditLogEvent records an action applied by a request (ignored if audit logging is disabled)
+func auditLogEvent(r *http.Request, l *zap.Logger, o *Options, event string) {
+	if l == nil {
 		return
 	}
+	logData := newAuditLogData(r, o)
+	logData.Event = event
+	if err := writeAuditLog(l, o, logData); err != nil {
+		o.Logger().Errorf("Failed to write audit log event: %s", err)
+	}
+}
+
+//newAuditLogData returns the audit log entry of a request whose caller was already authenticated
+func newAuditLogData(r *http.Request, o *Options) data {
+	contractV, _ := server.NewParams(r).Int64(paramContractVersion)
+	logData := data{
+		ContractVersion: contractV,
+		Method:          r.Method,
+		URI:             r.RequestURI,
+		User:            "UNKNOWN_USER",
+		Tenant:          o.AuditLogTenantID,
+		IP:              "-",
+	}
+	if ip := r.Header.Get(ExternalAddressHeaderName); ip != "" {
+		logData.IP = ip
+	}
+	if identity := identityFromContext(r); identity != nil {
+		logData.User = identity.Subject
+	}
+	return logData
+}
+
+func writeAuditLog(l *zap.Logger, o *Options, logData data) error {
+	data, err := json.Marshal(logData)
+	if err != nil {
+		return err
+	}
 	l.With(zap.String("time", time.Now().Format(time.RFC3339))).
 		With(zap.String("uuid", uuid.New().String())).
 		With(zap.String("user", logData.User)).
@@ -154,6 +207,7 @@ func auditLogRequest(w http.ResponseWriter, r *http.Request, l *zap.Logger, o *O
 		With(zap.String("ip", logData.IP)).
 		With(zap.String("category", "audit.security-events")). // comply with required log backend format
 		Info("")
+	return nil
 }
 
 func getJWTPayload(r *http.Request) (string, error) {
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 const (
@@ -176,3 +177,35 @@ func validateLog(t *testing.T, logMsg, method string, useJWT bool) {
 		require.NotEmptyf(t, d.RequestBody, "empty request body in log message data field: %#v", l.Data)
 	}
 }
+
+func Test_AuditlogEvent(t *testing.T) {
+	o := NewOptions(&cli.Options{})
+	o.AuditLogTenantID = tenantID
+	req, err := http.NewRequest(http.MethodPost, "http://localhost/v1/operations/scheduling1/correlation1/skip", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{
+		paramContractVersion: "1",
+	})
+	req.Header.Add(ExternalAddressHeaderName, clientIP)
+
+	t.Run("Event is logged", func(t *testing.T) {
+		core, auditLogs := observer.New(zap.InfoLevel)
+		auditLogEvent(req, zap.New(core), o, "Skipped operation")
+		require.Equal(t, 1, auditLogs.Len())
+		fields := auditLogs.All()[0].ContextMap()
+		require.Equal(t, tenantID, fields["tenant"])
+		require.Equal(t, clientIP, fields["ip"])
+
+		d := &data{}
+		require.NoError(t, json.Unmarshal([]byte(fields["data"].(string)), d))
+		require.Equal(t, "Skipped operation", d.Event)
+		require.Equal(t, int64(1), d.ContractVersion)
+		require.Equal(t, http.MethodPost, d.Method)
+	})
+
+	t.Run("Audit logging is disabled", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			auditLogEvent(req, nil, o, "Skipped operation")
+		})
+	})
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"go.uber.org/zap"
+)
+
+//scopes required by the routes of the REST API
+const (
+	scopeClustersRead         = "clusters:read"
+	scopeClustersWrite        = "clusters:write"
+	scopeClustersStatus       = "clusters:status"
+	scopeOperationsStop       = "operations:stop"
+	scopeOperationsCallback   = "operations:callback"
+	scopeReconciliationsRead  = "reconciliations:read"
+	scopeReconciliationsWrite = "reconciliations:write"
+	scopeRolloutsRead         = "rollouts:read"
+	scopeRolloutsWrite        = "rollouts:write"
+	scopeWebhooksRead         = "webhooks:read"
+	scopeWebhooksWrite        = "webhooks:write"
+	scopeTasksLease           = "tasks:lease"
+	scopeReconcilersRead      = "reconcilers:read"
+	scopeReconcilersRegister  = "reconcilers:register"
+)
+
+const wwwAuthenticateHeader = "WWW-Authenticate"
+
+type authContextKey struct{}
+
+//authResult is the outcome of the authentication of a request
+type authResult struct {
+	identity *auth.Identity
+	err      error
+}
+
+func identityFromContext(r *http.Request) *auth.Identity {
+	if result, ok := r.Context().Value(authContextKey{}).(*authResult); ok {
+		return result.identity
+	}
+	return nil
+}
+
+type authorizer struct {
+	o               *Options
+	authenticator   *auth.Authenticator //nil if authentication is disabled
+	anonymousScopes []string
+	auditLogger     *zap.Logger //nil if audit logging is disabled
+}
+
+func newAuthorizer(o *Options, cfg *config.AuthConfig, auditLogger *zap.Logger) (*authorizer, error) {
+	authz := &authorizer{
+		o:           o,
+		auditLogger: auditLogger,
+	}
+	if !cfg.Enabled {
+		o.Logger().Warn("Authentication of the REST API is disabled: all requests are accepted")
+		return authz, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var keySet auth.KeySet
+	if cfg.JWKSFile != "" {
+		var err error
+		if keySet, err = auth.NewFileKeySet(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	} else {
+		keySet = auth.NewURLKeySet(cfg.JWKSURL, cfg.JWKSRefreshInterval, nil)
+	}
+
+	authenticator, err := auth.NewAuthenticator(keySet, cfg.Issuers, cfg.Audience, cfg.ScopeClaim)
+	if err != nil {
+		return nil, err
+	}
+	authz.authenticator = authenticator
+	authz.anonymousScopes = cfg.AnonymousScopes
+	return authz, nil
+}
+
+//authenticate verifies the bearer token of a request and adds the result to the request context
+func (a *authorizer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.authenticator == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		identity, err := a.authenticator.Authenticate(r)
+		ctx := context.WithValue(r.Context(), authContextKey{}, &authResult{identity: identity, err: err})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+//authorize rejects requests whose caller was not granted the scope
+func (a *authorizer) authorize(scope string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if a.authenticator == nil {
+			handler(w, r)
+			return
+		}
+
+		result, ok := r.Context().Value(authContextKey{}).(*authResult)
+		if !ok {
+			result = &authResult{err: auth.ErrNoToken}
+		}
+
+		if result.identity == nil {
+			if result.err == auth.ErrNoToken && contains(a.anonymousScopes, scope) {
+				handler(w, r)
+				return
+			}
+			challenge := `Bearer`
+			if result.err != auth.ErrNoToken {
+				challenge = `Bearer error="invalid_token"`
+			}
+			w.Header().Set(wwwAuthenticateHeader, challenge)
+			a.deny(w, r, http.StatusUnauthorized, fmt.Sprintf("Authentication failed: %s", result.err))
+			return
+		}
+
+		if !result.identity.HasScope(scope) {
+			w.Header().Set(wwwAuthenticateHeader, fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, scope))
+			a.deny(w, r, http.StatusForbidden, fmt.Sprintf("Scope '%s' is required", scope))
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+func (a *authorizer) deny(w http.ResponseWriter, r *http.Request, status int, reason string) {
+	a.o.Logger().Warnf("Rejected request %s %s (HTTP status %d): %s", r.Method, r.RequestURI, status, reason)
+	if a.auditLogger != nil {
+		auditLogDenial(r, a.auditLogger, a.o, status, reason)
+	}
+	server.SendHTTPError(w, status, &keb.HTTPErrorResponse{
+		Error: reason,
+	})
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
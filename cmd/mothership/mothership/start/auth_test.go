@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	jose "github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const authTestIssuer = "https://issuer.kyma.local"
+
+func TestAuthorizer(t *testing.T) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks, err := json.Marshal(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &privKey.PublicKey, KeyID: "key1"}}})
+	require.NoError(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, ioutil.WriteFile(jwksFile, jwks, 0600))
+
+	newToken := func(scope string) string {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: privKey},
+			(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "key1"))
+		require.NoError(t, err)
+		token, err := jwt.Signed(signer).Claims(map[string]interface{}{
+			"sub":   jwtPayloadSub,
+			"iss":   authTestIssuer,
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": scope,
+		}).CompactSerialize()
+		require.NoError(t, err)
+		return token
+	}
+
+	newRouter := func(t *testing.T, cfg *config.AuthConfig) (*mux.Router, *observer.ObservedLogs) {
+		core, auditLogs := observer.New(zap.InfoLevel)
+		o := NewOptions(&cli.Options{})
+		o.AuditLogTenantID = tenantID
+		authz, err := newAuthorizer(o, cfg, zap.New(core))
+		require.NoError(t, err)
+
+		router := mux.NewRouter()
+		router.Use(authz.authenticate)
+		router.HandleFunc(fmt.Sprintf("/v{%s}/clusters", paramContractVersion),
+			authz.authorize(scopeClustersWrite, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})).Methods(http.MethodPost)
+		router.HandleFunc(fmt.Sprintf("/v{%s}/operations/callback", paramContractVersion),
+			authz.authorize(scopeOperationsCallback, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})).Methods(http.MethodPost)
+		return router, auditLogs
+	}
+
+	call := func(router *mux.Router, url, token string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, url, nil)
+		if token != "" {
+			req.Header.Set(auth.AuthorizationHeader, "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	cfg := &config.AuthConfig{
+		Enabled:         true,
+		Issuers:         []string{authTestIssuer},
+		JWKSFile:        jwksFile,
+		AnonymousScopes: []string{scopeOperationsCallback},
+	}
+
+	t.Run("Authorized request", func(t *testing.T) {
+		router, auditLogs := newRouter(t, cfg)
+		resp := call(router, "/v1/clusters", newToken("clusters:read clusters:write"))
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, 0, auditLogs.Len())
+	})
+
+	t.Run("Request without token", func(t *testing.T) {
+		router, auditLogs := newRouter(t, cfg)
+		resp := call(router, "/v1/clusters", "")
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		require.Equal(t, "Bearer", resp.Header.Get(wwwAuthenticateHeader))
+		requireDenialLog(t, auditLogs, http.StatusUnauthorized, "UNKNOWN_USER")
+	})
+
+	t.Run("Request with invalid token", func(t *testing.T) {
+		router, auditLogs := newRouter(t, cfg)
+		resp := call(router, "/v1/clusters", "abc.def.ghi")
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		require.Contains(t, resp.Header.Get(wwwAuthenticateHeader), "invalid_token")
+		requireDenialLog(t, auditLogs, http.StatusUnauthorized, "UNKNOWN_USER")
+	})
+
+	t.Run("Request with missing scope", func(t *testing.T) {
+		router, auditLogs := newRouter(t, cfg)
+		resp := call(router, "/v1/clusters", newToken("clusters:read"))
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+		require.Contains(t, resp.Header.Get(wwwAuthenticateHeader), "insufficient_scope")
+		requireDenialLog(t, auditLogs, http.StatusForbidden, jwtPayloadSub)
+	})
+
+	t.Run("Anonymous request to route with anonymous scope", func(t *testing.T) {
+		router, _ := newRouter(t, cfg)
+		resp := call(router, "/v1/operations/callback", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		//invalid tokens are rejected even if the scope is granted to anonymous requests
+		resp = call(router, "/v1/operations/callback", "abc.def.ghi")
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Disabled authentication", func(t *testing.T) {
+		router, auditLogs := newRouter(t, &config.AuthConfig{})
+		resp := call(router, "/v1/clusters", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, 0, auditLogs.Len())
+	})
+
+	t.Run("Invalid configuration", func(t *testing.T) {
+		_, err := newAuthorizer(NewOptions(&cli.Options{}), &config.AuthConfig{Enabled: true}, nil)
+		require.Error(t, err)
+	})
+}
+
+func requireDenialLog(t *testing.T, auditLogs *observer.ObservedLogs, status int, user string) {
+	require.Equal(t, 1, auditLogs.Len())
+	fields := auditLogs.All()[0].ContextMap()
+	require.Equal(t, user, fields["user"])
+	require.Equal(t, tenantID, fields["tenant"])
+
+	d := &data{}
+	require.NoError(t, json.Unmarshal([]byte(fields["data"].(string)), d))
+	require.Equal(t, status, d.Status)
+	require.NotEmpty(t, d.Reason)
+	require.Equal(t, int64(1), d.ContractVersion)
+}
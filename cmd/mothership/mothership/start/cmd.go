@@ -54,12 +54,21 @@ func NewCmd(o *Options) *cobra.Command {
 }
 
 func Run(ctx context.Context, o *Options) error {
+	elector, err := newLeaderElector(o, viper.ConfigFileUsed())
+	if err != nil {
+		return err
+	}
+	breakers, err := newCircuitBreakers(o, viper.ConfigFileUsed())
+	if err != nil {
+		return err
+	}
+
 	go func(ctx context.Context, o *Options) {
-		err := startScheduler(ctx, o, viper.ConfigFileUsed())
+		err := startScheduler(ctx, o, viper.ConfigFileUsed(), elector, breakers)
 		if err != nil {
 			panic(err)
 		}
 	}(ctx, o)
 
-	return startWebserver(ctx, o)
+	return startWebserver(ctx, o, elector, breakers)
 }
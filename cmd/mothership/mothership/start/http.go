@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kyma-incubator/reconciler/internal/converters"
@@ -17,14 +18,24 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
 	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/breaker"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/invoker"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/leader"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/rollout"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/service"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/webhook"
 	"github.com/kyma-incubator/reconciler/pkg/server"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/viper"
+	"go.uber.org/zap"
 )
 
 const (
@@ -34,6 +45,13 @@ const (
 	paramOffset          = "offset"
 	paramSchedulingID    = "schedulingID"
 	paramCorrelationID   = "correlationID"
+	paramKymaVersion     = "kymaVersion"
+	paramWebhookID       = "webhookID"
+	paramReconciler      = "reconciler"
+	paramLeaseID         = "leaseID"
+	paramInstanceID      = "instanceID"
+	paramFromVersion     = "from"
+	paramToVersion       = "to"
 
 	paramStatus     = "status"
 	paramRuntimeIDs = "runtimeID"
@@ -41,83 +59,273 @@ const (
 	paramAfter      = "after"
 	paramLast       = "last"
 	paramTimeFormat = time.RFC3339
+
+	paramKymaProfile      = "kymaProfile"
+	paramComponent        = "component"
+	paramComponentVersion = "componentVersion"
+	paramSortBy           = "sortBy"
+	paramSortOrder        = "sortOrder"
+	paramLimit            = "limit"
+	paramCursor           = "cursor"
+)
+
+const (
+	clusterPlanTimeout        = 2 * time.Minute //all components of a cluster have to be planned within this timeout
+	maxParallelComponentPlans = 10
 )
 
-func startWebserver(ctx context.Context, o *Options) error {
+func startWebserver(ctx context.Context, o *Options, elector *leader.Elector, breakers *breaker.Breakers) error {
 	//routing
 	mainRouter := mux.NewRouter()
 	apiRouter := mainRouter.PathPrefix("/").Subrouter()
 	metricsRouter := mainRouter.Path("/metrics").Subrouter()
 	healthRouter := mainRouter.PathPrefix("/health").Subrouter()
 
+	schedulerCfg, err := parseSchedulerConfig(viper.ConfigFileUsed())
+	if err != nil {
+		return err
+	}
+
+	var auditLogger *zap.Logger
+	if o.AuditLog && o.AuditLogFile != "" && o.AuditLogTenantID != "" {
+		auditLogger, err = NewLoggerWithFile(o.AuditLogFile)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = auditLogger.Sync() }() // make golint happy
+	}
+
+	//authentication and authorization
+	authz, err := newAuthorizer(o, &schedulerCfg.Auth, auditLogger)
+	if err != nil {
+		return err
+	}
+	apiRouter.Use(authz.authenticate)
+
+	//verifies the signatures of callbacks sent by component reconcilers (used nonces are shared between replicas)
+	callbackVerifier := callback.NewVerifier(callback.DefaultSignatureTolerance).
+		WithNonceStore(o.Registry.ReplayRepository())
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/stop", paramContractVersion, paramSchedulingID, paramCorrelationID),
-		callHandler(o, updateOperationStatus)).
+		authz.authorize(scopeOperationsStop, callHandler(o, updateOperationStatus))).
+		Methods("POST")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/retry", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		authz.authorize(scopeReconciliationsWrite, callHandler(o, retryOperation))).
+		Methods("POST")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/skip", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		authz.authorize(scopeReconciliationsWrite, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			skipOperation(o, auditLogger, w, r)
+		}))).
 		Methods("POST")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters", paramContractVersion),
-		callHandler(o, createOrUpdateCluster)).
+		authz.authorize(scopeClustersWrite, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			createOrUpdateCluster(o, schedulerCfg, w, r)
+		}))).
 		Methods("PUT", "POST")
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters", paramContractVersion),
+		authz.authorize(scopeClustersRead, callHandler(o, getClusters))).
+		Methods("GET")
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}", paramContractVersion, paramRuntimeID),
-		callHandler(o, deleteCluster)).
+		authz.authorize(scopeClustersWrite, callHandler(o, deleteCluster))).
 		Methods("DELETE")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/configs/{%s}/status", paramContractVersion, paramRuntimeID, paramConfigVersion),
-		callHandler(o, getCluster)).
+		authz.authorize(scopeClustersRead, callHandler(o, getCluster))).
 		Methods("GET")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/status", paramContractVersion, paramRuntimeID),
-		callHandler(o, getLatestCluster)).
+		authz.authorize(scopeClustersRead, callHandler(o, getLatestCluster))).
 		Methods("GET")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/status", paramContractVersion, paramRuntimeID),
-		callHandler(o, updateLatestCluster)).
+		authz.authorize(scopeClustersStatus, callHandler(o, updateLatestCluster))).
 		Methods("PUT")
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/plan", paramContractVersion, paramRuntimeID),
+		authz.authorize(scopeClustersRead, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			getClusterPlan(o, schedulerCfg, w, r)
+		}))).
+		Methods("GET")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/drift", paramContractVersion, paramRuntimeID),
+		authz.authorize(scopeClustersRead, callHandler(o, getClusterDrift))).
+		Methods("GET")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/reconcile", paramContractVersion, paramRuntimeID),
+		authz.authorize(scopeReconciliationsWrite, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			reconcileCluster(o, schedulerCfg, w, r)
+		}))).
+		Methods("POST")
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/statusChanges", paramContractVersion, paramRuntimeID), //supports offset-param
-		callHandler(o, statusChanges)).
+		authz.authorize(scopeClustersRead, callHandler(o, statusChanges))).
 		Methods("GET")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/operations/{%s}/callback/{%s}", paramContractVersion, paramSchedulingID, paramCorrelationID),
-		callHandler(o, operationCallback)).
+		authz.authorize(scopeOperationsCallback, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			operationCallback(o, callbackVerifier, w, r)
+		}))).
 		Methods("POST")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/reconciliations", paramContractVersion),
-		callHandler(o, getReconciliations)).
+		authz.authorize(scopeReconciliationsRead, callHandler(o, getReconciliations))).
 		Methods("GET")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/reconciliations/{%s}/info", paramContractVersion, paramSchedulingID),
-		callHandler(o, getReconciliationInfo)).
+		authz.authorize(scopeReconciliationsRead, callHandler(o, getReconciliationInfo))).
 		Methods("GET")
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/reconciliations/{%s}/pause", paramContractVersion, paramSchedulingID),
+		authz.authorize(scopeReconciliationsWrite, callHandler(o, pauseReconciliation))).
+		Methods("POST")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/reconciliations/{%s}/resume", paramContractVersion, paramSchedulingID),
+		authz.authorize(scopeReconciliationsWrite, callHandler(o, resumeReconciliation))).
+		Methods("POST")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/reconciliations/{%s}/cancel", paramContractVersion, paramSchedulingID),
+		authz.authorize(scopeReconciliationsWrite, callHandler(o, cancelReconciliation))).
+		Methods("POST")
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/config/{%s}", paramContractVersion, paramRuntimeID, paramConfigVersion),
-		callHandler(o, getKymaConfig)).Methods(http.MethodGet)
+		authz.authorize(scopeClustersRead, callHandler(o, getKymaConfig))).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/config/{%s}/diff/{%s}", paramContractVersion, paramRuntimeID, paramFromVersion, paramToVersion),
+		authz.authorize(scopeClustersRead, callHandler(o, getKymaConfigDiff))).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/rollouts", paramContractVersion),
+		authz.authorize(scopeRolloutsRead, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			getRollouts(o, schedulerCfg, w, r)
+		}))).
+		Methods("GET")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/rollouts/{%s}", paramContractVersion, paramKymaVersion),
+		authz.authorize(scopeRolloutsRead, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			getRollout(o, schedulerCfg, w, r)
+		}))).
+		Methods("GET")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/rollouts/{%s}/resume", paramContractVersion, paramKymaVersion),
+		authz.authorize(scopeRolloutsWrite, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			resumeRollout(o, schedulerCfg, w, r)
+		}))).
+		Methods("POST")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/rollouts/{%s}/abort", paramContractVersion, paramKymaVersion),
+		authz.authorize(scopeRolloutsWrite, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			abortRollout(o, schedulerCfg, w, r)
+		}))).
+		Methods("POST")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/webhooks", paramContractVersion),
+		authz.authorize(scopeWebhooksRead, callHandler(o, getWebhooks))).
+		Methods("GET")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/webhooks", paramContractVersion),
+		authz.authorize(scopeWebhooksWrite, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			createWebhook(o, schedulerCfg, w, r)
+		}))).
+		Methods("POST")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/webhooks/{%s}", paramContractVersion, paramWebhookID),
+		authz.authorize(scopeWebhooksRead, callHandler(o, getWebhook))).
+		Methods("GET")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/webhooks/{%s}", paramContractVersion, paramWebhookID),
+		authz.authorize(scopeWebhooksWrite, callHandler(o, deleteWebhook))).
+		Methods("DELETE")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/webhooks/{%s}/deadletters", paramContractVersion, paramWebhookID),
+		authz.authorize(scopeWebhooksRead, callHandler(o, getWebhookDeadLetters))).
+		Methods("GET")
+
+	//component reconcilers which are configured to lease their tasks are pulling them from the task queue
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/tasks/{%s}/lease", paramContractVersion, paramReconciler),
+		authz.authorize(scopeTasksLease, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			leaseTask(o, schedulerCfg, w, r)
+		}))).
+		Methods("POST")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/tasks/{%s}/{%s}/{%s}/extend", paramContractVersion, paramReconciler, paramCorrelationID, paramLeaseID),
+		authz.authorize(scopeTasksLease, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			extendTaskLease(o, schedulerCfg, w, r)
+		}))).
+		Methods("POST")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/tasks/{%s}/{%s}/{%s}", paramContractVersion, paramReconciler, paramCorrelationID, paramLeaseID),
+		authz.authorize(scopeTasksLease, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			completeTask(o, schedulerCfg, w, r)
+		}))).
+		Methods("DELETE")
+
+	//component reconcilers register themselves and renew their registration periodically
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/reconcilers", paramContractVersion),
+		authz.authorize(scopeReconcilersRead, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			getReconcilers(o, schedulerCfg, w, r)
+		}))).
+		Methods("GET")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/reconcilers", paramContractVersion),
+		authz.authorize(scopeReconcilersRegister, callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			registerReconciler(o, schedulerCfg, w, r)
+		}))).
+		Methods("POST")
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/reconcilers/{%s}", paramContractVersion, paramInstanceID),
+		authz.authorize(scopeReconcilersRegister, callHandler(o, deregisterReconciler))).
+		Methods("DELETE")
 
 	//metrics endpoint
-	metrics.RegisterAll(o.Registry.Inventory(), o.Logger())
+	metrics.RegisterAll(o.Registry.Inventory(), o.Registry.DriftRepository(), o.Registry.ReconciliationRepository(),
+		&schedulerCfg.Scheduler, o.Logger())
 	metricsRouter.Handle("", promhttp.Handler())
 
 	//liveness and readiness checks
 	healthRouter.HandleFunc("/live", live)
-	healthRouter.HandleFunc("/ready", ready(o))
+	healthRouter.HandleFunc("/ready", ready(o, elector, breakers))
 
-	if o.AuditLog && o.AuditLogFile != "" && o.AuditLogTenantID != "" {
-		auditLogger, err := NewLoggerWithFile(o.AuditLogFile)
-		if err != nil {
-			return err
-		}
-		defer func() { _ = auditLogger.Sync() }() // make golint happy
+	if auditLogger != nil {
 		auditLoggerMiddelware := newAuditLoggerMiddelware(auditLogger, o)
 		apiRouter.Use(auditLoggerMiddelware)
 	}
@@ -136,13 +344,35 @@ func live(w http.ResponseWriter, _ *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-func ready(o *Options) http.HandlerFunc {
+//readiness reports whether the replica is the leader: all replicas are ready to serve requests, only the leader
+//runs the scheduler, bookkeeper, cleaner and worker pool
+type readiness struct {
+	Leader   bool                     `json:"leader"`
+	Identity string                   `json:"identity,omitempty"`
+	Circuits map[string]breaker.State `json:"circuits,omitempty"` //circuit breaker states of the component reconcilers
+}
+
+//ready verifies the database connection (elector is nil if the leader election is disabled, breakers are nil
+//if the circuit breaker is disabled): circuits are only tracked by the leader which invokes the component reconcilers
+func ready(o *Options, elector *leader.Elector, breakers *breaker.Breakers) http.HandlerFunc {
 	return func(w http.ResponseWriter, _ *http.Request) {
 		if o.Registry.Connnection().Ping() != nil {
 			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
 			return
 		}
+		status := &readiness{Leader: true}
+		if elector != nil {
+			status.Leader = elector.IsLeader()
+			status.Identity = elector.Identity()
+		}
+		if breakers != nil {
+			status.Circuits = breakers.States()
+		}
+		w.Header().Set("content-type", "application/json")
 		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			o.Logger().Warnf("Failed to encode readiness response: %s", err)
+		}
 	}
 }
 
@@ -152,7 +382,7 @@ func callHandler(o *Options, handler func(o *Options, w http.ResponseWriter, r *
 	}
 }
 
-func createOrUpdateCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+func createOrUpdateCluster(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
 	contractV, err := params.Int64(paramContractVersion)
 	if err != nil {
@@ -181,6 +411,18 @@ func createOrUpdateCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if err := cluster.ValidateMaintenanceWindow(&clusterModel.Metadata); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "maintenance window not accepted").Error(),
+		})
+		return
+	}
+	if err := applyComponentDependencies(clusterModel, schedulerCfg); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "component dependencies not accepted").Error(),
+		})
+		return
+	}
 
 	clusterStateOld, err := o.Registry.Inventory().GetLatest(clusterModel.RuntimeID)
 	if err != nil && !repository.IsNotFoundError(err) {
@@ -396,6 +638,10 @@ func getReconciliationInfo(o *Options, w http.ResponseWriter, r *http.Request) {
 		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
 		return
 	}
+	sendReconciliationInfo(o, w, schedulingID)
+}
+
+func sendReconciliationInfo(o *Options, w http.ResponseWriter, schedulingID string) {
 	reconciliationEntity, err := o.Registry.ReconciliationRepository().GetReconciliation(schedulingID)
 	if err != nil {
 		server.SendHTTPErrorMap(w, err)
@@ -421,7 +667,48 @@ func getReconciliationInfo(o *Options, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+func pauseReconciliation(o *Options, w http.ResponseWriter, r *http.Request) {
+	controlReconciliation(o, w, r, func(schedulingID string) error {
+		return o.Registry.ReconciliationRepository().PauseReconciliation(schedulingID)
+	})
+}
+
+func resumeReconciliation(o *Options, w http.ResponseWriter, r *http.Request) {
+	controlReconciliation(o, w, r, func(schedulingID string) error {
+		return o.Registry.ReconciliationRepository().ResumeReconciliation(schedulingID)
+	})
+}
+
+func cancelReconciliation(o *Options, w http.ResponseWriter, r *http.Request) {
+	controlReconciliation(o, w, r, func(schedulingID string) error {
+		return o.Registry.ReconciliationRepository().CancelReconciliation(schedulingID, "reconciliation was cancelled")
+	})
+}
+
+//controlReconciliation applies the control function to a running reconciliation and responds with its new state
+func controlReconciliation(o *Options, w http.ResponseWriter, r *http.Request, controlFct func(schedulingID string) error) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	if err := controlFct(schedulingID); err != nil {
+		if reconciliation.IsFinishedReconciliationError(err) {
+			server.SendHTTPError(w, http.StatusConflict, &keb.HTTPErrorResponse{Error: err.Error()})
+			return
+		}
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	getReconciliationInfo(o, w, r)
+}
+
+//reconcileCluster starts a reconciliation of the latest cluster configuration without waiting for the
+//reconcile interval: if components are defined, only these components (and their dependencies) are reconciled
+func reconcileCluster(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
 	runtimeID, err := params.String(paramRuntimeID)
 	if err != nil {
@@ -430,6 +717,32 @@ func getLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	var reconcileRequest keb.ReconcileRequest
+	if err := json.Unmarshal(reqBody, &reconcileRequest); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	if strings.TrimSpace(reconcileRequest.Reason) == "" {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: "Reason of the reconciliation is undefined",
+		})
+		return
+	}
+	onDemand := &reconciliation.OnDemand{Reason: reconcileRequest.Reason}
+	if reconcileRequest.Components != nil {
+		onDemand.Components = *reconcileRequest.Components
+	}
+
 	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
 	if err != nil {
 		httpCode := http.StatusInternalServerError
@@ -441,12 +754,36 @@ func getLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
-	sendResponse(w, r, clusterState, o.Registry.ReconciliationRepository())
+	//reject unknown components before a reconciliation gets created
+	if len(onDemand.Components) > 0 {
+		sequence := clusterState.Configuration.GetReconciliationSequence(schedulerCfg.Scheduler.PreComponents)
+		if _, err := sequence.Select(onDemand.Components); err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+	}
+
+	transition := service.NewClusterStatusTransition(o.Registry.Connnection(), o.Registry.Inventory(),
+		o.Registry.ReconciliationRepository(), o.Logger())
+	reconEntity, err := transition.StartOnDemandReconciliation(runtimeID, clusterState.Configuration.Version,
+		schedulerCfg.Scheduler.PreComponents, onDemand)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if service.IsClusterStateConflictError(err) || reconciliation.IsDuplicateClusterReconciliationError(err) {
+			httpCode = http.StatusConflict
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Failed to reconcile cluster '%s'", runtimeID)).Error(),
+		})
+		return
+	}
+	sendReconciliationInfo(o, w, reconEntity.SchedulingID)
 }
 
-func statusChanges(o *Options, w http.ResponseWriter, r *http.Request) {
+func getLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-
 	runtimeID, err := params.String(paramRuntimeID)
 	if err != nil {
 		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
@@ -454,61 +791,113 @@ func statusChanges(o *Options, w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
-
-	offset, err := params.String(paramOffset)
+	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
 	if err != nil {
-		offset = fmt.Sprintf("%dh", 24*7) //default offset is 1 week
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster state").Error(),
+		})
+		return
 	}
+	sendResponse(w, r, clusterState, o.Registry.ReconciliationRepository())
+}
 
-	o.Logger().Debugf("Using an offset of '%s' for cluster status updates", offset)
-
-	duration, err := time.ParseDuration(offset)
+func getClusterPlan(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
 	if err != nil {
 		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
 			Error: err.Error(),
 		})
 		return
 	}
-
-	statusChanges, err := o.Registry.Inventory().StatusChanges(runtimeID, duration)
+	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
 	if err != nil {
 		httpCode := http.StatusInternalServerError
 		if repository.IsNotFoundError(err) {
 			httpCode = http.StatusNotFound
 		}
 		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Could not retrieve cluster statusChanges").Error(),
+			Error: errors.Wrap(err, "Could not retrieve cluster state").Error(),
 		})
 		return
 	}
+	//ask the component reconcilers for the changes of each component (the cleaner never has a manifest):
+	//the components are planned in parallel and the whole plan has to finish within the timeout
+	var components []*keb.Component
+	for _, queued := range clusterState.Configuration.GetReconciliationSequence(schedulerCfg.Scheduler.PreComponents).Queue {
+		for _, component := range queued {
+			if component.Component != model.CleanupComponent {
+				components = append(components, component)
+			}
+		}
+	}
 
-	resp := keb.HTTPClusterStatusResponse{}
-	for _, statusChange := range statusChanges {
-		kebClusterStatus, err := statusChange.Status.GetKEBClusterStatus()
-		if err != nil {
-			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
-				Error: errors.Wrap(err, "Failed to map reconciler internal cluster status to KEB cluster status").Error(),
+	ctx, cancel := context.WithTimeout(r.Context(), clusterPlanTimeout)
+	defer cancel()
+	planner := invoker.NewRemoteReoncilerInvoker(o.Registry.ReconciliationRepository(), schedulerCfg, o.Logger())
+	componentPlans := make([]keb.ComponentPlan, len(components))
+	semaphore := make(chan struct{}, maxParallelComponentPlans)
+	var wg sync.WaitGroup
+	for idx, component := range components {
+		wg.Add(1)
+		go func(idx int, component *keb.Component) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			componentPlan := keb.ComponentPlan{
+				Component: component.Component,
+				Changes:   []keb.ResourceChange{},
+			}
+			changes, err := planner.Plan(ctx, &invoker.Params{
+				ComponentToReconcile: component,
+				ClusterState:         clusterState,
+				CorrelationID:        fmt.Sprintf("plan-%s", uuid.NewString()),
 			})
-			return
-		}
-		resp.StatusChanges = append(resp.StatusChanges, keb.StatusChange{
-			Started:  statusChange.Status.Created,
-			Duration: int64(statusChange.Duration),
-			Status:   kebClusterStatus,
-		})
+			if err == nil {
+				componentPlan.Changes = converters.ConvertResourceChanges(changes)
+			} else {
+				o.Logger().Warnf("Failed to plan component '%s' of cluster '%s': %s", component.Component, runtimeID, err)
+				errMsg := err.Error()
+				componentPlan.Error = &errMsg
+			}
+			componentPlans[idx] = componentPlan
+		}(idx, component)
+	}
+	wg.Wait()
+
+	response := keb.HTTPClusterPlanResponse{
+		RuntimeID:     clusterState.Cluster.RuntimeID,
+		ConfigVersion: clusterState.Configuration.Version,
+		Components:    componentPlans,
 	}
 
-	//respond
 	w.Header().Set("content-type", "application/json")
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
+	if err := json.NewEncoder(w).Encode(keb.ClusterPlanOKResponse(response)); err != nil {
 		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Failed to encode cluster statusChanges response").Error(),
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
 		})
-		return
 	}
 }
 
-func deleteCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+//applyComponentDependencies adds the dependencies of the scheduler configuration to the components
+//and verifies that the components can be ordered (no cycles)
+func applyComponentDependencies(clusterModel *keb.Cluster, schedulerCfg *config.Config) error {
+	schedulerCfg.Scheduler.ApplyDependencies(clusterModel.KymaConfig.Components)
+
+	configEntity := &model.ClusterConfigurationEntity{}
+	for idx := range clusterModel.KymaConfig.Components {
+		configEntity.Components = append(configEntity.Components, &clusterModel.KymaConfig.Components[idx])
+	}
+	_, err := configEntity.GetReconciliationSequence(schedulerCfg.Scheduler.PreComponents).DependencyGraph()
+	return err
+}
+
+func getClusterDrift(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
 	runtimeID, err := params.String(paramRuntimeID)
 	if err != nil {
@@ -517,34 +906,271 @@ func deleteCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
-	if _, err := o.Registry.Inventory().GetLatest(runtimeID); repository.IsNotFoundError(err) {
+	drifts, err := o.Registry.DriftRepository().Get(runtimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve drifts of cluster").Error(),
+		})
+		return
+	}
+	if len(drifts) == 0 {
 		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, fmt.Sprintf("Deletion impossible: Cluster '%s' not found", runtimeID)).Error(),
+			Error: fmt.Sprintf("No drift detection result found for cluster '%s' "+
+				"(drift detection is disabled or the cluster is not ready)", runtimeID),
 		})
 		return
 	}
-	state, err := o.Registry.Inventory().MarkForDeletion(runtimeID)
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.ClusterDriftOKResponse(converters.ConvertClusterDrift(runtimeID, drifts))); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
+}
+
+func getRollouts(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
+	policy := rolloutPolicy(o, schedulerCfg)
+	statuses, err := policy.Statuses()
 	if err != nil {
 		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, fmt.Sprintf("Failed to delete cluster '%s'", runtimeID)).Error(),
+			Error: errors.Wrap(err, "Could not retrieve rollouts").Error(),
 		})
 		return
 	}
-	sendResponse(w, r, state, o.Registry.ReconciliationRepository())
+
+	response := keb.HTTPRolloutsResponse{}
+	for _, status := range statuses {
+		response = append(response, converters.ConvertRolloutStatus(status))
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.RolloutsOKResponse(response)); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
 }
 
-func updateOperationStatus(o *Options, w http.ResponseWriter, r *http.Request) {
+func getRollout(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-	schedulingID, err := params.String(paramSchedulingID)
+	kymaVersion, err := params.String(paramKymaVersion)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
 			Error: err.Error(),
 		})
 		return
 	}
-	correlationID, err := params.String(paramCorrelationID)
+	policy := rolloutPolicy(o, schedulerCfg)
+	status, err := policy.Status(kymaVersion)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve rollout").Error(),
+		})
+		return
+	}
+	if status.Pending+status.Succeeded+status.Failed+status.Superseded == 0 {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("No rollout found for Kyma version '%s'", kymaVersion),
+		})
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.RolloutOKResponse(converters.ConvertRolloutStatus(status))); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
+}
+
+func resumeRollout(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	kymaVersion, err := params.String(paramKymaVersion)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	policy := rolloutPolicy(o, schedulerCfg)
+	status, err := policy.Resume(kymaVersion)
+	if err != nil {
+		sendRolloutError(w, kymaVersion, errors.Wrap(err, "Could not resume rollout"))
+		return
+	}
+	sendRolloutStatus(w, status)
+}
+
+func abortRollout(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	kymaVersion, err := params.String(paramKymaVersion)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	abort := &keb.RolloutAbort{}
+	if err := json.Unmarshal(reqBody, abort); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	if abort.Reason == "" {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: "Reason for aborting the rollout is missing",
+		})
+		return
+	}
+	policy := rolloutPolicy(o, schedulerCfg)
+	status, err := policy.Abort(kymaVersion, abort.Reason)
+	if err != nil {
+		sendRolloutError(w, kymaVersion, errors.Wrap(err, "Could not abort rollout"))
+		return
+	}
+	sendRolloutStatus(w, status)
+}
+
+func sendRolloutError(w http.ResponseWriter, kymaVersion string, err error) {
+	switch errors.Cause(err) {
+	case rollout.ErrRolloutNotFound:
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("No rollout found for Kyma version '%s'", kymaVersion),
+		})
+	case rollout.ErrRolloutNotHalted:
+		server.SendHTTPError(w, http.StatusConflict, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+	default:
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+	}
+}
+
+func sendRolloutStatus(w http.ResponseWriter, status *rollout.Status) {
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.RolloutOKResponse(converters.ConvertRolloutStatus(status))); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
+}
+
+func rolloutPolicy(o *Options, schedulerCfg *config.Config) *rollout.Policy {
+	return rollout.NewPolicy(&schedulerCfg.Scheduler.Rollout, o.Registry.RolloutRepository(),
+		o.Registry.Inventory(), o.Logger())
+}
+
+func statusChanges(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	offset, err := params.String(paramOffset)
+	if err != nil {
+		offset = fmt.Sprintf("%dh", 24*7) //default offset is 1 week
+	}
+
+	o.Logger().Debugf("Using an offset of '%s' for cluster status updates", offset)
+
+	duration, err := time.ParseDuration(offset)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	statusChanges, err := o.Registry.Inventory().StatusChanges(runtimeID, duration)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster statusChanges").Error(),
+		})
+		return
+	}
+
+	resp := keb.HTTPClusterStatusResponse{}
+	for _, statusChange := range statusChanges {
+		kebClusterStatus, err := statusChange.Status.GetKEBClusterStatus()
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, "Failed to map reconciler internal cluster status to KEB cluster status").Error(),
+			})
+			return
+		}
+		resp.StatusChanges = append(resp.StatusChanges, keb.StatusChange{
+			Started:  statusChange.Status.Created,
+			Duration: int64(statusChange.Duration),
+			Status:   kebClusterStatus,
+		})
+	}
+
+	//respond
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode cluster statusChanges response").Error(),
+		})
+		return
+	}
+}
+
+func deleteCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	if _, err := o.Registry.Inventory().GetLatest(runtimeID); repository.IsNotFoundError(err) {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Deletion impossible: Cluster '%s' not found", runtimeID)).Error(),
+		})
+		return
+	}
+	state, err := o.Registry.Inventory().MarkForDeletion(runtimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Failed to delete cluster '%s'", runtimeID)).Error(),
+		})
+		return
+	}
+	sendResponse(w, r, state, o.Registry.ReconciliationRepository())
+}
+
+func updateOperationStatus(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
 			Error: err.Error(),
 		})
 		return
@@ -600,7 +1226,78 @@ func updateOperationStatus(o *Options, w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-func operationCallback(o *Options, w http.ResponseWriter, r *http.Request) {
+//retryOperation resets a failed operation so that it and the remaining operations of its reconciliation get processed
+func retryOperation(o *Options, w http.ResponseWriter, r *http.Request) {
+	resolveFailedOperation(o, w, r, func(transition *service.ClusterStatusTransition, schedulingID, correlationID string) error {
+		return transition.RetryOperation(schedulingID, correlationID)
+	})
+}
+
+//skipOperation marks a failed operation as skipped so that the remaining operations of its reconciliation get processed
+func skipOperation(o *Options, auditLogger *zap.Logger, w http.ResponseWriter, r *http.Request) {
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	var skip keb.OperationSkip
+	if err := json.Unmarshal(reqBody, &skip); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	if strings.TrimSpace(skip.Reason) == "" {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: "Reason for skipping the operation is undefined",
+		})
+		return
+	}
+
+	resolveFailedOperation(o, w, r, func(transition *service.ClusterStatusTransition, schedulingID, correlationID string) error {
+		if err := transition.SkipOperation(schedulingID, correlationID, skip.Reason); err != nil {
+			return err
+		}
+		auditLogEvent(r, auditLogger, o, fmt.Sprintf("Skipped operation (schedulingID:%s/correlationID:%s): %s",
+			schedulingID, correlationID, skip.Reason))
+		return nil
+	})
+}
+
+func resolveFailedOperation(o *Options, w http.ResponseWriter, r *http.Request,
+	resolveFct func(transition *service.ClusterStatusTransition, schedulingID, correlationID string) error) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{Error: err.Error()})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{Error: err.Error()})
+		return
+	}
+
+	transition := service.NewClusterStatusTransition(o.Registry.Connnection(), o.Registry.Inventory(),
+		o.Registry.ReconciliationRepository(), o.Logger())
+	if err := resolveFct(transition, schedulingID, correlationID); err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		} else if reconciliation.IsOperationNotFailedError(err) || service.IsClusterStateConflictError(err) {
+			httpCode = http.StatusConflict
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to resolve failed operation").Error(),
+		})
+		return
+	}
+	sendReconciliationInfo(o, w, schedulingID)
+}
+
+func operationCallback(o *Options, verifier *callback.Verifier, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
 	schedulingID, err := params.String(paramSchedulingID)
 	if err != nil {
@@ -641,20 +1338,47 @@ func operationCallback(o *Options, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	//accept only callbacks which were signed with the secret issued for the operation
+	op, err := getOperationStatus(o, schedulingID, correlationID)
+	if err == nil && op == nil {
+		err = &repository.EntityNotFoundError{}
+	}
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	if err := verifier.Verify(r, op.CallbackSecret, correlationID, reqBody); err != nil {
+		o.Logger().Warnf("REST endpoint rejected callback for operation (schedulingID:%s/correlationID:%s): %s",
+			schedulingID, correlationID, err)
+		server.SendHTTPError(w, http.StatusUnauthorized, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Callback rejected").Error(),
+		})
+		return
+	}
+
 	switch body.Status {
 	case reconciler.StatusNotstarted, reconciler.StatusRunning:
 		err = updateOperationState(o, schedulingID, correlationID, model.OperationStateInProgress)
 	case reconciler.StatusFailed:
-		err = updateOperationState(o, schedulingID, correlationID, model.OperationStateFailed, body.Error)
+		err = updateOperationState(o, schedulingID, correlationID, model.OperationStateFailed, body.Reason())
 	case reconciler.StatusSuccess:
 		err = updateOperationState(o, schedulingID, correlationID, model.OperationStateDone)
 	case reconciler.StatusError:
-		err = updateOperationState(o, schedulingID, correlationID, model.OperationStateError, body.Error)
+		err = updateOperationState(o, schedulingID, correlationID, model.OperationStateError, body.Reason())
 	}
 	if err != nil {
 		httpCode := http.StatusBadRequest
 		if repository.IsNotFoundError(err) {
 			httpCode = http.StatusNotFound
+		} else if op, getErr := getOperationStatus(o, schedulingID, correlationID); getErr == nil &&
+			op.State == model.OperationStateCancelled {
+			httpCode = http.StatusGone //component reconciler stops processing the operation
 		}
 		server.SendHTTPError(w, httpCode, &reconciler.HTTPErrorResponse{
 			Error: err.Error(),
@@ -696,6 +1420,51 @@ func getKymaConfig(o *Options, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func getKymaConfigDiff(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
+		return
+	}
+	fromVersion, err := params.Int64(paramFromVersion)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
+		return
+	}
+	toVersion, err := params.Int64(paramToVersion)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
+		return
+	}
+
+	fromState, err := o.Registry.Inventory().Get(runtimeID, fromVersion)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	toState, err := o.Registry.Inventory().Get(runtimeID, toVersion)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	encryptedKeys, err := o.Registry.KVRepository().EncryptedKeys()
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to retrieve encrypted KV-bucket keys").Error(),
+		})
+		return
+	}
+	response := converters.ConvertConfigDiff(fromState.Configuration, toState.Configuration, encryptedKeys)
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.ClusterConfigDiffOKResponse(response)); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
+}
+
 func updateOperationState(o *Options, schedulingID, correlationID string, state model.OperationState, reason ...string) error {
 	err := o.Registry.ReconciliationRepository().UpdateOperationState(schedulingID, correlationID, state, true, strings.Join(reason, ", "))
 	if err != nil {
@@ -760,12 +1529,18 @@ func newClusterResponse(r *http.Request, clusterState *cluster.State, reconcilia
 		}
 	}
 
+	nextAllowedStart, err := cluster.NextAllowedStart(clusterState, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
 	return &keb.HTTPClusterResponse{
 		Cluster:              clusterState.Cluster.RuntimeID,
 		ClusterVersion:       clusterState.Cluster.Version,
 		ConfigurationVersion: clusterState.Configuration.Version,
 		Status:               kebStatus,
 		Failures:             &failures,
+		NextAllowedStart:     nextAllowedStart,
 		StatusURL: (&url.URL{
 			Scheme: viper.GetString("mothership.scheme"),
 			Host:   fmt.Sprintf("%s:%s", viper.GetString("mothership.host"), viper.GetString("mothership.port")),
@@ -777,3 +1552,235 @@ func newClusterResponse(r *http.Request, clusterState *cluster.State, reconcilia
 		}).String(),
 	}, nil
 }
+
+func getClusters(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	filter := &cluster.FleetFilter{
+		Metadata: make(map[string]string),
+	}
+	if statuses, err := params.StrSlice(paramStatus); err == nil {
+		for _, status := range statuses {
+			filter.Statuses = append(filter.Statuses, model.Status(status))
+		}
+	}
+	filter.KymaVersion, _ = params.String(paramKymaVersion)
+	filter.KymaProfile, _ = params.String(paramKymaProfile)
+	filter.Component, _ = params.String(paramComponent)
+	filter.ComponentVersion, _ = params.String(paramComponentVersion)
+	for _, field := range cluster.MetadataFields() {
+		if value, err := params.String(field); err == nil && value != "" {
+			filter.Metadata[field] = value
+		}
+	}
+	if sortBy, err := params.String(paramSortBy); err == nil {
+		filter.SortBy = cluster.FleetSortField(sortBy)
+	}
+	if sortOrder, err := params.String(paramSortOrder); err == nil {
+		switch keb.GetClustersParamsSortOrder(sortOrder) {
+		case keb.GetClustersParamsSortOrderAsc:
+		case keb.GetClustersParamsSortOrderDesc:
+			filter.SortDescending = true
+		default:
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+				Error: fmt.Sprintf("sort order '%s' is not supported", sortOrder),
+			})
+			return
+		}
+	}
+	if params.Exists(paramLimit) {
+		limit, err := params.Int(paramLimit)
+		if err != nil || limit < 1 {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+				Error: fmt.Sprintf("limit has to be between 1 and %d", cluster.MaxFleetLimit),
+			})
+			return
+		}
+		filter.Limit = limit
+	}
+	filter.Cursor, _ = params.String(paramCursor)
+
+	if err := filter.Validate(); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	page, err := o.Registry.Inventory().Fleet(filter)
+	if cluster.IsInvalidCursorError(err) {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to list clusters").Error(),
+		})
+		return
+	}
+	response, err := converters.ConvertFleetPage(page)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to convert cluster list").Error(),
+		})
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.FleetOKResponse(response)); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode cluster list response").Error(),
+		})
+	}
+}
+
+func getWebhooks(o *Options, w http.ResponseWriter, r *http.Request) {
+	webhooks, err := o.Registry.WebhookRepository().GetAll()
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve webhooks").Error(),
+		})
+		return
+	}
+
+	response := keb.HTTPWebhooksResponse{}
+	for _, webhook := range webhooks {
+		response = append(response, converters.ConvertWebhook(webhook))
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.WebhooksOKResponse(response)); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
+}
+
+func createWebhook(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	registration := &keb.WebhookRegistration{}
+	if err := json.Unmarshal(reqBody, registration); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	entity := converters.ConvertWebhookRegistration(registration)
+	if err := webhook.Validate(entity); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "webhook not accepted").Error(),
+		})
+		return
+	}
+	guard, err := webhook.NewAddressGuard(schedulerCfg.Scheduler.Webhooks.AllowedNetworks)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not read webhook configuration").Error(),
+		})
+		return
+	}
+	if err := guard.Verify(r.Context(), entity.URL); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "webhook not accepted").Error(),
+		})
+		return
+	}
+	entity, err = o.Registry.WebhookRepository().Create(entity)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to register webhook").Error(),
+		})
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(keb.WebhookOKResponse(converters.ConvertWebhook(entity))); err != nil {
+		o.Logger().Warnf("Failed to encode response payload to JSON: %s", err)
+	}
+}
+
+func getWebhook(o *Options, w http.ResponseWriter, r *http.Request) {
+	entity, ok := findWebhook(o, w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.WebhookOKResponse(converters.ConvertWebhook(entity))); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
+}
+
+func deleteWebhook(o *Options, w http.ResponseWriter, r *http.Request) {
+	entity, ok := findWebhook(o, w, r)
+	if !ok {
+		return
+	}
+	if err := o.Registry.WebhookRepository().Delete(entity.ID); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Failed to delete webhook '%s'", entity.ID)).Error(),
+		})
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func getWebhookDeadLetters(o *Options, w http.ResponseWriter, r *http.Request) {
+	entity, ok := findWebhook(o, w, r)
+	if !ok {
+		return
+	}
+	deliveries, err := o.Registry.WebhookRepository().GetDeliveries(entity.ID, model.WebhookDeliveryStateDead)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve dead letters").Error(),
+		})
+		return
+	}
+
+	response := keb.HTTPWebhookDeliveriesResponse{}
+	for _, delivery := range deliveries {
+		kebDelivery, err := converters.ConvertWebhookDelivery(delivery)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, "Failed to convert dead letter").Error(),
+			})
+			return
+		}
+		response = append(response, kebDelivery)
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.WebhookDeliveriesOKResponse(response)); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
+}
+
+//findWebhook returns the webhook addressed by the request or sends an error response
+func findWebhook(o *Options, w http.ResponseWriter, r *http.Request) (*model.WebhookEntity, bool) {
+	params := server.NewParams(r)
+	webhookID, err := params.String(paramWebhookID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return nil, false
+	}
+	entity, err := o.Registry.WebhookRepository().Get(webhookID)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Could not retrieve webhook '%s'", webhookID)).Error(),
+		})
+		return nil, false
+	}
+	return entity, true
+}
@@ -273,6 +273,34 @@ func TestMothership(t *testing.T) {
 			responseModel:    &keb.HTTPErrorResponse{},
 			verifier:         requireErrorResponseFct,
 		},
+		{
+			name:             "Get list of clusters: paginated",
+			url:              fmt.Sprintf("%s/clusters?limit=1&sortBy=updated&sortOrder=desc", baseURL),
+			method:           httpGet,
+			expectedHTTPCode: 200,
+			responseModel:    &keb.FleetOKResponse{},
+			verifier: func(t *testing.T, response interface{}) {
+				fleet := response.(*keb.FleetOKResponse)
+				require.Len(t, fleet.Clusters, 1)
+				require.NotNil(t, fleet.NextCursor)
+			},
+		},
+		{
+			name:             "Get list of clusters: unsupported sort field",
+			url:              fmt.Sprintf("%s/clusters?sortBy=kubeconfig", baseURL),
+			method:           httpGet,
+			expectedHTTPCode: 400,
+			responseModel:    &keb.HTTPErrorResponse{},
+			verifier:         requireErrorResponseFct,
+		},
+		{
+			name:             "Get list of clusters: invalid cursor",
+			url:              fmt.Sprintf("%s/clusters?cursor=invalid", baseURL),
+			method:           httpGet,
+			expectedHTTPCode: 400,
+			responseModel:    &keb.HTTPErrorResponse{},
+			verifier:         requireErrorResponseFct,
+		},
 		{
 			name:             "Get list of reconciliations: all",
 			url:              fmt.Sprintf("%s/reconciliations", baseURL),
@@ -366,6 +394,71 @@ func TestMothership(t *testing.T) {
 			verifier:         threeReconciliationOps,
 			// no need for waiting in initFn
 		},
+		{
+			name: "Component reconciler heartbeat: unsigned callback",
+			dynamicURL: func() string {
+				resp := callMothership(t, &testCase{
+					url:              fmt.Sprintf("%s/reconciliations", baseURL),
+					method:           httpGet,
+					expectedHTTPCode: 200,
+					responseModel:    &keb.ReconcilationsOKResponse{},
+				})
+				respModel := *(resp.(*keb.ReconcilationsOKResponse))
+				require.NotEmpty(t, respModel)
+
+				resp = callMothership(t, &testCase{
+					url:              fmt.Sprintf("%s/reconciliations/%s/info", baseURL, respModel[0].SchedulingID),
+					method:           httpGet,
+					expectedHTTPCode: 200,
+					responseModel:    &keb.HTTPReconciliationInfo{},
+				})
+				info := resp.(*keb.HTTPReconciliationInfo)
+				require.NotEmpty(t, info.Operations)
+
+				return fmt.Sprintf("%s/operations/%s/callback/%s", baseURL, info.SchedulingID, info.Operations[0].CorrelationID)
+			},
+			payload:          payload(t, "callback.json", ""),
+			method:           httpPost,
+			expectedHTTPCode: 401,
+			responseModel:    &keb.HTTPErrorResponse{},
+			verifier:         requireErrorResponseFct,
+		},
+		{
+			name:             "Reconcile cluster: without reason",
+			url:              fmt.Sprintf("%s/clusters/%s/reconcile", baseURL, clusterName2),
+			method:           httpPost,
+			payload:          payload(t, "reconcile_cluster_without_reason.json", ""),
+			expectedHTTPCode: 400,
+			responseModel:    &keb.HTTPErrorResponse{},
+			verifier:         requireErrorResponseFct,
+		},
+		{
+			name:             "Reconcile cluster: using unknown component",
+			url:              fmt.Sprintf("%s/clusters/%s/reconcile", baseURL, clusterName2),
+			method:           httpPost,
+			payload:          payload(t, "reconcile_cluster_unknown_component.json", ""),
+			expectedHTTPCode: 400,
+			responseModel:    &keb.HTTPErrorResponse{},
+			verifier:         requireErrorResponseFct,
+		},
+		{
+			name:             "Reconcile cluster: using non-existing cluster",
+			url:              fmt.Sprintf("%s/clusters/%s/reconcile", baseURL, "idontexist"),
+			method:           httpPost,
+			payload:          payload(t, "reconcile_cluster.json", ""),
+			expectedHTTPCode: 404,
+			responseModel:    &keb.HTTPErrorResponse{},
+			verifier:         requireErrorResponseFct,
+		},
+		{
+			name:             "Reconcile cluster: reconciliation is already running",
+			url:              fmt.Sprintf("%s/clusters/%s/reconcile", baseURL, clusterName2),
+			method:           httpPost,
+			payload:          payload(t, "reconcile_cluster.json", ""),
+			expectedHTTPCode: 409,
+			responseModel:    &keb.HTTPErrorResponse{},
+			verifier:         requireErrorResponseFct,
+		},
 		{
 			name:             "Disable reconciliation",
 			url:              fmt.Sprintf("%s/clusters/%s/status", baseURL, clusterName2),
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/discovery"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//getReconcilers lists the registered component reconciler instances and whether they are healthy
+func getReconcilers(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
+	instances, err := o.Registry.DiscoveryRepository().GetAll()
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve registered component reconcilers").Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	response := &reconciler.HTTPReconcilersResponse{Reconcilers: []*reconciler.HTTPReconcilerInstance{}}
+	for _, instance := range instances {
+		response.Reconcilers = append(response.Reconcilers, &reconciler.HTTPReconcilerInstance{
+			HTTPReconcilerRegistration: reconciler.HTTPReconcilerRegistration{
+				InstanceID: instance.InstanceID,
+				Name:       instance.Name,
+				URL:        instance.URL,
+				Components: instance.Components,
+				Version:    instance.Version,
+				Workers:    int(instance.Workers),
+			},
+			Heartbeat: time.Unix(0, instance.Heartbeat).UTC(),
+			Healthy:   schedulerCfg.Scheduler.Discovery.Enabled && instance.Healthy(now, heartbeatTimeout(schedulerCfg)),
+		})
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
+}
+
+//registerReconciler registers a component reconciler instance or renews its registration (heartbeat)
+func registerReconciler(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
+	if !schedulerCfg.Scheduler.Discovery.Enabled {
+		server.SendHTTPError(w, http.StatusNotFound, &reconciler.HTTPErrorResponse{
+			Error: "Discovery of component reconcilers is disabled: registrations are not accepted",
+		})
+		return
+	}
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	registration := &reconciler.HTTPReconcilerRegistration{}
+	if err := json.Unmarshal(reqBody, registration); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	owner, err := registrationOwner(r)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusForbidden, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	if err := validateRegistration(registration); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "registration not accepted").Error(),
+		})
+		return
+	}
+	if err := authorizeRegistration(registration, identityFromContext(r), schedulerCfg); err != nil {
+		server.SendHTTPError(w, http.StatusForbidden, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "registration not accepted").Error(),
+		})
+		return
+	}
+	err = o.Registry.DiscoveryRepository().Register(&model.ReconcilerInstanceEntity{
+		InstanceID: registration.InstanceID,
+		Name:       registration.Name,
+		URL:        registration.URL,
+		Components: registration.Components,
+		Version:    registration.Version,
+		Workers:    int64(registration.Workers),
+		Owner:      owner,
+	})
+	if err == discovery.ErrOwnerMismatch {
+		server.SendHTTPError(w, http.StatusForbidden, &reconciler.HTTPErrorResponse{
+			Error: fmt.Sprintf("Instance '%s' is registered by another identity", registration.InstanceID),
+		})
+		return
+	}
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Failed to register instance '%s' of component reconciler '%s'",
+				registration.InstanceID, registration.Name)).Error(),
+		})
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+//deregisterReconciler removes a component reconciler instance (e.g. when it shuts down)
+func deregisterReconciler(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	instanceID, err := params.String(paramInstanceID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	owner, err := registrationOwner(r)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusForbidden, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	deregistered, err := o.Registry.DiscoveryRepository().Deregister(instanceID, owner)
+	if err == discovery.ErrOwnerMismatch {
+		server.SendHTTPError(w, http.StatusForbidden, &reconciler.HTTPErrorResponse{
+			Error: fmt.Sprintf("Instance '%s' is registered by another identity", instanceID),
+		})
+		return
+	}
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Failed to deregister instance '%s'", instanceID)).Error(),
+		})
+		return
+	}
+	if !deregistered {
+		server.SendHTTPError(w, http.StatusNotFound, &reconciler.HTTPErrorResponse{
+			Error: fmt.Sprintf("Instance '%s' is not registered", instanceID),
+		})
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+//registrationOwner returns the authenticated identity of the caller which owns the registrations it creates
+func registrationOwner(r *http.Request) (string, error) {
+	identity := identityFromContext(r)
+	if identity == nil {
+		return "", errors.New("registrations of component reconcilers require an authenticated identity")
+	}
+	return fmt.Sprintf("%s/%s", identity.Issuer, identity.Subject), nil
+}
+
+//authorizeRegistration verifies that the caller can register the reconciler and its components: each of them has to
+//be configured, match the allowlist of the discovery or the caller was granted the scope 'reconcilers:register:<name>'
+func authorizeRegistration(registration *reconciler.HTTPReconcilerRegistration, identity *auth.Identity, schedulerCfg *config.Config) error {
+	for _, name := range append([]string{registration.Name}, registration.Components...) {
+		if _, ok := schedulerCfg.Scheduler.Reconcilers[name]; ok {
+			continue
+		}
+		if schedulerCfg.Scheduler.Discovery.Allows(name) {
+			continue
+		}
+		if identity != nil && identity.HasScope(fmt.Sprintf("%s:%s", scopeReconcilersRegister, name)) {
+			continue
+		}
+		return fmt.Errorf("component reconciler or component '%s' is neither configured nor allowed to be registered", name)
+	}
+	return nil
+}
+
+func validateRegistration(registration *reconciler.HTTPReconcilerRegistration) error {
+	if registration.InstanceID == "" {
+		return errors.New("instance ID is missing")
+	}
+	if registration.Name == "" {
+		return errors.New("name of component reconciler is missing")
+	}
+	for _, component := range registration.Components {
+		if component == "" {
+			return errors.New("name of component is missing")
+		}
+	}
+	reconcilerURL, err := url.Parse(registration.URL)
+	if err != nil || reconcilerURL.Scheme == "" || reconcilerURL.Host == "" {
+		return fmt.Errorf("URL '%s' of component reconciler is not an absolute URL", registration.URL)
+	}
+	if registration.Workers < 0 {
+		return fmt.Errorf("workers of component reconciler cannot be < 0 (was %d)", registration.Workers)
+	}
+	return nil
+}
+
+func heartbeatTimeout(schedulerCfg *config.Config) time.Duration {
+	if schedulerCfg.Scheduler.Discovery.HeartbeatTimeout > 0 {
+		return schedulerCfg.Scheduler.Discovery.HeartbeatTimeout
+	}
+	return discovery.DefaultHeartbeatTimeout
+}
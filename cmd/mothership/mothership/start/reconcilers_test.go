@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newRegistration() *reconciler.HTTPReconcilerRegistration {
+	return &reconciler.HTTPReconcilerRegistration{
+		InstanceID: "instance1",
+		Name:       "base",
+		URL:        "http://base-reconciler:8080/v1/run",
+		Workers:    5,
+	}
+}
+
+func TestValidateRegistration(t *testing.T) {
+
+	t.Run("Valid registration", func(t *testing.T) {
+		require.NoError(t, validateRegistration(newRegistration()))
+	})
+
+	t.Run("Instance ID and name are required", func(t *testing.T) {
+		registration := newRegistration()
+		registration.InstanceID = ""
+		require.Error(t, validateRegistration(registration))
+
+		registration = newRegistration()
+		registration.Name = ""
+		require.Error(t, validateRegistration(registration))
+	})
+
+	t.Run("Components cannot be empty", func(t *testing.T) {
+		registration := newRegistration()
+		registration.Components = []string{"istio", ""}
+		require.Error(t, validateRegistration(registration))
+	})
+
+	t.Run("URL has to be absolute", func(t *testing.T) {
+		registration := newRegistration()
+		registration.URL = "/v1/run"
+		require.Error(t, validateRegistration(registration))
+	})
+
+	t.Run("Workers cannot be negative", func(t *testing.T) {
+		registration := newRegistration()
+		registration.Workers = -1
+		require.Error(t, validateRegistration(registration))
+	})
+}
+
+func TestAuthorizeRegistration(t *testing.T) {
+	schedulerCfg := &config.Config{
+		Scheduler: config.SchedulerConfig{
+			Reconcilers: map[string]config.ComponentReconciler{
+				"base":  {URL: "http://base-reconciler:8080/v1/run"},
+				"istio": {URL: "http://istio-reconciler:8080/v1/run"},
+			},
+			Discovery: config.DiscoveryConfig{
+				Enabled:   true,
+				Allowlist: []string{"serverless-*"},
+			},
+		},
+	}
+	identity := &auth.Identity{Subject: "reconciler", Issuer: "issuer", Scopes: []string{scopeReconcilersRegister}}
+
+	t.Run("Configured reconciler and components", func(t *testing.T) {
+		registration := newRegistration()
+		registration.Components = []string{"istio"}
+		require.NoError(t, authorizeRegistration(registration, identity, schedulerCfg))
+	})
+
+	t.Run("Reconciler and components which are neither configured nor allowed", func(t *testing.T) {
+		registration := newRegistration()
+		registration.Name = "unknown"
+		require.Error(t, authorizeRegistration(registration, identity, schedulerCfg))
+
+		registration = newRegistration()
+		registration.Components = []string{"istio", "unknown"}
+		require.Error(t, authorizeRegistration(registration, identity, schedulerCfg))
+	})
+
+	t.Run("Reconciler and components matching the allowlist", func(t *testing.T) {
+		registration := newRegistration()
+		registration.Name = "serverless-reconciler"
+		registration.Components = []string{"serverless-configuration"}
+		require.NoError(t, authorizeRegistration(registration, identity, schedulerCfg))
+	})
+
+	t.Run("Reconciler and components granted by scope", func(t *testing.T) {
+		registration := newRegistration()
+		registration.Name = "monitoring"
+		registration.Components = []string{"monitoring-configuration"}
+		require.Error(t, authorizeRegistration(registration, identity, schedulerCfg))
+
+		scopedIdentity := &auth.Identity{Subject: "reconciler", Issuer: "issuer", Scopes: []string{
+			scopeReconcilersRegister, "reconcilers:register:monitoring", "reconcilers:register:monitoring-configuration",
+		}}
+		require.NoError(t, authorizeRegistration(registration, scopedIdentity, schedulerCfg))
+
+		registration.Components = append(registration.Components, "istio-configuration")
+		require.Error(t, authorizeRegistration(registration, scopedIdentity, schedulerCfg))
+	})
+}
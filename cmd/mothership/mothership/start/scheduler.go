@@ -5,13 +5,17 @@ import (
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/breaker"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/leader"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/rollout"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/service"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/worker"
 	"github.com/spf13/viper"
 )
 
-func startScheduler(ctx context.Context, o *Options, configFile string) error {
+func startScheduler(ctx context.Context, o *Options, configFile string, elector *leader.Elector, breakers *breaker.Breakers) error {
 	schedulerCfg, err := parseSchedulerConfig(configFile)
 	if err != nil {
 		return err
@@ -24,6 +28,13 @@ func startScheduler(ctx context.Context, o *Options, configFile string) error {
 			o.Registry.Connnection(),
 			o.Registry.Inventory(),
 			schedulerCfg).
+		WithRolloutPolicy(newRolloutPolicy(o, schedulerCfg)).
+		WithDriftRepository(o.Registry.DriftRepository()).
+		WithWebhookRepository(o.Registry.WebhookRepository()).
+		WithTaskQueue(o.Registry.TaskQueue()).
+		WithDiscoveryRepository(o.Registry.DiscoveryRepository()).
+		WithCircuitBreakers(breakers).
+		WithLeaderElector(elector).
 		WithWorkerPoolConfig(&worker.Config{
 			MaxParallelOperations: o.MaxParallelOperations,
 			PoolSize:              o.Workers,
@@ -32,12 +43,14 @@ func startScheduler(ctx context.Context, o *Options, configFile string) error {
 			OperationCheckInterval: 30 * time.Second,
 			InvokerMaxRetries:      2,
 			InvokerRetryDelay:      10 * time.Second,
+			Scheduler:              &schedulerCfg.Scheduler,
 		}).
 		WithSchedulerConfig(
 			&service.SchedulerConfig{
 				InventoryWatchInterval:   o.WatchInterval,
 				ClusterReconcileInterval: o.ClusterReconcileInterval,
 				ClusterQueueSize:         10,
+				DisableAutoCorrection:    schedulerCfg.Scheduler.Drift.Enabled && schedulerCfg.Scheduler.Drift.DisableAutoCorrection,
 			}).
 		WithBookkeeperConfig(&service.BookkeeperConfig{
 			OperationsWatchInterval: 45 * time.Second,
@@ -50,6 +63,48 @@ func startScheduler(ctx context.Context, o *Options, configFile string) error {
 		Run(ctx)
 }
 
+//newRolloutPolicy returns nil if no rollout policy is enabled
+func newRolloutPolicy(o *Options, schedulerCfg *config.Config) *rollout.Policy {
+	if !schedulerCfg.Scheduler.Rollout.Enabled {
+		return nil
+	}
+	return rollout.NewPolicy(&schedulerCfg.Scheduler.Rollout, o.Registry.RolloutRepository(),
+		o.Registry.Inventory(), logger.NewLogger(o.Verbose))
+}
+
+//newLeaderElector returns nil if the leader election is disabled
+func newLeaderElector(o *Options, configFile string) (*leader.Elector, error) {
+	schedulerCfg, err := parseSchedulerConfig(configFile)
+	if err != nil {
+		return nil, err
+	}
+	leaderCfg := schedulerCfg.Scheduler.LeaderElection
+	if !leaderCfg.Enabled {
+		return nil, nil
+	}
+	return leader.NewElector(o.Registry.Connnection(), &leader.Config{
+		LeaseDuration: leaderCfg.LeaseDuration,
+		RenewInterval: leaderCfg.RenewInterval,
+	}, metrics.NewLeaderCollector(), logger.NewLogger(o.Verbose))
+}
+
+//newCircuitBreakers returns nil if the circuit breaker is disabled
+func newCircuitBreakers(o *Options, configFile string) (*breaker.Breakers, error) {
+	schedulerCfg, err := parseSchedulerConfig(configFile)
+	if err != nil {
+		return nil, err
+	}
+	breakerCfg := schedulerCfg.Scheduler.CircuitBreaker
+	if !breakerCfg.Enabled {
+		return nil, nil
+	}
+	return breaker.NewBreakers(&breaker.Config{
+		FailureThreshold: breakerCfg.FailureThreshold,
+		ProbeInterval:    breakerCfg.ProbeInterval,
+		ProbeTimeout:     breakerCfg.ProbeTimeout,
+	}, metrics.NewCircuitBreakerCollector(), logger.NewLogger(o.Verbose))
+}
+
 func parseSchedulerConfig(configFile string) (*config.Config, error) {
 	viper.SetConfigFile(configFile)
 	if err := viper.ReadInConfig(); err != nil {
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/taskqueue"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//leaseTask hands the next queued task out to a component reconciler (responds HTTP 204 if no task is queued)
+func leaseTask(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
+	reconcilerName, visibilityTimeout, ok := taskQueueOfReconciler(schedulerCfg, w, r)
+	if !ok {
+		return
+	}
+	maxAttempts := taskqueue.DefaultMaxAttempts
+	if compRecon := schedulerCfg.Scheduler.Reconcilers[reconcilerName]; compRecon.MaxAttempts > 0 {
+		maxAttempts = compRecon.MaxAttempts
+	}
+
+	for {
+		task, err := o.Registry.TaskQueue().Lease(reconcilerName, visibilityTimeout)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+				Error: errors.Wrap(err, fmt.Sprintf("Failed to lease task for component reconciler '%s'", reconcilerName)).Error(),
+			})
+			return
+		}
+		if task == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		//tasks of operations which are no longer in progress (e.g. finished, cancelled or purged) are dropped
+		op, err := getOperationStatus(o, task.SchedulingID, task.CorrelationID)
+		if err != nil && !repository.IsNotFoundError(err) {
+			server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		if err != nil || op.State != model.OperationStateInProgress {
+			o.Logger().Infof("REST endpoint drops queued task of operation (schedulingID:%s/correlationID:%s) "+
+				"because the operation is no longer in progress", task.SchedulingID, task.CorrelationID)
+			if err := o.Registry.TaskQueue().Complete(task.CorrelationID, task.LeaseID); err != nil {
+				o.Logger().Warnf("REST endpoint failed to drop queued task of operation '%s': %s", task.CorrelationID, err)
+			}
+			continue
+		}
+
+		//tasks which were delivered too often without being completed are dead-lettered and their operation fails
+		if task.Attempts > int64(maxAttempts) {
+			o.Logger().Warnf("REST endpoint moves queued task of operation (schedulingID:%s/correlationID:%s) "+
+				"to the dead-letter queue: task was delivered %d times", task.SchedulingID, task.CorrelationID, maxAttempts)
+			if err := o.Registry.TaskQueue().DeadLetter(task.CorrelationID, task.LeaseID); err != nil {
+				o.Logger().Warnf("REST endpoint failed to dead-letter queued task of operation '%s': %s", task.CorrelationID, err)
+				continue
+			}
+			_ = updateOperationState(o, task.SchedulingID, task.CorrelationID, model.OperationStateError,
+				fmt.Sprintf("component reconciler '%s' did not complete the task within %d attempts", reconcilerName, maxAttempts))
+			continue
+		}
+
+		payload := &reconciler.Task{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+				Error: errors.Wrap(err, fmt.Sprintf("Failed to unmarshal queued task of operation '%s'", task.CorrelationID)).Error(),
+			})
+			return
+		}
+		sendTaskLease(o, w, &reconciler.HTTPTaskLeaseResponse{
+			LeaseID: task.LeaseID,
+			Expires: time.Unix(0, task.VisibleAt).UTC(),
+			Task:    payload,
+		})
+		return
+	}
+}
+
+//extendTaskLease hides a leased task for another visibility timeout (responds HTTP 409 if the lease is lost)
+func extendTaskLease(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
+	_, visibilityTimeout, ok := taskQueueOfReconciler(schedulerCfg, w, r)
+	if !ok {
+		return
+	}
+	correlationID, leaseID, ok := taskLease(w, r)
+	if !ok {
+		return
+	}
+	expires := time.Now().Add(visibilityTimeout).UTC()
+	if err := o.Registry.TaskQueue().Extend(correlationID, leaseID, visibilityTimeout); err != nil {
+		sendTaskLeaseError(w, err)
+		return
+	}
+	sendTaskLease(o, w, &reconciler.HTTPTaskLeaseResponse{
+		LeaseID: leaseID,
+		Expires: expires,
+	})
+}
+
+//completeTask removes a leased task from the queue (responds HTTP 409 if the lease is lost)
+func completeTask(o *Options, schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := taskQueueOfReconciler(schedulerCfg, w, r); !ok {
+		return
+	}
+	correlationID, leaseID, ok := taskLease(w, r)
+	if !ok {
+		return
+	}
+	if err := o.Registry.TaskQueue().Complete(correlationID, leaseID); err != nil {
+		sendTaskLeaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+//taskQueueOfReconciler returns the component reconciler addressed by the request and the visibility timeout
+//of its tasks or sends an error response if the component reconciler doesn't lease its tasks or the caller
+//isn't allowed to access its tasks
+func taskQueueOfReconciler(schedulerCfg *config.Config, w http.ResponseWriter, r *http.Request) (string, time.Duration, bool) {
+	params := server.NewParams(r)
+	reconcilerName, err := params.String(paramReconciler)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return "", 0, false
+	}
+	if err := authorizeTaskQueue(identityFromContext(r), reconcilerName); err != nil {
+		server.SendHTTPError(w, http.StatusForbidden, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return "", 0, false
+	}
+	compRecon, ok := schedulerCfg.Scheduler.Reconcilers[reconcilerName]
+	if !ok || !compRecon.QueuesTasks() {
+		server.SendHTTPError(w, http.StatusNotFound, &reconciler.HTTPErrorResponse{
+			Error: fmt.Sprintf("Component reconciler '%s' is not configured to lease its tasks", reconcilerName),
+		})
+		return "", 0, false
+	}
+	visibilityTimeout := compRecon.VisibilityTimeout
+	if visibilityTimeout == 0 {
+		visibilityTimeout = taskqueue.DefaultVisibilityTimeout
+	}
+	return reconcilerName, visibilityTimeout, true
+}
+
+//authorizeTaskQueue verifies that the caller acts on behalf of the component reconciler: tasks contain the
+//kubeconfigs of clusters and are only handed out to the reconciler itself. The subject of the identity has to be
+//the name of the reconciler or the identity was granted the scope 'tasks:lease:<name>'.
+func authorizeTaskQueue(identity *auth.Identity, reconcilerName string) error {
+	if identity == nil {
+		return errors.New("accessing queued tasks requires an authenticated identity")
+	}
+	if identity.Subject == reconcilerName || identity.HasScope(fmt.Sprintf("%s:%s", scopeTasksLease, reconcilerName)) {
+		return nil
+	}
+	return fmt.Errorf("identity '%s' is not allowed to access the queued tasks of component reconciler '%s'",
+		identity.Subject, reconcilerName)
+}
+
+//taskLease returns the correlation ID and the lease ID addressed by the request or sends an error response
+func taskLease(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	params := server.NewParams(r)
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return "", "", false
+	}
+	leaseID, err := params.String(paramLeaseID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return "", "", false
+	}
+	return correlationID, leaseID, true
+}
+
+func sendTaskLease(o *Options, w http.ResponseWriter, lease *reconciler.HTTPTaskLeaseResponse) {
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(lease); err != nil {
+		o.Logger().Warnf("Failed to encode task lease response: %s", err)
+	}
+}
+
+func sendTaskLeaseError(w http.ResponseWriter, err error) {
+	httpCode := http.StatusInternalServerError
+	if taskqueue.IsLeaseLostError(err) {
+		httpCode = http.StatusConflict
+	}
+	server.SendHTTPError(w, httpCode, &reconciler.HTTPErrorResponse{
+		Error: err.Error(),
+	})
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizeTaskQueue(t *testing.T) {
+	istio := &auth.Identity{Subject: "istio", Issuer: "issuer", Scopes: []string{scopeTasksLease}}
+	base := &auth.Identity{Subject: "base-reconciler", Issuer: "issuer", Scopes: []string{
+		scopeTasksLease, "tasks:lease:base",
+	}}
+
+	t.Run("Identity without subject or scope of the reconciler is rejected", func(t *testing.T) {
+		require.Error(t, authorizeTaskQueue(nil, "istio"))
+		require.Error(t, authorizeTaskQueue(istio, "base"))
+		require.Error(t, authorizeTaskQueue(base, "istio"))
+	})
+
+	t.Run("Identity with subject of the reconciler is accepted", func(t *testing.T) {
+		require.NoError(t, authorizeTaskQueue(istio, "istio"))
+	})
+
+	t.Run("Identity with scope of the reconciler is accepted", func(t *testing.T) {
+		require.NoError(t, authorizeTaskQueue(base, "base"))
+	})
+}
@@ -19,6 +19,9 @@ import (
 
 const defaultTimeout = 10 * time.Minute //max time a rec onciliation process is allowed to take
 
+//version of the component reconciler (set at build time via -ldflags '-X main.version=...')
+var version = "dev"
+
 func main() {
 	o := &cli.Options{}
 	cmd := newCmd(o)
@@ -66,6 +69,27 @@ func newCmd(o *cli.Options) *cobra.Command {
 		"Interval to verify the installation progress of a deployed Kubernetes resource")
 	reconcilerOpts.ProgressTrackerConfig.Timeout = reconcilerOpts.WorkerConfig.Timeout //coupled to reconcile-timeout
 
+	//mothership configuration
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.MothershipConfig.URL, "mothership-url", "",
+		"URL of the mothership reconciler (e.g. http://mothership-reconciler:8080): required to lease tasks or to register at it")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.MothershipConfig.TokenFile, "mothership-token-file", "",
+		"Path to file containing the bearer token used to authenticate at the mothership reconciler")
+
+	//task queue configuration
+	cmd.PersistentFlags().BoolVar(&reconcilerOpts.TaskQueueConfig.Enabled, "lease-tasks", false,
+		"Lease tasks from the task queue of the mothership reconciler")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.TaskQueueConfig.Interval, "lease-interval", 5*time.Second,
+		"Interval to lease new tasks from the task queue of the mothership reconciler")
+
+	//registration configuration
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.RegistrationConfig.AdvertiseURL, "advertise-url", "",
+		"URL the mothership reconciler sends tasks to (e.g. http://istio-reconciler:8080/v1/run): if set, the component reconciler registers itself at the mothership reconciler")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.RegistrationConfig.Interval, "registration-interval", 10*time.Second,
+		"Interval to renew the registration at the mothership reconciler")
+	cmd.PersistentFlags().StringSliceVar(&reconcilerOpts.RegistrationConfig.Components, "components", nil,
+		"Further components handled by the component reconciler (announced in its registration)")
+	reconcilerOpts.RegistrationConfig.Version = version
+
 	//file cache for Kyma sources
 	cmd.PersistentFlags().StringVar(&reconcilerOpts.Workspace, "workspace", ".",
 		"Workspace directory used to cache Kyma sources")
@@ -30,5 +30,11 @@ func Run(o *reconCli.Options, reconcilerName string) error {
 	if err != nil {
 		return err
 	}
+	if err := StartTaskPuller(ctx, o, reconcilerName, workerPool); err != nil {
+		return err
+	}
+	if err := StartRegistrar(ctx, o, reconcilerName); err != nil {
+		return err
+	}
 	return StartWebserver(ctx, o, workerPool)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gorilla/mux"
 	reconCli "github.com/kyma-incubator/reconciler/internal/cli/reconciler"
+	reconModel "github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 	"github.com/kyma-incubator/reconciler/pkg/server"
@@ -117,6 +118,12 @@ func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o
 		return
 	}
 
+	//plan tasks are processed synchronously and return the changes in the response
+	if model.Type == reconModel.OperationTypePlan {
+		plan(ctx, w, o, workerPool, model)
+		return
+	}
+
 	o.Logger().Debugf("Assigning reconciliation worker to model '%s'", model)
 	if err := workerPool.AssignWorker(ctx, model); err != nil {
 		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
@@ -127,6 +134,23 @@ func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o
 	sendResponse(w)
 }
 
+func plan(ctx context.Context, w http.ResponseWriter, o *reconCli.Options, workerPool *service.WorkerPool, model *reconciler.Task) {
+	o.Logger().Debugf("Planning changes of model '%s'", model)
+	changes, err := workerPool.Plan(ctx, model)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(&reconciler.HTTPPlanResponse{Changes: changes}); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
+}
+
 func sendResponse(w http.ResponseWriter) {
 	w.Header().Set("content-type", "application/json")
 	if err := json.NewEncoder(w).Encode(&reconciler.HTTPReconciliationResponse{}); err != nil {
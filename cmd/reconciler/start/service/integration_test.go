@@ -331,6 +331,9 @@ func newTestFct(testCase testCase) func(t *testing.T) {
 	return func(t *testing.T) {
 		var callbackC chan *reconciler.CallbackMessage
 
+		if testCase.model.CallbackSecret == "" { //callbacks without secret are rejected
+			testCase.model.CallbackSecret = "test-secret"
+		}
 		if testCase.model.CallbackURL == "" { //set fallback callback URL
 			if testCase.verifyCallbacksFct == nil { //check if validation of callback events has to happen
 				testCase.model.CallbackURL = urlCallbackHTTPBin
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	reconCli "github.com/kyma-incubator/reconciler/internal/cli/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 )
 
@@ -16,3 +17,38 @@ func StartComponentReconciler(ctx context.Context, o *reconCli.Options, reconcil
 	o.Logger().Infof("Starting component reconciler '%s'", reconcilerName)
 	return recon.StartRemote(ctx)
 }
+
+//StartTaskPuller leases tasks from the task queue of the mothership if the leasing of tasks is enabled
+func StartTaskPuller(ctx context.Context, o *reconCli.Options, reconcilerName string, workerPool *service.WorkerPool) error {
+	if !o.TaskQueueConfig.Enabled {
+		return nil
+	}
+	token, err := o.MothershipConfig.Token()
+	if err != nil {
+		return err
+	}
+	puller := service.NewTaskPuller(workerPool, reconcilerName, o.MothershipConfig.URL,
+		o.TaskQueueConfig.Interval, o.Logger()).WithToken(token)
+	go puller.Run(ctx)
+	return nil
+}
+
+//StartRegistrar registers the component reconciler at the mothership if an advertise URL was configured
+func StartRegistrar(ctx context.Context, o *reconCli.Options, reconcilerName string) error {
+	if !o.RegistrationConfig.Enabled() {
+		return nil
+	}
+	token, err := o.MothershipConfig.Token()
+	if err != nil {
+		return err
+	}
+	registrar := service.NewRegistrar(&reconciler.HTTPReconcilerRegistration{
+		Name:       reconcilerName,
+		URL:        o.RegistrationConfig.AdvertiseURL,
+		Components: o.RegistrationConfig.Components,
+		Version:    o.RegistrationConfig.Version,
+		Workers:    o.WorkerConfig.Workers,
+	}, o.MothershipConfig.URL, o.RegistrationConfig.Interval, o.Logger()).WithToken(token)
+	go registrar.Run(ctx)
+	return nil
+}
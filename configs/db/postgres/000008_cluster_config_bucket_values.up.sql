@@ -0,0 +1 @@
+ALTER TABLE inventory_cluster_configs ADD COLUMN "bucket_values" text;
\ No newline at end of file
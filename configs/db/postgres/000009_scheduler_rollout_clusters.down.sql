@@ -0,0 +1 @@
+DROP TABLE IF EXISTS scheduler_rollout_clusters;
\ No newline at end of file
@@ -0,0 +1,10 @@
+--DDL for clusters which were admitted to the rollout of a Kyma version
+CREATE TABLE IF NOT EXISTS scheduler_rollout_clusters (
+    "kyma_version" varchar(255) NOT NULL,
+    "runtime_id" varchar(255) NOT NULL,
+    "wave" int NOT NULL, --0 = canary
+    "status" varchar(255) NOT NULL,
+    "created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    "updated" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT scheduler_rollout_clusters_pk PRIMARY KEY ("kyma_version", "runtime_id")
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS inventory_cluster_drifts;
\ No newline at end of file
@@ -0,0 +1,10 @@
+--DDL for resources which differ from the configuration of a cluster (detected by the drift detection)
+CREATE TABLE IF NOT EXISTS inventory_cluster_drifts (
+    "runtime_id" varchar(255) NOT NULL,
+    "config_version" int NOT NULL,
+    "component" varchar(255) NOT NULL,
+    "resources" text,
+    "error" text,
+    "created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT inventory_cluster_drifts_pk PRIMARY KEY ("runtime_id", "component")
+);
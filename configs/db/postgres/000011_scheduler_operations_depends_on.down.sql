@@ -0,0 +1 @@
+ALTER TABLE scheduler_operations DROP COLUMN IF EXISTS "depends_on";
\ No newline at end of file
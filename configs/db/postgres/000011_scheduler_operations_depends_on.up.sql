@@ -0,0 +1 @@
+ALTER TABLE scheduler_operations ADD COLUMN IF NOT EXISTS "depends_on" text;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE scheduler_reconciliations ADD COLUMN IF NOT EXISTS "paused" boolean NOT NULL DEFAULT FALSE;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS scheduler_webhook_deliveries;
+DROP TABLE IF EXISTS scheduler_webhooks;
\ No newline at end of file
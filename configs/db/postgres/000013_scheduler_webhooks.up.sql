@@ -0,0 +1,31 @@
+--DDL for webhooks of subscribers which are notified about status changes of clusters
+CREATE TABLE IF NOT EXISTS scheduler_webhooks (
+    "id" varchar(255) NOT NULL,
+    "url" text NOT NULL,
+    "secret" text NOT NULL,
+    "runtime_id_filter" text,
+    "metadata_filter" text,
+    "status_filter" text,
+    "last_status_id" bigint NOT NULL DEFAULT 0, --ID of the last cluster status which was checked for this webhook
+    "created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT scheduler_webhooks_pk PRIMARY KEY ("id")
+);
+
+--DDL for events which are sent to webhooks
+CREATE TABLE IF NOT EXISTS scheduler_webhook_deliveries (
+    "id" varchar(255) NOT NULL,
+    "webhook_id" varchar(255) NOT NULL,
+    "status_id" bigint NOT NULL,
+    "runtime_id" varchar(255) NOT NULL,
+    "payload" text NOT NULL,
+    "state" varchar(255) NOT NULL,
+    "attempts" int NOT NULL DEFAULT 0,
+    "next_attempt" TIMESTAMP WITHOUT TIME ZONE,
+    "last_error" text,
+    "created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    "updated" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT scheduler_webhook_deliveries_pk PRIMARY KEY ("id"),
+    CONSTRAINT scheduler_webhook_deliveries_event UNIQUE ("webhook_id", "status_id"),
+    FOREIGN KEY ("webhook_id") REFERENCES scheduler_webhooks ("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS scheduler_webhook_deliveries_state_idx ON scheduler_webhook_deliveries ("state");
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE scheduler_operations DROP COLUMN IF EXISTS "callback_secret";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE scheduler_reconciliations DROP COLUMN IF EXISTS "reason";
\ No newline at end of file
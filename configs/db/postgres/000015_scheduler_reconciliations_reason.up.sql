@@ -0,0 +1 @@
+ALTER TABLE scheduler_reconciliations ADD COLUMN IF NOT EXISTS "reason" text;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE config_values DROP COLUMN IF EXISTS "encrypted";
\ No newline at end of file
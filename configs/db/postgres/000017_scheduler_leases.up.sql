@@ -0,0 +1,7 @@
+--DDL for the lease which is held by the leader of the mothership replicas
+CREATE TABLE IF NOT EXISTS scheduler_leases (
+    "name" varchar(255) NOT NULL,
+    "holder" varchar(255) NOT NULL,
+    "expires" bigint NOT NULL, --unix time in nanoseconds
+    CONSTRAINT scheduler_leases_pk PRIMARY KEY ("name")
+);
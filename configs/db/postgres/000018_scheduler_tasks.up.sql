@@ -0,0 +1,14 @@
+--DDL for the queue of tasks which are leased by component reconcilers (used if a reconciler pulls its tasks)
+CREATE TABLE IF NOT EXISTS scheduler_tasks (
+    "correlation_id" varchar(255) NOT NULL,
+    "scheduling_id" varchar(255) NOT NULL,
+    "reconciler" varchar(255) NOT NULL,
+    "payload" text NOT NULL,
+    "lease_id" varchar(255),
+    "attempts" int NOT NULL DEFAULT 0,
+    "visible_at" bigint NOT NULL, --unix time in nanoseconds: task can be leased after this point in time
+    "dead_letter" boolean NOT NULL DEFAULT false, --task exceeded the max attempts and is no longer delivered
+    "created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT scheduler_tasks_pk PRIMARY KEY ("correlation_id")
+);
+CREATE INDEX IF NOT EXISTS scheduler_tasks_idx_visibility ON scheduler_tasks ("reconciler", "visible_at");
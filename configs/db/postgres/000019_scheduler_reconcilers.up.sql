@@ -0,0 +1,13 @@
+--DDL for the component reconciler instances which registered themselves at the mothership
+CREATE TABLE IF NOT EXISTS scheduler_reconcilers (
+    "instance_id" varchar(255) NOT NULL,
+    "name" varchar(255) NOT NULL,
+    "url" text NOT NULL,
+    "components" text,
+    "version" varchar(255),
+    "workers" int NOT NULL DEFAULT 0,
+    "heartbeat" bigint NOT NULL, --unix time in nanoseconds of the latest registration
+    "owner" varchar(255) NOT NULL, --authenticated identity which registered the instance
+    "created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT scheduler_reconcilers_pk PRIMARY KEY ("instance_id")
+);
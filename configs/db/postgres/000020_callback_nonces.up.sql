@@ -0,0 +1,8 @@
+--DDL for the nonces of the callbacks accepted from component reconcilers (used to reject replayed callbacks)
+CREATE TABLE IF NOT EXISTS callback_nonces (
+    "correlation_id" varchar(255) NOT NULL,
+    "nonce" varchar(255) NOT NULL,
+    "expires" bigint NOT NULL, --unix time in nanoseconds: nonce can be purged after this point in time
+    CONSTRAINT callback_nonces_pk PRIMARY KEY ("correlation_id", "nonce")
+);
+CREATE INDEX IF NOT EXISTS callback_nonces_idx_expires ON callback_nonces ("expires");
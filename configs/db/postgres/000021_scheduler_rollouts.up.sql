@@ -0,0 +1,8 @@
+--DDL for the state of rollouts which were halted, aborted or resumed
+CREATE TABLE IF NOT EXISTS scheduler_rollouts (
+    "kyma_version" varchar(255) NOT NULL,
+    "state" varchar(255) NOT NULL,
+    "reason" text,
+    "updated" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT scheduler_rollouts_pk PRIMARY KEY ("kyma_version")
+);
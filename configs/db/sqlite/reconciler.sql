@@ -22,6 +22,7 @@ CREATE TABLE IF NOT EXISTS config_values (
 	"data_type" varchar(255) NOT NULL,
 	"value" text NULL,
 	"username" varchar(255) NOT NULL,
+	"encrypted" boolean DEFAULT FALSE,
 	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	CONSTRAINT config_values_pk UNIQUE ("bucket", "key", "version"),
 	FOREIGN KEY ("key", "key_version") REFERENCES config_keys ("key", "version") ON UPDATE CASCADE ON DELETE CASCADE
@@ -74,6 +75,7 @@ CREATE TABLE IF NOT EXISTS inventory_cluster_configs (
 	"kyma_profile" text,
 	"components" text,
 	"administrators" text,
+	"bucket_values" text,
 	"contract" int NOT NULL,
 	"deleted" boolean DEFAULT FALSE,
 	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
@@ -100,6 +102,8 @@ CREATE TABLE IF NOT EXISTS scheduler_reconciliations (
     "status" text NOT NULL,
     "cluster_config_status" int,
     "finished" boolean DEFAULT FALSE,
+    "paused" boolean DEFAULT FALSE, --paused reconciliations don't start further operations
+    "reason" text, --reason of an on-demand reconciliation
     "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     "updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY("lock") REFERENCES inventory_clusters("runtime_id"),
@@ -116,13 +120,118 @@ CREATE TABLE IF NOT EXISTS scheduler_operations (
     "runtime_id" text NOT NULL,
     "cluster_config" int NOT NULL,
     "component" text NOT NULL,
+    "depends_on" text,
     "type" text NOT NULL,
     "state" text NOT NULL,
     "reason" text,
+    "callback_secret" text,
     "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     "updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     CONSTRAINT scheduler_operations_pk UNIQUE ("scheduling_id", "correlation_id"),
     FOREIGN KEY("scheduling_id") REFERENCES scheduler_reconciliations("scheduling_id") ON UPDATE CASCADE ON DELETE CASCADE,
     FOREIGN KEY("runtime_id") REFERENCES inventory_clusters("runtime_id") ON UPDATE CASCADE,
     FOREIGN KEY("cluster_config") REFERENCES inventory_cluster_configs("version")
-)
+);
+
+--DDL for clusters which were admitted to the rollout of a Kyma version:
+CREATE TABLE IF NOT EXISTS scheduler_rollout_clusters (
+    "kyma_version" text NOT NULL,
+    "runtime_id" text NOT NULL,
+    "wave" int NOT NULL, --0 = canary
+    "status" text NOT NULL,
+    "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scheduler_rollout_clusters_pk PRIMARY KEY ("kyma_version", "runtime_id")
+);
+
+--DDL for the state of rollouts which were halted, aborted or resumed:
+CREATE TABLE IF NOT EXISTS scheduler_rollouts (
+    "kyma_version" text NOT NULL,
+    "state" text NOT NULL,
+    "reason" text,
+    "updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scheduler_rollouts_pk PRIMARY KEY ("kyma_version")
+);
+
+--DDL for resources which differ from the configuration of a cluster (detected by the drift detection):
+CREATE TABLE IF NOT EXISTS inventory_cluster_drifts (
+    "runtime_id" text NOT NULL,
+    "config_version" int NOT NULL,
+    "component" text NOT NULL,
+    "resources" text,
+    "error" text,
+    "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_cluster_drifts_pk PRIMARY KEY ("runtime_id", "component")
+);
+
+--DDL for webhooks of subscribers which are notified about status changes of clusters:
+CREATE TABLE IF NOT EXISTS scheduler_webhooks (
+    "id" text NOT NULL PRIMARY KEY,
+    "url" text NOT NULL,
+    "secret" text NOT NULL,
+    "runtime_id_filter" text,
+    "metadata_filter" text,
+    "status_filter" text,
+    "last_status_id" int NOT NULL DEFAULT 0,
+    "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+--DDL for events which are sent to webhooks:
+CREATE TABLE IF NOT EXISTS scheduler_webhook_deliveries (
+    "id" text NOT NULL PRIMARY KEY,
+    "webhook_id" text NOT NULL,
+    "status_id" int NOT NULL,
+    "runtime_id" text NOT NULL,
+    "payload" text NOT NULL,
+    "state" text NOT NULL,
+    "attempts" int NOT NULL DEFAULT 0,
+    "next_attempt" TIMESTAMP,
+    "last_error" text,
+    "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scheduler_webhook_deliveries_event UNIQUE ("webhook_id", "status_id"),
+    FOREIGN KEY("webhook_id") REFERENCES scheduler_webhooks("id") ON DELETE CASCADE
+);
+
+--DDL for the lease which is held by the leader of the mothership replicas:
+CREATE TABLE IF NOT EXISTS scheduler_leases (
+    "name" text NOT NULL PRIMARY KEY,
+    "holder" text NOT NULL,
+    "expires" int NOT NULL --unix time in nanoseconds
+);
+
+--DDL for the queue of tasks which are leased by component reconcilers (used if a reconciler pulls its tasks):
+CREATE TABLE IF NOT EXISTS scheduler_tasks (
+    "correlation_id" text NOT NULL PRIMARY KEY,
+    "scheduling_id" text NOT NULL,
+    "reconciler" text NOT NULL,
+    "payload" text NOT NULL,
+    "lease_id" text,
+    "attempts" int NOT NULL DEFAULT 0,
+    "visible_at" int NOT NULL, --unix time in nanoseconds: task can be leased after this point in time
+    "dead_letter" boolean NOT NULL DEFAULT false, --task exceeded the max attempts and is no longer delivered
+    "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS scheduler_tasks_idx_visibility ON scheduler_tasks ("reconciler", "visible_at");
+
+--DDL for the component reconciler instances which registered themselves at the mothership:
+CREATE TABLE IF NOT EXISTS scheduler_reconcilers (
+    "instance_id" text NOT NULL PRIMARY KEY,
+    "name" text NOT NULL,
+    "url" text NOT NULL,
+    "components" text,
+    "version" text,
+    "workers" int NOT NULL DEFAULT 0,
+    "heartbeat" int NOT NULL, --unix time in nanoseconds of the latest registration
+    "owner" text NOT NULL, --authenticated identity which registered the instance
+    "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+--DDL for the nonces of the callbacks accepted from component reconcilers (used to reject replayed callbacks):
+CREATE TABLE IF NOT EXISTS callback_nonces (
+    "correlation_id" text NOT NULL,
+    "nonce" text NOT NULL,
+    "expires" int NOT NULL, --unix time in nanoseconds: nonce can be purged after this point in time
+    PRIMARY KEY ("correlation_id", "nonce")
+);
+CREATE INDEX IF NOT EXISTS callback_nonces_idx_expires ON callback_nonces ("expires");
@@ -0,0 +1,39 @@
+package reconciler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//MothershipConfig defines how the mothership reconciler is reached (required for leasing tasks from its task queue
+//and for registering at it)
+type MothershipConfig struct {
+	URL       string //empty = the component reconciler is only receiving tasks via its REST API
+	TokenFile string //optional: file containing the bearer token sent to the mothership
+}
+
+//Token returns the bearer token which is sent to the mothership (empty if no token file is configured)
+func (c *MothershipConfig) Token() (string, error) {
+	if c.TokenFile == "" {
+		return "", nil
+	}
+	token, err := ioutil.ReadFile(c.TokenFile)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read token file of mothership reconciler")
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+func (c *MothershipConfig) validate() error {
+	if c.URL == "" {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(c.URL); err != nil {
+		return fmt.Errorf("mothership URL '%s' is invalid: %s", c.URL, err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package reconciler
 
 import (
 	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/pkg/errors"
 )
 
 type Options struct {
@@ -12,6 +13,9 @@ type Options struct {
 	RetryConfig           *RetryConfig
 	HeartbeatSenderConfig *RecurringTaskConfig
 	ProgressTrackerConfig *RecurringTaskConfig
+	MothershipConfig      *MothershipConfig
+	TaskQueueConfig       *TaskQueueConfig
+	RegistrationConfig    *RegistrationConfig
 }
 
 func NewOptions(o *cli.Options) *Options {
@@ -23,6 +27,9 @@ func NewOptions(o *cli.Options) *Options {
 		&RetryConfig{},
 		&RecurringTaskConfig{},
 		&RecurringTaskConfig{},
+		&MothershipConfig{},
+		&TaskQueueConfig{},
+		&RegistrationConfig{},
 	}
 }
 
@@ -45,5 +52,17 @@ func (o *Options) Validate() error {
 	if err := o.ProgressTrackerConfig.validate(); err != nil {
 		return err
 	}
+	if err := o.MothershipConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.TaskQueueConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.RegistrationConfig.validate(); err != nil {
+		return err
+	}
+	if (o.TaskQueueConfig.Enabled || o.RegistrationConfig.Enabled()) && o.MothershipConfig.URL == "" {
+		return errors.New("mothership URL is required to lease tasks or to register at the mothership")
+	}
 	return nil
 }
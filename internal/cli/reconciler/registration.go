@@ -0,0 +1,35 @@
+package reconciler
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+//RegistrationConfig enables the registration of the component reconciler at the mothership reconciler
+//(used if the discovery of component reconcilers is enabled in the mothership)
+type RegistrationConfig struct {
+	AdvertiseURL string        //URL the mothership sends tasks to (empty = no registration)
+	Interval     time.Duration //interval the registration is renewed (has to be shorter than the heartbeat timeout of the mothership)
+	Components   []string      //further components handled by the component reconciler
+	Version      string
+}
+
+//Enabled returns true if the component reconciler registers itself at the mothership
+func (c *RegistrationConfig) Enabled() bool {
+	return c.AdvertiseURL != ""
+}
+
+func (c *RegistrationConfig) validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	advertiseURL, err := url.ParseRequestURI(c.AdvertiseURL)
+	if err != nil || advertiseURL.Host == "" {
+		return fmt.Errorf("advertise URL '%s' has to be an absolute URL", c.AdvertiseURL)
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("registration interval cannot be <= 0")
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package reconciler
+
+import (
+	"fmt"
+	"time"
+)
+
+//TaskQueueConfig enables the leasing of tasks from the task queue of the mothership reconciler
+//(used if the mothership is configured to queue the tasks of this component reconciler)
+type TaskQueueConfig struct {
+	Enabled  bool          //requires the URL of the mothership
+	Interval time.Duration //interval the task queue is checked for new tasks
+}
+
+func (c *TaskQueueConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("lease interval cannot be <= 0")
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ type Component struct {
 	URL           string
 	Configuration map[string]interface{}
 	Version       string
+	DependsOn     []string `yaml:"dependsOn" json:"dependsOn"` //nil = ordered by the pre-components
 }
 
 func NewComponentList(compListFile string) (*ComponentList, error) {
@@ -0,0 +1,162 @@
+package converters
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+const maskedValue = "*****"
+
+//ConvertConfigDiff returns the changes between two configurations of a cluster: values of
+//secret configurations and of encrypted KV-bucket keys are masked
+func ConvertConfigDiff(from, to *model.ClusterConfigurationEntity, encryptedKeys map[string]bool) keb.HTTPClusterConfigDiffResponse {
+	result := keb.HTTPClusterConfigDiffResponse{
+		RuntimeID:   to.RuntimeID,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		KymaVersion: valueChange(from.KymaVersion, to.KymaVersion),
+		KymaProfile: valueChange(from.KymaProfile, to.KymaProfile),
+		Components:  []keb.ComponentChange{},
+		BucketValues: configurationChanges(
+			bucketConfiguration(from.BucketValues, encryptedKeys),
+			bucketConfiguration(to.BucketValues, encryptedKeys)),
+	}
+
+	fromComponents := componentsByName(from.Components)
+	toComponents := componentsByName(to.Components)
+	var names []string
+	for name := range fromComponents {
+		names = append(names, name)
+	}
+	for name := range toComponents {
+		if _, ok := fromComponents[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fromComp, toComp := fromComponents[name], toComponents[name]
+		var change keb.ComponentChange
+		switch {
+		case fromComp == nil:
+			change = keb.ComponentChange{
+				Type:          keb.ConfigChangeTypeAdded,
+				Configuration: configurationChanges(nil, toComp.Configuration),
+			}
+		case toComp == nil:
+			change = keb.ComponentChange{
+				Type:          keb.ConfigChangeTypeRemoved,
+				Configuration: configurationChanges(fromComp.Configuration, nil),
+			}
+		default:
+			change = keb.ComponentChange{
+				Type:          keb.ConfigChangeTypeChanged,
+				Version:       valueChange(componentVersion(fromComp, from), componentVersion(toComp, to)),
+				Namespace:     valueChange(fromComp.Namespace, toComp.Namespace),
+				Url:           valueChange(fromComp.URL, toComp.URL),
+				Configuration: configurationChanges(fromComp.Configuration, toComp.Configuration),
+			}
+			if change.Version == nil && change.Namespace == nil && change.Url == nil && len(change.Configuration) == 0 {
+				continue
+			}
+		}
+		change.Component = name
+		result.Components = append(result.Components, change)
+	}
+
+	return result
+}
+
+func valueChange(from, to string) *keb.ValueChange {
+	if from == to {
+		return nil
+	}
+	return &keb.ValueChange{From: from, To: to}
+}
+
+func componentsByName(components []*keb.Component) map[string]*keb.Component {
+	result := make(map[string]*keb.Component, len(components))
+	for _, component := range components {
+		result[component.Component] = component
+	}
+	return result
+}
+
+//componentVersion returns the version of the component: components without version use the Kyma version
+func componentVersion(component *keb.Component, config *model.ClusterConfigurationEntity) string {
+	if component.Version == "" {
+		return config.KymaVersion
+	}
+	return component.Version
+}
+
+//bucketConfiguration converts the merged KV-bucket values of a cluster to configuration entries
+func bucketConfiguration(values map[string]interface{}, encryptedKeys map[string]bool) []keb.Configuration {
+	result := make([]keb.Configuration, 0, len(values))
+	for key, value := range values {
+		result = append(result, keb.Configuration{Key: key, Value: value, Secret: encryptedKeys[key]})
+	}
+	return result
+}
+
+func configurationChanges(from, to []keb.Configuration) []keb.ConfigurationChange {
+	fromConfigs := make(map[string]keb.Configuration, len(from))
+	for _, config := range from {
+		fromConfigs[config.Key] = config
+	}
+	toConfigs := make(map[string]keb.Configuration, len(to))
+	for _, config := range to {
+		toConfigs[config.Key] = config
+	}
+	var keys []string
+	for key := range fromConfigs {
+		keys = append(keys, key)
+	}
+	for key := range toConfigs {
+		if _, ok := fromConfigs[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	result := []keb.ConfigurationChange{}
+	for _, key := range keys {
+		fromConfig, inFrom := fromConfigs[key]
+		toConfig, inTo := toConfigs[key]
+		change := keb.ConfigurationChange{
+			Key:    key,
+			Secret: fromConfig.Secret || toConfig.Secret,
+		}
+		switch {
+		case !inFrom:
+			change.Type = keb.ConfigChangeTypeAdded
+			change.To = toConfig.Value
+		case !inTo:
+			change.Type = keb.ConfigChangeTypeRemoved
+			change.From = fromConfig.Value
+		case reflect.DeepEqual(fromConfig.Value, toConfig.Value) && fromConfig.Secret == toConfig.Secret:
+			continue
+		default:
+			change.Type = keb.ConfigChangeTypeChanged
+			change.From = fromConfig.Value
+			change.To = toConfig.Value
+		}
+		if change.Secret {
+			change.From = mask(change.From)
+			change.To = mask(change.To)
+		}
+		result = append(result, change)
+	}
+	return result
+}
+
+func mask(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	return maskedValue
+}
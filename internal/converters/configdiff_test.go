@@ -0,0 +1,113 @@
+package converters_test
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertConfigDiff(t *testing.T) {
+	from := &model.ClusterConfigurationEntity{
+		RuntimeID:   "runtime",
+		Version:     1,
+		KymaVersion: "2.0.0",
+		KymaProfile: "evaluation",
+		BucketValues: map[string]interface{}{
+			"global.domain":   "old.com",
+			"global.password": "old",
+			"global.obsolete": true,
+			"global.same":     "value",
+		},
+		Components: []*keb.Component{
+			{Component: "istio", Namespace: "istio-system", Configuration: []keb.Configuration{
+				{Key: "replicas", Value: 1},
+				{Key: "password", Value: "old", Secret: true},
+				{Key: "obsolete", Value: true},
+			}},
+			{Component: "serverless", Version: "1.0.0"},
+			{Component: "eventing", Configuration: []keb.Configuration{
+				{Key: "token", Value: "abc", Secret: true},
+			}},
+			{Component: "unchanged", Version: "3.0.0", Configuration: []keb.Configuration{
+				{Key: "key", Value: "value"},
+			}},
+		},
+	}
+	to := &model.ClusterConfigurationEntity{
+		RuntimeID:   "runtime",
+		Version:     2,
+		KymaVersion: "2.0.1",
+		KymaProfile: "evaluation",
+		BucketValues: map[string]interface{}{
+			"global.domain":   "new.com",
+			"global.password": "new",
+			"global.token":    "abc",
+			"global.same":     "value",
+		},
+		Components: []*keb.Component{
+			{Component: "istio", Namespace: "istio-system", Configuration: []keb.Configuration{
+				{Key: "replicas", Value: 2},
+				{Key: "password", Value: "new", Secret: true},
+				{Key: "added", Value: "x"},
+			}},
+			{Component: "serverless", Version: "1.0.0", URL: "https://serverless"},
+			{Component: "monitoring"},
+			{Component: "unchanged", Version: "3.0.0", Configuration: []keb.Configuration{
+				{Key: "key", Value: "value"},
+			}},
+		},
+	}
+
+	result := converters.ConvertConfigDiff(from, to, map[string]bool{"global.password": true, "global.token": true})
+	require.Equal(t, keb.HTTPClusterConfigDiffResponse{
+		RuntimeID:   "runtime",
+		FromVersion: 1,
+		ToVersion:   2,
+		KymaVersion: &keb.ValueChange{From: "2.0.0", To: "2.0.1"},
+		BucketValues: []keb.ConfigurationChange{
+			{Key: "global.domain", Type: keb.ConfigChangeTypeChanged, From: "old.com", To: "new.com"},
+			{Key: "global.obsolete", Type: keb.ConfigChangeTypeRemoved, From: true},
+			{Key: "global.password", Type: keb.ConfigChangeTypeChanged, Secret: true, From: "*****", To: "*****"},
+			{Key: "global.token", Type: keb.ConfigChangeTypeAdded, Secret: true, To: "*****"},
+		},
+		Components: []keb.ComponentChange{
+			{
+				Component: "eventing",
+				Type:      keb.ConfigChangeTypeRemoved,
+				Configuration: []keb.ConfigurationChange{
+					{Key: "token", Type: keb.ConfigChangeTypeRemoved, Secret: true, From: "*****"},
+				},
+			},
+			{
+				Component: "istio",
+				Type:      keb.ConfigChangeTypeChanged,
+				Version:   &keb.ValueChange{From: "2.0.0", To: "2.0.1"}, //uses Kyma version
+				Configuration: []keb.ConfigurationChange{
+					{Key: "added", Type: keb.ConfigChangeTypeAdded, To: "x"},
+					{Key: "obsolete", Type: keb.ConfigChangeTypeRemoved, From: true},
+					{Key: "password", Type: keb.ConfigChangeTypeChanged, Secret: true, From: "*****", To: "*****"},
+					{Key: "replicas", Type: keb.ConfigChangeTypeChanged, From: 1, To: 2},
+				},
+			},
+			{
+				Component:     "monitoring",
+				Type:          keb.ConfigChangeTypeAdded,
+				Configuration: []keb.ConfigurationChange{},
+			},
+			{
+				Component:     "serverless",
+				Type:          keb.ConfigChangeTypeChanged,
+				Url:           &keb.ValueChange{From: "", To: "https://serverless"},
+				Configuration: []keb.ConfigurationChange{},
+			},
+		},
+	}, result)
+
+	t.Run("Configurations without bucket values", func(t *testing.T) {
+		from.BucketValues, to.BucketValues = nil, nil
+		require.Empty(t, converters.ConvertConfigDiff(from, to, nil).BucketValues)
+	})
+}
@@ -0,0 +1,33 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+//ConvertClusterDrift expects the drifts of all components detected for one cluster
+func ConvertClusterDrift(runtimeID string, drifts []*model.ClusterDriftEntity) keb.HTTPClusterDriftResponse {
+	result := keb.HTTPClusterDriftResponse{
+		RuntimeID:  runtimeID,
+		Components: make([]keb.ComponentDrift, 0, len(drifts)),
+	}
+	for _, drift := range drifts {
+		if drift.Created.After(result.Detected) {
+			result.Detected = drift.Created
+			result.ConfigVersion = drift.ConfigVersion
+		}
+		componentDrift := keb.ComponentDrift{
+			Component: drift.Component,
+			Resources: drift.Resources,
+		}
+		if componentDrift.Resources == nil {
+			componentDrift.Resources = []keb.ResourceChange{}
+		}
+		if drift.Error != "" {
+			errMsg := drift.Error
+			componentDrift.Error = &errMsg
+		}
+		result.Components = append(result.Components, componentDrift)
+	}
+	return result
+}
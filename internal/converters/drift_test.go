@@ -0,0 +1,34 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertClusterDrift(t *testing.T) {
+	detected := time.Date(2021, 10, 15, 12, 0, 0, 0, time.UTC)
+	resources := []keb.ResourceChange{
+		{ApiVersion: "apps/v1", Kind: "Deployment", Name: "dpl", Namespace: "kyma-system", Type: keb.ResourceChangeTypeUpdate},
+	}
+	errMsg := "reconciler not reachable"
+
+	result := converters.ConvertClusterDrift("runtime", []*model.ClusterDriftEntity{
+		{RuntimeID: "runtime", ConfigVersion: 2, Component: "istio", Resources: resources, Created: detected},
+		{RuntimeID: "runtime", ConfigVersion: 2, Component: "serverless", Error: errMsg, Created: detected},
+	})
+
+	require.Equal(t, keb.HTTPClusterDriftResponse{
+		RuntimeID:     "runtime",
+		ConfigVersion: 2,
+		Detected:      detected,
+		Components: []keb.ComponentDrift{
+			{Component: "istio", Resources: resources},
+			{Component: "serverless", Resources: []keb.ResourceChange{}, Error: &errMsg},
+		},
+	}, result)
+}
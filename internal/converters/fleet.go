@@ -0,0 +1,39 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+func ConvertFleetPage(page *cluster.FleetPage) (keb.HTTPFleetResponse, error) {
+	result := keb.HTTPFleetResponse{
+		Clusters: []keb.FleetCluster{},
+	}
+	for _, state := range page.Clusters {
+		kebStatus, err := state.Status.GetKEBClusterStatus()
+		if err != nil {
+			return result, err
+		}
+		fleetCluster := keb.FleetCluster{
+			Cluster:              state.Cluster.RuntimeID,
+			ClusterVersion:       state.Cluster.Version,
+			ConfigurationVersion: state.Configuration.Version,
+			KymaVersion:          state.Configuration.KymaVersion,
+			Status:               kebStatus,
+			Updated:              state.Status.Created,
+		}
+		if state.Cluster.Metadata != nil {
+			fleetCluster.Metadata = *state.Cluster.Metadata
+		}
+		if state.Configuration.KymaProfile != "" {
+			kymaProfile := state.Configuration.KymaProfile
+			fleetCluster.KymaProfile = &kymaProfile
+		}
+		result.Clusters = append(result.Clusters, fleetCluster)
+	}
+	if page.NextCursor != "" {
+		nextCursor := page.NextCursor
+		result.NextCursor = &nextCursor
+	}
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertFleetPage(t *testing.T) {
+	updated := time.Date(2021, 10, 15, 12, 0, 0, 0, time.UTC)
+	metadata := keb.Metadata{Region: "westeurope"}
+	profile := "evaluation"
+	nextCursor := "abc"
+
+	result, err := converters.ConvertFleetPage(&cluster.FleetPage{
+		Clusters: []*cluster.State{
+			{
+				Cluster:       &model.ClusterEntity{RuntimeID: "runtime", Version: 1, Metadata: &metadata},
+				Configuration: &model.ClusterConfigurationEntity{Version: 2, KymaVersion: "2.0.0", KymaProfile: profile},
+				Status:        &model.ClusterStatusEntity{Status: model.ClusterStatusReady, Created: updated},
+			},
+		},
+		NextCursor: nextCursor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, keb.HTTPFleetResponse{
+		Clusters: []keb.FleetCluster{
+			{
+				Cluster:              "runtime",
+				ClusterVersion:       1,
+				ConfigurationVersion: 2,
+				KymaProfile:          &profile,
+				KymaVersion:          "2.0.0",
+				Metadata:             metadata,
+				Status:               keb.StatusReady,
+				Updated:              updated,
+			},
+		},
+		NextCursor: &nextCursor,
+	}, result)
+
+	result, err = converters.ConvertFleetPage(&cluster.FleetPage{})
+	require.NoError(t, err)
+	require.Empty(t, result.Clusters)
+	require.Nil(t, result.NextCursor)
+}
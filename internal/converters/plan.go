@@ -0,0 +1,28 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+)
+
+func ConvertResourceChanges(changes []*kubernetes.ResourceChange) []keb.ResourceChange {
+	result := make([]keb.ResourceChange, 0, len(changes))
+	for _, change := range changes {
+		if change == nil {
+			continue
+		}
+		resourceChange := keb.ResourceChange{
+			ApiVersion: change.APIVersion,
+			Kind:       change.Kind,
+			Name:       change.Name,
+			Namespace:  change.Namespace,
+			Type:       keb.ResourceChangeType(change.Type),
+		}
+		if len(change.Patch) > 0 {
+			patch := change.Patch
+			resourceChange.Patch = &patch
+		}
+		result = append(result, resourceChange)
+	}
+	return result
+}
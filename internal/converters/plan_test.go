@@ -0,0 +1,25 @@
+package converters_test
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertResourceChanges(t *testing.T) {
+	patch := map[string]interface{}{"spec": map[string]interface{}{"replicas": 2}}
+
+	result := converters.ConvertResourceChanges([]*kubernetes.ResourceChange{
+		{Kind: "Deployment", APIVersion: "apps/v1", Name: "dpl", Namespace: "kyma-system", Type: kubernetes.ChangeTypeUpdate, Patch: patch},
+		nil,
+		{Kind: "ConfigMap", APIVersion: "v1", Name: "cm", Namespace: "kyma-system", Type: kubernetes.ChangeTypePrune},
+	})
+
+	require.Equal(t, []keb.ResourceChange{
+		{ApiVersion: "apps/v1", Kind: "Deployment", Name: "dpl", Namespace: "kyma-system", Type: keb.ResourceChangeTypeUpdate, Patch: &patch},
+		{ApiVersion: "v1", Kind: "ConfigMap", Name: "cm", Namespace: "kyma-system", Type: keb.ResourceChangeTypePrune},
+	}, result)
+}
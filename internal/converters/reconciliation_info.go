@@ -21,6 +21,7 @@ func ConvertReconciliation(reconciliation *model.ReconciliationEntity, operation
 	result := keb.ReconciliationInfoOKResponse{
 		Created:       reconciliation.Created,
 		Finished:      reconciliation.Finished,
+		Paused:        reconciliation.Paused,
 		RuntimeID:     reconciliation.RuntimeID,
 		SchedulingID:  reconciliation.SchedulingID,
 		Updated:       reconciliation.Updated,
@@ -28,6 +29,9 @@ func ConvertReconciliation(reconciliation *model.ReconciliationEntity, operation
 		Status:        resultStatus,
 		Operations:    resultOperations,
 	}
+	if reconciliation.Reason != "" {
+		result.Reason = &reconciliation.Reason
+	}
 
 	return result, nil
 }
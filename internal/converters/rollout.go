@@ -0,0 +1,25 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/rollout"
+)
+
+func ConvertRolloutStatus(status *rollout.Status) keb.RolloutStatus {
+	var reason *string
+	if status.Reason != "" {
+		reason = &status.Reason
+	}
+	return keb.RolloutStatus{
+		KymaVersion:     status.KymaVersion,
+		Phase:           keb.RolloutPhase(status.Phase),
+		Wave:            status.Wave,
+		Pending:         status.Pending,
+		Succeeded:       status.Succeeded,
+		Failed:          status.Failed,
+		Superseded:      status.Superseded,
+		ErrorRate:       status.ErrorRate,
+		StartedLastHour: status.StartedLastHour,
+		Reason:          reason,
+	}
+}
@@ -0,0 +1,66 @@
+package converters
+
+import (
+	"encoding/json"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+//ConvertWebhookRegistration returns the entity of a new webhook
+func ConvertWebhookRegistration(registration *keb.WebhookRegistration) *model.WebhookEntity {
+	entity := &model.WebhookEntity{
+		URL:    registration.Url,
+		Secret: registration.Secret,
+	}
+	if registration.RuntimeIDs != nil {
+		entity.RuntimeIDFilter = *registration.RuntimeIDs
+	}
+	if registration.Metadata != nil {
+		entity.MetadataFilter = *registration.Metadata
+	}
+	if registration.Statuses != nil {
+		for _, status := range *registration.Statuses {
+			entity.StatusFilter = append(entity.StatusFilter, model.Status(status))
+		}
+	}
+	return entity
+}
+
+//ConvertWebhook returns the webhook without its secret
+func ConvertWebhook(entity *model.WebhookEntity) keb.Webhook {
+	result := keb.Webhook{
+		WebhookID: entity.ID,
+		Url:       entity.URL,
+		Created:   entity.Created,
+	}
+	if len(entity.RuntimeIDFilter) > 0 {
+		runtimeIDs := entity.RuntimeIDFilter
+		result.RuntimeIDs = &runtimeIDs
+	}
+	if len(entity.MetadataFilter) > 0 {
+		metadata := entity.MetadataFilter
+		result.Metadata = &metadata
+	}
+	if len(entity.StatusFilter) > 0 {
+		statuses := make([]keb.Status, 0, len(entity.StatusFilter))
+		for _, status := range entity.StatusFilter {
+			statuses = append(statuses, keb.Status(status))
+		}
+		result.Statuses = &statuses
+	}
+	return result
+}
+
+func ConvertWebhookDelivery(entity *model.WebhookDeliveryEntity) (keb.WebhookDelivery, error) {
+	result := keb.WebhookDelivery{
+		DeliveryID: entity.ID,
+		State:      string(entity.State),
+		Attempts:   entity.Attempts,
+		LastError:  entity.LastError,
+		Created:    entity.Created,
+		Updated:    entity.Updated,
+	}
+	err := json.Unmarshal([]byte(entity.Payload), &result.Event)
+	return result, err
+}
@@ -1,12 +1,21 @@
 package persistency
 
 import (
+	"fmt"
+
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/kv"
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/discovery"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/drift"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/replay"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/rollout"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/taskqueue"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/webhook"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
@@ -17,6 +26,12 @@ type Registry struct {
 	inventory       cluster.Inventory
 	kvRepository    *kv.Repository
 	reconRepository reconciliation.Repository
+	rolloutRepo     rollout.Repository
+	driftRepo       drift.Repository
+	webhookRepo     webhook.Repository
+	taskQueue       taskqueue.Repository
+	discoveryRepo   discovery.Repository
+	replayRepo      replay.Repository
 	initialized     bool
 }
 
@@ -48,6 +63,24 @@ func (or *Registry) init() error {
 	if or.reconRepository, err = or.initReconciliationRepository(); err != nil {
 		return err
 	}
+	if or.rolloutRepo, err = or.initRolloutRepository(); err != nil {
+		return err
+	}
+	if or.driftRepo, err = or.initDriftRepository(); err != nil {
+		return err
+	}
+	if or.webhookRepo, err = or.initWebhookRepository(); err != nil {
+		return err
+	}
+	if or.taskQueue, err = or.initTaskQueue(); err != nil {
+		return err
+	}
+	if or.discoveryRepo, err = or.initDiscoveryRepository(); err != nil {
+		return err
+	}
+	if or.replayRepo, err = or.initReplayRepository(); err != nil {
+		return err
+	}
 
 	or.initialized = true
 
@@ -77,12 +110,41 @@ func (or *Registry) ReconciliationRepository() reconciliation.Repository {
 	return or.reconRepository
 }
 
+func (or *Registry) RolloutRepository() rollout.Repository {
+	return or.rolloutRepo
+}
+
+func (or *Registry) DriftRepository() drift.Repository {
+	return or.driftRepo
+}
+
+func (or *Registry) WebhookRepository() webhook.Repository {
+	return or.webhookRepo
+}
+
+func (or *Registry) TaskQueue() taskqueue.Repository {
+	return or.taskQueue
+}
+
+func (or *Registry) DiscoveryRepository() discovery.Repository {
+	return or.discoveryRepo
+}
+
+func (or *Registry) ReplayRepository() replay.Repository {
+	return or.replayRepo
+}
+
 func (or *Registry) initRepository() (*kv.Repository, error) {
 	repository, err := kv.NewRepository(or.connection, or.debug)
 	if err != nil {
 		or.logger.Errorf("Failed to create configuration entry repository: %s", err)
+		return nil, err
 	}
-	return repository, err
+	//triggers of keys can enqueue the reconciliation of clusters which are using a bucket
+	return repository.WithReconcileClusters(func(bucket string) error {
+		_, err := or.inventory.ReconcileBucketClusters(bucket)
+		return err
+	}), nil
 }
 
 func (or *Registry) initInventory() (cluster.Inventory, error) {
@@ -90,8 +152,16 @@ func (or *Registry) initInventory() (cluster.Inventory, error) {
 	inventory, err := cluster.NewInventory(or.connection, or.debug, collector)
 	if err != nil {
 		or.logger.Errorf("Failed to create cluster inventory: %s", err)
+		return nil, err
 	}
-	return inventory, err
+	//landscape is optional and used to resolve the landscape-specific bucket of the KV-store
+	defaultInventory, ok := inventory.(*cluster.DefaultInventory)
+	if !ok {
+		err := fmt.Errorf("cluster inventory of type '%T' doesn't support landscapes", inventory)
+		or.logger.Errorf("Failed to create cluster inventory: %s", err)
+		return nil, err
+	}
+	return defaultInventory.WithLandscape(viper.GetString("mothership.landscape")), nil
 }
 
 func (or *Registry) initReconciliationRepository() (reconciliation.Repository, error) {
@@ -101,3 +171,51 @@ func (or *Registry) initReconciliationRepository() (reconciliation.Repository, e
 	}
 	return reconRepo, err
 }
+
+func (or *Registry) initRolloutRepository() (rollout.Repository, error) {
+	rolloutRepo, err := rollout.NewPersistentRepository(or.connection, or.debug)
+	if err != nil {
+		or.logger.Errorf("Failed to create rollout repository: %s", err)
+	}
+	return rolloutRepo, err
+}
+
+func (or *Registry) initDriftRepository() (drift.Repository, error) {
+	driftRepo, err := drift.NewPersistentRepository(or.connection, or.debug)
+	if err != nil {
+		or.logger.Errorf("Failed to create drift repository: %s", err)
+	}
+	return driftRepo, err
+}
+
+func (or *Registry) initWebhookRepository() (webhook.Repository, error) {
+	webhookRepo, err := webhook.NewPersistentRepository(or.connection, or.debug)
+	if err != nil {
+		or.logger.Errorf("Failed to create webhook repository: %s", err)
+	}
+	return webhookRepo, err
+}
+
+func (or *Registry) initTaskQueue() (taskqueue.Repository, error) {
+	taskQueue, err := taskqueue.NewPersistentRepository(or.connection, or.debug)
+	if err != nil {
+		or.logger.Errorf("Failed to create task queue: %s", err)
+	}
+	return taskQueue, err
+}
+
+func (or *Registry) initDiscoveryRepository() (discovery.Repository, error) {
+	discoveryRepo, err := discovery.NewPersistentRepository(or.connection, or.debug)
+	if err != nil {
+		or.logger.Errorf("Failed to create discovery repository: %s", err)
+	}
+	return discoveryRepo, err
+}
+
+func (or *Registry) initReplayRepository() (replay.Repository, error) {
+	replayRepo, err := replay.NewPersistentRepository(or.connection, or.debug)
+	if err != nil {
+		or.logger.Errorf("Failed to create replay repository: %s", err)
+	}
+	return replayRepo, err
+}
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/square/go-jose/v3/jwt"
+)
+
+const (
+	AuthorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+	DefaultScopeClaim   = "scope"
+)
+
+//ErrNoToken is returned if the request contains no bearer token
+var ErrNoToken = errors.New("no bearer token found in authorization header")
+
+//Identity is the verified caller of a request
+type Identity struct {
+	Subject string
+	Issuer  string
+	Scopes  []string
+}
+
+//HasScope returns true if the identity was granted the scope
+func (i *Identity) HasScope(scope string) bool {
+	for _, s := range i.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *Identity) String() string {
+	return fmt.Sprintf("Identity [Subject=%s,Issuer=%s,Scopes=%s]", i.Subject, i.Issuer, strings.Join(i.Scopes, " "))
+}
+
+//Authenticator verifies the bearer tokens (JWT) of requests
+type Authenticator struct {
+	keySet     KeySet
+	issuers    []string
+	audience   string
+	scopeClaim string
+	clock      func() time.Time
+}
+
+//NewAuthenticator creates an authenticator which accepts tokens of the given issuers.
+//If audience is not empty, the token has to be issued for this audience.
+func NewAuthenticator(keySet KeySet, issuers []string, audience, scopeClaim string) (*Authenticator, error) {
+	if keySet == nil {
+		return nil, errors.New("key set of authenticator is undefined")
+	}
+	if len(issuers) == 0 {
+		return nil, errors.New("at least one issuer has to be accepted by the authenticator")
+	}
+	if scopeClaim == "" {
+		scopeClaim = DefaultScopeClaim
+	}
+	return &Authenticator{
+		keySet:     keySet,
+		issuers:    issuers,
+		audience:   audience,
+		scopeClaim: scopeClaim,
+		clock:      time.Now,
+	}, nil
+}
+
+//Authenticate verifies the bearer token of the request and returns the identity of the caller
+func (a *Authenticator) Authenticate(r *http.Request) (*Identity, error) {
+	header := r.Header.Get(AuthorizationHeader)
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return nil, ErrNoToken
+	}
+	return a.Verify(strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix)))
+}
+
+//Verify validates the signature and the claims of the token and returns the identity of the caller
+func (a *Authenticator) Verify(token string) (*Identity, error) {
+	jwtToken, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, errors.Wrap(err, "token is not a signed JWT")
+	}
+	if len(jwtToken.Headers) != 1 {
+		return nil, errors.New("token has to contain exactly one signature")
+	}
+
+	keyID := jwtToken.Headers[0].KeyID
+	keys, err := a.keySet.Keys(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("signing key '%s' of token is unknown", keyID)
+	}
+
+	claims := jwt.Claims{}
+	customClaims := map[string]interface{}{}
+	var verifyErr error
+	for _, key := range keys {
+		if verifyErr = jwtToken.Claims(key.Key, &claims, &customClaims); verifyErr == nil {
+			break
+		}
+	}
+	if verifyErr != nil {
+		return nil, errors.Wrap(verifyErr, "signature of token is invalid")
+	}
+
+	if err := a.validateClaims(claims); err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		Subject: claims.Subject,
+		Issuer:  claims.Issuer,
+		Scopes:  scopes(customClaims[a.scopeClaim]),
+	}, nil
+}
+
+func (a *Authenticator) validateClaims(claims jwt.Claims) error {
+	if claims.Expiry == nil {
+		return errors.New("token has no expiry")
+	}
+	if !contains(a.issuers, claims.Issuer) {
+		return fmt.Errorf("issuer '%s' of token is not accepted", claims.Issuer)
+	}
+	expected := jwt.Expected{Time: a.clock()}
+	if a.audience != "" {
+		expected.Audience = jwt.Audience{a.audience}
+	}
+	if err := claims.Validate(expected); err != nil {
+		return errors.Wrap(err, "token is invalid")
+	}
+	return nil
+}
+
+//scopes supports space separated scopes (OAuth2) and lists of scopes
+func scopes(claim interface{}) []string {
+	switch value := claim.(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		var result []string
+		for _, scope := range value {
+			if s, ok := scope.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
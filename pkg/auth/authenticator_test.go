@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jose "github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer = "https://issuer.kyma.local"
+	testKeyID  = "key1"
+)
+
+type testClaims struct {
+	jwt.Claims
+	Scope interface{} `json:"scope,omitempty"`
+}
+
+func newTestKey(t *testing.T, keyID string) (*rsa.PrivateKey, jose.JSONWebKey) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return privKey, jose.JSONWebKey{Key: &privKey.PublicKey, KeyID: keyID, Algorithm: string(jose.RS256), Use: "sig"}
+}
+
+func newTestToken(t *testing.T, privKey *rsa.PrivateKey, keyID string, claims *testClaims) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: privKey},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", keyID))
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func newValidClaims(scope interface{}) *testClaims {
+	return &testClaims{
+		Claims: jwt.Claims{
+			Subject:  "user@kyma.local",
+			Issuer:   testIssuer,
+			Audience: jwt.Audience{"reconciler"},
+			Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+		Scope: scope,
+	}
+}
+
+func writeKeySet(t *testing.T, keys ...jose.JSONWebKey) string {
+	data, err := json.Marshal(&jose.JSONWebKeySet{Keys: keys})
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, ioutil.WriteFile(file, data, 0600))
+	return file
+}
+
+func TestAuthenticator(t *testing.T) {
+	privKey, pubKey := newTestKey(t, testKeyID)
+	keySet, err := NewFileKeySet(writeKeySet(t, pubKey))
+	require.NoError(t, err)
+
+	authenticator, err := NewAuthenticator(keySet, []string{testIssuer}, "reconciler", "")
+	require.NoError(t, err)
+
+	t.Run("Valid token with space separated scopes", func(t *testing.T) {
+		identity, err := authenticator.Verify(newTestToken(t, privKey, testKeyID, newValidClaims("clusters:read clusters:write")))
+		require.NoError(t, err)
+		require.Equal(t, "user@kyma.local", identity.Subject)
+		require.Equal(t, testIssuer, identity.Issuer)
+		require.True(t, identity.HasScope("clusters:read"))
+		require.True(t, identity.HasScope("clusters:write"))
+		require.False(t, identity.HasScope("operations:stop"))
+	})
+
+	t.Run("Valid token with list of scopes", func(t *testing.T) {
+		identity, err := authenticator.Verify(newTestToken(t, privKey, testKeyID, newValidClaims([]string{"operations:stop"})))
+		require.NoError(t, err)
+		require.Equal(t, []string{"operations:stop"}, identity.Scopes)
+	})
+
+	t.Run("Token from authorization header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/clusters", nil)
+		_, err := authenticator.Authenticate(req)
+		require.Equal(t, ErrNoToken, err)
+
+		req.Header.Set(AuthorizationHeader, "Bearer "+newTestToken(t, privKey, testKeyID, newValidClaims("clusters:read")))
+		identity, err := authenticator.Authenticate(req)
+		require.NoError(t, err)
+		require.True(t, identity.HasScope("clusters:read"))
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		claims := newValidClaims("clusters:read")
+		claims.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+		_, err := authenticator.Verify(newTestToken(t, privKey, testKeyID, claims))
+		require.Error(t, err)
+	})
+
+	t.Run("Token without expiry", func(t *testing.T) {
+		claims := newValidClaims("clusters:read")
+		claims.Expiry = nil
+		_, err := authenticator.Verify(newTestToken(t, privKey, testKeyID, claims))
+		require.Error(t, err)
+	})
+
+	t.Run("Token of unknown issuer", func(t *testing.T) {
+		claims := newValidClaims("clusters:read")
+		claims.Issuer = "https://evil.local"
+		_, err := authenticator.Verify(newTestToken(t, privKey, testKeyID, claims))
+		require.Error(t, err)
+	})
+
+	t.Run("Token for other audience", func(t *testing.T) {
+		claims := newValidClaims("clusters:read")
+		claims.Audience = jwt.Audience{"other"}
+		_, err := authenticator.Verify(newTestToken(t, privKey, testKeyID, claims))
+		require.Error(t, err)
+	})
+
+	t.Run("Token signed with unknown key", func(t *testing.T) {
+		otherPrivKey, _ := newTestKey(t, testKeyID)
+		_, err := authenticator.Verify(newTestToken(t, otherPrivKey, testKeyID, newValidClaims("clusters:read")))
+		require.Error(t, err)
+
+		_, err = authenticator.Verify(newTestToken(t, otherPrivKey, "key2", newValidClaims("clusters:read")))
+		require.Error(t, err)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		_, err := authenticator.Verify("abc.def.ghi")
+		require.Error(t, err)
+	})
+
+	t.Run("Issuers are required", func(t *testing.T) {
+		_, err := NewAuthenticator(keySet, nil, "", "")
+		require.Error(t, err)
+	})
+}
+
+func TestKeySet(t *testing.T) {
+	_, pubKey1 := newTestKey(t, "key1")
+	_, pubKey2 := newTestKey(t, "key2")
+
+	t.Run("Load JWKS from file", func(t *testing.T) {
+		keySet, err := NewFileKeySet(writeKeySet(t, pubKey1))
+		require.NoError(t, err)
+		keys, err := keySet.Keys("key1")
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+	})
+
+	t.Run("Reject JWKS with private keys", func(t *testing.T) {
+		privKey, _ := newTestKey(t, "key1")
+		_, err := NewFileKeySet(writeKeySet(t, jose.JSONWebKey{Key: privKey, KeyID: "key1"}))
+		require.Error(t, err)
+	})
+
+	t.Run("Load JWKS from URL", func(t *testing.T) {
+		var requests int32
+		jwks := &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{pubKey1}}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			require.NoError(t, json.NewEncoder(w).Encode(jwks))
+		}))
+		defer srv.Close()
+
+		keySet := NewURLKeySet(srv.URL, time.Hour, nil)
+		keys, err := keySet.Keys("key1")
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+
+		//keys are cached
+		_, err = keySet.Keys("key1")
+		require.NoError(t, err)
+		require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+		//unknown keys do not trigger a reload within the min refresh interval
+		jwks.Keys = append(jwks.Keys, pubKey2)
+		keys, err = keySet.Keys("key2")
+		require.NoError(t, err)
+		require.Empty(t, keys)
+		require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+		//unknown keys trigger a reload after the min refresh interval
+		keySet.(*urlKeySet).lastAttempt = time.Now().Add(-2 * minRefreshInterval)
+		keys, err = keySet.Keys("key2")
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("Unreachable JWKS URL", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		_, err := NewURLKeySet(srv.URL, time.Hour, nil).Keys("key1")
+		require.Error(t, err)
+	})
+}
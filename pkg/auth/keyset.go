@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	jose "github.com/square/go-jose/v3"
+)
+
+const (
+	defaultRefreshInterval = 1 * time.Hour
+	minRefreshInterval     = 1 * time.Minute //tokens with unknown key IDs cannot trigger more frequent reloads
+)
+
+//KeySet provides the public keys (JWKS) used to verify the signatures of tokens
+type KeySet interface {
+	Keys(keyID string) ([]jose.JSONWebKey, error)
+}
+
+//NewFileKeySet loads the JWKS from a local file
+func NewFileKeySet(file string) (KeySet, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to read JWKS file '%s'", file))
+	}
+	jwks, err := parseKeySet(data)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse JWKS file '%s'", file))
+	}
+	return &staticKeySet{jwks: jwks}, nil
+}
+
+type staticKeySet struct {
+	jwks *jose.JSONWebKeySet
+}
+
+func (ks *staticKeySet) Keys(keyID string) ([]jose.JSONWebKey, error) {
+	return ks.jwks.Key(keyID), nil
+}
+
+//NewURLKeySet loads the JWKS from an URL. The keys are cached and reloaded after the refresh interval
+//or if a token was signed with an unknown key (e.g. after a key rotation of the issuer).
+func NewURLKeySet(url string, refreshInterval time.Duration, client *http.Client) KeySet {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultRefreshInterval
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &urlKeySet{
+		url:             url,
+		refreshInterval: refreshInterval,
+		client:          client,
+	}
+}
+
+type urlKeySet struct {
+	url             string
+	refreshInterval time.Duration
+	client          *http.Client
+	jwks            *jose.JSONWebKeySet
+	loaded          time.Time
+	lastAttempt     time.Time
+	mu              sync.Mutex
+}
+
+func (ks *urlKeySet) Keys(keyID string) ([]jose.JSONWebKey, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	now := time.Now()
+	expired := ks.jwks == nil || now.Sub(ks.loaded) > ks.refreshInterval
+	unknownKey := ks.jwks != nil && len(ks.jwks.Key(keyID)) == 0
+	if (expired || unknownKey) && (ks.jwks == nil || now.Sub(ks.lastAttempt) > minRefreshInterval) {
+		ks.lastAttempt = now
+		//cached keys stay in use if the JWKS cannot be reloaded
+		if err := ks.load(); err != nil && ks.jwks == nil {
+			return nil, err
+		}
+	}
+	return ks.jwks.Key(keyID), nil
+}
+
+func (ks *urlKeySet) load() error {
+	resp, err := ks.client.Get(ks.url)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to load JWKS from '%s'", ks.url))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to load JWKS from '%s': received HTTP status %d", ks.url, resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to read JWKS from '%s'", ks.url))
+	}
+	jwks, err := parseKeySet(data)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to parse JWKS from '%s'", ks.url))
+	}
+	ks.jwks = jwks
+	ks.loaded = time.Now()
+	return nil
+}
+
+func parseKeySet(data []byte) (*jose.JSONWebKeySet, error) {
+	jwks := &jose.JSONWebKeySet{}
+	if err := json.Unmarshal(data, jwks); err != nil {
+		return nil, err
+	}
+	for _, key := range jwks.Keys {
+		if !key.IsPublic() {
+			return nil, fmt.Errorf("JWKS contains the private key '%s': only public keys are accepted", key.KeyID)
+		}
+	}
+	return jwks, nil
+}
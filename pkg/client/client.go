@@ -0,0 +1,188 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+const (
+	contractVersion     = "v1"
+	DefaultAttempts     = 3
+	DefaultRetryDelay   = 1 * time.Second
+	defaultTimeout      = 30 * time.Second
+	defaultWaitInterval = 5 * time.Second
+)
+
+//Config of the mothership client
+type Config struct {
+	URL        string        //base URL of the mothership reconciler (e.g. http://localhost:8080)
+	Token      string        //bearer token sent with each request (optional)
+	HTTPClient *http.Client  //optional: a client with a timeout of 30 secs is used by default
+	Attempts   uint          //attempts of a request before giving up (default: 3)
+	RetryDelay time.Duration //delay between the attempts of a request (default: 1 sec)
+}
+
+//Client calls the REST API of the mothership reconciler.
+//Requests are retried if the mothership is not reachable or temporarily unavailable. Non-idempotent requests (POST)
+//are only retried if the mothership didn't process them: otherwise e.g. a reconciliation could be triggered twice.
+type Client struct {
+	baseURL    *url.URL
+	token      string
+	httpClient *http.Client
+	attempts   uint
+	retryDelay time.Duration
+}
+
+func NewClient(cfg *Config) (*Client, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("URL of the mothership reconciler is undefined")
+	}
+	baseURL, err := url.Parse(strings.TrimSuffix(cfg.URL, "/"))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("URL '%s' of the mothership reconciler is invalid", cfg.URL))
+	}
+	if (baseURL.Scheme != "http" && baseURL.Scheme != "https") || baseURL.Host == "" {
+		return nil, fmt.Errorf("URL '%s' of the mothership reconciler is not an absolute HTTP(S) URL", cfg.URL)
+	}
+
+	client := &Client{
+		baseURL:    baseURL,
+		token:      cfg.Token,
+		httpClient: cfg.HTTPClient,
+		attempts:   cfg.Attempts,
+		retryDelay: cfg.RetryDelay,
+	}
+	if client.httpClient == nil {
+		client.httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	if client.attempts == 0 {
+		client.attempts = DefaultAttempts
+	}
+	if client.retryDelay <= 0 {
+		client.retryDelay = DefaultRetryDelay
+	}
+	return client, nil
+}
+
+//url returns the URL of a route: path segments are escaped
+func (c *Client) url(query url.Values, segments ...string) string {
+	unescaped := []string{contractVersion}
+	escaped := []string{contractVersion}
+	for _, segment := range segments {
+		unescaped = append(unescaped, segment)
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+	result := *c.baseURL
+	result.RawPath = fmt.Sprintf("%s/%s", result.EscapedPath(), strings.Join(escaped, "/"))
+	result.Path = fmt.Sprintf("%s/%s", result.Path, strings.Join(unescaped, "/"))
+	result.RawQuery = query.Encode()
+	return result.String()
+}
+
+//do sends the request and unmarshals the response into respBody (if not nil)
+func (c *Client) do(ctx context.Context, method, route string, reqBody, respBody interface{}) error {
+	var payload []byte
+	if reqBody != nil {
+		var err error
+		if payload, err = json.Marshal(reqBody); err != nil {
+			return errors.Wrap(err, "failed to marshal request payload")
+		}
+	}
+
+	return retry.Do(
+		func() error {
+			return c.send(ctx, method, route, payload, respBody)
+		},
+		retry.Attempts(c.attempts),
+		retry.Delay(c.retryDelay),
+		retry.DelayType(retry.FixedDelay),
+		retry.LastErrorOnly(true),
+		retry.RetryIf(func(err error) bool {
+			return isRetryable(method, err)
+		}),
+		retry.Context(ctx))
+}
+
+func (c *Client) send(ctx context.Context, method, route string, payload []byte, respBody interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, route, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set(auth.AuthorizationHeader, "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to call mothership reconciler (%s %s)", method, route))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to read response of mothership reconciler (%s %s)", method, route))
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode > 299 {
+		return newResponseError(method, route, resp.StatusCode, body)
+	}
+	if respBody == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, respBody); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to unmarshal response of mothership reconciler (%s %s)", method, route))
+	}
+	return nil
+}
+
+//isRetryable returns true for errors which are probably temporary (network issues or unavailable mothership).
+//Non-idempotent requests are only retried if the mothership didn't receive or rejected them.
+func isRetryable(method string, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		switch respErr.StatusCode {
+		case http.StatusTooManyRequests:
+			return true //request was rejected by the rate limiter
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return isIdempotent(method) //a proxy could have forwarded the request to the mothership
+		default:
+			return false
+		}
+	}
+	return isIdempotent(method) || isDialError(err)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+//isDialError returns true if the connection to the mothership couldn't be established (the request wasn't sent)
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,243 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client, err := NewClient(&Config{
+		URL:        srv.URL,
+		Token:      "token",
+		RetryDelay: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func respond(t *testing.T, w http.ResponseWriter, statusCode int, payload interface{}) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(statusCode)
+	require.NoError(t, json.NewEncoder(w).Encode(payload))
+}
+
+func TestClient(t *testing.T) {
+	t.Run("Invalid URL", func(t *testing.T) {
+		_, err := NewClient(&Config{})
+		require.Error(t, err)
+		_, err = NewClient(&Config{URL: "localhost:8080"})
+		require.Error(t, err)
+	})
+
+	t.Run("Create cluster", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/v1/clusters", r.URL.Path)
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			cluster := &keb.Cluster{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(cluster))
+			respond(t, w, http.StatusOK, &keb.HTTPClusterResponse{
+				Cluster:              cluster.RuntimeID,
+				ConfigurationVersion: 1,
+				Status:               keb.StatusReconcilePending,
+			})
+		})
+		resp, err := client.CreateOrUpdateCluster(context.Background(), &keb.Cluster{RuntimeID: "runtime1"})
+		require.NoError(t, err)
+		require.Equal(t, "runtime1", resp.Cluster)
+		require.Equal(t, keb.StatusReconcilePending, resp.Status)
+	})
+
+	t.Run("Reconcile cluster", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/v1/clusters/runtime1/reconcile", r.URL.Path)
+			request := &keb.ReconcileRequest{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(request))
+			require.Equal(t, []string{"istio"}, *request.Components)
+			respond(t, w, http.StatusOK, &keb.HTTPReconciliationInfo{
+				RuntimeID:    "runtime1",
+				SchedulingID: "scheduling1",
+				Reason:       &request.Reason,
+			})
+		})
+		resp, err := client.ReconcileCluster(context.Background(), "runtime1", &keb.ReconcileRequest{
+			Components: &[]string{"istio"},
+			Reason:     "hotfix",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "scheduling1", resp.SchedulingID)
+		require.Equal(t, "hotfix", *resp.Reason)
+	})
+
+	t.Run("Skip operation", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "/v1/operations/scheduling1/correlation1/skip", r.URL.Path)
+			request := &keb.OperationSkip{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(request))
+			require.Equal(t, "broken chart", request.Reason)
+			respond(t, w, http.StatusOK, &keb.HTTPReconciliationInfo{SchedulingID: "scheduling1"})
+		})
+		resp, err := client.SkipOperation(context.Background(), "scheduling1", "correlation1", "broken chart")
+		require.NoError(t, err)
+		require.Equal(t, "scheduling1", resp.SchedulingID)
+	})
+
+	t.Run("Path segments and query parameters are escaped", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/v1/reconciliations", r.URL.Path)
+			require.Equal(t, []string{"runtime/1", "runtime2"}, r.URL.Query()["runtimeID"])
+			require.Equal(t, []string{"ready"}, r.URL.Query()["status"])
+			require.Equal(t, "5", r.URL.Query().Get("last"))
+			respond(t, w, http.StatusOK, keb.HTTPReconcilerStatus{{SchedulingID: "scheduling1"}})
+		})
+		last := 5
+		resp, err := client.ListReconciliations(context.Background(), &keb.GetReconciliationsParams{
+			RuntimeID: &[]string{"runtime/1", "runtime2"},
+			Status:    &[]keb.Status{keb.StatusReady},
+			Last:      &last,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp, 1)
+
+		client = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/v1/clusters/runtime%2F1/statusChanges", r.URL.EscapedPath())
+			require.Equal(t, "1h0m0s", r.URL.Query().Get("offset"))
+			respond(t, w, http.StatusOK, &keb.HTTPClusterStatusResponse{})
+		})
+		_, err = client.GetClusterStatusChanges(context.Background(), "runtime/1", time.Hour)
+		require.NoError(t, err)
+	})
+
+	t.Run("Error responses are mapped to typed errors", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			respond(t, w, http.StatusNotFound, &keb.HTTPErrorResponse{Error: "operation not found"})
+		})
+		err := client.StopOperation(context.Background(), "scheduling1", "correlation1", "not required")
+		require.Error(t, err)
+		require.True(t, IsNotFoundError(err))
+		require.False(t, IsConflictError(err))
+		require.Contains(t, err.Error(), "operation not found")
+
+		client = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			respond(t, w, http.StatusConflict, &keb.HTTPErrorResponse{Error: "reconciliation is finished"})
+		})
+		_, err = client.PauseReconciliation(context.Background(), "scheduling1")
+		require.True(t, IsConflictError(err))
+	})
+
+	t.Run("Temporary errors are retried", func(t *testing.T) {
+		var calls int32
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < DefaultAttempts {
+				respond(t, w, http.StatusServiceUnavailable, &keb.HTTPErrorResponse{Error: "unavailable"})
+				return
+			}
+			respond(t, w, http.StatusOK, &keb.HTTPClusterResponse{Status: keb.StatusReady})
+		})
+		resp, err := client.GetClusterStatus(context.Background(), "runtime1")
+		require.NoError(t, err)
+		require.Equal(t, keb.StatusReady, resp.Status)
+		require.Equal(t, int32(DefaultAttempts), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Non-idempotent requests are not retried if they could have been processed", func(t *testing.T) {
+		var calls int32
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			respond(t, w, http.StatusBadGateway, &keb.HTTPErrorResponse{Error: "bad gateway"})
+		})
+		_, err := client.ReconcileCluster(context.Background(), "runtime1", &keb.ReconcileRequest{Reason: "hotfix"})
+		require.Error(t, err)
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Non-idempotent requests are retried if they were rejected", func(t *testing.T) {
+		var calls int32
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < DefaultAttempts {
+				respond(t, w, http.StatusTooManyRequests, &keb.HTTPErrorResponse{Error: "too many requests"})
+				return
+			}
+			respond(t, w, http.StatusOK, &keb.HTTPReconciliationInfo{SchedulingID: "scheduling1"})
+		})
+		resp, err := client.ReconcileCluster(context.Background(), "runtime1", &keb.ReconcileRequest{Reason: "hotfix"})
+		require.NoError(t, err)
+		require.Equal(t, "scheduling1", resp.SchedulingID)
+		require.Equal(t, int32(DefaultAttempts), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Retry decision depends on method and error", func(t *testing.T) {
+		unavailable := &ResponseError{StatusCode: http.StatusServiceUnavailable}
+		require.True(t, isRetryable(http.MethodGet, unavailable))
+		require.True(t, isRetryable(http.MethodPut, unavailable))
+		require.False(t, isRetryable(http.MethodPost, unavailable))
+
+		dialErr := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+		require.True(t, isRetryable(http.MethodPost, dialErr))
+		readErr := &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}
+		require.True(t, isRetryable(http.MethodGet, readErr))
+		require.False(t, isRetryable(http.MethodPost, readErr))
+
+		require.False(t, isRetryable(http.MethodGet, context.Canceled))
+	})
+
+	t.Run("Client errors are not retried", func(t *testing.T) {
+		var calls int32
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			respond(t, w, http.StatusBadRequest, &keb.HTTPErrorResponse{Error: "invalid"})
+		})
+		_, err := client.GetClusterStatus(context.Background(), "runtime1")
+		require.True(t, IsBadRequestError(err))
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestWaitForStatus(t *testing.T) {
+	newStatusClient := func(t *testing.T, statuses ...keb.Status) *Client {
+		var calls int32
+		return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			idx := int(atomic.AddInt32(&calls, 1)) - 1
+			if idx >= len(statuses) {
+				idx = len(statuses) - 1
+			}
+			respond(t, w, http.StatusOK, &keb.HTTPClusterResponse{Status: statuses[idx]})
+		})
+	}
+
+	t.Run("Expected status is reached", func(t *testing.T) {
+		client := newStatusClient(t, keb.StatusReconcilePending, keb.StatusReconciling, keb.StatusReady)
+		resp, err := client.WaitForStatus(context.Background(), "runtime1", 10*time.Millisecond, keb.StatusReady)
+		require.NoError(t, err)
+		require.Equal(t, keb.StatusReady, resp.Status)
+	})
+
+	t.Run("Unexpected final status is reached", func(t *testing.T) {
+		client := newStatusClient(t, keb.StatusReconciling, keb.StatusError)
+		resp, err := client.WaitForStatus(context.Background(), "runtime1", 10*time.Millisecond, keb.StatusReady)
+		require.True(t, IsUnexpectedStatusError(err))
+		require.Equal(t, keb.StatusError, resp.Status)
+	})
+
+	t.Run("Context is done", func(t *testing.T) {
+		client := newStatusClient(t, keb.StatusReconciling)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.WaitForStatus(ctx, "runtime1", 10*time.Millisecond, keb.StatusReady)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+//CreateOrUpdateCluster adds a cluster to the inventory or updates its configuration
+func (c *Client) CreateOrUpdateCluster(ctx context.Context, cluster *keb.Cluster) (*keb.HTTPClusterResponse, error) {
+	result := &keb.HTTPClusterResponse{}
+	if err := c.do(ctx, http.MethodPost, c.url(nil, "clusters"), cluster, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//ListClusters returns a page of the clusters matching the filters of params (params can be nil)
+func (c *Client) ListClusters(ctx context.Context, params *keb.GetClustersParams) (*keb.HTTPFleetResponse, error) {
+	result := &keb.HTTPFleetResponse{}
+	if err := c.do(ctx, http.MethodGet, c.url(clustersQuery(params), "clusters"), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//DeleteCluster marks a cluster for deletion
+func (c *Client) DeleteCluster(ctx context.Context, runtimeID string) (*keb.HTTPClusterResponse, error) {
+	result := &keb.HTTPClusterResponse{}
+	if err := c.do(ctx, http.MethodDelete, c.url(nil, "clusters", runtimeID), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//GetClusterStatus returns the status of the latest configuration of a cluster
+func (c *Client) GetClusterStatus(ctx context.Context, runtimeID string) (*keb.HTTPClusterResponse, error) {
+	result := &keb.HTTPClusterResponse{}
+	if err := c.do(ctx, http.MethodGet, c.url(nil, "clusters", runtimeID, "status"), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//GetClusterConfigStatus returns the status of a particular configuration version of a cluster
+func (c *Client) GetClusterConfigStatus(ctx context.Context, runtimeID string, configVersion int64) (*keb.HTTPClusterResponse, error) {
+	result := &keb.HTTPClusterResponse{}
+	route := c.url(nil, "clusters", runtimeID, "configs", strconv.FormatInt(configVersion, 10), "status")
+	if err := c.do(ctx, http.MethodGet, route, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//UpdateClusterStatus sets the status of the latest configuration of a cluster (e.g. to disable its reconciliation)
+func (c *Client) UpdateClusterStatus(ctx context.Context, runtimeID string, status keb.Status) (*keb.HTTPClusterResponse, error) {
+	result := &keb.HTTPClusterResponse{}
+	route := c.url(nil, "clusters", runtimeID, "status")
+	if err := c.do(ctx, http.MethodPut, route, &keb.StatusUpdate{Status: status}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//GetClusterStatusChanges returns the status changes of a cluster within the offset (0 uses the default offset of the mothership)
+func (c *Client) GetClusterStatusChanges(ctx context.Context, runtimeID string, offset time.Duration) (*keb.HTTPClusterStatusResponse, error) {
+	query := url.Values{}
+	if offset > 0 {
+		query.Set("offset", offset.String())
+	}
+	result := &keb.HTTPClusterStatusResponse{}
+	if err := c.do(ctx, http.MethodGet, c.url(query, "clusters", runtimeID, "statusChanges"), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//GetClusterConfig returns a configuration version of a cluster
+func (c *Client) GetClusterConfig(ctx context.Context, runtimeID string, configVersion int64) (*keb.KymaConfig, error) {
+	result := &keb.KymaConfig{}
+	route := c.url(nil, "clusters", runtimeID, "config", strconv.FormatInt(configVersion, 10))
+	if err := c.do(ctx, http.MethodGet, route, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//GetClusterConfigDiff returns the changes between two configuration versions of a cluster
+func (c *Client) GetClusterConfigDiff(ctx context.Context, runtimeID string, fromVersion, toVersion int64) (*keb.HTTPClusterConfigDiffResponse, error) {
+	result := &keb.HTTPClusterConfigDiffResponse{}
+	route := c.url(nil, "clusters", runtimeID, "config",
+		strconv.FormatInt(fromVersion, 10), "diff", strconv.FormatInt(toVersion, 10))
+	if err := c.do(ctx, http.MethodGet, route, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//GetClusterPlan returns the changes a reconciliation of the latest cluster configuration would apply
+func (c *Client) GetClusterPlan(ctx context.Context, runtimeID string) (*keb.HTTPClusterPlanResponse, error) {
+	result := &keb.HTTPClusterPlanResponse{}
+	if err := c.do(ctx, http.MethodGet, c.url(nil, "clusters", runtimeID, "plan"), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//GetClusterDrift returns the resources which were changed on the cluster outside of the reconciler
+func (c *Client) GetClusterDrift(ctx context.Context, runtimeID string) (*keb.HTTPClusterDriftResponse, error) {
+	result := &keb.HTTPClusterDriftResponse{}
+	if err := c.do(ctx, http.MethodGet, c.url(nil, "clusters", runtimeID, "drift"), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//ReconcileCluster starts a reconciliation of a cluster immediately (limited to the components of the request if defined)
+func (c *Client) ReconcileCluster(ctx context.Context, runtimeID string, request *keb.ReconcileRequest) (*keb.HTTPReconciliationInfo, error) {
+	result := &keb.HTTPReconciliationInfo{}
+	if err := c.do(ctx, http.MethodPost, c.url(nil, "clusters", runtimeID, "reconcile"), request, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//WaitForStatus polls the status of a cluster until it reaches one of the expected statuses.
+//An UnexpectedStatusError is returned if the cluster reached another final status.
+func (c *Client) WaitForStatus(ctx context.Context, runtimeID string, interval time.Duration, statuses ...keb.Status) (*keb.HTTPClusterResponse, error) {
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		resp, err := c.GetClusterStatus(ctx, runtimeID)
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range statuses {
+			if resp.Status == status {
+				return resp, nil
+			}
+		}
+		if isFinalStatus(resp.Status) {
+			return resp, &UnexpectedStatusError{
+				RuntimeID: runtimeID,
+				Status:    resp.Status,
+				Expected:  statuses,
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return resp, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func clustersQuery(params *keb.GetClustersParams) url.Values {
+	query := url.Values{}
+	if params == nil {
+		return query
+	}
+	if params.Status != nil {
+		for _, status := range *params.Status {
+			query.Add("status", string(status))
+		}
+	}
+	addString(query, "kymaVersion", params.KymaVersion)
+	addString(query, "kymaProfile", params.KymaProfile)
+	addString(query, "component", params.Component)
+	addString(query, "componentVersion", params.ComponentVersion)
+	addString(query, "globalAccountID", params.GlobalAccountID)
+	addString(query, "subAccountID", params.SubAccountID)
+	addString(query, "serviceID", params.ServiceID)
+	addString(query, "servicePlanID", params.ServicePlanID)
+	addString(query, "servicePlanName", params.ServicePlanName)
+	addString(query, "shootName", params.ShootName)
+	addString(query, "instanceID", params.InstanceID)
+	addString(query, "region", params.Region)
+	if params.SortBy != nil {
+		query.Set("sortBy", string(*params.SortBy))
+	}
+	if params.SortOrder != nil {
+		query.Set("sortOrder", string(*params.SortOrder))
+	}
+	if params.Limit != nil {
+		query.Set("limit", strconv.Itoa(*params.Limit))
+	}
+	addString(query, "cursor", params.Cursor)
+	return query
+}
+
+func addString(query url.Values, name string, value *string) {
+	if value != nil && *value != "" {
+		query.Set(name, *value)
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+)
+
+//ResponseError is returned if the mothership reconciler responded with an HTTP error code
+type ResponseError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Response   keb.HTTPErrorResponse //empty if the response body was not an error response
+}
+
+func newResponseError(method, url string, statusCode int, body []byte) *ResponseError {
+	respErr := &ResponseError{
+		Method:     method,
+		URL:        url,
+		StatusCode: statusCode,
+	}
+	if err := json.Unmarshal(body, &respErr.Response); err != nil || respErr.Response.Error == "" {
+		respErr.Response.Error = strings.TrimSpace(string(body))
+	}
+	return respErr
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("mothership reconciler responded with HTTP status %d (%s %s): %s",
+		e.StatusCode, e.Method, e.URL, e.Response.Error)
+}
+
+//IsNotFoundError returns true if the requested entity does not exist
+func IsNotFoundError(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+//IsBadRequestError returns true if the request was rejected as invalid
+func IsBadRequestError(err error) bool {
+	return hasStatusCode(err, http.StatusBadRequest)
+}
+
+//IsConflictError returns true if the request conflicts with the state of the entity (e.g. a finished reconciliation)
+func IsConflictError(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+//IsForbiddenError returns true if the request is not allowed (e.g. insufficient scopes)
+func IsForbiddenError(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+//IsUnauthorizedError returns true if the request was not authenticated
+func IsUnauthorizedError(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var respErr *ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == statusCode
+}
+
+//UnexpectedStatusError is returned by WaitForStatus if the cluster reached a final status which was not expected
+type UnexpectedStatusError struct {
+	RuntimeID string
+	Status    keb.Status
+	Expected  []keb.Status
+}
+
+func (e *UnexpectedStatusError) Error() string {
+	var expected []string
+	for _, status := range e.Expected {
+		expected = append(expected, string(status))
+	}
+	return fmt.Sprintf("cluster '%s' reached status '%s' but expected was '%s'",
+		e.RuntimeID, e.Status, strings.Join(expected, "', '"))
+}
+
+func IsUnexpectedStatusError(err error) bool {
+	var statusErr *UnexpectedStatusError
+	return errors.As(err, &statusErr)
+}
+
+//isFinalStatus returns true if the cluster status changes only if a new reconciliation is triggered
+func isFinalStatus(status keb.Status) bool {
+	return model.Status(status).IsFinal()
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+//ListReconciliations returns the reconciliations matching the filters of params (params can be nil)
+func (c *Client) ListReconciliations(ctx context.Context, params *keb.GetReconciliationsParams) (keb.HTTPReconcilerStatus, error) {
+	var result keb.HTTPReconcilerStatus
+	if err := c.do(ctx, http.MethodGet, c.url(reconciliationsQuery(params), "reconciliations"), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//GetReconciliation returns a reconciliation including its operations
+func (c *Client) GetReconciliation(ctx context.Context, schedulingID string) (*keb.HTTPReconciliationInfo, error) {
+	return c.reconciliationInfo(ctx, http.MethodGet, schedulingID, "info")
+}
+
+//PauseReconciliation holds back the operations of a running reconciliation which are not started yet
+func (c *Client) PauseReconciliation(ctx context.Context, schedulingID string) (*keb.HTTPReconciliationInfo, error) {
+	return c.reconciliationInfo(ctx, http.MethodPost, schedulingID, "pause")
+}
+
+//ResumeReconciliation continues a paused reconciliation
+func (c *Client) ResumeReconciliation(ctx context.Context, schedulingID string) (*keb.HTTPReconciliationInfo, error) {
+	return c.reconciliationInfo(ctx, http.MethodPost, schedulingID, "resume")
+}
+
+//CancelReconciliation stops a running reconciliation
+func (c *Client) CancelReconciliation(ctx context.Context, schedulingID string) (*keb.HTTPReconciliationInfo, error) {
+	return c.reconciliationInfo(ctx, http.MethodPost, schedulingID, "cancel")
+}
+
+func (c *Client) reconciliationInfo(ctx context.Context, method, schedulingID, action string) (*keb.HTTPReconciliationInfo, error) {
+	result := &keb.HTTPReconciliationInfo{}
+	if err := c.do(ctx, method, c.url(nil, "reconciliations", schedulingID, action), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//StopOperation prevents that an operation which was not started yet gets processed
+func (c *Client) StopOperation(ctx context.Context, schedulingID, correlationID, reason string) error {
+	route := c.url(nil, "operations", schedulingID, correlationID, "stop")
+	return c.do(ctx, http.MethodPost, route, &keb.OperationStop{Reason: reason}, nil)
+}
+
+//RetryOperation resets a failed operation so that it gets processed again
+func (c *Client) RetryOperation(ctx context.Context, schedulingID, correlationID string) (*keb.HTTPReconciliationInfo, error) {
+	result := &keb.HTTPReconciliationInfo{}
+	route := c.url(nil, "operations", schedulingID, correlationID, "retry")
+	if err := c.do(ctx, http.MethodPost, route, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//SkipOperation marks a failed operation as skipped so that the reconciliation can continue without it
+func (c *Client) SkipOperation(ctx context.Context, schedulingID, correlationID, reason string) (*keb.HTTPReconciliationInfo, error) {
+	result := &keb.HTTPReconciliationInfo{}
+	route := c.url(nil, "operations", schedulingID, correlationID, "skip")
+	if err := c.do(ctx, http.MethodPost, route, &keb.OperationSkip{Reason: reason}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func reconciliationsQuery(params *keb.GetReconciliationsParams) url.Values {
+	query := url.Values{}
+	if params == nil {
+		return query
+	}
+	if params.RuntimeID != nil {
+		for _, runtimeID := range *params.RuntimeID {
+			query.Add("runtimeID", runtimeID)
+		}
+	}
+	if params.Status != nil {
+		for _, status := range *params.Status {
+			query.Add("status", string(status))
+		}
+	}
+	if params.Before != nil {
+		query.Set("before", params.Before.Format(time.RFC3339))
+	}
+	if params.After != nil {
+		query.Set("after", params.After.Format(time.RFC3339))
+	}
+	if params.Last != nil {
+		query.Set("last", strconv.Itoa(*params.Last))
+	}
+	return query
+}
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+//ListRollouts returns the rollout state of all Kyma versions rolled out by a rollout policy
+func (c *Client) ListRollouts(ctx context.Context) (keb.HTTPRolloutsResponse, error) {
+	var result keb.HTTPRolloutsResponse
+	if err := c.do(ctx, http.MethodGet, c.url(nil, "rollouts"), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//GetRollout returns the rollout state of a Kyma version
+func (c *Client) GetRollout(ctx context.Context, kymaVersion string) (*keb.HTTPRolloutResponse, error) {
+	result := &keb.HTTPRolloutResponse{}
+	if err := c.do(ctx, http.MethodGet, c.url(nil, "rollouts", kymaVersion), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+//ListWebhooks returns the registered webhooks
+func (c *Client) ListWebhooks(ctx context.Context) (keb.HTTPWebhooksResponse, error) {
+	var result keb.HTTPWebhooksResponse
+	if err := c.do(ctx, http.MethodGet, c.url(nil, "webhooks"), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//CreateWebhook registers a webhook which receives the status changes of clusters
+func (c *Client) CreateWebhook(ctx context.Context, registration *keb.WebhookRegistration) (*keb.HTTPWebhookResponse, error) {
+	result := &keb.HTTPWebhookResponse{}
+	if err := c.do(ctx, http.MethodPost, c.url(nil, "webhooks"), registration, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//GetWebhook returns a registered webhook
+func (c *Client) GetWebhook(ctx context.Context, webhookID string) (*keb.HTTPWebhookResponse, error) {
+	result := &keb.HTTPWebhookResponse{}
+	if err := c.do(ctx, http.MethodGet, c.url(nil, "webhooks", webhookID), nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+//DeleteWebhook unregisters a webhook
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
+	return c.do(ctx, http.MethodDelete, c.url(nil, "webhooks", webhookID), nil, nil)
+}
+
+//GetWebhookDeadLetters returns the events which could not be delivered to a webhook
+func (c *Client) GetWebhookDeadLetters(ctx context.Context, webhookID string) (keb.HTTPWebhookDeliveriesResponse, error) {
+	var result keb.HTTPWebhookDeliveriesResponse
+	if err := c.do(ctx, http.MethodGet, c.url(nil, "webhooks", webhookID, "deadletters"), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package cluster
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/kv"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+)
+
+const (
+	bucketPrefixLandscape = "landscape"
+	bucketPrefixRegion    = "region"
+	bucketPrefixAccount   = "account"
+	bucketPrefixRuntime   = "runtime"
+)
+
+var invalidBucketChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+//bucketHierarchy returns the names of the KV-buckets which are relevant for a cluster.
+//The buckets are ordered by their priority: values of a later bucket overwrite values of the previous buckets.
+func bucketHierarchy(landscape string, cluster *keb.Cluster) []string {
+	buckets := []string{model.DefaultBucket}
+	for _, bucket := range []string{
+		bucketName(bucketPrefixLandscape, landscape),
+		bucketName(bucketPrefixRegion, cluster.Metadata.Region),
+		bucketName(bucketPrefixAccount, cluster.Metadata.GlobalAccountID),
+		bucketName(bucketPrefixRuntime, cluster.RuntimeID),
+	} {
+		if bucket != "" {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets
+}
+
+func containsBucket(buckets []string, bucket string) bool {
+	for _, b := range buckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+//bucketName converts an identifier (e.g. a region or account ID) into a valid bucket name
+func bucketName(prefix, ident string) string {
+	ident = strings.Trim(invalidBucketChars.ReplaceAllString(strings.ToLower(ident), "-"), "-")
+	if ident == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%s", prefix, ident)
+}
+
+//resolveBucketValues merges the values of all buckets in the hierarchy of a cluster
+func resolveBucketValues(kvRepo *kv.Repository, buckets []string) (map[string]interface{}, error) {
+	merger := &bucketMerger{}
+	for _, bucket := range buckets {
+		values, err := kvRepo.ValuesByBucket(bucket)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to retrieve values of bucket '%s'", bucket))
+		}
+		if err := merger.Add(bucket, values); err != nil {
+			return nil, err
+		}
+	}
+	if merger.Len() == 0 {
+		return nil, nil
+	}
+	return merger.GetAll()
+}
@@ -0,0 +1,147 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/keb/test"
+	"github.com/kyma-incubator/reconciler/pkg/kv"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketHierarchy(t *testing.T) {
+	t.Run("Full hierarchy", func(t *testing.T) {
+		buckets := bucketHierarchy("Canary", &keb.Cluster{
+			RuntimeID: "6F8EC1BC-f300-4f00",
+			Metadata: keb.Metadata{
+				Region:          "europe_west1",
+				GlobalAccountID: "abc.123",
+			},
+		})
+		require.Equal(t, []string{
+			"default", "landscape-canary", "region-europe-west1", "account-abc-123", "runtime-6f8ec1bc-f300-4f00",
+		}, buckets)
+		for _, bucket := range buckets {
+			require.NoError(t, model.ValidateBucketName(bucket))
+		}
+	})
+
+	t.Run("Skip undefined levels", func(t *testing.T) {
+		buckets := bucketHierarchy("", &keb.Cluster{
+			RuntimeID: "runtime1",
+			Metadata: keb.Metadata{
+				Region: "---",
+			},
+		})
+		require.Equal(t, []string{"default", "runtime-runtime1"}, buckets)
+	})
+}
+
+func TestInventoryBucketValues(t *testing.T) {
+	dbConn := db.NewTestConnection(t)
+	inventory, err := NewInventory(dbConn, true, MetricsCollectorMock{})
+	require.NoError(t, err)
+	kvRepo, err := kv.NewRepository(dbConn, true)
+	require.NoError(t, err)
+
+	cluster := test.NewCluster(t, fmt.Sprintf("bucket%d", time.Now().UnixNano()), 1, false, test.OneComponentDummy)
+	accountBucket := bucketName(bucketPrefixAccount, cluster.Metadata.GlobalAccountID)
+	runtimeBucket := bucketName(bucketPrefixRuntime, cluster.RuntimeID)
+
+	keyName := fmt.Sprintf("bucket.test.key%d", time.Now().UnixNano())
+	key, err := kvRepo.CreateKey(&model.KeyEntity{
+		Key:      keyName,
+		DataType: model.String,
+		Username: "unittest",
+	})
+	require.NoError(t, err)
+
+	createValue := func(bucket, value string) {
+		_, err := kvRepo.CreateValue(&model.ValueEntity{
+			Key:        key.Key,
+			KeyVersion: key.Version,
+			Bucket:     bucket,
+			Value:      value,
+			DataType:   key.DataType,
+			Username:   "unittest",
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("Runtime bucket overrides account bucket", func(t *testing.T) {
+		createValue(accountBucket, "account")
+		createValue(runtimeBucket, "runtime")
+
+		state, err := inventory.CreateOrUpdate(1, cluster)
+		require.NoError(t, err)
+		require.Equal(t, "runtime", state.Configuration.BucketValues[keyName])
+
+		//bucket values are persisted with the cluster configuration
+		stateLatest, err := inventory.GetLatest(cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Equal(t, state.Configuration.Version, stateLatest.Configuration.Version)
+		require.Equal(t, "runtime", stateLatest.Configuration.BucketValues[keyName])
+
+		//unchanged values are not leading to a new configuration version
+		stateNew, err := inventory.CreateOrUpdate(1, cluster)
+		require.NoError(t, err)
+		require.Equal(t, state.Configuration.Version, stateNew.Configuration.Version)
+	})
+
+	t.Run("Changed bucket value creates new configuration version", func(t *testing.T) {
+		stateOld, err := inventory.GetLatest(cluster.RuntimeID)
+		require.NoError(t, err)
+
+		createValue(runtimeBucket, "runtime-changed")
+
+		state, err := inventory.CreateOrUpdate(1, cluster)
+		require.NoError(t, err)
+		require.NotEqual(t, stateOld.Configuration.Version, state.Configuration.Version)
+		require.Equal(t, "runtime-changed", state.Configuration.BucketValues[keyName])
+
+		//previous configuration keeps its snapshot
+		stateOld, err = inventory.Get(cluster.RuntimeID, stateOld.Configuration.Version)
+		require.NoError(t, err)
+		require.Equal(t, "runtime", stateOld.Configuration.BucketValues[keyName])
+	})
+
+	t.Run("Trigger of encrypted key reconciles clusters using the bucket", func(t *testing.T) {
+		kvRepo.WithReconcileClusters(func(bucket string) error {
+			_, err := inventory.(*DefaultInventory).ReconcileBucketClusters(bucket)
+			return err
+		})
+		triggerKey, err := kvRepo.CreateKey(&model.KeyEntity{
+			Key:       fmt.Sprintf("bucket.test.trigger%d", time.Now().UnixNano()),
+			DataType:  model.String,
+			Encrypted: true,
+			Trigger: `import "reconciler"
+reconciler.ReconcileClusters(bucket)`,
+			Username: "unittest",
+		})
+		require.NoError(t, err)
+
+		stateOld, err := inventory.GetLatest(cluster.RuntimeID)
+		require.NoError(t, err)
+
+		_, err = kvRepo.CreateValue(&model.ValueEntity{
+			Key:        triggerKey.Key,
+			KeyVersion: triggerKey.Version,
+			Bucket:     runtimeBucket,
+			Value:      "secret",
+			DataType:   triggerKey.DataType,
+			Username:   "unittest",
+		})
+		require.NoError(t, err)
+
+		//the cluster got a new configuration including the decrypted value and is waiting for its reconciliation
+		state, err := inventory.GetLatest(cluster.RuntimeID)
+		require.NoError(t, err)
+		require.NotEqual(t, stateOld.Configuration.Version, state.Configuration.Version)
+		require.Equal(t, "secret", state.Configuration.BucketValues[triggerKey.Key])
+		require.Equal(t, model.ClusterStatusReconcilePending, state.Status.Status)
+	})
+}
@@ -0,0 +1,456 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+const (
+	DefaultFleetLimit = 50
+	MaxFleetLimit     = 500
+)
+
+type FleetSortField string
+
+const (
+	FleetSortByRuntimeID   FleetSortField = "runtimeID"
+	FleetSortByKymaVersion FleetSortField = "kymaVersion"
+	FleetSortByStatus      FleetSortField = "status"
+	FleetSortByUpdated     FleetSortField = "updated" //time of the latest status change
+)
+
+//FleetFilter defines the criteria for listing clusters. All criteria are combined with AND.
+//Versions support the wildcards 'x' and '*' as last segment (e.g. "2.0.x" matches "2.0.3").
+type FleetFilter struct {
+	Statuses         []model.Status
+	KymaVersion      string
+	KymaProfile      string
+	Component        string
+	ComponentVersion string            //requires Component: components without version use the Kyma version
+	Metadata         map[string]string //key: JSON field name of keb.Metadata
+	SortBy           FleetSortField
+	SortDescending   bool
+	Limit            int
+	Cursor           string //returned by the previous page
+}
+
+//FleetPage is the result of a fleet listing
+type FleetPage struct {
+	Clusters   []*State
+	NextCursor string //empty if no further page exists
+}
+
+type fleetCursor struct {
+	Value    string `json:"v"`
+	StatusID int64  `json:"id"`
+}
+
+type fleetRow struct {
+	statusID      int64
+	runtimeID     string
+	configVersion int64
+	sortValue     string
+}
+
+func (r *fleetRow) cursor() (string, error) {
+	cursor, err := json.Marshal(&fleetCursor{Value: r.sortValue, StatusID: r.statusID})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(cursor), nil
+}
+
+//InvalidCursorError is returned if the cursor of a fleet filter wasn't returned by a previous page
+type InvalidCursorError struct {
+	Cursor string
+	err    error
+}
+
+func (e *InvalidCursorError) Error() string {
+	return fmt.Sprintf("cursor '%s' is invalid: %s", e.Cursor, e.err)
+}
+
+func (e *InvalidCursorError) Is(err error) bool {
+	_, ok := err.(*InvalidCursorError)
+	return ok
+}
+
+func (e *InvalidCursorError) Unwrap() error {
+	return e.err
+}
+
+func IsInvalidCursorError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, &InvalidCursorError{})
+}
+
+func decodeFleetCursor(cursor string) (*fleetCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, &InvalidCursorError{Cursor: cursor, err: err}
+	}
+	result := &fleetCursor{}
+	if err := json.Unmarshal(decoded, result); err != nil {
+		return nil, &InvalidCursorError{Cursor: cursor, err: err}
+	}
+	return result, nil
+}
+
+//MetadataFields returns the JSON field names of the cluster metadata which can be used in fleet filters
+func MetadataFields() []string {
+	var result []string
+	metadataType := reflect.TypeOf(keb.Metadata{})
+	for i := 0; i < metadataType.NumField(); i++ {
+		field := metadataType.Field(i)
+		if field.Type.Kind() != reflect.String {
+			continue
+		}
+		result = append(result, strings.Split(field.Tag.Get("json"), ",")[0])
+	}
+	return result
+}
+
+//versionPrefix returns the prefix of a version pattern and whether the pattern contains a wildcard
+func versionPrefix(pattern string) (string, bool) {
+	for _, wildcard := range []string{"x", "X", "*"} {
+		if pattern == wildcard {
+			return "", true
+		}
+		if strings.HasSuffix(pattern, "."+wildcard) {
+			return strings.TrimSuffix(pattern, wildcard), true
+		}
+	}
+	return pattern, false
+}
+
+func matchesVersion(pattern, version string) bool {
+	if prefix, ok := versionPrefix(pattern); ok {
+		return strings.HasPrefix(version, prefix)
+	}
+	return version == pattern
+}
+
+//Validate checks the filter criteria: an invalid cursor is reported as InvalidCursorError
+func (f *FleetFilter) Validate() error {
+	for _, status := range f.Statuses {
+		if _, err := model.NewClusterStatus(status); err != nil {
+			return err
+		}
+	}
+	if f.ComponentVersion != "" && f.Component == "" {
+		return fmt.Errorf("filtering by component version requires a component name")
+	}
+	metadataFields := MetadataFields()
+	for field := range f.Metadata {
+		var found bool
+		for _, metadataField := range metadataFields {
+			if field == metadataField {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("metadata field '%s' is not supported (supported fields: %s)",
+				field, strings.Join(metadataFields, ", "))
+		}
+	}
+	switch f.SortBy {
+	case "", FleetSortByRuntimeID, FleetSortByKymaVersion, FleetSortByStatus, FleetSortByUpdated:
+	default:
+		return fmt.Errorf("sorting by '%s' is not supported", f.SortBy)
+	}
+	if f.Limit < 0 || f.Limit > MaxFleetLimit {
+		return fmt.Errorf("limit has to be between 1 and %d", MaxFleetLimit)
+	}
+	if f.Cursor != "" {
+		if _, err := decodeFleetCursor(f.Cursor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FleetFilter) limit() int {
+	if f.Limit == 0 {
+		return DefaultFleetLimit
+	}
+	return f.Limit
+}
+
+//matchesComponent checks the components of a cluster: they are encrypted and cannot be filtered by SQL
+func (f *FleetFilter) matchesComponent(state *State) bool {
+	if f.Component == "" {
+		return true
+	}
+	for _, component := range state.Configuration.Components {
+		if component.Component != f.Component {
+			continue
+		}
+		if f.ComponentVersion == "" {
+			return true
+		}
+		version := component.Version
+		if version == "" {
+			version = state.Configuration.KymaVersion
+		}
+		return matchesVersion(f.ComponentVersion, version)
+	}
+	return false
+}
+
+func (i *DefaultInventory) Fleet(filter *FleetFilter) (*FleetPage, error) {
+	if filter == nil {
+		filter = &FleetFilter{}
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	var cursor *fleetCursor
+	if filter.Cursor != "" {
+		var err error
+		if cursor, err = decodeFleetCursor(filter.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	//components are filtered after the clusters were loaded: fetch batches until the page is complete
+	limit := filter.limit()
+	batchSize := limit + 1
+	page := &FleetPage{}
+	var lastRow *fleetRow
+	for {
+		rows, err := i.fleetRows(filter, cursor, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		states, err := i.fleetStates(rows)
+		if err != nil {
+			return nil, err
+		}
+		for idx, row := range rows {
+			state := states[idx]
+			cursor = &fleetCursor{Value: row.sortValue, StatusID: row.statusID}
+			if !filter.matchesComponent(state) {
+				continue
+			}
+			if len(page.Clusters) == limit { //a further cluster exists
+				if page.NextCursor, err = lastRow.cursor(); err != nil {
+					return nil, err
+				}
+				return page, nil
+			}
+			page.Clusters = append(page.Clusters, state)
+			lastRow = row
+		}
+		if len(rows) < batchSize {
+			return page, nil
+		}
+	}
+}
+
+//fleetStates loads the states of a batch of rows with one query per entity type (instead of three queries per cluster)
+func (i *DefaultInventory) fleetStates(rows []*fleetRow) ([]*State, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	statusIDs := make([]interface{}, 0, len(rows))
+	configVersions := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		statusIDs = append(statusIDs, row.statusID)
+		configVersions = append(configVersions, row.configVersion)
+	}
+
+	statuses := make(map[int64]*model.ClusterStatusEntity, len(rows))
+	entities, err := i.selectIn(&model.ClusterStatusEntity{}, "ID", statusIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range entities {
+		status := entity.(*model.ClusterStatusEntity)
+		statuses[status.ID] = status
+	}
+
+	configs := make(map[int64]*model.ClusterConfigurationEntity, len(rows))
+	var clusterVersions []interface{}
+	entities, err = i.selectIn(&model.ClusterConfigurationEntity{}, "Version", configVersions)
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range entities {
+		config := entity.(*model.ClusterConfigurationEntity)
+		configs[config.Version] = config
+		clusterVersions = append(clusterVersions, config.ClusterVersion)
+	}
+
+	clusters := make(map[int64]*model.ClusterEntity, len(rows))
+	entities, err = i.selectIn(&model.ClusterEntity{}, "Version", clusterVersions)
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range entities {
+		cluster := entity.(*model.ClusterEntity)
+		clusters[cluster.Version] = cluster
+	}
+
+	result := make([]*State, 0, len(rows))
+	for _, row := range rows {
+		status, config := statuses[row.statusID], configs[row.configVersion]
+		if status == nil || config == nil || clusters[config.ClusterVersion] == nil {
+			return nil, fmt.Errorf("state of cluster '%s' with configuration version %d is incomplete",
+				row.runtimeID, row.configVersion)
+		}
+		result = append(result, &State{
+			Cluster:       clusters[config.ClusterVersion],
+			Configuration: config,
+			Status:        status,
+		})
+	}
+	return result, nil
+}
+
+//selectIn returns all entities whose field matches one of the values
+func (i *DefaultInventory) selectIn(entity db.DatabaseEntity, field string, values []interface{}) ([]db.DatabaseEntity, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	q, err := db.NewQuery(i.Conn, entity, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	placeholders := make([]string, 0, len(values))
+	for idx := range values {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", idx+1))
+	}
+	return q.Select().WhereIn(field, strings.Join(placeholders, ", "), values...).GetMany()
+}
+
+//fleetRows returns the latest statuses of all clusters matching the filter which are sorted after the cursor
+func (i *DefaultInventory) fleetRows(filter *FleetFilter, cursor *fleetCursor, limit int) ([]*fleetRow, error) {
+	statusEntity := &model.ClusterStatusEntity{}
+	statusCols, err := i.columnNames(statusEntity, "s", "ID", "RuntimeID", "ClusterVersion", "ConfigVersion", "Status", "Deleted")
+	if err != nil {
+		return nil, err
+	}
+	clusterEntity := &model.ClusterEntity{}
+	clusterCols, err := i.columnNames(clusterEntity, "c", "Version", "Metadata")
+	if err != nil {
+		return nil, err
+	}
+	configEntity := &model.ClusterConfigurationEntity{}
+	configCols, err := i.columnNames(configEntity, "g", "Version", "KymaVersion", "KymaProfile")
+	if err != nil {
+		return nil, err
+	}
+
+	var args []interface{}
+	addArg := func(arg interface{}) int {
+		args = append(args, arg)
+		return len(args)
+	}
+
+	var sqlBuffer bytes.Buffer
+	sortColumn := statusCols["ID"]
+	switch filter.SortBy {
+	case "", FleetSortByRuntimeID:
+		sortColumn = statusCols["RuntimeID"]
+	case FleetSortByKymaVersion:
+		sortColumn = configCols["KymaVersion"]
+	case FleetSortByStatus:
+		sortColumn = statusCols["Status"]
+	}
+	sqlBuffer.WriteString(fmt.Sprintf("SELECT %s, %s, %s, %s FROM %s s JOIN %s c ON %s=%s JOIN %s g ON %s=%s",
+		statusCols["ID"], statusCols["RuntimeID"], statusCols["ConfigVersion"], sortColumn,
+		statusEntity.Table(),
+		clusterEntity.Table(), clusterCols["Version"], statusCols["ClusterVersion"],
+		configEntity.Table(), configCols["Version"], statusCols["ConfigVersion"]))
+
+	//latest status of each cluster
+	sqlBuffer.WriteString(fmt.Sprintf(" WHERE %s IN (SELECT MAX(%s) FROM %s l GROUP BY %s) AND %s=$%d",
+		statusCols["ID"], strings.Replace(statusCols["ID"], "s.", "l.", 1), statusEntity.Table(),
+		strings.Replace(statusCols["RuntimeID"], "s.", "l.", 1), statusCols["Deleted"], addArg(false)))
+
+	if len(filter.Statuses) > 0 {
+		var placeholders []string
+		for _, status := range filter.Statuses {
+			placeholders = append(placeholders, fmt.Sprintf("$%d", addArg(string(status))))
+		}
+		sqlBuffer.WriteString(fmt.Sprintf(" AND %s IN (%s)", statusCols["Status"], strings.Join(placeholders, ", ")))
+	}
+	if filter.KymaVersion != "" {
+		if prefix, ok := versionPrefix(filter.KymaVersion); ok {
+			cond, arg := db.PrefixCondition(configCols["KymaVersion"], len(args)+1, prefix)
+			addArg(arg)
+			sqlBuffer.WriteString(" AND " + cond)
+		} else {
+			sqlBuffer.WriteString(fmt.Sprintf(" AND %s=$%d", configCols["KymaVersion"], addArg(filter.KymaVersion)))
+		}
+	}
+	if filter.KymaProfile != "" {
+		sqlBuffer.WriteString(fmt.Sprintf(" AND %s=$%d", configCols["KymaProfile"], addArg(filter.KymaProfile)))
+	}
+	for field, value := range filter.Metadata {
+		cond, arg, err := db.JSONFieldCondition(i.Conn.Type(), clusterCols["Metadata"], field, len(args)+1, value)
+		if err != nil {
+			return nil, err
+		}
+		addArg(arg)
+		sqlBuffer.WriteString(" AND " + cond)
+	}
+
+	operator, order := ">", "ASC"
+	if filter.SortDescending {
+		operator, order = "<", "DESC"
+	}
+	if cursor != nil {
+		if sortColumn == statusCols["ID"] {
+			sqlBuffer.WriteString(fmt.Sprintf(" AND %s%s$%d", statusCols["ID"], operator, addArg(cursor.StatusID)))
+		} else {
+			valuePlc := addArg(cursor.Value)
+			sqlBuffer.WriteString(fmt.Sprintf(" AND (%s%s$%d OR (%s=$%d AND %s%s$%d))",
+				sortColumn, operator, valuePlc, sortColumn, valuePlc, statusCols["ID"], operator, addArg(cursor.StatusID)))
+		}
+	}
+	sqlBuffer.WriteString(fmt.Sprintf(" ORDER BY %s %s, %s %s LIMIT %d", sortColumn, order, statusCols["ID"], order, limit))
+
+	dataRows, err := i.Conn.Query(sqlBuffer.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	var result []*fleetRow
+	for dataRows.Next() {
+		row := &fleetRow{}
+		if err := dataRows.Scan(&row.statusID, &row.runtimeID, &row.configVersion, &row.sortValue); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, nil
+}
+
+//columnNames resolves the column names of entity fields and qualifies them with the table alias
+func (i *DefaultInventory) columnNames(entity db.DatabaseEntity, alias string, fields ...string) (map[string]string, error) {
+	colHandler, err := db.NewColumnHandler(entity, i.Conn, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(fields))
+	for _, field := range fields {
+		colName, err := colHandler.ColumnName(field)
+		if err != nil {
+			return nil, err
+		}
+		result[field] = fmt.Sprintf("%s.%s", alias, colName)
+	}
+	return result, nil
+}
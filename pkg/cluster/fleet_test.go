@@ -0,0 +1,153 @@
+package cluster
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/keb/test"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleet(t *testing.T) {
+	inventory := newInventory(t)
+	region := uuid.NewString() //isolates the clusters of this test from other clusters in the database
+
+	createCluster := func(runtimeID, kymaVersion, componentVersion string, status model.Status) *State {
+		cluster := test.NewCluster(t, runtimeID, 1, false, test.OneComponentDummy)
+		cluster.RuntimeID = runtimeID
+		cluster.KymaConfig.Version = kymaVersion
+		cluster.KymaConfig.Components[0].Version = componentVersion
+		cluster.Metadata.Region = region
+		state, err := inventory.CreateOrUpdate(1, cluster)
+		require.NoError(t, err)
+		state, err = inventory.UpdateStatus(state, status)
+		require.NoError(t, err)
+		return state
+	}
+	runtimeIDs := func(page *FleetPage) []string {
+		var result []string
+		for _, state := range page.Clusters {
+			result = append(result, state.Cluster.RuntimeID)
+		}
+		return result
+	}
+
+	prefix := uuid.NewString()
+	createCluster(prefix+"-a", "2.0.1", "1.2.0", model.ClusterStatusReady)
+	createCluster(prefix+"-b", "2.0.2", "", model.ClusterStatusReady)
+	createCluster(prefix+"-c", "2.1.0", "1.3.0", model.ClusterStatusReady)
+	createCluster(prefix+"-d", "2.0.3", "1.4.0", model.ClusterStatusReconcileError)
+	deleted := createCluster(prefix+"-e", "2.0.3", "1.4.0", model.ClusterStatusReady)
+	require.NoError(t, inventory.Delete(deleted.Cluster.RuntimeID))
+
+	t.Run("Filter by metadata", func(t *testing.T) {
+		page, err := inventory.Fleet(&FleetFilter{Metadata: map[string]string{"region": region}})
+		require.NoError(t, err)
+		require.Equal(t, []string{prefix + "-a", prefix + "-b", prefix + "-c", prefix + "-d"}, runtimeIDs(page))
+		require.Empty(t, page.NextCursor)
+
+		//states of a page are loaded in batches and equal the states of single clusters
+		for _, state := range page.Clusters {
+			expected, err := inventory.Get(state.Cluster.RuntimeID, state.Configuration.Version)
+			require.NoError(t, err)
+			require.Equal(t, expected.Cluster.Version, state.Cluster.Version)
+			require.Equal(t, expected.Status.ID, state.Status.ID)
+			require.True(t, expected.Configuration.Equal(state.Configuration))
+		}
+	})
+
+	t.Run("Filter by status and versions", func(t *testing.T) {
+		page, err := inventory.Fleet(&FleetFilter{
+			Statuses:         []model.Status{model.ClusterStatusReady},
+			KymaVersion:      "2.0.x",
+			Component:        "dummy",
+			ComponentVersion: "1.x",
+			Metadata:         map[string]string{"region": region},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{prefix + "-a"}, runtimeIDs(page))
+
+		//component without version uses the Kyma version
+		page, err = inventory.Fleet(&FleetFilter{
+			Component:        "dummy",
+			ComponentVersion: "2.0.2",
+			Metadata:         map[string]string{"region": region},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{prefix + "-b"}, runtimeIDs(page))
+
+		page, err = inventory.Fleet(&FleetFilter{
+			Component: "unknown",
+			Metadata:  map[string]string{"region": region},
+		})
+		require.NoError(t, err)
+		require.Empty(t, page.Clusters)
+	})
+
+	t.Run("Paginate sorted clusters", func(t *testing.T) {
+		filter := &FleetFilter{
+			Metadata:       map[string]string{"region": region},
+			SortBy:         FleetSortByKymaVersion,
+			SortDescending: true,
+			Limit:          3,
+		}
+		page, err := inventory.Fleet(filter)
+		require.NoError(t, err)
+		require.Equal(t, []string{prefix + "-c", prefix + "-d", prefix + "-b"}, runtimeIDs(page))
+		require.NotEmpty(t, page.NextCursor)
+
+		filter.Cursor = page.NextCursor
+		page, err = inventory.Fleet(filter)
+		require.NoError(t, err)
+		require.Equal(t, []string{prefix + "-a"}, runtimeIDs(page))
+		require.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Paginate with component filter", func(t *testing.T) {
+		filter := &FleetFilter{
+			Component:        "dummy",
+			ComponentVersion: "1.x",
+			Metadata:         map[string]string{"region": region},
+			SortBy:           FleetSortByUpdated,
+			Limit:            1,
+		}
+		var result []string
+		for {
+			page, err := inventory.Fleet(filter)
+			require.NoError(t, err)
+			result = append(result, runtimeIDs(page)...)
+			if page.NextCursor == "" {
+				break
+			}
+			filter.Cursor = page.NextCursor
+		}
+		require.Equal(t, []string{prefix + "-a", prefix + "-c", prefix + "-d"}, result)
+	})
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		for _, filter := range []*FleetFilter{
+			{Statuses: []model.Status{"unknown"}},
+			{ComponentVersion: "1.x"},
+			{Metadata: map[string]string{"kubeconfig": "x"}},
+			{SortBy: "created"},
+			{Limit: MaxFleetLimit + 1},
+			{Cursor: "invalid"},
+		} {
+			_, err := inventory.Fleet(filter)
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		for _, cursor := range []string{"invalid", "!", base64.RawURLEncoding.EncodeToString([]byte(`{"id":"x"}`))} {
+			filter := &FleetFilter{Cursor: cursor}
+			require.True(t, IsInvalidCursorError(filter.Validate()))
+			_, err := inventory.Fleet(filter)
+			require.True(t, IsInvalidCursorError(err))
+		}
+		require.False(t, IsInvalidCursorError(errors.New("other error")))
+	})
+}
@@ -9,10 +9,15 @@ import (
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/kv"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/repository"
 )
 
+//NotificationClusters is the notification channel which wakes up the inventory watcher whenever a cluster
+//is waiting for a reconciliation (e.g. a new cluster configuration was stored)
+const NotificationClusters = "reconciler_clusters"
+
 type Inventory interface {
 	CreateOrUpdate(contractVersion int64, cluster *keb.Cluster) (*State, error)
 	UpdateStatus(State *State, status model.Status) (*State, error)
@@ -23,13 +28,17 @@ type Inventory interface {
 	StatusChanges(runtimeID string, offset time.Duration) ([]*StatusChange, error)
 	ClustersToReconcile(reconcileInterval time.Duration) ([]*State, error)
 	ClustersNotReady() ([]*State, error)
+	ClustersReady() ([]*State, error)
+	Fleet(filter *FleetFilter) (*FleetPage, error)
 	CountRetries(runtimeID string, configVersion int64, maxRetries int, errorStatus ...model.Status) (int, error)
+	ReconcileBucketClusters(bucket string) ([]*State, error)
 	WithTx(tx *db.TxConnection) (Inventory, error)
 }
 
 type DefaultInventory struct {
 	*repository.Repository
 	metricsCollector
+	landscape string
 }
 
 type metricsCollector interface {
@@ -46,11 +55,22 @@ func NewInventory(conn db.Connection, debug bool, collector metricsCollector) (I
 	if err != nil {
 		return nil, err
 	}
-	return &DefaultInventory{repo, collector}, nil
+	return &DefaultInventory{repo, collector, ""}, nil
+}
+
+//WithLandscape defines the landscape the inventory is running in: the landscape bucket
+//will be considered when the KV-bucket values of a cluster are resolved
+func (i *DefaultInventory) WithLandscape(landscape string) *DefaultInventory {
+	i.landscape = landscape
+	return i
 }
 
 func (i *DefaultInventory) WithTx(tx *db.TxConnection) (Inventory, error) {
-	return NewInventory(tx, i.Debug, i.metricsCollector)
+	repo, err := repository.NewRepository(tx, i.Debug)
+	if err != nil {
+		return nil, err
+	}
+	return &DefaultInventory{repo, i.metricsCollector, i.landscape}, nil
 }
 
 func (i *DefaultInventory) CountRetries(runtimeID string, configVersion int64, maxRetries int, errorStatus ...model.Status) (int, error) {
@@ -128,6 +148,7 @@ func (i *DefaultInventory) CreateOrUpdate(contractVersion int64, cluster *keb.Cl
 	}
 
 	stateEntity := state.(*State)
+	i.Notify(NotificationClusters)
 	err = i.metricsCollector.OnClusterStateUpdate(stateEntity)
 	if err != nil {
 		return nil, err
@@ -176,6 +197,11 @@ func (i *DefaultInventory) createCluster(contractVersion int64, cluster *keb.Clu
 }
 
 func (i *DefaultInventory) createConfiguration(contractVersion int64, cluster *keb.Cluster, clusterEntity *model.ClusterEntity) (*model.ClusterConfigurationEntity, error) {
+	bucketValues, err := i.bucketValues(cluster)
+	if err != nil {
+		return nil, err
+	}
+
 	newConfigEntity := &model.ClusterConfigurationEntity{
 		RuntimeID:      clusterEntity.RuntimeID,
 		ClusterVersion: clusterEntity.Version,
@@ -189,6 +215,7 @@ func (i *DefaultInventory) createConfiguration(contractVersion int64, cluster *k
 			return result
 		}(),
 		Administrators: cluster.KymaConfig.Administrators,
+		BucketValues:   bucketValues,
 		Contract:       contractVersion,
 	}
 
@@ -217,6 +244,73 @@ func (i *DefaultInventory) createConfiguration(contractVersion int64, cluster *k
 	return newConfigEntity, nil
 }
 
+func (i *DefaultInventory) bucketValues(cluster *keb.Cluster) (map[string]interface{}, error) {
+	kvRepo, err := kv.NewRepository(i.Conn, i.Debug)
+	if err != nil {
+		return nil, err
+	}
+	buckets := bucketHierarchy(i.landscape, cluster)
+	values, err := resolveBucketValues(kvRepo, buckets)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to resolve bucket values of cluster '%s'", cluster.RuntimeID))
+	}
+	i.Logger.Debugf("Resolved %d bucket values for cluster '%s' (buckets: %s)", len(values), cluster.RuntimeID, buckets)
+	return values, nil
+}
+
+//ReconcileBucketClusters applies the latest values of a KV-bucket to all clusters which are using the bucket:
+//a new configuration version is created for each of these clusters and they are marked for reconciliation.
+//Clusters which are disabled or deleted are not touched.
+func (i *DefaultInventory) ReconcileBucketClusters(bucket string) ([]*State, error) {
+	states, err := i.filterClusters(&statusFilter{
+		allowedStatuses: []model.Status{
+			model.ClusterStatusReady, model.ClusterStatusReconcilePending, model.ClusterStatusReconciling,
+			model.ClusterStatusReconcileError, model.ClusterStatusReconcileErrorRetryable, model.ClusterStatusReconcileCancelled},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*State
+	for _, state := range states {
+		cluster := kebCluster(state)
+		if !containsBucket(bucketHierarchy(i.landscape, cluster), bucket) {
+			continue
+		}
+		newState, err := i.CreateOrUpdate(state.Configuration.Contract, cluster)
+		if err != nil {
+			return result, errors.Wrap(err, fmt.Sprintf("failed to apply values of bucket '%s' to cluster '%s'",
+				bucket, cluster.RuntimeID))
+		}
+		result = append(result, newState)
+	}
+	i.Logger.Infof("Inventory marked %d clusters using bucket '%s' for reconciliation", len(result), bucket)
+	return result, nil
+}
+
+//kebCluster converts the state of a cluster back into the model used for creating or updating a cluster
+func kebCluster(state *State) *keb.Cluster {
+	cluster := &keb.Cluster{
+		RuntimeID:  state.Cluster.RuntimeID,
+		Kubeconfig: state.Cluster.Kubeconfig,
+		KymaConfig: keb.KymaConfig{
+			Version:        state.Configuration.KymaVersion,
+			Profile:        state.Configuration.KymaProfile,
+			Administrators: state.Configuration.Administrators,
+		},
+	}
+	if state.Cluster.Runtime != nil {
+		cluster.RuntimeInput = *state.Cluster.Runtime
+	}
+	if state.Cluster.Metadata != nil {
+		cluster.Metadata = *state.Cluster.Metadata
+	}
+	for _, component := range state.Configuration.Components {
+		cluster.KymaConfig.Components = append(cluster.KymaConfig.Components, *component)
+	}
+	return cluster
+}
+
 func (i *DefaultInventory) createStatus(configEntity *model.ClusterConfigurationEntity, status model.Status) (*model.ClusterStatusEntity, error) {
 	newStatusEntity := &model.ClusterStatusEntity{
 		RuntimeID:      configEntity.RuntimeID,
@@ -256,6 +350,9 @@ func (i *DefaultInventory) UpdateStatus(state *State, status model.Status) (*Sta
 		return state, err
 	}
 	state.Status = newStatus
+	if status == model.ClusterStatusReconcilePending || status == model.ClusterStatusDeletePending {
+		i.Notify(NotificationClusters)
+	}
 	err = i.metricsCollector.OnClusterStateUpdate(state)
 	if err != nil {
 		return state, err
@@ -489,7 +586,63 @@ func (i *DefaultInventory) ClustersToReconcile(reconcileInterval time.Duration)
 	filters = append(filters, &statusFilter{
 		allowedStatuses: []model.Status{model.ClusterStatusReconcilePending, model.ClusterStatusDeletePending},
 	})
-	return i.filterClusters(filters...)
+	states, err := i.filterClusters(filters...)
+	if err != nil {
+		return nil, err
+	}
+	return i.filterMaintenanceWindows(states, time.Now())
+}
+
+//filterMaintenanceWindows drops clusters which cannot be reconciled because they are outside of their maintenance window.
+//Deletions and the initial installation of a cluster are not restricted by maintenance windows.
+func (i *DefaultInventory) filterMaintenanceWindows(states []*State, now time.Time) ([]*State, error) {
+	var result []*State
+	for _, state := range states {
+		if !state.Status.Status.IsReconcileCandidate() {
+			result = append(result, state)
+			continue
+		}
+		window, err := newMaintenanceWindow(state.Cluster.Metadata)
+		if err != nil { //don't block clusters because of an invalid window
+			i.Logger.Warnf("Inventory ignores invalid maintenance window of cluster '%s': %s", state.Cluster.RuntimeID, err)
+			result = append(result, state)
+			continue
+		}
+		if window == nil || window.IsOpen(now) {
+			result = append(result, state)
+			continue
+		}
+		installed, err := i.wasReady(state.Cluster.RuntimeID)
+		if err != nil {
+			return nil, err
+		}
+		if !installed {
+			result = append(result, state)
+			continue
+		}
+		i.Logger.Debugf("Inventory skips cluster '%s' because it is outside of its maintenance window (next start: %s)",
+			state.Cluster.RuntimeID, window.NextStart(now))
+	}
+	return result, nil
+}
+
+//wasReady checks whether a cluster was successfully reconciled at least once
+func (i *DefaultInventory) wasReady(runtimeID string) (bool, error) {
+	q, err := db.NewQuery(i.Conn, &model.ClusterStatusEntity{}, i.Logger)
+	if err != nil {
+		return false, err
+	}
+	readyStatuses, err := q.Select().
+		Where(map[string]interface{}{
+			"RuntimeID": runtimeID,
+			"Status":    string(model.ClusterStatusReady),
+		}).
+		Limit(1).
+		GetMany()
+	if err != nil {
+		return false, err
+	}
+	return len(readyStatuses) > 0, nil
 }
 
 func (i *DefaultInventory) ClustersNotReady() ([]*State, error) {
@@ -501,6 +654,12 @@ func (i *DefaultInventory) ClustersNotReady() ([]*State, error) {
 	return i.filterClusters(statusFilter)
 }
 
+func (i *DefaultInventory) ClustersReady() ([]*State, error) {
+	return i.filterClusters(&statusFilter{
+		allowedStatuses: []model.Status{model.ClusterStatusReady},
+	})
+}
+
 func (i *DefaultInventory) filterClusters(filters ...statusSQLFilter) ([]*State, error) {
 	//get DDL for sub-query
 	clusterStatusEntity := &model.ClusterStatusEntity{}
@@ -0,0 +1,182 @@
+package cluster
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/pkg/errors"
+)
+
+const maxMaintenanceWindowDuration = 7 * 24 * time.Hour
+
+var weekdayNames = map[string]time.Weekday{
+	"SUN": time.Sunday,
+	"MON": time.Monday,
+	"TUE": time.Tuesday,
+	"WED": time.Wednesday,
+	"THU": time.Thursday,
+	"FRI": time.Friday,
+	"SAT": time.Saturday,
+}
+
+//maintenanceWindow restricts the start of reconciliations to a weekly recurring time range
+type maintenanceWindow struct {
+	minute        int
+	hour          int
+	weekdays      map[time.Weekday]bool
+	duration      time.Duration
+	location      *time.Location
+	overrideUntil *time.Time
+}
+
+//newMaintenanceWindow parses the maintenance window of a cluster (nil is returned if no window is defined)
+func newMaintenanceWindow(metadata *keb.Metadata) (*maintenanceWindow, error) {
+	if metadata == nil || metadata.MaintenanceWindow == nil {
+		return nil, nil
+	}
+	window := &maintenanceWindow{
+		location:      time.UTC,
+		overrideUntil: metadata.MaintenanceWindow.OverrideUntil,
+	}
+
+	fields := strings.Fields(metadata.MaintenanceWindow.Schedule)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule '%s' of maintenance window has to consist of 5 fields "+
+			"('minute hour * * weekdays')", metadata.MaintenanceWindow.Schedule)
+	}
+	var err error
+	if window.minute, err = parseScheduleNumber(fields[0], 0, 59); err != nil {
+		return nil, errors.Wrap(err, "invalid minute in schedule of maintenance window")
+	}
+	if window.hour, err = parseScheduleNumber(fields[1], 0, 23); err != nil {
+		return nil, errors.Wrap(err, "invalid hour in schedule of maintenance window")
+	}
+	if fields[2] != "*" || fields[3] != "*" {
+		return nil, errors.New("maintenance windows are scheduled weekly: day of month and month have to be '*'")
+	}
+	if window.weekdays, err = parseWeekdays(fields[4]); err != nil {
+		return nil, errors.Wrap(err, "invalid weekdays in schedule of maintenance window")
+	}
+
+	if window.duration, err = time.ParseDuration(metadata.MaintenanceWindow.Duration); err != nil {
+		return nil, errors.Wrap(err, "invalid duration of maintenance window")
+	}
+	if window.duration <= 0 || window.duration > maxMaintenanceWindowDuration {
+		return nil, fmt.Errorf("duration of maintenance window has to be > 0 and <= %s", maxMaintenanceWindowDuration)
+	}
+
+	if metadata.MaintenanceWindow.Timezone != nil && *metadata.MaintenanceWindow.Timezone != "" {
+		if window.location, err = time.LoadLocation(*metadata.MaintenanceWindow.Timezone); err != nil {
+			return nil, errors.Wrap(err, "invalid timezone of maintenance window")
+		}
+	}
+	return window, nil
+}
+
+func parseScheduleNumber(value string, min, max int) (int, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if number < min || number > max {
+		return 0, fmt.Errorf("value %d is not between %d and %d", number, min, max)
+	}
+	return number, nil
+}
+
+//parseWeekdays supports '*', lists and ranges of weekdays (e.g. "MON-FRI" or "0,6")
+func parseWeekdays(value string) (map[time.Weekday]bool, error) {
+	result := make(map[time.Weekday]bool)
+	if value == "*" {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			result[day] = true
+		}
+		return result, nil
+	}
+	for _, item := range strings.Split(value, ",") {
+		bounds := strings.SplitN(item, "-", 2)
+		from, err := parseWeekday(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if len(bounds) == 2 {
+			if to, err = parseWeekday(bounds[1]); err != nil {
+				return nil, err
+			}
+		}
+		for day := from; ; day = (day + 1) % 7 { //ranges can wrap around the end of the week (e.g. FRI-MON)
+			result[day] = true
+			if day == to {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	if day, ok := weekdayNames[strings.ToUpper(value)]; ok {
+		return day, nil
+	}
+	number, err := parseScheduleNumber(value, 0, 7)
+	if err != nil {
+		return 0, fmt.Errorf("weekday '%s' is neither a name (SUN-SAT) nor a number between 0 and 7", value)
+	}
+	return time.Weekday(number % 7), nil //0 and 7 are both Sunday
+}
+
+//start returns the begin of the window on the day of the given time (in the timezone of the window)
+func (w *maintenanceWindow) start(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), w.hour, w.minute, 0, 0, w.location)
+}
+
+//IsOpen returns true if a reconciliation can start at the given time
+func (w *maintenanceWindow) IsOpen(now time.Time) bool {
+	if w.overrideUntil != nil && now.Before(*w.overrideUntil) {
+		return true
+	}
+	localNow := now.In(w.location)
+	for days := 0; days <= 7; days++ { //windows can last up to one week and span multiple days
+		start := w.start(localNow.AddDate(0, 0, -days))
+		if w.weekdays[start.Weekday()] && !start.After(localNow) && localNow.Before(start.Add(w.duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+//NextStart returns the earliest time at or after the given time a reconciliation can start
+func (w *maintenanceWindow) NextStart(now time.Time) time.Time {
+	if w.IsOpen(now) {
+		return now
+	}
+	localNow := now.In(w.location)
+	for days := 0; days <= 7; days++ {
+		start := w.start(localNow.AddDate(0, 0, days))
+		if w.weekdays[start.Weekday()] && start.After(localNow) {
+			return start
+		}
+	}
+	return now //not reachable as each valid schedule contains at least one weekday
+}
+
+//ValidateMaintenanceWindow verifies the maintenance window of the cluster metadata (if defined)
+func ValidateMaintenanceWindow(metadata *keb.Metadata) error {
+	_, err := newMaintenanceWindow(metadata)
+	return err
+}
+
+//NextAllowedStart returns the earliest time a reconciliation of the cluster can start.
+//Nil is returned if the cluster has no maintenance window.
+func NextAllowedStart(state *State, now time.Time) (*time.Time, error) {
+	window, err := newMaintenanceWindow(state.Cluster.Metadata)
+	if err != nil || window == nil {
+		return nil, err
+	}
+	nextStart := window.NextStart(now)
+	return &nextStart, nil
+}
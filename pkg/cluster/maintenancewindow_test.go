@@ -0,0 +1,133 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/keb/test"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newMaintenanceWindowMetadata(schedule, duration, timezone string) *keb.Metadata {
+	return &keb.Metadata{
+		MaintenanceWindow: &keb.MaintenanceWindow{
+			Schedule: schedule,
+			Duration: duration,
+			Timezone: &timezone,
+		},
+	}
+}
+
+func TestMaintenanceWindow(t *testing.T) {
+	t.Run("Validate maintenance window", func(t *testing.T) {
+		require.NoError(t, ValidateMaintenanceWindow(nil))
+		require.NoError(t, ValidateMaintenanceWindow(&keb.Metadata{}))
+		require.NoError(t, ValidateMaintenanceWindow(newMaintenanceWindowMetadata("0 2 * * SAT,SUN", "4h", "Europe/Berlin")))
+		require.NoError(t, ValidateMaintenanceWindow(newMaintenanceWindowMetadata("30 22 * * 1-5", "90m", "")))
+		require.NoError(t, ValidateMaintenanceWindow(newMaintenanceWindowMetadata("0 0 * * *", "168h", "UTC")))
+
+		for _, invalid := range []*keb.Metadata{
+			newMaintenanceWindowMetadata("0 2 * SAT", "4h", ""),       //missing field
+			newMaintenanceWindowMetadata("60 2 * * SAT", "4h", ""),    //invalid minute
+			newMaintenanceWindowMetadata("0 24 * * SAT", "4h", ""),    //invalid hour
+			newMaintenanceWindowMetadata("0 2 1 * SAT", "4h", ""),     //day of month not supported
+			newMaintenanceWindowMetadata("0 2 * * XYZ", "4h", ""),     //invalid weekday
+			newMaintenanceWindowMetadata("0 2 * * SAT", "abc", ""),    //invalid duration
+			newMaintenanceWindowMetadata("0 2 * * SAT", "169h", ""),   //duration too long
+			newMaintenanceWindowMetadata("0 2 * * SAT", "4h", "Mars"), //invalid timezone
+		} {
+			require.Error(t, ValidateMaintenanceWindow(invalid), invalid.MaintenanceWindow.Schedule)
+		}
+	})
+
+	t.Run("Open and next start of maintenance window", func(t *testing.T) {
+		//Saturday and Sunday from 23:00 until 01:00 (next day) in UTC+2
+		window, err := newMaintenanceWindow(newMaintenanceWindowMetadata("0 23 * * SAT,SUN", "2h", "Europe/Berlin"))
+		require.NoError(t, err)
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+
+		testCases := []struct {
+			now       time.Time
+			open      bool
+			nextStart time.Time
+		}{
+			{ //Friday
+				now:       time.Date(2021, 10, 15, 12, 0, 0, 0, berlin),
+				nextStart: time.Date(2021, 10, 16, 23, 0, 0, 0, berlin),
+			},
+			{ //Saturday inside the window
+				now:  time.Date(2021, 10, 16, 23, 30, 0, 0, berlin),
+				open: true,
+			},
+			{ //Sunday after midnight (window started on Saturday)
+				now:  time.Date(2021, 10, 17, 0, 30, 0, 0, berlin),
+				open: true,
+			},
+			{ //Sunday morning
+				now:       time.Date(2021, 10, 17, 1, 0, 0, 0, berlin),
+				nextStart: time.Date(2021, 10, 17, 23, 0, 0, 0, berlin),
+			},
+			{ //Monday after the window of Sunday was closed
+				now:       time.Date(2021, 10, 18, 1, 30, 0, 0, berlin),
+				nextStart: time.Date(2021, 10, 23, 23, 0, 0, 0, berlin),
+			},
+			{ //same time in UTC (Saturday 21:30 UTC = 23:30 in Berlin)
+				now:  time.Date(2021, 10, 16, 21, 30, 0, 0, time.UTC),
+				open: true,
+			},
+		}
+		for _, testCase := range testCases {
+			require.Equal(t, testCase.open, window.IsOpen(testCase.now), testCase.now)
+			if testCase.open {
+				require.Equal(t, testCase.now, window.NextStart(testCase.now))
+			} else {
+				require.True(t, testCase.nextStart.Equal(window.NextStart(testCase.now)), testCase.now)
+			}
+		}
+	})
+
+	t.Run("Urgent override", func(t *testing.T) {
+		now := time.Date(2021, 10, 15, 12, 0, 0, 0, time.UTC)
+		metadata := newMaintenanceWindowMetadata("0 2 * * SAT", "1h", "")
+		overrideUntil := now.Add(1 * time.Hour)
+		metadata.MaintenanceWindow.OverrideUntil = &overrideUntil
+		window, err := newMaintenanceWindow(metadata)
+		require.NoError(t, err)
+
+		require.True(t, window.IsOpen(now))
+		require.False(t, window.IsOpen(overrideUntil))
+	})
+
+	t.Run("Filter clusters outside of maintenance window", func(t *testing.T) {
+		inventory := newInventory(t).(*DefaultInventory)
+		now := time.Date(2021, 10, 15, 12, 0, 0, 0, time.UTC) //Friday
+
+		newState := func(schedule string, status model.Status) *State {
+			kebCluster := test.NewCluster(t, uuid.NewString(), 1, false, test.OneComponentDummy)
+			kebCluster.Metadata.MaintenanceWindow = &keb.MaintenanceWindow{Schedule: schedule, Duration: "1h"}
+			state, err := inventory.CreateOrUpdate(1, kebCluster)
+			require.NoError(t, err)
+			state, err = inventory.UpdateStatus(state, status)
+			require.NoError(t, err)
+			return state
+		}
+
+		openWindow := newState("30 11 * * FRI", model.ClusterStatusReady)
+		closedWindow := newState("0 2 * * SAT", model.ClusterStatusReady)
+		closedWindowDeletion := newState("0 2 * * SAT", model.ClusterStatusDeletePending)
+		closedWindowNotInstalled := newState("0 2 * * SAT", model.ClusterStatusReconcilePending)
+
+		states, err := inventory.filterMaintenanceWindows(
+			[]*State{openWindow, closedWindow, closedWindowDeletion, closedWindowNotInstalled}, now)
+		require.NoError(t, err)
+		require.Equal(t, []*State{openWindow, closedWindowDeletion, closedWindowNotInstalled}, states)
+
+		nextStart, err := NextAllowedStart(closedWindow, now)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2021, 10, 16, 2, 0, 0, 0, time.UTC), *nextStart)
+	})
+}
@@ -16,6 +16,8 @@ const envVarKubeconfig = "KUBECONFIG"
 type MockInventory struct {
 	ClustersToReconcileResult []*State
 	ClustersNotReadyResult    []*State
+	ClustersReadyResult       []*State
+	FleetResult               *FleetPage
 	GetResult                 *State
 	GetLatestResult           *State
 	CreateOrUpdateResult      *State
@@ -24,6 +26,7 @@ type MockInventory struct {
 	UpdateStatusResult        *State
 	ChangesResult             []*StatusChange
 	RetriesCount              int
+	ReconcileBucketResult     []*State
 }
 
 func (i *MockInventory) WithTx(tx *db.TxConnection) (Inventory, error) {
@@ -62,6 +65,14 @@ func (i *MockInventory) ClustersNotReady() ([]*State, error) {
 	return i.ClustersNotReadyResult, nil
 }
 
+func (i *MockInventory) Fleet(filter *FleetFilter) (*FleetPage, error) {
+	return i.FleetResult, nil
+}
+
+func (i *MockInventory) ClustersReady() ([]*State, error) {
+	return i.ClustersReadyResult, nil
+}
+
 func (i *MockInventory) StatusChanges(runtimeID string, offset time.Duration) ([]*StatusChange, error) {
 	return i.ChangesResult, nil
 }
@@ -81,6 +92,10 @@ func (kp *MockKubeconfigProvider) Get() (string, error) {
 	return kp.KubeconfigResult, nil
 }
 
+func (i *MockInventory) ReconcileBucketClusters(bucket string) ([]*State, error) {
+	return i.ReconcileBucketResult, nil
+}
+
 func (i *MockInventory) CountRetries(runtimeID string, configVersion int64, maxRetries int, errorStatus ...model.Status) (int, error) {
 	return i.RetriesCount, nil
 }
@@ -154,6 +154,17 @@ func (ch *ColumnHandler) ColumnName(field string) (string, error) {
 	return "", fmt.Errorf("entity '%s' has no field '%s': cannot resolve column name", ch.entity, field)
 }
 
+//EncryptedColumnNames returns the names of the columns which are tagged with 'encrypt'
+func (ch *ColumnHandler) EncryptedColumnNames() []string {
+	var result []string
+	for _, col := range ch.columns {
+		if col.encrypt {
+			result = append(result, col.name)
+		}
+	}
+	return result
+}
+
 //ColumnNamesCsv returns the CSV string of the column names
 func (ch *ColumnHandler) ColumnNamesCsv(onlyWriteable bool) string {
 	var buffer bytes.Buffer
@@ -11,18 +11,22 @@ import (
 	"github.com/pkg/errors"
 	"io"
 	"io/ioutil"
+	"sort"
 	"strings"
 )
 
 const keyIDLength = 15
 const KeyLength = 32
 
+//Encryptor is a keyring: data is always encrypted with the active key, the decryption keys
+//(e.g. keys used before a key rotation) can only be used to decrypt data
 type Encryptor struct {
 	keyID [16]byte
 	aead  cipher.AEAD
+	aeads map[string]cipher.AEAD //key: key ID (includes the active key)
 }
 
-func NewEncryptor(key string) (*Encryptor, error) {
+func NewEncryptor(key string, decryptionKeys ...string) (*Encryptor, error) {
 	if len(key) == 0 {
 		return nil, fmt.Errorf("cannot create new encryptor instance because encryption key was an empty string")
 	}
@@ -32,10 +36,22 @@ func NewEncryptor(key string) (*Encryptor, error) {
 		return nil, err
 	}
 
-	return &Encryptor{
+	encryptor := &Encryptor{
 		aead:  aead,
 		keyID: md5.Sum([]byte(key)), //nolint: gosec //using MD5 just for generating a checksum of the key
-	}, nil
+		aeads: make(map[string]cipher.AEAD, len(decryptionKeys)+1),
+	}
+	encryptor.aeads[encryptor.KeyID()] = aead
+
+	for _, decryptionKey := range decryptionKeys {
+		decryptionAEAD, err := newAEAD(decryptionKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid decryption key")
+		}
+		encryptor.aeads[keyID(decryptionKey)] = decryptionAEAD
+	}
+
+	return encryptor, nil
 }
 
 //NewEncryptionKey generates a random 32 byte key for AES-256
@@ -57,11 +73,25 @@ func newAEAD(key string) (cipher.AEAD, error) {
 	return cipher.NewGCM(block)
 }
 
+func keyID(key string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(key)))[:keyIDLength] //nolint: gosec //using MD5 just for generating a checksum of the key
+}
+
 //KeyID returns the first characters of the MD5 keys checksum as HEX string
 func (e *Encryptor) KeyID() string {
 	return fmt.Sprintf("%x", e.keyID)[:keyIDLength]
 }
 
+//KeyIDs returns the IDs of all keys of the keyring
+func (e *Encryptor) KeyIDs() []string {
+	result := make([]string, 0, len(e.aeads))
+	for keyID := range e.aeads {
+		result = append(result, keyID)
+	}
+	sort.Strings(result)
+	return result
+}
+
 func (e *Encryptor) Encrypt(data string) (string, error) {
 	nonce := make([]byte, e.aead.NonceSize())
 	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
@@ -75,16 +105,20 @@ func (e *Encryptor) Decrypt(encData string) (string, error) {
 	if !e.Decryptable(encData) {
 		return "", fmt.Errorf("data cannot be decrypted because encryption key does not match")
 	}
+	aead := e.aeads[encData[:keyIDLength]]
 
-	enc, err := hex.DecodeString(strings.TrimPrefix(encData, e.KeyID())) //remove keyID from encrypted data
+	enc, err := hex.DecodeString(encData[keyIDLength:]) //remove keyID from encrypted data
 	if err != nil {
 		return "", fmt.Errorf("failed to decode HEX string to bytes")
 	}
 
-	nonceSize := e.aead.NonceSize()
+	nonceSize := aead.NonceSize()
+	if len(enc) < nonceSize {
+		return "", fmt.Errorf("encrypted data is too short")
+	}
 	nonce, cipherText := enc[:nonceSize], enc[nonceSize:]
 
-	data, err := e.aead.Open(nil, nonce, cipherText, nil)
+	data, err := aead.Open(nil, nonce, cipherText, nil)
 	if err != nil {
 		return "", err
 	}
@@ -94,7 +128,16 @@ func (e *Encryptor) Decrypt(encData string) (string, error) {
 
 //Decryptable verifies whether the encrypted data can be decrypted by this Encryptor instance
 func (e *Encryptor) Decryptable(encData string) bool {
-	return strings.HasPrefix(encData, e.KeyID()) //KeyID prefix of encrypted data has to match with current KeyID
+	if len(encData) < keyIDLength {
+		return false
+	}
+	_, ok := e.aeads[encData[:keyIDLength]] //KeyID prefix of encrypted data has to match with a key of the keyring
+	return ok
+}
+
+//EncryptedWithActiveKey verifies whether the data was encrypted with the active key
+func (e *Encryptor) EncryptedWithActiveKey(encData string) bool {
+	return strings.HasPrefix(encData, e.KeyID())
 }
 
 func readKeyFile(encKeyFile string) (string, error) {
@@ -72,6 +72,41 @@ func TestEncryptor(t *testing.T) {
 		require.Equal(t, decData1, decData2)
 	})
 
+	t.Run("Decrypt with decryption keys of keyring", func(t *testing.T) {
+		oldKey, err := NewEncryptionKey()
+		require.NoError(t, err)
+		oldEnc, err := NewEncryptor(oldKey)
+		require.NoError(t, err)
+		oldEncData, err := oldEnc.Encrypt(data)
+		require.NoError(t, err)
+
+		newKey, err := NewEncryptionKey()
+		require.NoError(t, err)
+		keyring, err := NewEncryptor(newKey, oldKey)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{oldEnc.KeyID(), keyring.KeyID()}, keyring.KeyIDs())
+
+		//old data is decryptable but not encrypted with the active key
+		require.True(t, keyring.Decryptable(oldEncData))
+		require.False(t, keyring.EncryptedWithActiveKey(oldEncData))
+		decData, err := keyring.Decrypt(oldEncData)
+		require.NoError(t, err)
+		require.Equal(t, data, decData)
+
+		//new data is always encrypted with the active key
+		newEncData, err := keyring.Encrypt(data)
+		require.NoError(t, err)
+		require.True(t, keyring.EncryptedWithActiveKey(newEncData))
+		require.False(t, oldEnc.Decryptable(newEncData))
+	})
+
+	t.Run("Works not with non-HEX decryption key", func(t *testing.T) {
+		key, err := NewEncryptionKey()
+		require.NoError(t, err)
+		_, err = NewEncryptor(key, "abc123!")
+		require.Error(t, err)
+	})
+
 }
 
 func TestReadKeyFile(t *testing.T) {
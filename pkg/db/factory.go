@@ -5,6 +5,7 @@ import (
 	"github.com/pkg/errors"
 	"os"
 	"path/filepath"
+	"strings"
 
 	file "github.com/kyma-incubator/reconciler/pkg/files"
 	"github.com/spf13/viper"
@@ -21,17 +22,22 @@ func NewConnectionFactory(configFile string, migrate bool, debug bool) (Connecti
 		return nil, err
 	}
 
+	decKeys, err := readDecryptionKeys()
+	if err != nil {
+		return nil, err
+	}
+
 	dbToUse := viper.GetString("db.driver")
 	blockQueries := viper.GetBool("db.blockQueries")
 	logQueries := viper.GetBool("db.logQueries")
 
 	switch dbToUse {
 	case "postgres":
-		connFact := createPostgresConnectionFactory(encKey, debug, blockQueries, logQueries)
+		connFact := createPostgresConnectionFactory(encKey, decKeys, debug, blockQueries, logQueries)
 		return connFact, connFact.Init(migrate)
 
 	case "sqlite":
-		connFact, err := createSqliteConnectionFactory(encKey, debug, blockQueries, logQueries)
+		connFact, err := createSqliteConnectionFactory(encKey, decKeys, debug, blockQueries, logQueries)
 		if err != nil {
 			return nil, errors.Wrap(err, "error creating sqliteConnectionFactory")
 		}
@@ -43,13 +49,7 @@ func NewConnectionFactory(configFile string, migrate bool, debug bool) (Connecti
 }
 
 func readEncryptionKey() (string, error) {
-	encKeyFile := viper.GetString("db.encryption.keyFile")
-	if encKeyFile != "" {
-		if !filepath.IsAbs(encKeyFile) {
-			//define absolute path relative to config-file directory
-			encKeyFile = filepath.Join(filepath.Dir(viper.ConfigFileUsed()), encKeyFile)
-		}
-	}
+	encKeyFile := keyFilePath(viper.GetString("db.encryption.keyFile"))
 
 	//overwrite encKeyFile if env-var if defined
 	if viper.IsSet("DATABASE_ENCRYPTION_KEYFILE") {
@@ -59,7 +59,38 @@ func readEncryptionKey() (string, error) {
 	return readKeyFile(encKeyFile)
 }
 
-func createSqliteConnectionFactory(encKey string, debug bool, blockQueries, logQueries bool) (*sqliteConnectionFactory, error) {
+//readDecryptionKeys returns the keys which were used before a key rotation: they are only used for decrypting data
+func readDecryptionKeys() ([]string, error) {
+	decKeyFiles := viper.GetStringSlice("db.encryption.decryptionKeyFiles")
+
+	//overwrite decKeyFiles if env-var if defined
+	if viper.IsSet("DATABASE_ENCRYPTION_DECRYPTION_KEYFILES") {
+		decKeyFiles = strings.Split(viper.GetString("DATABASE_ENCRYPTION_DECRYPTION_KEYFILES"), ",")
+	}
+
+	var result []string
+	for _, decKeyFile := range decKeyFiles {
+		if strings.TrimSpace(decKeyFile) == "" {
+			continue
+		}
+		decKey, err := readKeyFile(keyFilePath(strings.TrimSpace(decKeyFile)))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read decryption key")
+		}
+		result = append(result, decKey)
+	}
+	return result, nil
+}
+
+func keyFilePath(keyFile string) string {
+	if keyFile != "" && !filepath.IsAbs(keyFile) {
+		//define absolute path relative to config-file directory
+		return filepath.Join(filepath.Dir(viper.ConfigFileUsed()), keyFile)
+	}
+	return keyFile
+}
+
+func createSqliteConnectionFactory(encKey string, decKeys []string, debug bool, blockQueries, logQueries bool) (*sqliteConnectionFactory, error) {
 	dbFile := viper.GetString("db.sqlite.file")
 	//ensure directory structure of db-file exists
 	dbFileDir := filepath.Dir(dbFile)
@@ -69,12 +100,14 @@ func createSqliteConnectionFactory(encKey string, debug bool, blockQueries, logQ
 		}
 	}
 	connFact := &sqliteConnectionFactory{
-		file:          dbFile,
-		debug:         debug,
-		reset:         viper.GetBool("db.sqlite.resetDatabase"),
-		encryptionKey: encKey,
-		blockQueries:  blockQueries,
-		logQueries:    logQueries,
+		file:           dbFile,
+		debug:          debug,
+		reset:          viper.GetBool("db.sqlite.resetDatabase"),
+		encryptionKey:  encKey,
+		decryptionKeys: decKeys,
+		blockQueries:   blockQueries,
+		logQueries:     logQueries,
+		notifier:       NewInProcessNotifier(),
 	}
 	if viper.GetBool("db.sqlite.deploySchema") {
 		connFact.schemaFile = filepath.Join(filepath.Dir(viper.ConfigFileUsed()), "db", "sqlite", "reconciler.sql")
@@ -82,7 +115,7 @@ func createSqliteConnectionFactory(encKey string, debug bool, blockQueries, logQ
 	return connFact, nil
 }
 
-func createPostgresConnectionFactory(encKey string, _ bool, blockQueries, logQueries bool) *postgresConnectionFactory {
+func createPostgresConnectionFactory(encKey string, decKeys []string, _ bool, blockQueries, logQueries bool) *postgresConnectionFactory {
 	host := viper.GetString("db.postgres.host")
 	port := viper.GetInt("db.postgres.port")
 	database := viper.GetString("db.postgres.database")
@@ -114,15 +147,16 @@ func createPostgresConnectionFactory(encKey string, _ bool, blockQueries, logQue
 	}
 
 	return &postgresConnectionFactory{
-		host:          host,
-		port:          port,
-		database:      database,
-		user:          user,
-		password:      password,
-		sslMode:       sslMode,
-		encryptionKey: encKey,
-		migrationsDir: migrationsDir,
-		blockQueries:  blockQueries,
-		logQueries:    logQueries,
+		host:           host,
+		port:           port,
+		database:       database,
+		user:           user,
+		password:       password,
+		sslMode:        sslMode,
+		encryptionKey:  encKey,
+		decryptionKeys: decKeys,
+		migrationsDir:  migrationsDir,
+		blockQueries:   blockQueries,
+		logQueries:     logQueries,
 	}
 }
@@ -13,6 +13,7 @@ const (
 type Connection interface {
 	DB() *sql.DB
 	Encryptor() *Encryptor
+	Notifier() Notifier
 	Ping() error
 	QueryRow(query string, args ...interface{}) (DataRow, error)
 	Query(query string, args ...interface{}) (DataRows, error)
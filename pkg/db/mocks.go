@@ -17,8 +17,9 @@ type MockDbEntity struct {
 }
 
 type MockConnection struct {
-	query string
-	args  []interface{}
+	query    string
+	args     []interface{}
+	notifier Notifier
 }
 
 type MockDataRow struct {
@@ -59,6 +60,13 @@ func (c *MockConnection) Encryptor() *Encryptor {
 	return encryptor
 }
 
+func (c *MockConnection) Notifier() Notifier {
+	if c.notifier == nil {
+		c.notifier = NewInProcessNotifier()
+	}
+	return c.notifier
+}
+
 func (c *MockConnection) Ping() error {
 	return nil
 }
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+//Notifier wakes up processes which are waiting for changes in the database (e.g. for new operations).
+//Notifications carry no data: subscribers have to query the database and should still poll in a longer interval
+//because notifications can get lost (e.g. while the connection to the database is interrupted).
+type Notifier interface {
+	//Notify wakes up the subscribers of the channel
+	Notify(channel string) error
+	//Subscribe returns a subscription which receives a signal whenever a notification was sent to the channel
+	Subscribe(channel string) *Subscription
+}
+
+//Subscription receives the signals of a notification channel. Signals are coalesced: a subscriber which is busy
+//receives only one signal for all notifications which were sent in the meantime.
+type Subscription struct {
+	C      <-chan struct{}
+	signal chan struct{}
+	cancel func()
+}
+
+func newSubscription() *Subscription {
+	signal := make(chan struct{}, 1)
+	return &Subscription{
+		C:      signal,
+		signal: signal,
+	}
+}
+
+func (s *Subscription) wakeUp() {
+	select {
+	case s.signal <- struct{}{}:
+	default: //subscriber has a pending signal
+	}
+}
+
+//Close stops the delivery of signals to the subscription
+func (s *Subscription) Close() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+//inProcessNotifier delivers notifications only to subscribers of the same process (used for SQLite and mocks)
+type inProcessNotifier struct {
+	subscriptions map[string]map[*Subscription]bool //key1: channel
+	mu            sync.Mutex
+}
+
+func NewInProcessNotifier() Notifier {
+	return newInProcessNotifier()
+}
+
+func newInProcessNotifier() *inProcessNotifier {
+	return &inProcessNotifier{
+		subscriptions: make(map[string]map[*Subscription]bool),
+	}
+}
+
+func (n *inProcessNotifier) Notify(channel string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for subscription := range n.subscriptions[channel] {
+		subscription.wakeUp()
+	}
+	return nil
+}
+
+//notifyAll wakes up the subscribers of all channels
+func (n *inProcessNotifier) notifyAll() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, subscriptions := range n.subscriptions {
+		for subscription := range subscriptions {
+			subscription.wakeUp()
+		}
+	}
+}
+
+func (n *inProcessNotifier) Subscribe(channel string) *Subscription {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	subscription := newSubscription()
+	subscription.cancel = func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.subscriptions[channel], subscription)
+	}
+	if _, ok := n.subscriptions[channel]; !ok {
+		n.subscriptions[channel] = make(map[*Subscription]bool)
+	}
+	n.subscriptions[channel][subscription] = true
+	return subscription
+}
+
+//txNotifier holds back the notifications sent within a transaction until the transaction is committed:
+//otherwise subscribers could query the database before the changes are visible
+type txNotifier struct {
+	notifier Notifier
+	logger   *zap.SugaredLogger
+	channels []string
+	mu       sync.Mutex
+}
+
+func (n *txNotifier) Notify(channel string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, pending := range n.channels {
+		if pending == channel {
+			return nil
+		}
+	}
+	n.channels = append(n.channels, channel)
+	return nil
+}
+
+func (n *txNotifier) Subscribe(channel string) *Subscription {
+	return n.notifier.Subscribe(channel)
+}
+
+//flush sends the pending notifications after the transaction was committed
+func (n *txNotifier) flush() {
+	for _, channel := range n.reset() {
+		if err := n.notifier.Notify(channel); err != nil {
+			n.logger.Warnf("Failed to send notification to channel '%s' after transaction was committed: %s", channel, err)
+		}
+	}
+}
+
+//reset drops the pending notifications (e.g. after a rollback) and returns them
+func (n *txNotifier) reset() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	channels := n.channels
+	n.channels = nil
+	return channels
+}
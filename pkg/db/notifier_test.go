@@ -0,0 +1,79 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func requireSignal(t *testing.T, subscription *Subscription, expected bool) {
+	select {
+	case <-subscription.C:
+		require.True(t, expected, "unexpected signal received")
+	default:
+		require.False(t, expected, "expected signal was not received")
+	}
+}
+
+func TestInProcessNotifier(t *testing.T) {
+	t.Run("Signals are delivered to the subscribers of the channel", func(t *testing.T) {
+		notifier := NewInProcessNotifier()
+		sub1 := notifier.Subscribe("channel1")
+		sub2 := notifier.Subscribe("channel1")
+		sub3 := notifier.Subscribe("channel2")
+
+		require.NoError(t, notifier.Notify("channel1"))
+		requireSignal(t, sub1, true)
+		requireSignal(t, sub2, true)
+		requireSignal(t, sub3, false)
+	})
+
+	t.Run("Signals are coalesced", func(t *testing.T) {
+		notifier := NewInProcessNotifier()
+		sub := notifier.Subscribe("channel")
+
+		require.NoError(t, notifier.Notify("channel"))
+		require.NoError(t, notifier.Notify("channel"))
+		requireSignal(t, sub, true)
+		requireSignal(t, sub, false)
+	})
+
+	t.Run("Closed subscriptions receive no signals", func(t *testing.T) {
+		notifier := NewInProcessNotifier()
+		sub := notifier.Subscribe("channel")
+		sub.Close()
+
+		require.NoError(t, notifier.Notify("channel"))
+		requireSignal(t, sub, false)
+	})
+}
+
+func TestTxNotifier(t *testing.T) {
+	conn := NewTestConnection(t)
+
+	t.Run("Notifications are sent after commit", func(t *testing.T) {
+		sub := conn.Notifier().Subscribe("channel")
+		defer sub.Close()
+
+		err := Transaction(conn, func(tx *TxConnection) error {
+			require.NoError(t, tx.Notifier().Notify("channel"))
+			requireSignal(t, sub, false)
+			return nil
+		}, nil)
+		require.NoError(t, err)
+		requireSignal(t, sub, true)
+	})
+
+	t.Run("Notifications are dropped after rollback", func(t *testing.T) {
+		sub := conn.Notifier().Subscribe("channel")
+		defer sub.Close()
+
+		err := Transaction(conn, func(tx *TxConnection) error {
+			require.NoError(t, tx.Notifier().Notify("channel"))
+			return errors.New("rollback")
+		}, nil)
+		require.Error(t, err)
+		requireSignal(t, sub, false)
+	})
+}
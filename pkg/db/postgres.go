@@ -4,12 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
+	"time"
 
 	log "github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/pkg/errors"
 
 	//add Postgres driver:
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -19,17 +21,23 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	listenerMinReconnectInterval = 1 * time.Second
+	listenerMaxReconnectInterval = 1 * time.Minute
+)
+
 type postgresConnection struct {
 	db        *sql.DB
 	encryptor *Encryptor
 	validator *Validator
+	notifier  *postgresNotifier
 	logger    *zap.SugaredLogger
 }
 
-func newPostgresConnection(db *sql.DB, encryptionKey string, debug bool, blockQueries bool) (*postgresConnection, error) {
+func newPostgresConnection(db *sql.DB, dataSourceName string, encryptionKey string, decryptionKeys []string, debug bool, blockQueries bool) (*postgresConnection, error) {
 	logger := log.NewLogger(debug)
 
-	encryptor, err := NewEncryptor(encryptionKey)
+	encryptor, err := NewEncryptor(encryptionKey, decryptionKeys...)
 	if err != nil {
 		return nil, err
 	}
@@ -40,6 +48,7 @@ func newPostgresConnection(db *sql.DB, encryptionKey string, debug bool, blockQu
 		db:        db,
 		encryptor: encryptor,
 		validator: validator,
+		notifier:  newPostgresNotifier(db, dataSourceName, logger),
 		logger:    logger,
 	}, nil
 }
@@ -52,6 +61,11 @@ func (pc *postgresConnection) Encryptor() *Encryptor {
 	return pc.encryptor
 }
 
+//Notifier returns a notifier which uses LISTEN/NOTIFY and wakes up the subscribers of all mothership replicas
+func (pc *postgresConnection) Notifier() Notifier {
+	return pc.notifier
+}
+
 func (pc *postgresConnection) Ping() error {
 	pc.logger.Debugf("Postgres Ping()")
 	return pc.db.Ping()
@@ -100,6 +114,9 @@ func (pc *postgresConnection) Begin() (*TxConnection, error) {
 
 func (pc *postgresConnection) Close() error {
 	pc.logger.Debug("Postgres Close()")
+	if err := pc.notifier.close(); err != nil {
+		pc.logger.Warnf("Failed to close Postgres listener of notifications: %s", err)
+	}
 	return pc.db.Close()
 }
 
@@ -107,18 +124,101 @@ func (pc *postgresConnection) Type() Type {
 	return Postgres
 }
 
+//postgresNotifier sends notifications with NOTIFY and receives them with a listener which is started by the
+//first subscription. The listener uses its own database connection and re-establishes it after an interruption.
+type postgresNotifier struct {
+	db             *sql.DB
+	dataSourceName string
+	logger         *zap.SugaredLogger
+	local          *inProcessNotifier //delivers the received notifications to the subscribers of this process
+	listener       *pq.Listener
+	channels       map[string]bool
+	mu             sync.Mutex
+}
+
+func newPostgresNotifier(db *sql.DB, dataSourceName string, logger *zap.SugaredLogger) *postgresNotifier {
+	return &postgresNotifier{
+		db:             db,
+		dataSourceName: dataSourceName,
+		logger:         logger,
+		local:          newInProcessNotifier(),
+		channels:       make(map[string]bool),
+	}
+}
+
+func (pn *postgresNotifier) Notify(channel string) error {
+	_, err := pn.db.Exec("SELECT pg_notify($1, '')", channel)
+	return err
+}
+
+func (pn *postgresNotifier) Subscribe(channel string) *Subscription {
+	pn.mu.Lock()
+	defer pn.mu.Unlock()
+	if pn.listener == nil {
+		pn.listener = pq.NewListener(pn.dataSourceName, listenerMinReconnectInterval, listenerMaxReconnectInterval,
+			pn.onListenerEvent)
+		go pn.dispatch(pn.listener)
+	}
+	if !pn.channels[channel] {
+		pn.channels[channel] = true
+		//listen asynchronously: the call blocks until the database is reachable
+		go func(listener *pq.Listener) {
+			if err := listener.Listen(channel); err != nil && err != pq.ErrChannelAlreadyOpen {
+				pn.logger.Warnf("Failed to listen to notifications of channel '%s' "+
+					"(subscribers have to rely on polling): %s", channel, err)
+			}
+		}(pn.listener)
+	}
+	return pn.local.Subscribe(channel)
+}
+
+func (pn *postgresNotifier) dispatch(listener *pq.Listener) {
+	for notification := range listener.Notify {
+		if notification == nil {
+			//listener re-connected: notifications sent during the interruption are lost
+			pn.local.notifyAll()
+			continue
+		}
+		_ = pn.local.Notify(notification.Channel)
+	}
+}
+
+func (pn *postgresNotifier) onListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventDisconnected:
+		pn.logger.Warnf("Postgres listener of notifications lost its connection: %s", err)
+	case pq.ListenerEventConnectionAttemptFailed:
+		pn.logger.Warnf("Postgres listener of notifications failed to connect: %s", err)
+	case pq.ListenerEventReconnected:
+		pn.logger.Info("Postgres listener of notifications re-connected")
+	}
+}
+
+func (pn *postgresNotifier) close() error {
+	pn.mu.Lock()
+	defer pn.mu.Unlock()
+	if pn.listener == nil {
+		return nil
+	}
+	listener := pn.listener
+	pn.listener = nil
+	pn.channels = make(map[string]bool)
+	return listener.Close()
+}
+
 type postgresConnectionFactory struct {
-	host          string
-	port          int
-	database      string
-	user          string
-	password      string
-	sslMode       bool
-	encryptionKey string
-	migrationsDir string
-	debug         bool
-	blockQueries  bool
-	logQueries    bool
+	host           string
+	port           int
+	database       string
+	user           string
+	password       string
+	sslMode        bool
+	encryptionKey  string
+	decryptionKeys []string //keys used before a key rotation
+	migrationsDir  string
+	debug          bool
+	blockQueries   bool
+	logQueries     bool
 }
 
 func (pcf *postgresConnectionFactory) Init(migrate bool) error {
@@ -134,15 +234,8 @@ func (pcf *postgresConnectionFactory) Init(migrate bool) error {
 }
 
 func (pcf *postgresConnectionFactory) NewConnection() (Connection, error) {
-	sslMode := "disable"
-	if pcf.sslMode {
-		sslMode = "require"
-	}
-
-	db, err := sql.Open(
-		"postgres",
-		fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-			pcf.host, pcf.port, pcf.user, pcf.password, pcf.database, sslMode))
+	dataSourceName := pcf.dataSourceName()
+	db, err := sql.Open("postgres", dataSourceName)
 
 	if err == nil {
 		err = db.Ping()
@@ -152,7 +245,16 @@ func (pcf *postgresConnectionFactory) NewConnection() (Connection, error) {
 		return nil, err
 	}
 
-	return newPostgresConnection(db, pcf.encryptionKey, pcf.logQueries, pcf.blockQueries)
+	return newPostgresConnection(db, dataSourceName, pcf.encryptionKey, pcf.decryptionKeys, pcf.logQueries, pcf.blockQueries)
+}
+
+func (pcf *postgresConnectionFactory) dataSourceName() string {
+	sslMode := "disable"
+	if pcf.sslMode {
+		sslMode = "require"
+	}
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		pcf.host, pcf.port, pcf.user, pcf.password, pcf.database, sslMode)
 }
 
 func (pcf *postgresConnectionFactory) checkPostgresIsolationLevel() error {
@@ -0,0 +1,221 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const DefaultRekeyBatchSize = 100
+
+//RekeyProgress is reported after each processed batch of a table
+type RekeyProgress struct {
+	Table         string
+	Rows          int64 //rows of the table which were processed so far
+	Rekeyed       int64 //values which were re-encrypted with the active key so far
+	Undecryptable int64 //values which could not be decrypted with any key of the keyring
+}
+
+func (p *RekeyProgress) String() string {
+	return fmt.Sprintf("RekeyProgress [Table=%s,Rows=%d,Rekeyed=%d,Undecryptable=%d]",
+		p.Table, p.Rows, p.Rekeyed, p.Undecryptable)
+}
+
+//Rekeyer re-encrypts the encrypted columns of entity tables with the active key of the connection's keyring.
+//Values which are already encrypted with the active key are skipped which allows to re-run an interrupted rekeying.
+type Rekeyer struct {
+	conn      Connection
+	batchSize int
+	logger    *zap.SugaredLogger
+}
+
+func NewRekeyer(conn Connection, batchSize int, logger *zap.SugaredLogger) (*Rekeyer, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batch size has to be > 0 (was %d)", batchSize)
+	}
+	return &Rekeyer{
+		conn:      conn,
+		batchSize: batchSize,
+		logger:    logger,
+	}, nil
+}
+
+//Rekey re-encrypts all encrypted columns of the entity table. The table is processed in batches which are
+//ordered by the unique field idField. Each batch is processed in its own transaction.
+func (r *Rekeyer) Rekey(entity DatabaseEntity, idField string, progressFct func(progress *RekeyProgress)) (*RekeyProgress, error) {
+	colHdlr, err := NewColumnHandler(entity, r.conn, r.logger)
+	if err != nil {
+		return nil, err
+	}
+	return r.rekey(entity, colHdlr, idField, colHdlr.EncryptedColumnNames(), nil, progressFct)
+}
+
+//RekeyFields re-encrypts fields which are not tagged with 'encrypt' but encrypted by the caller (e.g. depending
+//on a flag of the row). Only the rows matching all conditions of the where map (field name -> value) are processed.
+func (r *Rekeyer) RekeyFields(entity DatabaseEntity, idField string, fields []string, where map[string]interface{},
+	progressFct func(progress *RekeyProgress)) (*RekeyProgress, error) {
+	colHdlr, err := NewColumnHandler(entity, r.conn, r.logger)
+	if err != nil {
+		return nil, err
+	}
+	encCols := make([]string, 0, len(fields))
+	for _, field := range fields {
+		col, err := colHdlr.ColumnName(field)
+		if err != nil {
+			return nil, err
+		}
+		encCols = append(encCols, col)
+	}
+	return r.rekey(entity, colHdlr, idField, encCols, where, progressFct)
+}
+
+func (r *Rekeyer) rekey(entity DatabaseEntity, colHdlr *ColumnHandler, idField string, encCols []string,
+	where map[string]interface{}, progressFct func(progress *RekeyProgress)) (*RekeyProgress, error) {
+	idCol, err := colHdlr.ColumnName(idField)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &RekeyProgress{Table: entity.Table()}
+	if len(encCols) == 0 {
+		return progress, nil
+	}
+
+	//render the conditions of the where map (sorted to get a stable order of the placeholders)
+	whereFields := make([]string, 0, len(where))
+	for field := range where {
+		whereFields = append(whereFields, field)
+	}
+	sort.Strings(whereFields)
+	var conds []string
+	var condArgs []interface{}
+	for _, field := range whereFields {
+		col, err := colHdlr.ColumnName(field)
+		if err != nil {
+			return nil, err
+		}
+		condArgs = append(condArgs, where[field])
+		conds = append(conds, fmt.Sprintf("%s=$%d", col, len(condArgs)))
+	}
+
+	var cursor interface{}
+	for {
+		batchProgress := &RekeyProgress{} //counters are only applied if the transaction of the batch was committed
+		dbOps := func(tx *TxConnection) (interface{}, error) {
+			return r.rekeyBatch(tx, entity.Table(), idCol, encCols, conds, condArgs, cursor, batchProgress)
+		}
+		nextCursor, err := TransactionResult(r.conn, dbOps, r.logger)
+		if err != nil {
+			return progress, errors.Wrap(err, fmt.Sprintf("failed to rekey table '%s'", entity.Table()))
+		}
+		if batchProgress.Rows == 0 {
+			return progress, nil
+		}
+		cursor = nextCursor
+		progress.Rows += batchProgress.Rows
+		progress.Rekeyed += batchProgress.Rekeyed
+		progress.Undecryptable += batchProgress.Undecryptable
+		if progressFct != nil {
+			progressFct(progress)
+		}
+		if batchProgress.Rows < int64(r.batchSize) {
+			return progress, nil
+		}
+	}
+}
+
+type rekeyRow struct {
+	id     interface{}
+	values []sql.NullString
+}
+
+func (r *Rekeyer) selectBatch(tx *TxConnection, query string, encColCnt int, args ...interface{}) ([]*rekeyRow, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if closer, ok := rows.(interface{ Close() error }); ok {
+		defer func() {
+			if err := closer.Close(); err != nil {
+				r.logger.Warnf("Failed to close result set of query '%s': %s", query, err)
+			}
+		}()
+	}
+
+	var batch []*rekeyRow
+	for rows.Next() {
+		row := &rekeyRow{values: make([]sql.NullString, encColCnt)}
+		dest := []interface{}{&row.id}
+		for i := range row.values {
+			dest = append(dest, &row.values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		if id, ok := row.id.([]byte); ok { //some drivers return text columns as byte slice
+			row.id = string(id)
+		}
+		batch = append(batch, row)
+	}
+	return batch, nil
+}
+
+func (r *Rekeyer) rekeyBatch(tx *TxConnection, table, idCol string, encCols []string, conds []string, condArgs []interface{},
+	cursor interface{}, progress *RekeyProgress) (interface{}, error) {
+	//select the next batch of rows
+	query := fmt.Sprintf("SELECT %s, %s FROM %s", idCol, strings.Join(encCols, ", "), table)
+	args := append([]interface{}{}, condArgs...)
+	if cursor != nil {
+		args = append(args, cursor)
+		conds = append(conds, fmt.Sprintf("%s > $%d", idCol, len(args)))
+	}
+	if len(conds) > 0 {
+		query = fmt.Sprintf("%s WHERE %s", query, strings.Join(conds, " AND "))
+	}
+	query = fmt.Sprintf("%s ORDER BY %s LIMIT %d", query, idCol, r.batchSize)
+
+	batch, err := r.selectBatch(tx, query, len(encCols), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	//re-encrypt values which were not encrypted with the active key
+	encryptor := tx.Encryptor()
+	for _, row := range batch {
+		for i, value := range row.values {
+			if !value.Valid || value.String == "" || encryptor.EncryptedWithActiveKey(value.String) {
+				continue
+			}
+			if !encryptor.Decryptable(value.String) {
+				r.logger.Warnf("Column '%s' of row '%v' in table '%s' cannot be decrypted with any key of the keyring",
+					encCols[i], row.id, table)
+				progress.Undecryptable++
+				continue
+			}
+			decValue, err := encryptor.Decrypt(value.String)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to decrypt column '%s' of row '%v'", encCols[i], row.id))
+			}
+			encValue, err := encryptor.Encrypt(decValue)
+			if err != nil {
+				return nil, err
+			}
+			//update only if the value was not changed in the meantime
+			update := fmt.Sprintf("UPDATE %s SET %s=$1 WHERE %s=$2 AND %s=$3", table, encCols[i], idCol, encCols[i])
+			if _, err := tx.Exec(update, encValue, row.id, value.String); err != nil {
+				return nil, err
+			}
+			progress.Rekeyed++
+		}
+	}
+
+	progress.Rows = int64(len(batch))
+	if len(batch) == 0 {
+		return cursor, nil
+	}
+	return batch[len(batch)-1].id, nil
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRekeyer(t *testing.T) {
+	oldKey, err := NewEncryptionKey()
+	require.NoError(t, err)
+	oldEnc, err := NewEncryptor(oldKey)
+	require.NoError(t, err)
+	unknownEnc := newEncryptor(t)
+
+	newKey, err := NewEncryptionKey()
+	require.NoError(t, err)
+
+	//prepare table with data encrypted by the old key (setup queries bypass the query validator)
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "rekey.db"))
+	require.NoError(t, err)
+	conn, err := newSqliteConnection(db, NewInProcessNotifier(), newKey, []string{oldKey}, true, true)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+
+	_, err = db.Exec("CREATE TABLE mockTable (col_1 text PRIMARY KEY, col_2 boolean, col_3 text)")
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		encValue, err := oldEnc.Encrypt(fmt.Sprintf("value%d", i))
+		require.NoError(t, err)
+		_, err = db.Exec("INSERT INTO mockTable (col_1, col_2, col_3) VALUES ($1, $2, $3)", fmt.Sprintf("id%d", i), false, encValue)
+		require.NoError(t, err)
+	}
+	_, err = db.Exec("INSERT INTO mockTable (col_1, col_2, col_3) VALUES ($1, $2, $3)", "id5", false, nil)
+	require.NoError(t, err)
+	unknownEncValue, err := unknownEnc.Encrypt("value6")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO mockTable (col_1, col_2, col_3) VALUES ($1, $2, $3)", "id6", false, unknownEncValue)
+	require.NoError(t, err)
+
+	rekeyer, err := NewRekeyer(conn, 2, log.NewLogger(true))
+	require.NoError(t, err)
+
+	t.Run("Rekey table", func(t *testing.T) {
+		var progressCalls int
+		progress, err := rekeyer.Rekey(&MockDbEntity{}, "Col1", func(progress *RekeyProgress) {
+			progressCalls++
+		})
+		require.NoError(t, err)
+		require.Equal(t, &RekeyProgress{Table: "mockTable", Rows: 7, Rekeyed: 5, Undecryptable: 1}, progress)
+		require.Equal(t, 4, progressCalls)
+
+		//all values are encrypted with the new key
+		for i := 0; i < 5; i++ {
+			row := db.QueryRow("SELECT col_3 FROM mockTable WHERE col_1=$1", fmt.Sprintf("id%d", i))
+			var encValue string
+			require.NoError(t, row.Scan(&encValue))
+			require.True(t, conn.Encryptor().EncryptedWithActiveKey(encValue))
+
+			newEnc, err := NewEncryptor(newKey)
+			require.NoError(t, err)
+			value, err := newEnc.Decrypt(encValue)
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("value%d", i), value)
+		}
+	})
+
+	t.Run("Rekey table again", func(t *testing.T) {
+		progress, err := rekeyer.Rekey(&MockDbEntity{}, "Col1", nil)
+		require.NoError(t, err)
+		require.Equal(t, &RekeyProgress{Table: "mockTable", Rows: 7, Rekeyed: 0, Undecryptable: 1}, progress)
+	})
+
+	t.Run("Rekey fields of matching rows", func(t *testing.T) {
+		for i, flag := range []bool{true, false} {
+			encValue, err := oldEnc.Encrypt("flagged")
+			require.NoError(t, err)
+			_, err = db.Exec("INSERT INTO mockTable (col_1, col_2, col_3) VALUES ($1, $2, $3)", fmt.Sprintf("id%d", 7+i), flag, encValue)
+			require.NoError(t, err)
+		}
+
+		progress, err := rekeyer.RekeyFields(&MockDbEntity{}, "Col1", []string{"Col3"}, map[string]interface{}{"Col2": true}, nil)
+		require.NoError(t, err)
+		require.Equal(t, &RekeyProgress{Table: "mockTable", Rows: 1, Rekeyed: 1}, progress)
+
+		//only the value of the matching row was re-encrypted
+		for id, rekeyed := range map[string]bool{"id7": true, "id8": false} {
+			row := db.QueryRow("SELECT col_3 FROM mockTable WHERE col_1=$1", id)
+			var encValue string
+			require.NoError(t, row.Scan(&encValue))
+			require.Equal(t, rekeyed, conn.Encryptor().EncryptedWithActiveKey(encValue))
+		}
+	})
+
+	t.Run("Invalid batch size", func(t *testing.T) {
+		_, err := NewRekeyer(conn, 0, log.NewLogger(true))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var jsonFieldRegex = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+//EscapeLike escapes the wildcard characters of a value used in a LIKE condition (the escape character is '\')
+func EscapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+//PrefixCondition returns a SQL condition which checks whether the value of a column starts with the given prefix
+func PrefixCondition(column string, placeholder int, prefix string) (string, interface{}) {
+	return fmt.Sprintf(`%s LIKE $%d ESCAPE '\'`, column, placeholder), EscapeLike(prefix) + "%"
+}
+
+//JSONFieldCondition returns a SQL condition which checks the value of a string field of a JSON object
+//stored in a text column. SQLite is not compiled with JSON support: the serialized JSON string is
+//compared instead which requires that the object was stored compact (as done by json.Marshal).
+func JSONFieldCondition(dbType Type, column, field string, placeholder int, value string) (string, interface{}, error) {
+	if !jsonFieldRegex.MatchString(field) {
+		return "", nil, fmt.Errorf("JSON field name '%s' is invalid", field)
+	}
+	switch dbType {
+	case Postgres:
+		return fmt.Sprintf("CAST(%s AS json)->>'%s'=$%d", column, field, placeholder), value, nil
+	case SQLite:
+		jsonField, err := json.Marshal(map[string]string{field: value})
+		if err != nil {
+			return "", nil, err
+		}
+		pattern := strings.TrimSuffix(strings.TrimPrefix(string(jsonField), "{"), "}")
+		return fmt.Sprintf(`%s LIKE $%d ESCAPE '\'`, column, placeholder), "%" + EscapeLike(pattern) + "%", nil
+	default:
+		return "", nil, fmt.Errorf("database type '%s' is not supported by JSON field conditions", dbType)
+	}
+}
@@ -0,0 +1,36 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLFilter(t *testing.T) {
+	t.Run("Escape LIKE", func(t *testing.T) {
+		require.Equal(t, `100\%\_a\\b`, EscapeLike(`100%_a\b`))
+	})
+
+	t.Run("Prefix condition", func(t *testing.T) {
+		cond, arg := PrefixCondition("kyma_version", 2, "2.0.")
+		require.Equal(t, `kyma_version LIKE $2 ESCAPE '\'`, cond)
+		require.Equal(t, "2.0.%", arg)
+	})
+
+	t.Run("JSON field condition", func(t *testing.T) {
+		cond, arg, err := JSONFieldCondition(Postgres, "metadata", "region", 1, "westeurope")
+		require.NoError(t, err)
+		require.Equal(t, "CAST(metadata AS json)->>'region'=$1", cond)
+		require.Equal(t, "westeurope", arg)
+
+		cond, arg, err = JSONFieldCondition(SQLite, "metadata", "region", 1, "west_europe")
+		require.NoError(t, err)
+		require.Equal(t, `metadata LIKE $1 ESCAPE '\'`, cond)
+		require.Equal(t, `%"region":"west\_europe"%`, arg)
+
+		_, _, err = JSONFieldCondition(Postgres, "metadata", "region'--", 1, "x")
+		require.Error(t, err)
+		_, _, err = JSONFieldCondition(Mock, "metadata", "region", 1, "x")
+		require.Error(t, err)
+	})
+}
@@ -18,13 +18,14 @@ type sqliteConnection struct {
 	db        *sql.DB
 	encryptor *Encryptor
 	validator *Validator
+	notifier  Notifier
 	logger    *zap.SugaredLogger
 }
 
-func newSqliteConnection(db *sql.DB, encKey string, debug bool, blockQueries bool) (*sqliteConnection, error) {
+func newSqliteConnection(db *sql.DB, notifier Notifier, encKey string, decryptionKeys []string, debug bool, blockQueries bool) (*sqliteConnection, error) {
 	logger := log.NewLogger(debug)
 
-	encryptor, err := NewEncryptor(encKey)
+	encryptor, err := NewEncryptor(encKey, decryptionKeys...)
 	if err != nil {
 		return nil, err
 	}
@@ -35,6 +36,7 @@ func newSqliteConnection(db *sql.DB, encKey string, debug bool, blockQueries boo
 		db:        db,
 		encryptor: encryptor,
 		validator: validator,
+		notifier:  notifier,
 		logger:    logger,
 	}, nil
 }
@@ -47,6 +49,12 @@ func (sc *sqliteConnection) Encryptor() *Encryptor {
 	return sc.encryptor
 }
 
+//Notifier returns a notifier which is shared by all connections of the connection factory
+//(SQLite doesn't support notifications between processes)
+func (sc *sqliteConnection) Notifier() Notifier {
+	return sc.notifier
+}
+
 func (sc *sqliteConnection) Ping() error {
 	sc.logger.Debugf("SQLite Ping()")
 	return sc.db.Ping()
@@ -103,13 +111,15 @@ func (sc *sqliteConnection) Type() Type {
 }
 
 type sqliteConnectionFactory struct {
-	file          string
-	debug         bool
-	reset         bool
-	schemaFile    string
-	encryptionKey string
-	blockQueries  bool
-	logQueries    bool
+	file           string
+	debug          bool
+	reset          bool
+	schemaFile     string
+	encryptionKey  string
+	decryptionKeys []string //keys used before a key rotation
+	blockQueries   bool
+	logQueries     bool
+	notifier       Notifier
 }
 
 func (scf *sqliteConnectionFactory) Init(_ bool) error {
@@ -149,7 +159,7 @@ func (scf *sqliteConnectionFactory) NewConnection() (Connection, error) {
 		return nil, err
 	}
 
-	return newSqliteConnection(db, scf.encryptionKey, scf.logQueries, scf.blockQueries) //connection ready to use
+	return newSqliteConnection(db, scf.notifier, scf.encryptionKey, scf.decryptionKeys, scf.logQueries, scf.blockQueries) //connection ready to use
 }
 
 func (scf *sqliteConnectionFactory) resetFile() error {
@@ -40,16 +40,23 @@ func Transaction(conn Connection, dbOps func(tx *TxConnection) error, logger *za
 }
 
 type TxConnection struct {
-	tx      *sql.Tx
-	conn    Connection
-	counter uint
-	logger  *zap.SugaredLogger
+	tx       *sql.Tx
+	conn     Connection
+	counter  uint
+	logger   *zap.SugaredLogger
+	notifier *txNotifier
 	sync.Mutex
 }
 
 func NewTxConnection(tx *sql.Tx, conn Connection, logger *zap.SugaredLogger) *TxConnection {
 	//setting counter to 1 since first begin is not called with counter increase
-	return &TxConnection{tx: tx, conn: conn, counter: 1, logger: logger}
+	return &TxConnection{
+		tx:       tx,
+		conn:     conn,
+		counter:  1,
+		logger:   logger,
+		notifier: &txNotifier{notifier: conn.Notifier(), logger: logger},
+	}
 }
 func (t *TxConnection) DB() *sql.DB {
 	return t.conn.DB()
@@ -59,6 +66,11 @@ func (t *TxConnection) Encryptor() *Encryptor {
 	return t.conn.Encryptor()
 }
 
+//Notifier returns a notifier which sends its notifications not before the transaction was committed
+func (t *TxConnection) Notifier() Notifier {
+	return t.notifier
+}
+
 func (t *TxConnection) Ping() error {
 	return t.conn.Ping()
 }
@@ -97,7 +109,11 @@ func (t *TxConnection) commit() error {
 	t.decreaseCounter()
 	if t.counter == 0 {
 		t.logger.Debug("Transaction Committed")
-		return t.tx.Commit()
+		if err := t.tx.Commit(); err != nil {
+			t.notifier.reset()
+			return err
+		}
+		t.notifier.flush()
 	}
 	return nil
 }
@@ -115,5 +131,6 @@ func (t *TxConnection) decreaseCounter() {
 }
 
 func (t *TxConnection) Rollback() error {
+	t.notifier.reset()
 	return t.tx.Rollback()
 }
@@ -2,12 +2,14 @@ package interpreter
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/traefik/yaegi/interp"
 	"github.com/traefik/yaegi/stdlib"
 )
@@ -15,10 +17,12 @@ import (
 const allowedPackages = "fmt|regexp|net/url|strings|time|strconv"
 
 var regExp = regexp.MustCompile(fmt.Sprintf(`^import\s+"(%s)"$`, allowedPackages))
+var regExpImport = regexp.MustCompile(`^import\s+"([^"]+)"$`)
 
 type GolangInterpreter struct {
-	code     string
-	bindings map[string]interface{}
+	code      string
+	bindings  map[string]interface{}
+	functions map[string]map[string]interface{} //key: package name, value: functions of the package
 }
 
 func NewGolangInterpreter(code string) *GolangInterpreter {
@@ -31,16 +35,32 @@ func (gi *GolangInterpreter) WithBindings(bindings map[string]interface{}) *Gola
 	if gi.bindings == nil {
 		gi.bindings = bindings
 	} else {
-		for k, v := range gi.bindings {
+		for k, v := range bindings {
 			gi.bindings[k] = v
 		}
 	}
 	return gi
 }
 
+//WithFunctions provides Go functions to the interpreted code: they are accessible
+//after importing the package (e.g. 'import "pkgName"')
+func (gi *GolangInterpreter) WithFunctions(pkgName string, functions map[string]interface{}) *GolangInterpreter {
+	if gi.functions == nil {
+		gi.functions = make(map[string]map[string]interface{})
+	}
+	gi.functions[pkgName] = functions
+	return gi
+}
+
 func (gi *GolangInterpreter) Eval() (reflect.Value, error) {
+	return gi.EvalWithContext(context.Background())
+}
+
+//EvalWithContext executes the code and stops the execution when the context is done (e.g. its deadline expired)
+func (gi *GolangInterpreter) EvalWithContext(ctx context.Context) (reflect.Value, error) {
 	interp := interp.New(interp.Options{})
 	interp.Use(stdlib.Symbols)
+	interp.Use(gi.exports())
 
 	var lastResult reflect.Value
 	var err error
@@ -56,11 +76,14 @@ func (gi *GolangInterpreter) Eval() (reflect.Value, error) {
 		line := scanner.Text()
 
 		//block execution of non-whitelisted imports
-		if strings.HasPrefix(line, "import") && !regExp.MatchString(line) {
+		if strings.HasPrefix(line, "import") && !gi.allowedImport(line) {
 			return lastResult, &BlockedImportError{BlockedImport: line}
 		}
 
-		lastResult, err = interp.Eval(line)
+		lastResult, err = interp.EvalWithContext(ctx, line)
+		if ctx.Err() != nil {
+			return lastResult, errors.Wrap(ctx.Err(), "Go interpreter stopped the execution")
+		}
 		if err != nil {
 			return lastResult, fmt.Errorf("Go interpreter failed to execute line '%s':\n%s", line, err.Error())
 		}
@@ -98,6 +121,30 @@ func (gi *GolangInterpreter) EvalString() (string, error) {
 	}
 }
 
+func (gi *GolangInterpreter) exports() interp.Exports {
+	exports := make(interp.Exports, len(gi.functions))
+	for pkgName, functions := range gi.functions {
+		symbols := make(map[string]reflect.Value, len(functions))
+		for name, fct := range functions {
+			symbols[name] = reflect.ValueOf(fct)
+		}
+		exports[pkgName] = symbols
+	}
+	return exports
+}
+
+func (gi *GolangInterpreter) allowedImport(line string) bool {
+	if regExp.MatchString(line) {
+		return true
+	}
+	match := regExpImport.FindStringSubmatch(line)
+	if match == nil {
+		return false
+	}
+	_, ok := gi.functions[match[1]]
+	return ok
+}
+
 func (gi *GolangInterpreter) bind(interp *interp.Interpreter, bindings map[string]interface{}) error {
 	if bindings == nil {
 		return nil
@@ -107,7 +154,7 @@ func (gi *GolangInterpreter) bind(interp *interp.Interpreter, bindings map[strin
 	for k, v := range bindings {
 		switch v.(type) {
 		case string:
-			_, err = interp.Eval(fmt.Sprintf(`var %s string = "%s"`, k, v))
+			_, err = interp.Eval(fmt.Sprintf(`var %s string = %q`, k, v))
 		case bool:
 			_, err = interp.Eval(fmt.Sprintf(`var %s bool = %t`, k, v))
 		case int:
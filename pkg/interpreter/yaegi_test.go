@@ -58,6 +58,39 @@ fmt.Sprintf("foo=%s | x=%d | y=%t", foo, x, y)
 		require.Equal(t, "foo=bar | x=123 | y=true", result)
 	})
 
+	t.Run("String bindings are escaped", func(t *testing.T) {
+		goInt := NewGolangInterpreter(`
+value
+`).WithBindings(map[string]interface{}{"value": `a"b\c`})
+		result, err := goInt.EvalString()
+		require.NoError(t, err)
+		require.Equal(t, `a"b\c`, result)
+	})
+
+	t.Run("Happy path with functions", func(t *testing.T) {
+		var calledWith string
+		goInt := NewGolangInterpreter(`
+import "reconciler"
+reconciler.Call(value)
+`).WithBindings(map[string]interface{}{"value": "xyz"}).
+			WithFunctions("reconciler", map[string]interface{}{
+				"Call": func(value string) {
+					calledWith = value
+				},
+			})
+		_, err := goInt.Eval()
+		require.NoError(t, err)
+		require.Equal(t, "xyz", calledWith)
+	})
+
+	t.Run("Block import of unknown function package", func(t *testing.T) {
+		goInt := NewGolangInterpreter(`
+import "reconciler"
+`).WithFunctions("other", map[string]interface{}{"Call": func() {}})
+		_, err := goInt.Eval()
+		require.True(t, IsBlockedImportError(err))
+	})
+
 	t.Run("Invalid boolean result", func(t *testing.T) {
 		goInt := NewGolangInterpreter(`
 "xyz"
@@ -5,6 +5,7 @@ import "fmt"
 func ToStatus(in string) (Status, error) {
 
 	for _, status := range []Status{
+		StatusDeleteCancelled,
 		StatusDeleteError,
 		StatusDeleteErrorRetryable,
 		StatusDeletePending,
@@ -12,6 +13,7 @@ func ToStatus(in string) (Status, error) {
 		StatusDeleting,
 		StatusError,
 		StatusReady,
+		StatusReconcileCancelled,
 		StatusReconcileDisabled,
 		StatusReconcileErrorRetryable,
 		StatusReconcilePending,
@@ -1,5 +1,10 @@
 package keb
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 //ConfigurationAsMap flattens the list of configuration entities to a map.
 //Component struct is generated from OpenAPI.
 func (c Component) ConfigurationAsMap() map[string]interface{} {
@@ -9,3 +14,42 @@ func (c Component) ConfigurationAsMap() map[string]interface{} {
 	}
 	return result
 }
+
+//Matches verifies that the metadata fulfill the filter: a filter maps JSON field names of the metadata (case-insensitive)
+//to the accepted values of the field. All fields have to match and nil metadata match only an empty filter.
+//Metadata struct is generated from OpenAPI.
+func (m *Metadata) Matches(filter map[string][]string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	if m == nil {
+		return false
+	}
+	metadataJSON, err := json.Marshal(m)
+	if err != nil {
+		return false
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(metadataJSON, &fields); err != nil {
+		return false
+	}
+	lowerFields := make(map[string]string, len(fields))
+	for field, value := range fields {
+		if strValue, ok := value.(string); ok {
+			lowerFields[strings.ToLower(field)] = strValue
+		}
+	}
+	for field, acceptedValues := range filter {
+		var found bool
+		for _, acceptedValue := range acceptedValues {
+			if acceptedValue == lowerFields[strings.ToLower(field)] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -7,8 +7,57 @@ import (
 	"time"
 )
 
+// Defines values for ConfigChangeType.
+const (
+	ConfigChangeTypeAdded ConfigChangeType = "added"
+
+	ConfigChangeTypeChanged ConfigChangeType = "changed"
+
+	ConfigChangeTypeRemoved ConfigChangeType = "removed"
+)
+
+// Defines values for GetClustersParamsSortBy.
+const (
+	GetClustersParamsSortByKymaVersion GetClustersParamsSortBy = "kymaVersion"
+
+	GetClustersParamsSortByRuntimeID GetClustersParamsSortBy = "runtimeID"
+
+	GetClustersParamsSortByStatus GetClustersParamsSortBy = "status"
+
+	GetClustersParamsSortByUpdated GetClustersParamsSortBy = "updated"
+)
+
+// Defines values for GetClustersParamsSortOrder.
+const (
+	GetClustersParamsSortOrderAsc GetClustersParamsSortOrder = "asc"
+
+	GetClustersParamsSortOrderDesc GetClustersParamsSortOrder = "desc"
+)
+
+// Defines values for ResourceChangeType.
+const (
+	ResourceChangeTypeCreate ResourceChangeType = "create"
+
+	ResourceChangeTypePrune ResourceChangeType = "prune"
+
+	ResourceChangeTypeUpdate ResourceChangeType = "update"
+)
+
+// Defines values for RolloutPhase.
+const (
+	RolloutPhaseAborted RolloutPhase = "aborted"
+
+	RolloutPhaseCanary RolloutPhase = "canary"
+
+	RolloutPhaseHalted RolloutPhase = "halted"
+
+	RolloutPhaseWaves RolloutPhase = "waves"
+)
+
 // Defines values for Status.
 const (
+	StatusDeleteCancelled Status = "delete_cancelled"
+
 	StatusDeleteError Status = "delete_error"
 
 	StatusDeleteErrorRetryable Status = "delete_error_retryable"
@@ -23,6 +72,8 @@ const (
 
 	StatusReady Status = "ready"
 
+	StatusReconcileCancelled Status = "reconcile_cancelled"
+
 	StatusReconcileDisabled Status = "reconcile_disabled"
 
 	StatusReconcileErrorRetryable Status = "reconcile_error_retryable"
@@ -35,14 +86,43 @@ const (
 // HTTPClusterConfig defines model for HTTPClusterConfig.
 type HTTPClusterConfig KymaConfig
 
+// HTTPClusterConfigDiffResponse defines model for HTTPClusterConfigDiffResponse.
+type HTTPClusterConfigDiffResponse struct {
+	BucketValues []ConfigurationChange `json:"bucketValues"`
+	Components   []ComponentChange     `json:"components"`
+	FromVersion  int64                 `json:"fromVersion"`
+	KymaProfile  *ValueChange          `json:"kymaProfile,omitempty"`
+	KymaVersion  *ValueChange          `json:"kymaVersion,omitempty"`
+	RuntimeID    string                `json:"runtimeID"`
+	ToVersion    int64                 `json:"toVersion"`
+}
+
+// HTTPClusterDriftResponse defines model for HTTPClusterDriftResponse.
+type HTTPClusterDriftResponse struct {
+	Components    []ComponentDrift `json:"components"`
+	ConfigVersion int64            `json:"configVersion"`
+	Detected      time.Time        `json:"detected"`
+	RuntimeID     string           `json:"runtimeID"`
+}
+
+// HTTPClusterPlanResponse defines model for HTTPClusterPlanResponse.
+type HTTPClusterPlanResponse struct {
+	Components    []ComponentPlan `json:"components"`
+	ConfigVersion int64           `json:"configVersion"`
+	RuntimeID     string          `json:"runtimeID"`
+}
+
 // HTTPClusterResponse defines model for HTTPClusterResponse.
 type HTTPClusterResponse struct {
 	Cluster              string     `json:"cluster"`
 	ClusterVersion       int64      `json:"clusterVersion"`
 	ConfigurationVersion int64      `json:"configurationVersion"`
 	Failures             *[]Failure `json:"failures,omitempty"`
-	Status               Status     `json:"status"`
-	StatusURL            string     `json:"statusURL"`
+
+	// earliest time a reconciliation can start (only set if the cluster has a maintenance window)
+	NextAllowedStart *time.Time `json:"nextAllowedStart,omitempty"`
+	Status           Status     `json:"status"`
+	StatusURL        string     `json:"statusURL"`
 }
 
 // HTTPClusterStatusResponse defines model for HTTPClusterStatusResponse.
@@ -55,6 +135,14 @@ type HTTPErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// HTTPFleetResponse defines model for HTTPFleetResponse.
+type HTTPFleetResponse struct {
+	Clusters []FleetCluster `json:"clusters"`
+
+	// cursor of the next page (only set if a further page exists)
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
 // HTTPReconcilerStatus defines model for HTTPReconcilerStatus.
 type HTTPReconcilerStatus []Reconciliation
 
@@ -64,12 +152,31 @@ type HTTPReconciliationInfo struct {
 	Created       time.Time   `json:"created"`
 	Finished      bool        `json:"finished"`
 	Operations    []Operation `json:"operations"`
-	RuntimeID     string      `json:"runtimeID"`
-	SchedulingID  string      `json:"schedulingID"`
-	Status        Status      `json:"status"`
-	Updated       time.Time   `json:"updated"`
+	Paused        bool        `json:"paused"`
+
+	// reason of an on-demand reconciliation
+	Reason       *string   `json:"reason,omitempty"`
+	RuntimeID    string    `json:"runtimeID"`
+	SchedulingID string    `json:"schedulingID"`
+	Status       Status    `json:"status"`
+	Updated      time.Time `json:"updated"`
 }
 
+// HTTPRolloutResponse defines model for HTTPRolloutResponse.
+type HTTPRolloutResponse RolloutStatus
+
+// HTTPRolloutsResponse defines model for HTTPRolloutsResponse.
+type HTTPRolloutsResponse []RolloutStatus
+
+// HTTPWebhookDeliveriesResponse defines model for HTTPWebhookDeliveriesResponse.
+type HTTPWebhookDeliveriesResponse []WebhookDelivery
+
+// HTTPWebhookResponse defines model for HTTPWebhookResponse.
+type HTTPWebhookResponse Webhook
+
+// HTTPWebhooksResponse defines model for HTTPWebhooksResponse.
+type HTTPWebhooksResponse []Webhook
+
 // Cluster defines model for cluster.
 type Cluster struct {
 	// valid kubeconfig to cluster
@@ -85,10 +192,42 @@ type Component struct {
 	URL           string          `json:"URL"`
 	Component     string          `json:"component"`
 	Configuration []Configuration `json:"configuration"`
-	Namespace     string          `json:"namespace"`
-	Version       string          `json:"version"`
+
+	// Components which have to be reconciled before this component (replaces the pre-component ordering)
+	DependsOn *[]string `json:"dependsOn,omitempty"`
+	Namespace string    `json:"namespace"`
+	Version   string    `json:"version"`
+}
+
+// ComponentChange defines model for componentChange.
+type ComponentChange struct {
+	Component     string                `json:"component"`
+	Configuration []ConfigurationChange `json:"configuration"`
+	Namespace     *ValueChange          `json:"namespace,omitempty"`
+	Type          ConfigChangeType      `json:"type"`
+	Url           *ValueChange          `json:"url,omitempty"`
+
+	// components without version use the Kyma version
+	Version *ValueChange `json:"version,omitempty"`
+}
+
+// ComponentDrift defines model for componentDrift.
+type ComponentDrift struct {
+	Component string           `json:"component"`
+	Error     *string          `json:"error,omitempty"`
+	Resources []ResourceChange `json:"resources"`
 }
 
+// ComponentPlan defines model for componentPlan.
+type ComponentPlan struct {
+	Changes   []ResourceChange `json:"changes"`
+	Component string           `json:"component"`
+	Error     *string          `json:"error,omitempty"`
+}
+
+// ConfigChangeType defines model for configChangeType.
+type ConfigChangeType string
+
 // Configuration defines model for configuration.
 type Configuration struct {
 	Key    string      `json:"key"`
@@ -96,12 +235,38 @@ type Configuration struct {
 	Value  interface{} `json:"value"`
 }
 
+// ConfigurationChange defines model for configurationChange.
+type ConfigurationChange struct {
+	// previous value (null if the key was added, masked if the value is a secret)
+	From   interface{} `json:"from"`
+	Key    string      `json:"key"`
+	Secret bool        `json:"secret"`
+
+	// new value (null if the key was removed, masked if the value is a secret)
+	To   interface{}      `json:"to"`
+	Type ConfigChangeType `json:"type"`
+}
+
 // Failure defines model for failure.
 type Failure struct {
 	Component string `json:"component"`
 	Reason    string `json:"reason"`
 }
 
+// FleetCluster defines model for fleetCluster.
+type FleetCluster struct {
+	Cluster              string   `json:"cluster"`
+	ClusterVersion       int64    `json:"clusterVersion"`
+	ConfigurationVersion int64    `json:"configurationVersion"`
+	KymaProfile          *string  `json:"kymaProfile,omitempty"`
+	KymaVersion          string   `json:"kymaVersion"`
+	Metadata             Metadata `json:"metadata"`
+	Status               Status   `json:"status"`
+
+	// time of the latest status change
+	Updated time.Time `json:"updated"`
+}
+
 // KymaConfig defines model for kymaConfig.
 type KymaConfig struct {
 	Administrators []string    `json:"administrators"`
@@ -110,16 +275,33 @@ type KymaConfig struct {
 	Version        string      `json:"version"`
 }
 
+// MaintenanceWindow defines model for maintenanceWindow.
+type MaintenanceWindow struct {
+	// duration of the maintenance window (e.g. 4h)
+	Duration string `json:"duration"`
+
+	// reconciliations can start outside of the maintenance window until this time is reached (urgent override)
+	OverrideUntil *time.Time `json:"overrideUntil,omitempty"`
+
+	// weekly cron-like schedule defining the begin of the maintenance window ("minute hour * * weekdays", e.g. "0 2 * * SAT,SUN")
+	Schedule string `json:"schedule"`
+
+	// IANA time zone of the schedule (default is UTC)
+	Timezone *string `json:"timezone,omitempty"`
+}
+
 // Metadata defines model for metadata.
 type Metadata struct {
-	GlobalAccountID string `json:"globalAccountID"`
-	InstanceID      string `json:"instanceID"`
-	Region          string `json:"region"`
-	ServiceID       string `json:"serviceID"`
-	ServicePlanID   string `json:"servicePlanID"`
-	ServicePlanName string `json:"servicePlanName"`
-	ShootName       string `json:"shootName"`
-	SubAccountID    string `json:"subAccountID"`
+	GlobalAccountID   string             `json:"globalAccountID"`
+	InstanceID        string             `json:"instanceID"`
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	Region            string             `json:"region"`
+	SchedulingWeight  *int64             `json:"schedulingWeight,omitempty"`
+	ServiceID         string             `json:"serviceID"`
+	ServicePlanID     string             `json:"servicePlanID"`
+	ServicePlanName   string             `json:"servicePlanName"`
+	ShootName         string             `json:"shootName"`
+	SubAccountID      string             `json:"subAccountID"`
 }
 
 // Operation defines model for operation.
@@ -134,11 +316,24 @@ type Operation struct {
 	Updated       time.Time `json:"updated"`
 }
 
+// OperationSkip defines model for operationSkip.
+type OperationSkip struct {
+	Reason string `json:"reason"`
+}
+
 // OperationStop defines model for operationStop.
 type OperationStop struct {
 	Reason string `json:"reason"`
 }
 
+// ReconcileRequest defines model for reconcileRequest.
+type ReconcileRequest struct {
+
+	// components to reconcile (all components are reconciled if omitted)
+	Components *[]string `json:"components,omitempty"`
+	Reason     string    `json:"reason"`
+}
+
 // ReconcilerStatus defines model for reconcilerStatus.
 type ReconcilerStatus struct {
 	Cluster  string    `json:"cluster"`
@@ -157,6 +352,43 @@ type Reconciliation struct {
 	Updated      time.Time `json:"updated"`
 }
 
+// ResourceChange defines model for resourceChange.
+type ResourceChange struct {
+	ApiVersion string                  `json:"apiVersion"`
+	Kind       string                  `json:"kind"`
+	Name       string                  `json:"name"`
+	Namespace  string                  `json:"namespace"`
+	Patch      *map[string]interface{} `json:"patch,omitempty"`
+	Type       ResourceChangeType      `json:"type"`
+}
+
+// ResourceChangeType defines model for resourceChangeType.
+type ResourceChangeType string
+
+// RolloutAbort defines model for rolloutAbort.
+type RolloutAbort struct {
+	Reason string `json:"reason"`
+}
+
+// RolloutPhase defines model for rolloutPhase.
+type RolloutPhase string
+
+// RolloutStatus defines model for rolloutStatus.
+type RolloutStatus struct {
+	ErrorRate   float64      `json:"errorRate"`
+	Failed      int          `json:"failed"`
+	KymaVersion string       `json:"kymaVersion"`
+	Pending     int          `json:"pending"`
+	Phase       RolloutPhase `json:"phase"`
+
+	// why the rollout was halted or aborted
+	Reason          *string `json:"reason,omitempty"`
+	StartedLastHour int     `json:"startedLastHour"`
+	Succeeded       int     `json:"succeeded"`
+	Superseded      int     `json:"superseded"`
+	Wave            int64   `json:"wave"`
+}
+
 // RuntimeInput defines model for runtimeInput.
 type RuntimeInput struct {
 	Description string `json:"description"`
@@ -178,9 +410,77 @@ type StatusUpdate struct {
 	Status Status `json:"status"`
 }
 
+// ValueChange defines model for valueChange.
+type ValueChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Webhook defines model for webhook.
+type Webhook struct {
+	Created time.Time `json:"created"`
+
+	// cluster metadata fields and their accepted values
+	Metadata   *map[string][]string `json:"metadata,omitempty"`
+	RuntimeIDs *[]string            `json:"runtimeIDs,omitempty"`
+	Statuses   *[]Status            `json:"statuses,omitempty"`
+	Url        string               `json:"url"`
+	WebhookID  string               `json:"webhookID"`
+}
+
+// WebhookDelivery defines model for webhookDelivery.
+type WebhookDelivery struct {
+	Attempts   int64        `json:"attempts"`
+	Created    time.Time    `json:"created"`
+	DeliveryID string       `json:"deliveryID"`
+	Event      WebhookEvent `json:"event"`
+	LastError  string       `json:"lastError"`
+	State      string       `json:"state"`
+	Updated    time.Time    `json:"updated"`
+}
+
+// WebhookEvent defines model for webhookEvent.
+type WebhookEvent struct {
+	ClusterVersion int64     `json:"clusterVersion"`
+	ConfigVersion  int64     `json:"configVersion"`
+	Created        time.Time `json:"created"`
+	EventID        string    `json:"eventID"`
+	Metadata       *Metadata `json:"metadata,omitempty"`
+	RuntimeID      string    `json:"runtimeID"`
+	Status         Status    `json:"status"`
+}
+
+// WebhookRegistration defines model for webhookRegistration.
+type WebhookRegistration struct {
+	// only clusters whose metadata fields have one of the accepted values are reported
+	Metadata *map[string][]string `json:"metadata,omitempty"`
+
+	// only these clusters are reported (all clusters are reported if not set)
+	RuntimeIDs *[]string `json:"runtimeIDs,omitempty"`
+
+	// key used to sign the events (HMAC-SHA256 of the request body in header 'X-Reconciler-Signature')
+	Secret string `json:"secret"`
+
+	// only these statuses are reported (all statuses are reported if not set)
+	Statuses *[]Status `json:"statuses,omitempty"`
+	Url      string    `json:"url"`
+}
+
 // BadRequest defines model for BadRequest.
 type BadRequest HTTPErrorResponse
 
+// ClusterConfigDiffOKResponse defines model for ClusterConfigDiffOKResponse.
+type ClusterConfigDiffOKResponse HTTPClusterConfigDiffResponse
+
+// ClusterDriftOKResponse defines model for ClusterDriftOKResponse.
+type ClusterDriftOKResponse HTTPClusterDriftResponse
+
+// ClusterPlanOKResponse defines model for ClusterPlanOKResponse.
+type ClusterPlanOKResponse HTTPClusterPlanResponse
+
+// FleetOKResponse defines model for FleetOKResponse.
+type FleetOKResponse HTTPFleetResponse
+
 // InternalError defines model for InternalError.
 type InternalError HTTPErrorResponse
 
@@ -196,6 +496,21 @@ type ReconcilationsOKResponse HTTPReconcilerStatus
 // ReconciliationInfoOKResponse defines model for ReconciliationInfoOKResponse.
 type ReconciliationInfoOKResponse HTTPReconciliationInfo
 
+// RolloutOKResponse defines model for RolloutOKResponse.
+type RolloutOKResponse HTTPRolloutResponse
+
+// RolloutsOKResponse defines model for RolloutsOKResponse.
+type RolloutsOKResponse HTTPRolloutsResponse
+
+// WebhookDeliveriesOKResponse defines model for WebhookDeliveriesOKResponse.
+type WebhookDeliveriesOKResponse HTTPWebhookDeliveriesResponse
+
+// WebhookOKResponse defines model for WebhookOKResponse.
+type WebhookOKResponse HTTPWebhookResponse
+
+// WebhooksOKResponse defines model for WebhooksOKResponse.
+type WebhooksOKResponse HTTPWebhooksResponse
+
 // ConfigurationOkResponse defines model for configurationOkResponse.
 type ConfigurationOkResponse HTTPClusterConfig
 
@@ -208,9 +523,52 @@ type PutClustersJSONBody Cluster
 // PutClustersRuntimeIDStatusJSONBody defines parameters for PutClustersRuntimeIDStatus.
 type PutClustersRuntimeIDStatusJSONBody StatusUpdate
 
+// PostClustersRuntimeIDReconcileJSONBody defines parameters for PostClustersRuntimeIDReconcile.
+type PostClustersRuntimeIDReconcileJSONBody ReconcileRequest
+
+// PostOperationsSchedulingIDCorrelationIDSkipJSONBody defines parameters for PostOperationsSchedulingIDCorrelationIDSkip.
+type PostOperationsSchedulingIDCorrelationIDSkipJSONBody OperationSkip
+
 // PostOperationsSchedulingIDCorrelationIDStopJSONBody defines parameters for PostOperationsSchedulingIDCorrelationIDStop.
 type PostOperationsSchedulingIDCorrelationIDStopJSONBody OperationStop
 
+// PostRolloutsKymaVersionAbortJSONBody defines parameters for PostRolloutsKymaVersionAbort.
+type PostRolloutsKymaVersionAbortJSONBody RolloutAbort
+
+// PostWebhooksJSONBody defines parameters for PostWebhooks.
+type PostWebhooksJSONBody WebhookRegistration
+
+// GetClustersParams defines parameters for GetClusters.
+type GetClustersParams struct {
+	Status      *[]Status `json:"status,omitempty"`
+	KymaVersion *string   `json:"kymaVersion,omitempty"`
+	KymaProfile *string   `json:"kymaProfile,omitempty"`
+	Component   *string   `json:"component,omitempty"`
+
+	// version of the component (requires the component parameter)
+	ComponentVersion *string                     `json:"componentVersion,omitempty"`
+	GlobalAccountID  *string                     `json:"globalAccountID,omitempty"`
+	SubAccountID     *string                     `json:"subAccountID,omitempty"`
+	ServiceID        *string                     `json:"serviceID,omitempty"`
+	ServicePlanID    *string                     `json:"servicePlanID,omitempty"`
+	ServicePlanName  *string                     `json:"servicePlanName,omitempty"`
+	ShootName        *string                     `json:"shootName,omitempty"`
+	InstanceID       *string                     `json:"instanceID,omitempty"`
+	Region           *string                     `json:"region,omitempty"`
+	SortBy           *GetClustersParamsSortBy    `json:"sortBy,omitempty"`
+	SortOrder        *GetClustersParamsSortOrder `json:"sortOrder,omitempty"`
+	Limit            *int                        `json:"limit,omitempty"`
+
+	// cursor of the next page (returned by the previous page)
+	Cursor *string `json:"cursor,omitempty"`
+}
+
+// GetClustersParamsSortBy defines parameters for GetClusters.
+type GetClustersParamsSortBy string
+
+// GetClustersParamsSortOrder defines parameters for GetClusters.
+type GetClustersParamsSortOrder string
+
 // GetReconciliationsParams defines parameters for GetReconciliations.
 type GetReconciliationsParams struct {
 	RuntimeID *[]string  `json:"runtimeID,omitempty"`
@@ -229,5 +587,14 @@ type PutClustersJSONRequestBody PutClustersJSONBody
 // PutClustersRuntimeIDStatusJSONRequestBody defines body for PutClustersRuntimeIDStatus for application/json ContentType.
 type PutClustersRuntimeIDStatusJSONRequestBody PutClustersRuntimeIDStatusJSONBody
 
+// PostClustersRuntimeIDReconcileJSONRequestBody defines body for PostClustersRuntimeIDReconcile for application/json ContentType.
+type PostClustersRuntimeIDReconcileJSONRequestBody PostClustersRuntimeIDReconcileJSONBody
+
+// PostOperationsSchedulingIDCorrelationIDSkipJSONRequestBody defines body for PostOperationsSchedulingIDCorrelationIDSkip for application/json ContentType.
+type PostOperationsSchedulingIDCorrelationIDSkipJSONRequestBody PostOperationsSchedulingIDCorrelationIDSkipJSONBody
+
 // PostOperationsSchedulingIDCorrelationIDStopJSONRequestBody defines body for PostOperationsSchedulingIDCorrelationIDStop for application/json ContentType.
 type PostOperationsSchedulingIDCorrelationIDStopJSONRequestBody PostOperationsSchedulingIDCorrelationIDStopJSONBody
+
+// PostWebhooksJSONRequestBody defines body for PostWebhooks for application/json ContentType.
+type PostWebhooksJSONRequestBody PostWebhooksJSONBody
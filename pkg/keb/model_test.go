@@ -25,4 +25,16 @@ func TestContract(t *testing.T) {
 			"test2": "value2",
 		}, comp.ConfigurationAsMap())
 	})
+	t.Run("Metadata matches filter", func(t *testing.T) {
+		metadata := &Metadata{Region: "europe-west1", ServicePlanName: "trial"}
+		require.True(t, metadata.Matches(nil))
+		require.True(t, metadata.Matches(map[string][]string{"region": {"us-east1", "europe-west1"}}))
+		require.True(t, metadata.Matches(map[string][]string{"REGION": {"europe-west1"}, "serviceplanname": {"trial"}}))
+		require.False(t, metadata.Matches(map[string][]string{"region": {"europe-west1"}, "servicePlanName": {"azure"}}))
+		require.False(t, metadata.Matches(map[string][]string{"unknown": {"x"}}))
+
+		var noMetadata *Metadata
+		require.True(t, noMetadata.Matches(nil))
+		require.False(t, noMetadata.Matches(map[string][]string{"region": {"europe-west1"}}))
+	})
 }
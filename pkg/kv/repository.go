@@ -1,15 +1,32 @@
 package kv
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/interpreter"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
 )
 
+const (
+	//TriggerPackage is the package which has to be imported by the trigger of a key to access the trigger functions
+	TriggerPackage = "reconciler"
+	//DefaultTriggerTimeout is the max. execution time of a trigger
+	DefaultTriggerTimeout = 30 * time.Second
+)
+
+//ReconcileClustersFct enqueues the reconciliation of all clusters which are using the values of a bucket
+type ReconcileClustersFct func(bucket string) error
+
 type Repository struct {
 	*repository.Repository
+	reconcileClusters ReconcileClustersFct
+	triggerTimeout    time.Duration
 }
 
 func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
@@ -17,7 +34,14 @@ func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Repository{repo}, nil
+	return &Repository{Repository: repo, triggerTimeout: DefaultTriggerTimeout}, nil
+}
+
+//WithReconcileClusters defines the function which is called if the trigger of a key
+//requests the reconciliation of the clusters using a bucket
+func (cer *Repository) WithReconcileClusters(fct ReconcileClustersFct) *Repository {
+	cer.reconcileClusters = fct
+	return cer
 }
 
 func (cer *Repository) Keys() ([]*model.KeyEntity, error) {
@@ -59,6 +83,21 @@ func (cer *Repository) Keys() ([]*model.KeyEntity, error) {
 	return result, nil
 }
 
+//EncryptedKeys returns the names of all keys whose latest version requires the encryption of their values
+func (cer *Repository) EncryptedKeys() (map[string]bool, error) {
+	keys, err := cer.Keys()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool)
+	for _, key := range keys {
+		if key.Encrypted {
+			result[key.Key] = true
+		}
+	}
+	return result, nil
+}
+
 func (cer *Repository) KeyHistory(key string) ([]*model.KeyEntity, error) {
 	entity := &model.KeyEntity{}
 	q, err := db.NewQuery(cer.Conn, entity, cer.Logger)
@@ -212,12 +251,7 @@ func (cer *Repository) ValuesByBucket(bucket string) ([]*model.ValueEntity, erro
 		return nil, err
 	}
 
-	//cast to specific entity
-	var result []*model.ValueEntity
-	for _, entity := range entities {
-		result = append(result, entity.(*model.ValueEntity))
-	}
-	return result, nil
+	return cer.castValues(entities)
 }
 
 func (cer *Repository) ValuesByKey(key *model.KeyEntity) ([]*model.ValueEntity, error) {
@@ -259,12 +293,7 @@ func (cer *Repository) ValuesByKey(key *model.KeyEntity) ([]*model.ValueEntity,
 		return nil, err
 	}
 
-	//cast to specific entity
-	var result []*model.ValueEntity
-	for _, entity := range entities {
-		result = append(result, entity.(*model.ValueEntity))
-	}
-	return result, nil
+	return cer.castValues(entities)
 }
 
 func (cer *Repository) ValueHistory(bucket, key string) ([]*model.ValueEntity, error) {
@@ -280,12 +309,7 @@ func (cer *Repository) ValueHistory(bucket, key string) ([]*model.ValueEntity, e
 	if err != nil {
 		return nil, err
 	}
-	//cast to specific entity
-	var result []*model.ValueEntity
-	for _, entity := range entities {
-		result = append(result, entity.(*model.ValueEntity))
-	}
-	return result, nil
+	return cer.castValues(entities)
 }
 
 func (cer *Repository) LatestValue(bucket, key string) (*model.ValueEntity, error) {
@@ -302,7 +326,8 @@ func (cer *Repository) LatestValue(bucket, key string) (*model.ValueEntity, erro
 	if err != nil {
 		return nil, cer.NewNotFoundError(err, &model.ValueEntity{}, whereCond)
 	}
-	return entity.(*model.ValueEntity), nil
+	valueEntity := entity.(*model.ValueEntity)
+	return valueEntity, cer.decrypt(valueEntity)
 }
 
 func (cer *Repository) Value(bucket, key string, version int64) (*model.ValueEntity, error) {
@@ -317,7 +342,8 @@ func (cer *Repository) Value(bucket, key string, version int64) (*model.ValueEnt
 	if err != nil {
 		return nil, cer.NewNotFoundError(err, &model.ValueEntity{}, whereCond)
 	}
-	return entity.(*model.ValueEntity), nil
+	valueEntity := entity.(*model.ValueEntity)
+	return valueEntity, cer.decrypt(valueEntity)
 }
 
 func (cer *Repository) CreateValue(value *model.ValueEntity) (*model.ValueEntity, error) {
@@ -351,32 +377,202 @@ func (cer *Repository) CreateValue(value *model.ValueEntity) (*model.ValueEntity
 
 	//insert operation
 	dbOps := func(tx *db.TxConnection) (interface{}, error) {
-		//add value entity
-		q, err := db.NewQuery(tx, value, cer.Logger)
+		//add value entity (values of encrypted keys are stored encrypted)
+		valueEntity := *value
+		valueEntity.Encrypted = key.Encrypted
+		if key.Encrypted {
+			encValue, err := tx.Encryptor().Encrypt(value.Value)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to encrypt value of key '%s'", value.Key))
+			}
+			valueEntity.Value = encValue
+		}
+		q, err := db.NewQuery(tx, &valueEntity, cer.Logger)
 		if err != nil {
 			return nil, err
 		}
-		valueEntity, err := value, q.Insert().Exec()
-		if err != nil {
-			return valueEntity, err
+		if err := q.Insert().Exec(); err != nil {
+			return nil, err
 		}
+		valueEntity.Value = value.Value
 
 		//new value provided - invalidate caches which were using the old value
 		if err := cer.CacheDep.Invalidate().WithBucket(value.Bucket).WithKey(value.Key).Exec(tx); err != nil {
-			return valueEntity, err
+			return nil, err
 		}
 
 		//done
-		return valueEntity, nil
+		return &valueEntity, nil
 	}
 
 	result, err := cer.TransactionalResult(dbOps)
-	var valueEntity *model.ValueEntity
-	if result != nil {
-		valueEntity = result.(*model.ValueEntity)
+	if err != nil {
+		return nil, err
+	}
+	valueEntity := result.(*model.ValueEntity)
+
+	//run the trigger after the value was stored: the trigger logic has to see the new value
+	if key.Trigger != "" {
+		if err := cer.runTrigger(key, existingValue, valueEntity); err != nil {
+			return valueEntity, err
+		}
 	}
 
-	return valueEntity, err
+	return valueEntity, nil
+}
+
+//runTrigger executes the trigger of a key in the Go interpreter. The trigger can access the values
+//by the variables 'bucket', 'key', 'oldValue' (empty if no previous value exists) and 'newValue' and
+//call the trigger functions after importing the TriggerPackage.
+func (cer *Repository) runTrigger(key *model.KeyEntity, oldValue, newValue *model.ValueEntity) error {
+	var oldPlainValue string
+	if oldValue != nil {
+		oldPlainValue = oldValue.Value
+	}
+
+	//a trigger which doesn't terminate (e.g. endless loop) is stopped after the timeout
+	ctx, cancel := context.WithTimeout(context.Background(), cer.triggerTimeout)
+	defer cancel()
+
+	var fctErrs []string
+	_, err := interpreter.NewGolangInterpreter(key.Trigger).
+		WithBindings(map[string]interface{}{
+			"bucket":   newValue.Bucket,
+			"key":      newValue.Key,
+			"oldValue": oldPlainValue,
+			"newValue": newValue.Value,
+		}).
+		WithFunctions(TriggerPackage, cer.triggerFunctions(func(err error) {
+			fctErrs = append(fctErrs, err.Error())
+		})).
+		EvalWithContext(ctx)
+	if ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("trigger did not finish within %.0f secs", cer.triggerTimeout.Seconds())
+	}
+	if err == nil && len(fctErrs) > 0 {
+		err = errors.New(strings.Join(fctErrs, "; "))
+	}
+	if err != nil {
+		return &TriggerError{
+			Key:    key,
+			Bucket: newValue.Bucket,
+			Err:    err,
+		}
+	}
+	return nil
+}
+
+//triggerFunctions returns the functions which are available for triggers: errors of the functions are
+//passed to the errorHandler as the trigger code cannot handle them
+func (cer *Repository) triggerFunctions(errorHandler func(err error)) map[string]interface{} {
+	return map[string]interface{}{
+		//InvalidateCache drops the cache entries which are using a value of the bucket and key (empty means any)
+		"InvalidateCache": func(bucket, key string) {
+			invalidate := cer.CacheDep.Invalidate()
+			if bucket != "" {
+				invalidate = invalidate.WithBucket(bucket)
+			}
+			if key != "" {
+				invalidate = invalidate.WithKey(key)
+			}
+			if err := invalidate.Exec(cer.Conn); err != nil {
+				errorHandler(errors.Wrap(err, "failed to invalidate cache"))
+			}
+		},
+		//ReconcileClusters enqueues the reconciliation of the clusters which are using the values of the bucket
+		"ReconcileClusters": func(bucket string) {
+			if cer.reconcileClusters == nil {
+				errorHandler(fmt.Errorf("reconciliation of clusters is not supported by this repository"))
+				return
+			}
+			if err := cer.reconcileClusters(bucket); err != nil {
+				errorHandler(errors.Wrap(err, fmt.Sprintf("failed to reconcile clusters using bucket '%s'", bucket)))
+			}
+		},
+	}
+}
+
+//EncryptPlaintextValues encrypts the values which are stored in plain text although their key requires encryption
+//(e.g. values created before the encryption of keys was supported) and returns the number of encrypted values.
+func (cer *Repository) EncryptPlaintextValues() (int64, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		qKeys, err := db.NewQuery(tx, &model.KeyEntity{}, cer.Logger)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := qKeys.Select().Where(map[string]interface{}{"Encrypted": true}).GetMany()
+		if err != nil {
+			return nil, err
+		}
+
+		var encrypted int64
+		for _, entity := range keys {
+			key := entity.(*model.KeyEntity)
+			qValues, err := db.NewQuery(tx, &model.ValueEntity{}, cer.Logger)
+			if err != nil {
+				return nil, err
+			}
+			values, err := qValues.Select().
+				Where(map[string]interface{}{"Key": key.Key, "KeyVersion": key.Version, "Encrypted": false}).
+				GetMany()
+			if err != nil {
+				return nil, err
+			}
+			for _, entity := range values {
+				value := entity.(*model.ValueEntity)
+				encValue, err := tx.Encryptor().Encrypt(value.Value)
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("failed to encrypt value of key '%s'", value.Key))
+				}
+				value.Value = encValue
+				value.Encrypted = true
+				qUpdate, err := db.NewQuery(tx, value, cer.Logger)
+				if err != nil {
+					return nil, err
+				}
+				//update only if the value was not encrypted in the meantime
+				updated, err := qUpdate.Update().
+					Where(map[string]interface{}{"Version": value.Version, "Encrypted": false}).
+					ExecCount()
+				if err != nil {
+					return nil, err
+				}
+				encrypted += updated
+			}
+		}
+		return encrypted, nil
+	}
+
+	result, err := cer.TransactionalResult(dbOps)
+	if err != nil {
+		return 0, err
+	}
+	return result.(int64), nil
+}
+
+//castValues converts the entities to value entities and decrypts encrypted values
+func (cer *Repository) castValues(entities []db.DatabaseEntity) ([]*model.ValueEntity, error) {
+	var result []*model.ValueEntity
+	for _, entity := range entities {
+		result = append(result, entity.(*model.ValueEntity))
+	}
+	return result, cer.decrypt(result...)
+}
+
+//decrypt replaces the encrypted values by their plain text: the Encrypted flag of the entities is kept
+func (cer *Repository) decrypt(values ...*model.ValueEntity) error {
+	for _, value := range values {
+		if !value.Encrypted {
+			continue
+		}
+		decValue, err := cer.Conn.Encryptor().Decrypt(value.Value)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to decrypt value of key '%s' in bucket '%s' (version %d)",
+				value.Key, value.Bucket, value.Version))
+		}
+		value.Value = decValue
+	}
+	return nil
 }
 
 func (cer *Repository) DeleteValue(key, bucket string) error {
@@ -494,3 +690,20 @@ func IsInvalidDataTypeError(err error) bool {
 	_, ok := err.(*InvalidDataTypeError)
 	return ok
 }
+
+//TriggerError indicates that the trigger of a key failed: the value was stored nevertheless
+type TriggerError struct {
+	Key    *model.KeyEntity
+	Bucket string
+	Err    error
+}
+
+func (e *TriggerError) Error() string {
+	return fmt.Sprintf("Trigger of key '%s' (version %d) failed for the stored value in bucket '%s': %s",
+		e.Key.Key, e.Key.Version, e.Bucket, e.Err)
+}
+
+func IsTriggerError(err error) bool {
+	_, ok := err.(*TriggerError)
+	return ok
+}
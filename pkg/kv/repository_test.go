@@ -336,6 +336,172 @@ func TestRepositoryValues(t *testing.T) {
 	})
 }
 
+func TestRepositoryEncryptedValues(t *testing.T) {
+	ceRepo := newKeyValueRepo(t)
+
+	keyEntity, err := ceRepo.CreateKey(&model.KeyEntity{
+		Key:       fmt.Sprintf("testKeyEncrypted%d", time.Now().UnixNano()),
+		DataType:  model.String,
+		Encrypted: true,
+		Username:  "testUsername",
+	})
+	require.NoError(t, err)
+
+	valueEntity, err := ceRepo.CreateValue(&model.ValueEntity{
+		Key:        keyEntity.Key,
+		KeyVersion: keyEntity.Version,
+		Bucket:     "test-encrypted",
+		DataType:   model.String,
+		Value:      "secret",
+		Username:   "testUsername",
+	})
+	require.NoError(t, err)
+	require.True(t, valueEntity.Encrypted)
+	require.Equal(t, "secret", valueEntity.Value)
+	require.Equal(t, model.MaskedValue, valueEntity.Masked())
+
+	t.Run("Key is listed as encrypted", func(t *testing.T) {
+		encryptedKeys, err := ceRepo.EncryptedKeys()
+		require.NoError(t, err)
+		require.True(t, encryptedKeys[keyEntity.Key])
+	})
+
+	t.Run("Value is stored encrypted", func(t *testing.T) {
+		row, err := ceRepo.Conn.QueryRow("SELECT value FROM config_values WHERE version=$1", valueEntity.Version)
+		require.NoError(t, err)
+		var storedValue string
+		require.NoError(t, row.Scan(&storedValue))
+		require.NotEqual(t, "secret", storedValue)
+		require.True(t, ceRepo.Conn.Encryptor().Decryptable(storedValue))
+	})
+
+	t.Run("Value is decrypted when read", func(t *testing.T) {
+		latestValue, err := ceRepo.LatestValue("test-encrypted", keyEntity.Key)
+		require.NoError(t, err)
+		require.Equal(t, "secret", latestValue.Value)
+
+		values, err := ceRepo.ValuesByBucket("test-encrypted")
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		require.Equal(t, "secret", values[0].Value)
+
+		//unchanged value is not stored again
+		sameValue, err := ceRepo.CreateValue(latestValue)
+		require.NoError(t, err)
+		require.Equal(t, valueEntity.Version, sameValue.Version)
+	})
+
+	t.Run("Plain text values are encrypted", func(t *testing.T) {
+		//value stored before the encryption of keys was supported
+		q, err := db.NewQuery(ceRepo.Conn, &model.ValueEntity{
+			Key:        keyEntity.Key,
+			KeyVersion: keyEntity.Version,
+			Bucket:     "test-encrypted",
+			DataType:   model.String,
+			Value:      "plain",
+			Username:   "testUsername",
+		}, ceRepo.Logger)
+		require.NoError(t, err)
+		require.NoError(t, q.Insert().Exec())
+
+		encrypted, err := ceRepo.EncryptPlaintextValues()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), encrypted)
+
+		latestValue, err := ceRepo.LatestValue("test-encrypted", keyEntity.Key)
+		require.NoError(t, err)
+		require.True(t, latestValue.Encrypted)
+		require.Equal(t, "plain", latestValue.Value)
+
+		//nothing left to encrypt
+		encrypted, err = ceRepo.EncryptPlaintextValues()
+		require.NoError(t, err)
+		require.Equal(t, int64(0), encrypted)
+	})
+
+	require.NoError(t, ceRepo.DeleteBucket("test-encrypted"))
+	require.NoError(t, ceRepo.DeleteKey(keyEntity.Key))
+}
+
+func TestRepositoryTrigger(t *testing.T) {
+	ceRepo := newKeyValueRepo(t)
+
+	createKey := func(trigger string) *model.KeyEntity {
+		keyEntity, err := ceRepo.CreateKey(&model.KeyEntity{
+			Key:      fmt.Sprintf("testKeyTrigger%d", time.Now().UnixNano()),
+			DataType: model.String,
+			Trigger:  trigger,
+			Username: "testUsername",
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, ceRepo.DeleteKey(keyEntity.Key))
+		})
+		return keyEntity
+	}
+	createValue := func(keyEntity *model.KeyEntity, value string) error {
+		_, err := ceRepo.CreateValue(&model.ValueEntity{
+			Key:        keyEntity.Key,
+			KeyVersion: keyEntity.Version,
+			Bucket:     "test-trigger",
+			DataType:   model.String,
+			Value:      value,
+			Username:   "testUsername",
+		})
+		return err
+	}
+
+	t.Run("Trigger receives old and new value", func(t *testing.T) {
+		var calls []string
+		ceRepo.WithReconcileClusters(func(bucket string) error {
+			calls = append(calls, bucket)
+			return nil
+		})
+		keyEntity := createKey(`import "reconciler"
+import "fmt"
+reconciler.ReconcileClusters(fmt.Sprintf("%s:%s->%s", bucket, oldValue, newValue))`)
+
+		require.NoError(t, createValue(keyEntity, "value1"))
+		require.NoError(t, createValue(keyEntity, "value2"))
+		require.Equal(t, []string{"test-trigger:->value1", "test-trigger:value1->value2"}, calls)
+	})
+
+	t.Run("Failing trigger function", func(t *testing.T) {
+		ceRepo.WithReconcileClusters(func(bucket string) error {
+			return fmt.Errorf("cluster inventory not reachable")
+		})
+		keyEntity := createKey(`import "reconciler"
+reconciler.InvalidateCache(bucket, key)
+reconciler.ReconcileClusters(bucket)`)
+
+		err := createValue(keyEntity, "value")
+		require.True(t, IsTriggerError(err))
+		require.Contains(t, err.Error(), "cluster inventory not reachable")
+
+		//value was stored nevertheless
+		valueEntity, err := ceRepo.LatestValue("test-trigger", keyEntity.Key)
+		require.NoError(t, err)
+		require.Equal(t, "value", valueEntity.Value)
+	})
+
+	t.Run("Trigger exceeding the timeout", func(t *testing.T) {
+		ceRepo.triggerTimeout = 100 * time.Millisecond
+		defer func() {
+			ceRepo.triggerTimeout = DefaultTriggerTimeout
+		}()
+		keyEntity := createKey(`for {}`)
+
+		err := createValue(keyEntity, "value")
+		require.True(t, IsTriggerError(err))
+		require.Contains(t, err.Error(), "did not finish")
+	})
+
+	t.Run("Invalid trigger code", func(t *testing.T) {
+		keyEntity := createKey(`import "os"`)
+		require.True(t, IsTriggerError(createValue(keyEntity, "value")))
+	})
+}
+
 func newKeyValueRepo(t *testing.T) *Repository {
 	ceRepo, err := NewRepository(db.NewTestConnection(t), true)
 	require.NoError(t, err)
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var circuitStates = []string{"closed", "open", "half-open"}
+
+// CircuitBreakerCollector provides the following metrics:
+// - reconciler_circuit_breaker_state{"url","state"} - 1 for the current state of the circuit of a component
+//   reconciler URL, otherwise 0
+// - reconciler_circuit_breaker_opened_total{"url"} - number of times the circuit of a component reconciler URL opened
+type CircuitBreakerCollector struct {
+	stateGauge    *prometheus.GaugeVec
+	openedCounter *prometheus.CounterVec
+}
+
+func NewCircuitBreakerCollector() *CircuitBreakerCollector {
+	collector := &CircuitBreakerCollector{
+		stateGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "circuit_breaker_state",
+			Help:      "State of the circuit breaker of a component reconciler (1 = current state)",
+		}, []string{"url", "state"}),
+		openedCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "circuit_breaker_opened_total",
+			Help:      "Number of times the circuit breaker of a component reconciler opened",
+		}, []string{"url"}),
+	}
+	prometheus.MustRegister(collector)
+	return collector
+}
+
+func (c *CircuitBreakerCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.stateGauge.Describe(ch)
+	c.openedCounter.Describe(ch)
+}
+
+func (c *CircuitBreakerCollector) Collect(ch chan<- prometheus.Metric) {
+	c.stateGauge.Collect(ch)
+	c.openedCounter.Collect(ch)
+}
+
+//OnCircuitStateChange is called by the circuit breakers whenever a circuit changes its state
+func (c *CircuitBreakerCollector) OnCircuitStateChange(url string, state string) {
+	for _, circuitState := range circuitStates {
+		value := 0.0
+		if circuitState == state {
+			value = 1
+		}
+		c.stateGauge.WithLabelValues(url, circuitState).Set(value)
+	}
+	if state == "open" {
+		c.openedCounter.WithLabelValues(url).Inc()
+	}
+}
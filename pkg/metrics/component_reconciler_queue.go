@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// ComponentReconcilerQueueCollector provides the load of each component reconciler:
+// - reconciler_component_reconciler_queue_depth - number of operations waiting to be processed by a component reconciler
+// - reconciler_component_reconciler_operations_in_progress - number of operations processed by a component reconciler
+type ComponentReconcilerQueueCollector struct {
+	reconRepo reconciliation.Repository
+	config    *config.SchedulerConfig
+	logger    *zap.SugaredLogger
+
+	queueDepthDesc *prometheus.Desc
+	inProgressDesc *prometheus.Desc
+}
+
+func NewComponentReconcilerQueueCollector(reconRepo reconciliation.Repository, cfg *config.SchedulerConfig, logger *zap.SugaredLogger) *ComponentReconcilerQueueCollector {
+	return &ComponentReconcilerQueueCollector{
+		reconRepo: reconRepo,
+		config:    cfg,
+		logger:    logger,
+		queueDepthDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "component_reconciler_queue_depth"),
+			"Number of operations of running reconciliations which are waiting to be processed by a component reconciler",
+			[]string{"component_reconciler", "url"},
+			nil),
+		inProgressDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "component_reconciler_operations_in_progress"),
+			"Number of operations which are currently processed by a component reconciler",
+			[]string{"component_reconciler", "url"},
+			nil),
+	}
+}
+
+func (c *ComponentReconcilerQueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.queueDepthDesc
+	ch <- c.inProgressDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *ComponentReconcilerQueueCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.reconRepo == nil || c.config == nil {
+		c.logger.Error("unable to register metric: reconciliation repository or scheduler configuration is nil")
+		return
+	}
+
+	ops, err := c.reconRepo.GetReconcilingOperations()
+	if err != nil {
+		c.logger.Error(err.Error())
+		return
+	}
+
+	queueDepth := make(map[string]int)
+	inProgress := make(map[string]int)
+	for _, op := range ops {
+		switch op.State {
+		case model.OperationStateNew:
+			queueDepth[c.config.ReconcilerName(op.Component)]++
+		case model.OperationStateInProgress, model.OperationStateFailed:
+			inProgress[c.config.ReconcilerName(op.Component)]++
+		}
+	}
+
+	for name, reconciler := range c.config.Reconcilers {
+		for desc, value := range map[*prometheus.Desc]int{c.queueDepthDesc: queueDepth[name], c.inProgressDesc: inProgress[name]} {
+			m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, float64(value), name, reconciler.URL)
+			if err != nil {
+				c.logger.Errorf("unable to register metric %s", err.Error())
+				return
+			}
+			ch <- m
+		}
+	}
+}
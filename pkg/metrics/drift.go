@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/drift"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// DriftCollector provides the results of the drift detection:
+// - reconciler_drifted_resources_total - number of resources per cluster and component which differ from the configuration
+// - reconciler_drifted_clusters_total - total number of clusters with at least one drifted resource
+type DriftCollector struct {
+	repo   drift.Repository
+	logger *zap.SugaredLogger
+
+	driftedResourcesDesc *prometheus.Desc
+	driftedClustersDesc  *prometheus.Desc
+}
+
+func NewDriftCollector(repo drift.Repository, logger *zap.SugaredLogger) *DriftCollector {
+	return &DriftCollector{
+		repo:   repo,
+		logger: logger,
+		driftedResourcesDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "drifted_resources_total"),
+			"Number of resources of a component which were changed on the cluster outside of the reconciler",
+			[]string{"runtime_id", "component"},
+			nil),
+		driftedClustersDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "drifted_clusters_total"),
+			"Total number of clusters with at least one drifted resource",
+			[]string{},
+			nil),
+	}
+}
+
+func (c *DriftCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.driftedResourcesDesc
+	ch <- c.driftedClustersDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *DriftCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.repo == nil {
+		c.logger.Error("unable to register metric: drift repository is nil")
+		return
+	}
+
+	drifts, err := c.repo.GetAll()
+	if err != nil {
+		c.logger.Error(err.Error())
+		return
+	}
+
+	driftedClusters := make(map[string]bool)
+	for _, componentDrift := range drifts {
+		if len(componentDrift.Resources) == 0 { //only drifted components are reported to limit the number of series
+			continue
+		}
+		driftedClusters[componentDrift.RuntimeID] = true
+		m, err := prometheus.NewConstMetric(c.driftedResourcesDesc, prometheus.GaugeValue,
+			float64(len(componentDrift.Resources)), componentDrift.RuntimeID, componentDrift.Component)
+		if err != nil {
+			c.logger.Errorf("unable to register metric %s", err.Error())
+			return
+		}
+		ch <- m
+	}
+
+	m, err := prometheus.NewConstMetric(c.driftedClustersDesc, prometheus.GaugeValue, float64(len(driftedClusters)))
+	if err != nil {
+		c.logger.Errorf("unable to register metric %s", err.Error())
+		return
+	}
+	ch <- m
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// LeaderCollector provides the following metrics:
+// - reconciler_leader{"identity"} - 1 if the mothership replica is the leader running the scheduler, bookkeeper,
+//   cleaner and worker pool, otherwise 0
+// - reconciler_leadership_changes_total{"identity"} - number of times the replica became leader or lost its leadership
+type LeaderCollector struct {
+	leaderGauge     *prometheus.GaugeVec
+	changesCounter  *prometheus.CounterVec
+	previousLeaders map[string]bool
+}
+
+func NewLeaderCollector() *LeaderCollector {
+	collector := &LeaderCollector{
+		leaderGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "leader",
+			Help:      "Leadership of the mothership replica (1 = leader)",
+		}, []string{"identity"}),
+		changesCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "leadership_changes_total",
+			Help:      "Number of leadership changes of the mothership replica",
+		}, []string{"identity"}),
+		previousLeaders: make(map[string]bool),
+	}
+	prometheus.MustRegister(collector)
+	return collector
+}
+
+func (c *LeaderCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.leaderGauge.Describe(ch)
+	c.changesCounter.Describe(ch)
+}
+
+func (c *LeaderCollector) Collect(ch chan<- prometheus.Metric) {
+	c.leaderGauge.Collect(ch)
+	c.changesCounter.Collect(ch)
+}
+
+//OnLeadershipChange is called by the leader election (always from the same goroutine)
+func (c *LeaderCollector) OnLeadershipChange(identity string, leader bool) {
+	value := 0.0
+	if leader {
+		value = 1
+	}
+	c.leaderGauge.WithLabelValues(identity).Set(value)
+	if previous, ok := c.previousLeaders[identity]; ok && previous != leader {
+		c.changesCounter.WithLabelValues(identity).Inc()
+	}
+	c.previousLeaders[identity] = leader
+}
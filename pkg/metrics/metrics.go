@@ -2,12 +2,19 @@ package metrics
 
 import (
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/drift"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
-func RegisterAll(inventory cluster.Inventory, logger *zap.SugaredLogger) {
+func RegisterAll(inventory cluster.Inventory, driftRepo drift.Repository, reconRepo reconciliation.Repository,
+	schedulerCfg *config.SchedulerConfig, logger *zap.SugaredLogger) {
 	reconciliationWaitingCollector := NewReconciliationWaitingCollector(inventory, logger)
 	reconciliationNotReadyCollector := NewReconciliationNotReadyCollector(inventory, logger)
-	prometheus.MustRegister(reconciliationWaitingCollector, reconciliationNotReadyCollector)
+	driftCollector := NewDriftCollector(driftRepo, logger)
+	componentReconcilerQueueCollector := NewComponentReconcilerQueueCollector(reconRepo, schedulerCfg, logger)
+	prometheus.MustRegister(reconciliationWaitingCollector, reconciliationNotReadyCollector, driftCollector,
+		componentReconcilerQueueCollector)
 }
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -29,9 +30,10 @@ type ClusterConfigurationEntity struct {
 	KymaProfile    string           `db:""`
 	Components     []*keb.Component `db:"notNull,encrypt"`
 	Administrators []string
-	Contract       int64     `db:"notNull"`
-	Deleted        bool      `db:"notNull"`
-	Created        time.Time `db:"readOnly"`
+	BucketValues   map[string]interface{} `db:"encrypt"` // Snapshot of the merged KV-bucket values
+	Contract       int64                  `db:"notNull"`
+	Deleted        bool                   `db:"notNull"`
+	Created        time.Time              `db:"readOnly"`
 }
 
 func (c *ClusterConfigurationEntity) String() string {
@@ -63,9 +65,18 @@ func (c *ClusterConfigurationEntity) Marshaller() *db.EntityMarshaller {
 		err := json.Unmarshal([]byte(value.(string)), &result)
 		return result, err
 	})
+	marshaller.AddUnmarshaller("BucketValues", func(value interface{}) (interface{}, error) {
+		var result map[string]interface{}
+		if value == nil { //configs created before bucket values were introduced
+			return result, nil
+		}
+		err := json.Unmarshal([]byte(fmt.Sprintf("%v", value)), &result)
+		return result, err
+	})
 
 	marshaller.AddMarshaller("Components", convertInterfaceToJSONString)
 	marshaller.AddMarshaller("Administrators", convertInterfaceToJSONString)
+	marshaller.AddMarshaller("BucketValues", convertInterfaceToJSONString)
 	return marshaller
 }
 
@@ -85,11 +96,29 @@ func (c *ClusterConfigurationEntity) Equal(other db.DatabaseEntity) bool {
 			c.KymaProfile == otherClProp.KymaProfile &&
 			reflect.DeepEqual(c.Components, otherClProp.Components) &&
 			reflect.DeepEqual(c.Administrators, otherClProp.Administrators) &&
+			equalBucketValues(c.BucketValues, otherClProp.BucketValues) &&
 			c.Contract == otherClProp.Contract
 	}
 	return false
 }
 
+//equalBucketValues compares the JSON representation of bucket values: values loaded from the database
+//are JSON decoded and their types (e.g. float64 instead of int64) can differ from freshly resolved values
+func equalBucketValues(values, otherValues map[string]interface{}) bool {
+	if len(values) == 0 && len(otherValues) == 0 {
+		return true
+	}
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		return false
+	}
+	otherValuesJSON, err := json.Marshal(otherValues)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(valuesJSON, otherValuesJSON)
+}
+
 func (c *ClusterConfigurationEntity) GetComponent(component string) *keb.Component {
 	if component == CRDComponent { //CRD is an artificial component which doesn't exist in the component list of any cluster
 		return crdComponent
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+const tblClusterDrift string = "inventory_cluster_drifts"
+
+//ClusterDriftEntity contains the resources of a component which differ from the cluster configuration
+type ClusterDriftEntity struct {
+	RuntimeID     string               `db:"notNull"`
+	ConfigVersion int64                `db:"notNull"`
+	Component     string               `db:"notNull"`
+	Resources     []keb.ResourceChange `db:""`
+	Error         string               `db:""` //set if the drift of the component could not be detected
+	Created       time.Time            `db:"readOnly"`
+}
+
+func (d *ClusterDriftEntity) String() string {
+	return fmt.Sprintf("ClusterDriftEntity [RuntimeID=%s,ConfigVersion=%d,Component=%s,Resources=%d]",
+		d.RuntimeID, d.ConfigVersion, d.Component, len(d.Resources))
+}
+
+func (*ClusterDriftEntity) New() db.DatabaseEntity {
+	return &ClusterDriftEntity{}
+}
+
+func (d *ClusterDriftEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&d)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Resources", func(value interface{}) (interface{}, error) {
+		var resources []keb.ResourceChange
+		err := json.Unmarshal([]byte(value.(string)), &resources)
+		return resources, err
+	})
+	marshaller.AddMarshaller("Resources", convertInterfaceToJSONString)
+	return marshaller
+}
+
+func (*ClusterDriftEntity) Table() string {
+	return tblClusterDrift
+}
+
+func (d *ClusterDriftEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherDrift, ok := other.(*ClusterDriftEntity)
+	if !ok {
+		return false
+	}
+	return d.RuntimeID == otherDrift.RuntimeID && d.ConfigVersion == otherDrift.ConfigVersion &&
+		d.Component == otherDrift.Component
+}
@@ -11,12 +11,14 @@ const (
 	ClusterStatusDeleting                Status = "deleting"
 	ClusterStatusDeleteError             Status = "delete_error"
 	ClusterStatusDeleteErrorRetryable    Status = "delete_error_retryable"
+	ClusterStatusDeleteCancelled         Status = "delete_cancelled"
 	ClusterStatusDeleted                 Status = "deleted"
 	ClusterStatusReconcilePending        Status = "reconcile_pending"
 	ClusterStatusReconcileDisabled       Status = "reconcile_disabled"
 	ClusterStatusReconciling             Status = "reconciling"
 	ClusterStatusReconcileError          Status = "error"
 	ClusterStatusReconcileErrorRetryable Status = "reconcile_error_retryable"
+	ClusterStatusReconcileCancelled      Status = "reconcile_cancelled"
 	ClusterStatusReady                   Status = "ready"
 )
 
@@ -33,7 +35,11 @@ func (s Status) IsReconcileCandidate() bool {
 }
 
 func (s Status) IsFinal() bool {
-	return s == ClusterStatusReady || s == ClusterStatusReconcileError || s == ClusterStatusDeleted || s == ClusterStatusDeleteError || s == ClusterStatusReconcileErrorRetryable || s == ClusterStatusDeleteErrorRetryable
+	return s == ClusterStatusReady || s == ClusterStatusReconcileError || s == ClusterStatusDeleted || s == ClusterStatusDeleteError || s == ClusterStatusReconcileErrorRetryable || s == ClusterStatusDeleteErrorRetryable || s.IsCancelled()
+}
+
+func (s Status) IsCancelled() bool {
+	return s == ClusterStatusReconcileCancelled || s == ClusterStatusDeleteCancelled
 }
 
 func (s Status) IsInProgress() bool {
@@ -78,6 +84,10 @@ func NewClusterStatus(status Status) (*ClusterStatus, error) {
 		clusterStatus.ID = 9
 	case ClusterStatusDeleteErrorRetryable:
 		clusterStatus.ID = 10
+	case ClusterStatusReconcileCancelled:
+		clusterStatus.ID = 11
+	case ClusterStatusDeleteCancelled:
+		clusterStatus.ID = 12
 	default:
 		return clusterStatus, fmt.Errorf("ClusterStatus '%s' is unknown", status)
 	}
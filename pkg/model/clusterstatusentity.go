@@ -96,6 +96,12 @@ func (c *ClusterStatusEntity) GetKEBClusterStatus() (keb.Status, error) {
 	case ClusterStatusDeleteErrorRetryable:
 		kebStatus = keb.StatusDeleteErrorRetryable
 
+	case ClusterStatusReconcileCancelled:
+		kebStatus = keb.StatusReconcileCancelled
+
+	case ClusterStatusDeleteCancelled:
+		kebStatus = keb.StatusDeleteCancelled
+
 	default:
 		return kebStatus, fmt.Errorf("cluster status '%s' not convertable to KEB cluster status", c.Status)
 	}
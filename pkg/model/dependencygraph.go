@@ -0,0 +1,202 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+//DependencyGraph defines for each component of a reconciliation the components which have to be reconciled before
+type DependencyGraph struct {
+	Dependencies map[string][]string
+	Priorities   map[string]int64 //depth of a component in the graph (1 = component has no dependencies)
+}
+
+type DependencyCycleError struct {
+	Cycle []string
+}
+
+func (e *DependencyCycleError) Error() string {
+	return fmt.Sprintf("dependency cycle detected between components: %s", strings.Join(e.Cycle, " -> "))
+}
+
+func IsDependencyCycleError(err error) bool {
+	_, ok := err.(*DependencyCycleError)
+	return ok
+}
+
+//DependencyGraph converts the reconciliation sequence into a graph. Components which declare their dependencies
+//only wait for these components (and the CRDs), all other components wait for the previous group of the sequence.
+//Dependencies to components which are not part of the cluster configuration are ignored.
+func (rs *ReconciliationSequence) DependencyGraph() (*DependencyGraph, error) {
+	known := make(map[string]bool)
+	for _, components := range rs.Queue {
+		for _, component := range components {
+			known[component.Component] = true
+		}
+	}
+
+	graph := &DependencyGraph{
+		Dependencies: make(map[string][]string),
+		Priorities:   make(map[string]int64),
+	}
+	var previousGroup []string
+	for _, components := range rs.Queue {
+		var currentGroup []string
+		for _, component := range components {
+			dependencies := append([]string{}, previousGroup...)
+			if component.DependsOn != nil && component.Component != CleanupComponent && component.Component != CRDComponent {
+				dependencies = []string{CRDComponent}
+				for _, dependency := range *component.DependsOn {
+					if known[dependency] && dependency != CRDComponent {
+						dependencies = append(dependencies, dependency)
+					}
+				}
+			}
+			graph.Dependencies[component.Component] = dependencies
+			currentGroup = append(currentGroup, component.Component)
+		}
+		previousGroup = currentGroup
+	}
+	return graph, graph.resolvePriorities()
+}
+
+//Select returns a reconciliation sequence which includes only the given components and the components
+//they require (their pre-components or declared dependencies, the CRDs and the cleaner). The order of the
+//sequence is kept, so the dependency graph of the selection is a sub-graph of the complete graph.
+func (rs *ReconciliationSequence) Select(components []string) (*ReconciliationSequence, error) {
+	graph, err := rs.DependencyGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool)
+	var visit func(component string)
+	visit = func(component string) {
+		if selected[component] {
+			return
+		}
+		selected[component] = true
+		for _, dependency := range graph.Dependencies[component] {
+			visit(dependency)
+		}
+	}
+	for _, component := range components {
+		if _, ok := graph.Dependencies[component]; !ok {
+			return nil, fmt.Errorf("component '%s' is not part of the cluster configuration", component)
+		}
+		visit(component)
+	}
+
+	result := &ReconciliationSequence{preComponents: rs.preComponents}
+	for _, group := range rs.Queue {
+		var selectedGroup []*keb.Component
+		for _, component := range group {
+			if selected[component.Component] {
+				selectedGroup = append(selectedGroup, component)
+			}
+		}
+		if len(selectedGroup) > 0 {
+			result.Queue = append(result.Queue, selectedGroup)
+		}
+	}
+	return result, nil
+}
+
+//resolvePriorities walks through the graph and fails if a cycle is found
+func (g *DependencyGraph) resolvePriorities() error {
+	components := make([]string, 0, len(g.Dependencies))
+	for component := range g.Dependencies {
+		components = append(components, component)
+	}
+	sort.Strings(components) //deterministic error messages
+
+	visiting := make(map[string]bool)
+	var visit func(component string, path []string) (int64, error)
+	visit = func(component string, path []string) (int64, error) {
+		if priority, ok := g.Priorities[component]; ok {
+			return priority, nil
+		}
+		path = append(path, component)
+		if visiting[component] {
+			for idx, pathComponent := range path {
+				if pathComponent == component {
+					return 0, &DependencyCycleError{Cycle: path[idx:]}
+				}
+			}
+		}
+		visiting[component] = true
+		var priority int64 = 1
+		for _, dependency := range g.Dependencies[component] {
+			depPriority, err := visit(dependency, path)
+			if err != nil {
+				return 0, err
+			}
+			if depPriority >= priority {
+				priority = depPriority + 1
+			}
+		}
+		visiting[component] = false
+		g.Priorities[component] = priority
+		return priority, nil
+	}
+
+	for _, component := range components {
+		if _, err := visit(component, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//OperationGraph resolves the dependencies between the operations of one reconciliation
+type OperationGraph struct {
+	predecessors map[string][]*OperationEntity //key: component
+}
+
+//NewOperationGraph returns nil if none of the operations has dependencies: such operations were created before
+//dependencies were introduced and are processed by their priority.
+func NewOperationGraph(ops []*OperationEntity) *OperationGraph {
+	opsByComponent := make(map[string]*OperationEntity, len(ops))
+	hasDependencies := false
+	for _, op := range ops {
+		opsByComponent[op.Component] = op
+		hasDependencies = hasDependencies || len(op.DependsOn) > 0
+	}
+	if !hasDependencies {
+		return nil
+	}
+
+	graph := &OperationGraph{predecessors: make(map[string][]*OperationEntity, len(ops))}
+	for _, op := range ops {
+		for _, dependency := range op.DependsOn {
+			depOp, ok := opsByComponent[dependency]
+			if !ok {
+				continue
+			}
+			if op.Type == OperationTypeDelete { //deletions are processed backwards
+				graph.predecessors[depOp.Component] = append(graph.predecessors[depOp.Component], op)
+			} else {
+				graph.predecessors[op.Component] = append(graph.predecessors[op.Component], depOp)
+			}
+		}
+	}
+	return graph
+}
+
+//Predecessors returns the operations which have to be finished before the operation can start
+func (g *OperationGraph) Predecessors(op *OperationEntity) []*OperationEntity {
+	return g.predecessors[op.Component]
+}
+
+//IsBlocked returns true if the operation can never start because one of its direct or indirect predecessors failed
+func (g *OperationGraph) IsBlocked(op *OperationEntity) bool {
+	for _, predecessor := range g.predecessors[op.Component] {
+		if predecessor.State == OperationStateError || g.IsBlocked(predecessor) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package model
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	newConfig := func(components ...*keb.Component) *ClusterConfigurationEntity {
+		return &ClusterConfigurationEntity{Components: components}
+	}
+	dependsOn := func(components ...string) *[]string {
+		return &components
+	}
+
+	t.Run("Graph of pre-components", func(t *testing.T) {
+		graph, err := newConfig(
+			&keb.Component{Component: "pre1"},
+			&keb.Component{Component: "comp1"},
+		).GetReconciliationSequence([][]string{{"pre1"}}).DependencyGraph()
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{
+			CleanupComponent: {},
+			CRDComponent:     {CleanupComponent},
+			"pre1":           {CRDComponent},
+			"comp1":          {"pre1"},
+		}, graph.Dependencies)
+		require.Equal(t, map[string]int64{CleanupComponent: 1, CRDComponent: 2, "pre1": 3, "comp1": 4}, graph.Priorities)
+	})
+
+	t.Run("Graph with declared dependencies", func(t *testing.T) {
+		graph, err := newConfig(
+			&keb.Component{Component: "pre1"},
+			&keb.Component{Component: "comp1", DependsOn: dependsOn()},
+			&keb.Component{Component: "comp2", DependsOn: dependsOn("comp1", "notInstalled")},
+			&keb.Component{Component: "comp3"},
+		).GetReconciliationSequence([][]string{{"pre1"}}).DependencyGraph()
+		require.NoError(t, err)
+		require.Equal(t, []string{CRDComponent}, graph.Dependencies["comp1"])
+		require.Equal(t, []string{CRDComponent, "comp1"}, graph.Dependencies["comp2"])
+		require.Equal(t, []string{"pre1"}, graph.Dependencies["comp3"]) //waits for the pre-components
+		require.Equal(t, int64(3), graph.Priorities["comp1"])
+		require.Equal(t, int64(4), graph.Priorities["comp2"])
+		require.Equal(t, int64(4), graph.Priorities["comp3"])
+	})
+
+	t.Run("Detect cycles", func(t *testing.T) {
+		_, err := newConfig(
+			&keb.Component{Component: "comp1", DependsOn: dependsOn("comp2")},
+			&keb.Component{Component: "comp2", DependsOn: dependsOn("comp1")},
+		).GetReconciliationSequence(nil).DependencyGraph()
+		require.True(t, IsDependencyCycleError(err))
+		require.Equal(t, []string{"comp1", "comp2", "comp1"}, err.(*DependencyCycleError).Cycle)
+
+		//component in a pre-component group depends on a component which waits for the pre-components
+		_, err = newConfig(
+			&keb.Component{Component: "pre1", DependsOn: dependsOn("comp1")},
+			&keb.Component{Component: "comp1"},
+		).GetReconciliationSequence([][]string{{"pre1"}}).DependencyGraph()
+		require.True(t, IsDependencyCycleError(err))
+	})
+}
+
+func TestSelect(t *testing.T) {
+	dependsOn := func(components ...string) *[]string {
+		return &components
+	}
+	config := &ClusterConfigurationEntity{Components: []*keb.Component{
+		{Component: "pre1"},
+		{Component: "pre2"},
+		{Component: "comp1"},
+		{Component: "comp2", DependsOn: dependsOn("comp3")},
+		{Component: "comp3", DependsOn: dependsOn()},
+	}}
+	names := func(seq *ReconciliationSequence) [][]string {
+		var result [][]string
+		for _, group := range seq.Queue {
+			var groupNames []string
+			for _, component := range group {
+				groupNames = append(groupNames, component.Component)
+			}
+			sort.Strings(groupNames)
+			result = append(result, groupNames)
+		}
+		return result
+	}
+
+	t.Run("Select component with pre-components", func(t *testing.T) {
+		seq, err := config.GetReconciliationSequence([][]string{{"pre1"}, {"pre2"}}).Select([]string{"comp1"})
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{CleanupComponent}, {CRDComponent}, {"pre1"}, {"pre2"}, {"comp1"}}, names(seq))
+
+		graph, err := seq.DependencyGraph()
+		require.NoError(t, err)
+		require.Equal(t, []string{"pre2"}, graph.Dependencies["comp1"])
+	})
+
+	t.Run("Select pre-component", func(t *testing.T) {
+		seq, err := config.GetReconciliationSequence([][]string{{"pre1"}, {"pre2"}}).Select([]string{"pre1"})
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{CleanupComponent}, {CRDComponent}, {"pre1"}}, names(seq))
+	})
+
+	t.Run("Select component with declared dependencies", func(t *testing.T) {
+		seq, err := config.GetReconciliationSequence([][]string{{"pre1"}, {"pre2"}}).Select([]string{"comp2"})
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{CleanupComponent}, {CRDComponent}, {"comp2", "comp3"}}, names(seq))
+
+		graph, err := seq.DependencyGraph()
+		require.NoError(t, err)
+		require.Equal(t, []string{CRDComponent, "comp3"}, graph.Dependencies["comp2"])
+	})
+
+	t.Run("Select unknown component", func(t *testing.T) {
+		_, err := config.GetReconciliationSequence(nil).Select([]string{"comp1", "unknown"})
+		require.Error(t, err)
+	})
+}
+
+func TestOperationGraph(t *testing.T) {
+	newOps := func(opType OperationType) []*OperationEntity {
+		return []*OperationEntity{
+			{Component: "comp1", Type: opType, State: OperationStateError},
+			{Component: "comp2", Type: opType, DependsOn: []string{"comp1"}},
+			{Component: "comp3", Type: opType, DependsOn: []string{"comp2"}},
+		}
+	}
+
+	t.Run("Operations without dependencies", func(t *testing.T) {
+		require.Nil(t, NewOperationGraph([]*OperationEntity{{Component: "comp1"}, {Component: "comp2"}}))
+	})
+
+	t.Run("Reconcile operations", func(t *testing.T) {
+		ops := newOps(OperationTypeReconcile)
+		graph := NewOperationGraph(ops)
+		require.Empty(t, graph.Predecessors(ops[0]))
+		require.Equal(t, []*OperationEntity{ops[0]}, graph.Predecessors(ops[1]))
+		require.False(t, graph.IsBlocked(ops[0]))
+		require.True(t, graph.IsBlocked(ops[1]))
+		require.True(t, graph.IsBlocked(ops[2]))
+	})
+
+	t.Run("Delete operations", func(t *testing.T) {
+		ops := newOps(OperationTypeDelete)
+		graph := NewOperationGraph(ops)
+		require.Equal(t, []*OperationEntity{ops[1]}, graph.Predecessors(ops[0]))
+		require.Empty(t, graph.Predecessors(ops[2]))
+		require.False(t, graph.IsBlocked(ops[1]))
+	})
+}
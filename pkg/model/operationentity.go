@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -10,17 +11,19 @@ import (
 const tblOperation string = "scheduler_operations"
 
 type OperationEntity struct {
-	Priority      int64          `db:"notNull"`
-	SchedulingID  string         `db:"notNull"`
-	CorrelationID string         `db:"notNull"`
-	RuntimeID     string         `db:"notNull"`
-	ClusterConfig int64          `db:"notNull"`
-	Component     string         `db:"notNull"`
-	Type          OperationType  `db:"notNull"`
-	State         OperationState `db:"notNull"`
-	Reason        string         `db:""`
-	Created       time.Time      `db:"readOnly"`
-	Updated       time.Time      `db:""`
+	Priority       int64          `db:"notNull"`
+	SchedulingID   string         `db:"notNull"`
+	CorrelationID  string         `db:"notNull"`
+	RuntimeID      string         `db:"notNull"`
+	ClusterConfig  int64          `db:"notNull"`
+	Component      string         `db:"notNull"`
+	DependsOn      []string       `db:""` //components which have to be finished before (empty for older operations)
+	Type           OperationType  `db:"notNull"`
+	State          OperationState `db:"notNull"`
+	Reason         string         `db:""`
+	CallbackSecret string         `db:"encrypt"` //secret used by the component reconciler to sign its callbacks
+	Created        time.Time      `db:"readOnly"`
+	Updated        time.Time      `db:""`
 }
 
 func (o *OperationEntity) String() string {
@@ -47,6 +50,15 @@ func (o *OperationEntity) Marshaller() *db.EntityMarshaller {
 	marshaller.AddUnmarshaller("State", func(value interface{}) (interface{}, error) {
 		return NewOperationState(fmt.Sprintf("%s", value))
 	})
+	marshaller.AddMarshaller("DependsOn", convertInterfaceToJSONString)
+	marshaller.AddUnmarshaller("DependsOn", func(value interface{}) (interface{}, error) {
+		var result []string
+		if value == nil { //operations created before dependencies were introduced
+			return result, nil
+		}
+		err := json.Unmarshal([]byte(fmt.Sprintf("%v", value)), &result)
+		return result, err
+	})
 	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
 	marshaller.AddUnmarshaller("Updated", convertTimestampToTime)
 	return marshaller
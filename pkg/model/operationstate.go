@@ -15,6 +15,8 @@ const (
 	OperationStateError       OperationState = "error"
 	OperationStateFailed      OperationState = "failed"
 	OperationStateOrphan      OperationState = "orphan"
+	OperationStateCancelled   OperationState = "cancelled"
+	OperationStateSkipped     OperationState = "skipped" //failed operation which was skipped by a user
 )
 
 func NewOperationState(state string) (OperationState, error) {
@@ -34,6 +36,10 @@ func NewOperationState(state string) (OperationState, error) {
 		result = OperationStateFailed
 	case string(OperationStateOrphan):
 		result = OperationStateOrphan
+	case string(OperationStateCancelled):
+		result = OperationStateCancelled
+	case string(OperationStateSkipped):
+		result = OperationStateSkipped
 	default:
 		return "", fmt.Errorf("operation state '%s' does not exist", state)
 	}
@@ -45,7 +51,12 @@ func (o OperationState) IsError() bool {
 }
 
 func (o OperationState) IsFinal() bool {
-	return o == OperationStateError || o == OperationStateDone
+	return o == OperationStateError || o == OperationStateDone || o == OperationStateCancelled || o == OperationStateSkipped
+}
+
+//IsCompleted returns true if the operation doesn't block other operations anymore (done or skipped)
+func (o OperationState) IsCompleted() bool {
+	return o == OperationStateDone || o == OperationStateSkipped
 }
 
 func (o OperationState) IsTemporary() bool {
@@ -10,6 +10,7 @@ type OperationType string
 const (
 	OperationTypeReconcile OperationType = "reconcile"
 	OperationTypeDelete    OperationType = "delete"
+	OperationTypePlan      OperationType = "plan"
 )
 
 func NewOperationType(state string) (OperationType, error) {
@@ -19,6 +20,8 @@ func NewOperationType(state string) (OperationType, error) {
 		result = OperationTypeReconcile
 	case string(OperationTypeDelete):
 		result = OperationTypeDelete
+	case string(OperationTypePlan):
+		result = OperationTypePlan
 	default:
 		return "", fmt.Errorf("operation state '%s' does not exist", state)
 	}
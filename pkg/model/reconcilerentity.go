@@ -0,0 +1,78 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblReconcilers string = "scheduler_reconcilers"
+
+//ReconcilerInstanceEntity is a replica of a component reconciler which registered itself at the mothership
+type ReconcilerInstanceEntity struct {
+	InstanceID string    `db:"notNull"`
+	Name       string    `db:"notNull"` //name of the component reconciler (e.g. 'base')
+	URL        string    `db:"notNull"` //URL the tasks are sent to
+	Components []string  `db:""`        //components handled by the instance in addition to the component named like it
+	Version    string    `db:""`
+	Workers    int64     `db:""` //number of workers of the instance (used as weight when tasks are distributed)
+	Heartbeat  int64     `db:""` //unix time in nanoseconds of the latest registration
+	Owner      string    `db:"notNull"` //authenticated identity which registered the instance
+	Created    time.Time `db:"readOnly"`
+}
+
+func (r *ReconcilerInstanceEntity) String() string {
+	return fmt.Sprintf("ReconcilerInstanceEntity [InstanceID=%s,Name=%s,URL=%s,Version=%s,Workers=%d]",
+		r.InstanceID, r.Name, r.URL, r.Version, r.Workers)
+}
+
+//Handles returns true if the instance processes tasks of the component
+func (r *ReconcilerInstanceEntity) Handles(component string) bool {
+	if r.Name == component {
+		return true
+	}
+	for _, handled := range r.Components {
+		if handled == component {
+			return true
+		}
+	}
+	return false
+}
+
+//Healthy returns true if the instance renewed its registration within the timeout
+func (r *ReconcilerInstanceEntity) Healthy(now time.Time, timeout time.Duration) bool {
+	return now.Sub(time.Unix(0, r.Heartbeat)) <= timeout
+}
+
+func (*ReconcilerInstanceEntity) New() db.DatabaseEntity {
+	return &ReconcilerInstanceEntity{}
+}
+
+func (r *ReconcilerInstanceEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&r)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Components", func(value interface{}) (interface{}, error) {
+		var components []string
+		err := json.Unmarshal([]byte(value.(string)), &components)
+		return components, err
+	})
+	marshaller.AddMarshaller("Components", convertInterfaceToJSONString)
+	return marshaller
+}
+
+func (*ReconcilerInstanceEntity) Table() string {
+	return tblReconcilers
+}
+
+func (r *ReconcilerInstanceEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherInstance, ok := other.(*ReconcilerInstanceEntity)
+	if !ok {
+		return false
+	}
+	return r.InstanceID == otherInstance.InstanceID
+}
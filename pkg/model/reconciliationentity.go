@@ -15,10 +15,12 @@ type ReconciliationEntity struct {
 	ClusterConfig       int64     `db:"notNull"`
 	ClusterConfigStatus int64     `db:"notNull"`
 	Finished            bool      `db:"notNull"`
+	Paused              bool      `db:""`
 	SchedulingID        string    `db:"notNull"`
 	Created             time.Time `db:"readOnly"`
 	Updated             time.Time `db:""`
 	Status              Status    `db:"notNull"`
+	Reason              string    `db:""` //set if the reconciliation was triggered on demand
 }
 
 func (r *ReconciliationEntity) String() string {
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblRolloutCluster string = "scheduler_rollout_clusters"
+
+type RolloutStatus string
+
+const (
+	RolloutStatusPending    RolloutStatus = "pending"
+	RolloutStatusSuccess    RolloutStatus = "success"
+	RolloutStatusFailed     RolloutStatus = "failed"
+	RolloutStatusSuperseded RolloutStatus = "superseded" //cluster received a newer configuration before the rollout finished
+)
+
+//RolloutClusterEntity tracks a cluster which was admitted to the rollout of a Kyma version
+type RolloutClusterEntity struct {
+	KymaVersion string        `db:"notNull"`
+	RuntimeID   string        `db:"notNull"`
+	Wave        int64         `db:""` //0 = canary (not tagged with notNull as 0 is a valid value)
+	Status      RolloutStatus `db:"notNull"`
+	Created     time.Time     `db:"readOnly"`
+	Updated     time.Time     `db:""`
+}
+
+func (r *RolloutClusterEntity) String() string {
+	return fmt.Sprintf("RolloutClusterEntity [KymaVersion=%s,RuntimeID=%s,Wave=%d,Status=%s]",
+		r.KymaVersion, r.RuntimeID, r.Wave, r.Status)
+}
+
+func (*RolloutClusterEntity) New() db.DatabaseEntity {
+	return &RolloutClusterEntity{}
+}
+
+func (r *RolloutClusterEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&r)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Updated", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Status", func(value interface{}) (interface{}, error) {
+		if reflect.TypeOf(value).Kind() == reflect.String {
+			return RolloutStatus(fmt.Sprintf("%v", value)), nil
+		}
+		return nil, fmt.Errorf("failed to convert value '%s' (kind: %s) for field 'Status' to RolloutStatus type",
+			value, reflect.TypeOf(value).Kind())
+	})
+	return marshaller
+}
+
+func (*RolloutClusterEntity) Table() string {
+	return tblRolloutCluster
+}
+
+func (r *RolloutClusterEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherRollout, ok := other.(*RolloutClusterEntity)
+	if !ok {
+		return false
+	}
+	return r.KymaVersion == otherRollout.KymaVersion && r.RuntimeID == otherRollout.RuntimeID
+}
+
+const tblRollout string = "scheduler_rollouts"
+
+type RolloutState string
+
+const (
+	RolloutStateActive RolloutState = "active"
+	//set by the policy if canaries failed or the error rate was exceeded: no further clusters are admitted until
+	//the rollout is resumed
+	RolloutStateHalted RolloutState = "halted"
+	//set by an operator: the rollout stays stopped even if the failed clusters recover and only an explicit
+	//resume of the operator re-activates it
+	RolloutStateAborted RolloutState = "aborted"
+)
+
+//RolloutEntity stores the state of the rollout of a Kyma version if it was halted, aborted or resumed
+type RolloutEntity struct {
+	KymaVersion string       `db:"notNull"`
+	State       RolloutState `db:"notNull"`
+	Reason      string       `db:""`
+	Updated     time.Time    `db:""` //point in time of the latest state change
+}
+
+func (r *RolloutEntity) String() string {
+	return fmt.Sprintf("RolloutEntity [KymaVersion=%s,State=%s,Reason=%s]", r.KymaVersion, r.State, r.Reason)
+}
+
+func (*RolloutEntity) New() db.DatabaseEntity {
+	return &RolloutEntity{}
+}
+
+func (r *RolloutEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&r)
+	marshaller.AddUnmarshaller("Updated", convertTimestampToTime)
+	marshaller.AddUnmarshaller("State", func(value interface{}) (interface{}, error) {
+		if reflect.TypeOf(value).Kind() == reflect.String {
+			return RolloutState(fmt.Sprintf("%v", value)), nil
+		}
+		return nil, fmt.Errorf("failed to convert value '%s' (kind: %s) for field 'State' to RolloutState type",
+			value, reflect.TypeOf(value).Kind())
+	})
+	return marshaller
+}
+
+func (*RolloutEntity) Table() string {
+	return tblRollout
+}
+
+func (r *RolloutEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherRollout, ok := other.(*RolloutEntity)
+	if !ok {
+		return false
+	}
+	return r.KymaVersion == otherRollout.KymaVersion && r.State == otherRollout.State && r.Reason == otherRollout.Reason
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblTasks string = "scheduler_tasks"
+
+//TaskEntity is a task in the queue of a component reconciler which pulls its tasks from the mothership
+type TaskEntity struct {
+	CorrelationID string    `db:"notNull"`
+	SchedulingID  string    `db:"notNull"`
+	Reconciler    string    `db:"notNull"`         //name of the component reconciler which processes the task
+	Payload       string    `db:"notNull,encrypt"` //JSON encoded task (contains the kubeconfig of the cluster)
+	LeaseID       string    `db:""`                //ID of the latest lease (empty if the task was never leased)
+	Attempts      int64     `db:""`                //number of times the task was leased
+	VisibleAt     int64     `db:""`                //unix time in nanoseconds: the task can be leased after this point in time
+	DeadLetter    bool      `db:"notNull"`         //true if the task exceeded the max attempts (it's no longer delivered)
+	Created       time.Time `db:"readOnly"`
+}
+
+func (t *TaskEntity) String() string {
+	return fmt.Sprintf("TaskEntity [CorrelationID=%s,SchedulingID=%s,Reconciler=%s,Attempts=%d,DeadLetter=%t]",
+		t.CorrelationID, t.SchedulingID, t.Reconciler, t.Attempts, t.DeadLetter)
+}
+
+func (*TaskEntity) New() db.DatabaseEntity {
+	return &TaskEntity{}
+}
+
+func (t *TaskEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&t)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*TaskEntity) Table() string {
+	return tblTasks
+}
+
+func (t *TaskEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherTask, ok := other.(*TaskEntity)
+	if !ok {
+		return false
+	}
+	return t.CorrelationID == otherTask.CorrelationID
+}
@@ -7,7 +7,10 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/db"
 )
 
-const tblValues string = "config_values"
+const (
+	tblValues   string = "config_values"
+	MaskedValue string = "********"
+)
 
 type ValueEntity struct {
 	Key        string    `db:"notNull"`
@@ -18,11 +21,12 @@ type ValueEntity struct {
 	DataType   DataType  `db:"notNull"`
 	Created    time.Time `db:"readOnly"`
 	Username   string    `db:"notNull"`
+	Encrypted  bool      //value is stored encrypted (the key of the value requires encryption)
 }
 
 func (ve *ValueEntity) String() string {
-	return fmt.Sprintf("ValueEntity [Key=%s,KeyVersion=%d,Value=%s,Version=%d,Bucket=%s,DataType=%s,User=%s]",
-		ve.Key, ve.KeyVersion, ve.Value, ve.Version, ve.Bucket, ve.DataType, ve.Username)
+	return fmt.Sprintf("ValueEntity [Key=%s,KeyVersion=%d,Value=%s,Version=%d,Bucket=%s,DataType=%s,User=%s,Encrypted=%t]",
+		ve.Key, ve.KeyVersion, ve.Masked(), ve.Version, ve.Bucket, ve.DataType, ve.Username, ve.Encrypted)
 }
 
 func (ve *ValueEntity) New() db.DatabaseEntity {
@@ -55,6 +59,14 @@ func (ve *ValueEntity) Equal(other db.DatabaseEntity) bool {
 	return false
 }
 
+//Masked returns the value or a placeholder if the value is encrypted
+func (ve *ValueEntity) Masked() string {
+	if ve.Encrypted {
+		return MaskedValue
+	}
+	return ve.Value
+}
+
 func (ve *ValueEntity) Get() (interface{}, error) {
 	return ve.DataType.Get(ve.Value)
 }
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const (
+	tblWebhooks          string = "scheduler_webhooks"
+	tblWebhookDeliveries string = "scheduler_webhook_deliveries"
+)
+
+type WebhookDeliveryState string
+
+const (
+	WebhookDeliveryStatePending   WebhookDeliveryState = "pending"
+	WebhookDeliveryStateDelivered WebhookDeliveryState = "delivered"
+	WebhookDeliveryStateDead      WebhookDeliveryState = "dead" //all delivery attempts failed
+)
+
+//WebhookEntity is an endpoint of a subscriber which gets notified about status changes of clusters
+type WebhookEntity struct {
+	ID              string              `db:"notNull"`
+	URL             string              `db:"notNull"`
+	Secret          string              `db:"notNull,encrypt"` //key used to sign the events (HMAC-SHA256)
+	RuntimeIDFilter []string            `db:""`                //empty = events of all clusters
+	MetadataFilter  map[string][]string `db:""`                //cluster metadata fields and the accepted values
+	StatusFilter    []Status            `db:""`                //empty = events of all statuses
+	LastStatusID    int64               `db:""`                //ID of the last cluster status which was checked for this webhook
+	Created         time.Time           `db:"readOnly"`
+}
+
+func (w *WebhookEntity) String() string {
+	return fmt.Sprintf("WebhookEntity [ID=%s,URL=%s,LastStatusID=%d]", w.ID, w.URL, w.LastStatusID)
+}
+
+func (*WebhookEntity) New() db.DatabaseEntity {
+	return &WebhookEntity{}
+}
+
+func (w *WebhookEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&w)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("RuntimeIDFilter", func(value interface{}) (interface{}, error) {
+		var runtimeIDs []string
+		err := json.Unmarshal([]byte(value.(string)), &runtimeIDs)
+		return runtimeIDs, err
+	})
+	marshaller.AddMarshaller("RuntimeIDFilter", convertInterfaceToJSONString)
+	marshaller.AddUnmarshaller("MetadataFilter", func(value interface{}) (interface{}, error) {
+		var metadata map[string][]string
+		err := json.Unmarshal([]byte(value.(string)), &metadata)
+		return metadata, err
+	})
+	marshaller.AddMarshaller("MetadataFilter", convertInterfaceToJSONString)
+	marshaller.AddUnmarshaller("StatusFilter", func(value interface{}) (interface{}, error) {
+		var statuses []Status
+		err := json.Unmarshal([]byte(value.(string)), &statuses)
+		return statuses, err
+	})
+	marshaller.AddMarshaller("StatusFilter", convertInterfaceToJSONString)
+	return marshaller
+}
+
+func (*WebhookEntity) Table() string {
+	return tblWebhooks
+}
+
+func (w *WebhookEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherWebhook, ok := other.(*WebhookEntity)
+	if !ok {
+		return false
+	}
+	return w.ID == otherWebhook.ID
+}
+
+//WebhookDeliveryEntity is an event which has to be sent to a webhook
+type WebhookDeliveryEntity struct {
+	ID          string               `db:"notNull"`
+	WebhookID   string               `db:"notNull"`
+	StatusID    int64                `db:"notNull"` //ID of the cluster status which caused the event
+	RuntimeID   string               `db:"notNull"`
+	Payload     string               `db:"notNull"` //JSON encoded event (signed as it is sent)
+	State       WebhookDeliveryState `db:"notNull"`
+	Attempts    int64                `db:""`
+	NextAttempt time.Time            `db:""`
+	LastError   string               `db:""`
+	Created     time.Time            `db:"readOnly"`
+	Updated     time.Time            `db:""`
+}
+
+func (d *WebhookDeliveryEntity) String() string {
+	return fmt.Sprintf("WebhookDeliveryEntity [ID=%s,WebhookID=%s,StatusID=%d,State=%s,Attempts=%d]",
+		d.ID, d.WebhookID, d.StatusID, d.State, d.Attempts)
+}
+
+func (*WebhookDeliveryEntity) New() db.DatabaseEntity {
+	return &WebhookDeliveryEntity{}
+}
+
+func (d *WebhookDeliveryEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&d)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Updated", convertTimestampToTime)
+	marshaller.AddUnmarshaller("NextAttempt", convertTimestampToTime)
+	marshaller.AddUnmarshaller("State", func(value interface{}) (interface{}, error) {
+		if reflect.TypeOf(value).Kind() == reflect.String {
+			return WebhookDeliveryState(fmt.Sprintf("%v", value)), nil
+		}
+		return nil, fmt.Errorf("failed to convert value '%s' (kind: %s) for field 'State' to WebhookDeliveryState type",
+			value, reflect.TypeOf(value).Kind())
+	})
+	return marshaller
+}
+
+func (*WebhookDeliveryEntity) Table() string {
+	return tblWebhookDeliveries
+}
+
+func (d *WebhookDeliveryEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherDelivery, ok := other.(*WebhookDeliveryEntity)
+	if !ok {
+		return false
+	}
+	return d.ID == otherDelivery.ID
+}
@@ -1,9 +1,47 @@
 package callback
 
 import (
+	"context"
+	"errors"
+
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 )
 
 type Handler interface {
 	Callback(msg *reconciler.CallbackMessage) error
 }
+
+//OperationCancelledError is returned by a callback handler if the operation was cancelled by the mothership
+type OperationCancelledError struct {
+}
+
+func (err *OperationCancelledError) Error() string {
+	return "operation was cancelled by the mothership reconciler"
+}
+
+func IsOperationCancelledError(err error) bool {
+	var cancelledErr *OperationCancelledError
+	return errors.As(err, &cancelledErr)
+}
+
+type cancellingHandler struct {
+	handler Handler
+	cancel  context.CancelFunc
+}
+
+//NewCancellingHandler wraps a callback handler and calls the cancel function as soon as
+//the mothership reports that the operation was cancelled.
+func NewCancellingHandler(handler Handler, cancel context.CancelFunc) Handler {
+	return &cancellingHandler{
+		handler: handler,
+		cancel:  cancel,
+	}
+}
+
+func (cb *cancellingHandler) Callback(msg *reconciler.CallbackMessage) error {
+	err := cb.handler.Callback(msg)
+	if IsOperationCancelledError(err) {
+		cb.cancel()
+	}
+	return err
+}
@@ -1,12 +1,14 @@
 package callback
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	log "github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/test"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,7 +18,7 @@ func TestRemoteCallbackHandler(t *testing.T) {
 	logger := log.NewLogger(true)
 
 	t.Run("Test successful remote status update", func(t *testing.T) {
-		rcb, err := NewRemoteCallbackHandler("https://httpbin.org/status/200", logger)
+		rcb, err := NewRemoteCallbackHandler("https://httpbin.org/status/200", "", "secret", logger)
 		require.NoError(t, err)
 		require.NoError(t, rcb.Callback(&reconciler.CallbackMessage{
 			Status: reconciler.StatusRunning,
@@ -24,7 +26,7 @@ func TestRemoteCallbackHandler(t *testing.T) {
 	})
 
 	t.Run("Test failed remote status update", func(t *testing.T) {
-		rcb, err := NewRemoteCallbackHandler("https://httpbin.org/status/400", logger)
+		rcb, err := NewRemoteCallbackHandler("https://httpbin.org/status/400", "", "secret", logger)
 		require.NoError(t, err)
 		require.Error(t, rcb.Callback(&reconciler.CallbackMessage{
 			Status: reconciler.StatusRunning,
@@ -58,3 +60,29 @@ func TestLocalCallbackHandler(t *testing.T) {
 		}))
 	})
 }
+
+func TestCancellingCallbackHandler(t *testing.T) {
+	logger := log.NewLogger(true)
+
+	newHandler := func(cbErr error) (Handler, context.Context) {
+		ctx, cancel := context.WithCancel(context.Background())
+		localCbh, err := NewLocalCallbackHandler(func(msg *reconciler.CallbackMessage) error {
+			return cbErr
+		}, logger)
+		require.NoError(t, err)
+		return NewCancellingHandler(localCbh, cancel), ctx
+	}
+
+	t.Run("Test context stays open", func(t *testing.T) {
+		cbh, ctx := newHandler(fmt.Errorf("I failed"))
+		require.Error(t, cbh.Callback(&reconciler.CallbackMessage{Status: reconciler.StatusRunning}))
+		require.NoError(t, ctx.Err())
+	})
+
+	t.Run("Test context is cancelled", func(t *testing.T) {
+		cbh, ctx := newHandler(errors.Wrap(&OperationCancelledError{}, "update failed"))
+		err := cbh.Callback(&reconciler.CallbackMessage{Status: reconciler.StatusRunning})
+		require.True(t, IsOperationCancelledError(err))
+		require.Equal(t, context.Canceled, ctx.Err())
+	})
+}
@@ -9,26 +9,38 @@ import (
 	"net/url"
 
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
 type RemoteCallbackHandler struct {
-	logger      *zap.SugaredLogger
-	callbackURL string
+	logger        *zap.SugaredLogger
+	callbackURL   string
+	correlationID string
+	secret        string
 }
 
-func NewRemoteCallbackHandler(callbackURL string, logger *zap.SugaredLogger) (Handler, error) {
+//NewRemoteCallbackHandler creates a callback handler which sends the callbacks of an operation to the mothership.
+//Callbacks are signed with the secret issued by the mothership for the operation: the mothership rejects unsigned
+//callbacks and a handler without secret cannot be created.
+func NewRemoteCallbackHandler(callbackURL, correlationID, secret string, logger *zap.SugaredLogger) (Handler, error) {
 	//validate URL
 	if callbackURL != "" { //empty URLs are allowed (used in some test cases)
 		if _, err := url.ParseRequestURI(callbackURL); err != nil {
 			return nil, err
 		}
+		if secret == "" {
+			return nil, fmt.Errorf("callback secret of operation '%s' is missing: "+
+				"callbacks to '%s' would be rejected", correlationID, callbackURL)
+		}
 	}
 
 	//return new remote callback
 	return &RemoteCallbackHandler{
-		logger:      logger,
-		callbackURL: callbackURL,
+		logger:        logger,
+		callbackURL:   callbackURL,
+		correlationID: correlationID,
+		secret:        secret,
 	}, nil
 }
 
@@ -43,7 +55,16 @@ func (cb *RemoteCallbackHandler) Callback(msg *reconciler.CallbackMessage) error
 		return err
 	}
 
-	resp, err := http.Post(cb.callbackURL, "application/json", bytes.NewBuffer(requestBody))
+	req, err := http.NewRequest(http.MethodPost, cb.callbackURL, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := SignRequest(req, cb.secret, cb.correlationID, requestBody); err != nil {
+		return errors.Wrap(err, "failed to sign callback")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		cb.logger.Errorf("Remote callback handler failed to send HTTP request: %s", err)
 		return err
@@ -56,6 +77,11 @@ func (cb *RemoteCallbackHandler) Callback(msg *reconciler.CallbackMessage) error
 		cb.logger.Debugf("Remote callback handler failed to generate HTTP response dump: %s", dumpErr)
 	}
 
+	if resp.StatusCode == http.StatusGone {
+		cb.logger.Infof("Remote callback handler was informed that the operation was cancelled: %s", msg)
+		return &OperationCancelledError{}
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		msg := fmt.Sprintf("Remote callack handler failed to send request [HTTP response code: %d]: %s",
 			resp.StatusCode, msg)
@@ -0,0 +1,168 @@
+package callback
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	SignatureHeader = "X-Reconciler-Callback-Signature"
+	TimestampHeader = "X-Reconciler-Callback-Timestamp"
+	NonceHeader     = "X-Reconciler-Callback-Nonce"
+	signaturePrefix = "sha256="
+	secretLength    = 32
+	nonceLength     = 16
+	//DefaultSignatureTolerance is the max. accepted age of a signed callback
+	DefaultSignatureTolerance = 5 * time.Minute
+)
+
+//NewSecret generates a random secret which the component reconciler uses to sign the callbacks of an operation
+func NewSecret() (string, error) {
+	return randomHex(secretLength)
+}
+
+//Sign returns the signature of a callback (HMAC-SHA256). The signature is bound to the correlation ID of the operation
+//and includes timestamp and nonce of the request to detect replayed callbacks.
+func Sign(secret, correlationID, timestamp, nonce string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%s\n%s\n%s\n", correlationID, timestamp, nonce)))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+//SignRequest adds the timestamp, nonce and signature headers to a callback request
+func SignRequest(req *http.Request, secret, correlationID string, payload []byte) error {
+	nonce, err := randomHex(nonceLength)
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(NonceHeader, nonce)
+	req.Header.Set(SignatureHeader, Sign(secret, correlationID, timestamp, nonce, payload))
+	return nil
+}
+
+//NonceStore remembers the nonces of accepted callbacks
+type NonceStore interface {
+	//Use stores the nonce of an operation and returns false if it was already used. The nonce is kept until it expires.
+	Use(correlationID, nonce string, expires time.Time) (bool, error)
+}
+
+//Verifier checks the signatures of callbacks and rejects callbacks which were already received.
+//Used nonces are remembered as long as the timestamp of their callback is accepted.
+type Verifier struct {
+	tolerance time.Duration
+	nonces    NonceStore
+	clock     func() time.Time
+}
+
+func NewVerifier(tolerance time.Duration) *Verifier {
+	if tolerance <= 0 {
+		tolerance = DefaultSignatureTolerance
+	}
+	return &Verifier{
+		tolerance: tolerance,
+		nonces:    newInMemoryNonceStore(),
+		clock:     time.Now,
+	}
+}
+
+//WithNonceStore replaces the in-memory nonce store. A shared store is required if callbacks are received
+//by multiple mothership replicas, otherwise a callback could be replayed against another replica.
+func (v *Verifier) WithNonceStore(store NonceStore) *Verifier {
+	v.nonces = store
+	return v
+}
+
+//Verify returns an error if the callback request is unsigned, outdated, replayed or not signed with the secret
+//issued for the operation
+func (v *Verifier) Verify(req *http.Request, secret, correlationID string, payload []byte) error {
+	if secret == "" {
+		return fmt.Errorf("no callback secret was issued for operation with correlationID '%s'", correlationID)
+	}
+	signature := req.Header.Get(SignatureHeader)
+	timestamp := req.Header.Get(TimestampHeader)
+	nonce := req.Header.Get(NonceHeader)
+	if signature == "" || timestamp == "" || nonce == "" {
+		return fmt.Errorf("callback is not signed: headers '%s', '%s' and '%s' are required",
+			SignatureHeader, TimestampHeader, NonceHeader)
+	}
+
+	unixTime, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("timestamp '%s' of callback is invalid", timestamp))
+	}
+	signedAt := time.Unix(unixTime, 0)
+	now := v.clock()
+	if now.Sub(signedAt) > v.tolerance || signedAt.Sub(now) > v.tolerance {
+		return fmt.Errorf("timestamp '%s' of callback is outside the accepted tolerance of %.0f secs",
+			timestamp, v.tolerance.Seconds())
+	}
+
+	if !hmac.Equal([]byte(Sign(secret, correlationID, timestamp, nonce, payload)), []byte(signature)) {
+		return fmt.Errorf("signature of callback does not match")
+	}
+
+	//nonce is only stored for valid signatures: otherwise, unsigned requests could block nonces
+	unused, err := v.nonces.Use(correlationID, nonce, signedAt.Add(v.tolerance))
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to check nonce '%s' of callback", nonce))
+	}
+	if !unused {
+		return fmt.Errorf("callback with nonce '%s' was already received (replayed request)", nonce)
+	}
+	return nil
+}
+
+//inMemoryNonceStore is the default nonce store which is sufficient if only a single instance receives callbacks
+type inMemoryNonceStore struct {
+	nonces map[string]time.Time //key is correlation ID + nonce, value is the expiry of the nonce
+	clock  func() time.Time
+	mu     sync.Mutex
+}
+
+func newInMemoryNonceStore() *inMemoryNonceStore {
+	return &inMemoryNonceStore{
+		nonces: make(map[string]time.Time),
+		clock:  time.Now,
+	}
+}
+
+func (s *inMemoryNonceStore) Use(correlationID, nonce string, expires time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.purge(s.clock())
+	key := correlationID + "/" + nonce
+	if _, ok := s.nonces[key]; ok {
+		return false, nil
+	}
+	s.nonces[key] = expires
+	return true, nil
+}
+
+//purge drops nonces whose callbacks would be rejected anyway because of their timestamp
+func (s *inMemoryNonceStore) purge(now time.Time) {
+	for key, expiry := range s.nonces {
+		if now.After(expiry) {
+			delete(s.nonces, key)
+		}
+	}
+}
+
+func randomHex(length int) (string, error) {
+	data := make([]byte, length)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
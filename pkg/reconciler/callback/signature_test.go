@@ -0,0 +1,139 @@
+package callback
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSecret        = "secret"
+	testCorrelationID = "correlationID"
+)
+
+func newSignedRequest(t *testing.T, secret, correlationID string, payload []byte) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/operations/schedulingID/callback/correlationID", bytes.NewBuffer(payload))
+	require.NoError(t, SignRequest(req, secret, correlationID, payload))
+	return req
+}
+
+func TestVerifier(t *testing.T) {
+	payload := []byte(`{"status":"success"}`)
+
+	t.Run("Valid signature", func(t *testing.T) {
+		req := newSignedRequest(t, testSecret, testCorrelationID, payload)
+		require.NoError(t, NewVerifier(0).Verify(req, testSecret, testCorrelationID, payload))
+	})
+
+	t.Run("Unsigned request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBuffer(payload))
+		require.Error(t, NewVerifier(0).Verify(req, testSecret, testCorrelationID, payload))
+	})
+
+	t.Run("Operation without secret", func(t *testing.T) {
+		req := newSignedRequest(t, "", testCorrelationID, payload)
+		require.Error(t, NewVerifier(0).Verify(req, "", testCorrelationID, payload))
+	})
+
+	t.Run("Mismatching signature", func(t *testing.T) {
+		verifier := NewVerifier(0)
+
+		//wrong secret
+		req := newSignedRequest(t, "other secret", testCorrelationID, payload)
+		require.Error(t, verifier.Verify(req, testSecret, testCorrelationID, payload))
+
+		//signature of another operation
+		req = newSignedRequest(t, testSecret, "other correlationID", payload)
+		require.Error(t, verifier.Verify(req, testSecret, testCorrelationID, payload))
+
+		//modified payload
+		req = newSignedRequest(t, testSecret, testCorrelationID, payload)
+		require.Error(t, verifier.Verify(req, testSecret, testCorrelationID, []byte(`{"status":"error"}`)))
+
+		//modified timestamp
+		req = newSignedRequest(t, testSecret, testCorrelationID, payload)
+		req.Header.Set(TimestampHeader, strconv.FormatInt(time.Now().Add(time.Second).Unix(), 10))
+		require.Error(t, verifier.Verify(req, testSecret, testCorrelationID, payload))
+	})
+
+	t.Run("Replayed request", func(t *testing.T) {
+		verifier := NewVerifier(0)
+		req := newSignedRequest(t, testSecret, testCorrelationID, payload)
+		require.NoError(t, verifier.Verify(req, testSecret, testCorrelationID, payload))
+		require.Error(t, verifier.Verify(req, testSecret, testCorrelationID, payload))
+	})
+
+	t.Run("Outdated request", func(t *testing.T) {
+		verifier := NewVerifier(time.Minute)
+		req := newSignedRequest(t, testSecret, testCorrelationID, payload)
+		verifier.clock = func() time.Time {
+			return time.Now().Add(2 * time.Minute)
+		}
+		require.Error(t, verifier.Verify(req, testSecret, testCorrelationID, payload))
+	})
+
+	t.Run("Expired nonces are purged", func(t *testing.T) {
+		verifier := NewVerifier(time.Minute)
+		req := newSignedRequest(t, testSecret, testCorrelationID, payload)
+		require.NoError(t, verifier.Verify(req, testSecret, testCorrelationID, payload))
+		store := verifier.nonces.(*inMemoryNonceStore)
+		require.Len(t, store.nonces, 1)
+
+		store.purge(time.Now().Add(2 * time.Minute))
+		require.Empty(t, store.nonces)
+	})
+
+	t.Run("Replayed request with shared nonce store", func(t *testing.T) {
+		store := newInMemoryNonceStore()
+		replica1 := NewVerifier(0).WithNonceStore(store)
+		replica2 := NewVerifier(0).WithNonceStore(store)
+		req := newSignedRequest(t, testSecret, testCorrelationID, payload)
+		require.NoError(t, replica1.Verify(req, testSecret, testCorrelationID, payload))
+		require.Error(t, replica2.Verify(req, testSecret, testCorrelationID, payload))
+	})
+}
+
+func TestSignedRemoteCallbackHandler(t *testing.T) {
+	verifier := NewVerifier(0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		if err := verifier.Verify(r, testSecret, testCorrelationID, payload); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	t.Run("Signed callback is accepted", func(t *testing.T) {
+		rcb, err := NewRemoteCallbackHandler(srv.URL, testCorrelationID, testSecret, log.NewLogger(true))
+		require.NoError(t, err)
+		require.NoError(t, rcb.Callback(&reconciler.CallbackMessage{Status: reconciler.StatusRunning}))
+		//each callback (e.g. heartbeats) is signed with a new nonce
+		require.NoError(t, rcb.Callback(&reconciler.CallbackMessage{Status: reconciler.StatusRunning}))
+	})
+
+	t.Run("Callback with wrong secret is rejected", func(t *testing.T) {
+		rcb, err := NewRemoteCallbackHandler(srv.URL, testCorrelationID, "other secret", log.NewLogger(true))
+		require.NoError(t, err)
+		require.Error(t, rcb.Callback(&reconciler.CallbackMessage{Status: reconciler.StatusRunning}))
+	})
+
+	t.Run("Handler without secret cannot be created", func(t *testing.T) {
+		_, err := NewRemoteCallbackHandler(srv.URL, testCorrelationID, "", log.NewLogger(true))
+		require.Error(t, err)
+
+		//handlers without callback URL don't send callbacks and don't require a secret
+		_, err = NewRemoteCallbackHandler("", testCorrelationID, "", log.NewLogger(true))
+		require.NoError(t, err)
+	})
+}
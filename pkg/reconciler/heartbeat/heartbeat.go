@@ -81,7 +81,7 @@ func (su *Sender) isContextClosed() bool {
 	return su.ctxClosed
 }
 
-func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bool) {
+func (su *Sender) sendUpdate(status reconciler.Status, reason error, rollback *reconciler.Rollback, onlyOnce bool) {
 	su.stopJob() //ensure previous interval-loop is stopped before starting a new loop
 
 	task := func(status reconciler.Status, rootCause error) error {
@@ -93,6 +93,7 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 				}
 				return ""
 			}(rootCause),
+			Rollback: rollback,
 		})
 		if err == nil {
 			su.logger.Debugf("Heartbeat communicated status '%s' successfully to mothership-reconciler", status)
@@ -130,7 +131,8 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 				}
 
 				//try to send status before interval starts (to avoid waiting period until first interval tick is reached)
-				if err := task(reconcilerStatus, su.ctx.Err()); err == nil {
+				//(no retries are needed if the mothership cancelled the operation)
+				if err := task(reconcilerStatus, su.ctx.Err()); err == nil || cb.IsOperationCancelledError(err) {
 					return
 				}
 
@@ -140,7 +142,7 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 				for {
 					select {
 					case <-ticker.C:
-						if err := task(reconcilerStatus, su.ctx.Err()); err == nil {
+						if err := task(reconcilerStatus, su.ctx.Err()); err == nil || cb.IsOperationCancelledError(err) {
 							return
 						}
 					case <-giveUp.C:
@@ -180,7 +182,7 @@ func (su *Sender) Running() error {
 	if err := su.statusChangeAllowed(reconciler.StatusRunning); err != nil {
 		return err
 	}
-	su.sendUpdate(reconciler.StatusRunning, nil, false) //Running is an interim status: use interval to send heartbeat-request to reconciler-controller
+	su.sendUpdate(reconciler.StatusRunning, nil, nil, false) //Running is an interim status: use interval to send heartbeat-request to reconciler-controller
 	return nil
 }
 
@@ -188,7 +190,7 @@ func (su *Sender) Failed(err error) error {
 	if err := su.statusChangeAllowed(reconciler.StatusFailed); err != nil {
 		return err
 	}
-	su.sendUpdate(reconciler.StatusFailed, err, false) //Failed is an interim status: use interval to send heartbeat-request to reconciler-controller
+	su.sendUpdate(reconciler.StatusFailed, err, nil, false) //Failed is an interim status: use interval to send heartbeat-request to reconciler-controller
 	return nil
 }
 
@@ -196,7 +198,7 @@ func (su *Sender) Success() error {
 	if err := su.statusChangeAllowed(reconciler.StatusSuccess); err != nil {
 		return err
 	}
-	su.sendUpdate(reconciler.StatusSuccess, nil, true) //Success is a final status: use retry because heartbeat-requests are no longer needed
+	su.sendUpdate(reconciler.StatusSuccess, nil, nil, true) //Success is a final status: use retry because heartbeat-requests are no longer needed
 	return nil
 }
 
@@ -204,7 +206,16 @@ func (su *Sender) Error(err error) error {
 	if err := su.statusChangeAllowed(reconciler.StatusError); err != nil {
 		return err
 	}
-	su.sendUpdate(reconciler.StatusError, err, true) //Error is a final status: use retry because heartbeat-requests are no longer needed
+	su.sendUpdate(reconciler.StatusError, err, nil, true) //Error is a final status: use retry because heartbeat-requests are no longer needed
+	return nil
+}
+
+//ErrorWithRollback reports the final error together with the outcome of the rollback of the component
+func (su *Sender) ErrorWithRollback(err error, rollback *reconciler.Rollback) error {
+	if err := su.statusChangeAllowed(reconciler.StatusError); err != nil {
+		return err
+	}
+	su.sendUpdate(reconciler.StatusError, err, rollback, true)
 	return nil
 }
 
@@ -1,5 +1,11 @@
 package reconciler
 
+import (
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+)
+
 //HTTPErrorResponse is the model used for general error responses
 type HTTPErrorResponse struct {
 	Error string `json:"error"`
@@ -8,3 +14,39 @@ type HTTPErrorResponse struct {
 type HTTPReconciliationResponse struct {
 	//mothership reconciler expects no payload in the reconciliation response at the moment
 }
+
+//HTTPPlanResponse is returned by a component reconciler for tasks of type 'plan'
+type HTTPPlanResponse struct {
+	Changes []*kubernetes.ResourceChange `json:"changes"`
+}
+
+//HTTPTaskLeaseResponse is returned by the mothership reconciler to a component reconciler which leased a task:
+//the lease has to be extended before it expires as long as the task is processed
+type HTTPTaskLeaseResponse struct {
+	LeaseID string    `json:"leaseID"`
+	Expires time.Time `json:"expires"`
+	Task    *Task     `json:"task,omitempty"`
+}
+
+//HTTPReconcilerRegistration is sent by a component reconciler to register itself at the mothership reconciler:
+//the registration has to be renewed periodically (heartbeat), otherwise the instance receives no tasks anymore
+type HTTPReconcilerRegistration struct {
+	InstanceID string   `json:"instanceID"`
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	Components []string `json:"components,omitempty"`
+	Version    string   `json:"version,omitempty"`
+	Workers    int      `json:"workers"`
+}
+
+//HTTPReconcilerInstance is a component reconciler instance which is registered at the mothership reconciler
+type HTTPReconcilerInstance struct {
+	HTTPReconcilerRegistration
+	Heartbeat time.Time `json:"heartbeat"`
+	Healthy   bool      `json:"healthy"`
+}
+
+//HTTPReconcilersResponse lists the component reconciler instances which are registered at the mothership reconciler
+type HTTPReconcilersResponse struct {
+	Reconcilers []*HTTPReconcilerInstance `json:"reconcilers"`
+}
@@ -23,6 +23,8 @@ type Client interface {
 	DeleteResource(kind, name, namespace string) (*Resource, error)
 	Deploy(ctx context.Context, manifest, namespace string, interceptors ...ResourceInterceptor) ([]*Resource, error)
 	Delete(ctx context.Context, manifest, namespace string) ([]*Resource, error)
+	Plan(ctx context.Context, manifest, namespace string, interceptors ...ResourceInterceptor) ([]*ResourceChange, error)
+	Get(kind, name, namespace string) (*unstructured.Unstructured, error)
 	PatchUsingStrategy(kind, name, namespace string, p []byte, strategy types.PatchType) error
 	Clientset() (kubernetes.Interface, error)
 
@@ -128,6 +128,29 @@ func (_m *Client) Deploy(ctx context.Context, manifest string, namespace string,
 	return r0, r1
 }
 
+// Get provides a mock function with given fields: kind, name, namespace
+func (_m *Client) Get(kind string, name string, namespace string) (*unstructured.Unstructured, error) {
+	ret := _m.Called(kind, name, namespace)
+
+	var r0 *unstructured.Unstructured
+	if rf, ok := ret.Get(0).(func(string, string, string) *unstructured.Unstructured); ok {
+		r0 = rf(kind, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*unstructured.Unstructured)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(kind, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDeployment provides a mock function with given fields: ctx, name, namespace
 func (_m *Client) GetDeployment(ctx context.Context, name string, namespace string) (*v1.Deployment, error) {
 	ret := _m.Called(ctx, name, namespace)
@@ -340,6 +363,36 @@ func (_m *Client) ListResource(resource string, lo metav1.ListOptions) (*unstruc
 	return r0, r1
 }
 
+// Plan provides a mock function with given fields: ctx, manifest, namespace, interceptors
+func (_m *Client) Plan(ctx context.Context, manifest string, namespace string, interceptors ...reconcilerkubernetes.ResourceInterceptor) ([]*reconcilerkubernetes.ResourceChange, error) {
+	_va := make([]interface{}, len(interceptors))
+	for _i := range interceptors {
+		_va[_i] = interceptors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, manifest, namespace)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []*reconcilerkubernetes.ResourceChange
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...reconcilerkubernetes.ResourceInterceptor) []*reconcilerkubernetes.ResourceChange); ok {
+		r0 = rf(ctx, manifest, namespace, interceptors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*reconcilerkubernetes.ResourceChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...reconcilerkubernetes.ResourceInterceptor) error); ok {
+		r1 = rf(ctx, manifest, namespace, interceptors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PatchUsingStrategy provides a mock function with given fields: kind, name, namespace, p, strategy
 func (_m *Client) PatchUsingStrategy(kind string, name string, namespace string, p []byte, strategy types.PatchType) error {
 	ret := _m.Called(kind, name, namespace, p, strategy)
@@ -0,0 +1,164 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type ChangeType string
+
+const (
+	ChangeTypeCreate ChangeType = "create"
+	ChangeTypeUpdate ChangeType = "update"
+	ChangeTypePrune  ChangeType = "prune"
+)
+
+//ResourceChange describes how a Kubernetes resource would be modified if a manifest gets applied
+type ResourceChange struct {
+	Kind       string                 `json:"kind"`
+	APIVersion string                 `json:"apiVersion"`
+	Name       string                 `json:"name"`
+	Namespace  string                 `json:"namespace"`
+	Type       ChangeType             `json:"type"`
+	Patch      map[string]interface{} `json:"patch,omitempty"` //fields which will be changed (only set for updates)
+}
+
+func (c *ResourceChange) String() string {
+	return fmt.Sprintf("ResourceChange [Type:%s,Kind:%s,Namespace:%s,Name:%s]", c.Type, c.Kind, c.Namespace, c.Name)
+}
+
+func NewPruneChange(u *unstructured.Unstructured) *ResourceChange {
+	return &ResourceChange{
+		Kind:       u.GetKind(),
+		APIVersion: u.GetAPIVersion(),
+		Name:       u.GetName(),
+		Namespace:  u.GetNamespace(),
+		Type:       ChangeTypePrune,
+	}
+}
+
+func (g *kubeClientAdapter) Get(kind, name, namespace string) (*unstructured.Unstructured, error) {
+	return g.kubeClient.Get(kind, name, namespace)
+}
+
+//Plan compares the resources of a manifest with the live objects in the cluster without applying any changes.
+//Resources which are already in the desired state are not part of the result.
+func (g *kubeClientAdapter) Plan(ctx context.Context, manifest, namespace string, interceptors ...ResourceInterceptor) ([]*ResourceChange, error) {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	unstructs, err := ToUnstructured([]byte(manifest), true)
+	if err != nil {
+		g.logger.Errorf("Failed to process manifest data: %s", err)
+		g.logger.Debugf("Manifest data: %s", manifest)
+		return nil, err
+	}
+
+	unstructs, err = g.addNamespaceUnstruct(unstructs, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := NewResourceList(unstructs)
+	for _, interceptor := range interceptors {
+		if interceptor == nil {
+			continue
+		}
+		if err := interceptor.Intercept(resources, namespace); err != nil {
+			g.logger.Errorf("One of the interceptors returned an error: %s", err)
+			return nil, err
+		}
+	}
+
+	var changes []*ResourceChange
+	err = resources.Visit(func(u *unstructured.Unstructured) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		resNamespace := ResolveNamespace(u, namespace)
+		live, err := g.kubeClient.Get(u.GetKind(), u.GetName(), resNamespace)
+		if err != nil {
+			if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) { //no match: CRD of the resource is not installed yet
+				changes = append(changes, newResourceChange(u, resNamespace, ChangeTypeCreate, nil))
+				return nil
+			}
+			return err
+		}
+		if patch := diffFields(u.Object, live.Object); len(patch) > 0 {
+			changes = append(changes, newResourceChange(u, resNamespace, ChangeTypeUpdate, patch))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g.logger.Debugf("Manifest planned: %d of %d Kubernetes resources would be created or updated",
+		len(changes), resources.Len())
+	return changes, nil
+}
+
+func newResourceChange(u *unstructured.Unstructured, namespace string, changeType ChangeType, patch map[string]interface{}) *ResourceChange {
+	return &ResourceChange{
+		Kind:       u.GetKind(),
+		APIVersion: u.GetAPIVersion(),
+		Name:       u.GetName(),
+		Namespace:  namespace,
+		Type:       changeType,
+		Patch:      patch,
+	}
+}
+
+//diffFields returns all fields of the desired object which differ from the live object.
+//Fields which exist only in the live object (e.g. status or fields defaulted by the API server) are ignored.
+func diffFields(desired, live map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for field, desiredValue := range desired {
+		liveValue, ok := live[field]
+		if !ok {
+			result[field] = desiredValue
+			continue
+		}
+		desiredMap, desiredIsMap := desiredValue.(map[string]interface{})
+		liveMap, liveIsMap := liveValue.(map[string]interface{})
+		if desiredIsMap && liveIsMap {
+			if subDiff := diffFields(desiredMap, liveMap); len(subDiff) > 0 {
+				result[field] = subDiff
+			}
+			continue
+		}
+		if valueDiffers(desiredValue, liveValue) {
+			result[field] = desiredValue //lists and scalar values are replaced completely
+		}
+	}
+	return result
+}
+
+func valueDiffers(desired, live interface{}) bool {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		return !ok || len(diffFields(desiredValue, liveMap)) > 0
+	case []interface{}:
+		liveSlice, ok := live.([]interface{})
+		if !ok || len(desiredValue) != len(liveSlice) {
+			return true
+		}
+		for idx := range desiredValue {
+			if valueDiffers(desiredValue[idx], liveSlice[idx]) {
+				return true
+			}
+		}
+		return false
+	case int64, float64:
+		return fmt.Sprintf("%v", desired) != fmt.Sprintf("%v", live)
+	default:
+		return !reflect.DeepEqual(desired, live)
+	}
+}
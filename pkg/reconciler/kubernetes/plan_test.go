@@ -0,0 +1,78 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFields(t *testing.T) {
+	live := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "test",
+			"resourceVersion": "123", //only existing in live object
+			"labels": map[string]interface{}{
+				"app": "test",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"ports":    []interface{}{int64(80), int64(443)},
+		},
+		"status": map[string]interface{}{
+			"ready": true,
+		},
+	}
+
+	t.Run("Unchanged object", func(t *testing.T) {
+		desired := map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name": "test",
+				"labels": map[string]interface{}{
+					"app": "test",
+				},
+			},
+			"spec": map[string]interface{}{
+				"replicas": float64(1), //numbers are compared by value
+				"ports":    []interface{}{int64(80), int64(443)},
+			},
+		}
+		require.Empty(t, diffFields(desired, live))
+	})
+
+	t.Run("Changed and added fields", func(t *testing.T) {
+		desired := map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name": "test",
+				"labels": map[string]interface{}{
+					"app":     "test",
+					"version": "2.0.0",
+				},
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(2),
+				"ports":    []interface{}{int64(80)},
+			},
+		}
+		require.Equal(t, map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{
+					"version": "2.0.0",
+				},
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(2),
+				"ports":    []interface{}{int64(80)},
+			},
+		}, diffFields(desired, live))
+	})
+
+	t.Run("Changed type of field", func(t *testing.T) {
+		desired := map[string]interface{}{
+			"spec": "invalid",
+		}
+		require.Equal(t, map[string]interface{}{
+			"spec": "invalid",
+		}, diffFields(desired, live))
+	})
+}
@@ -28,9 +28,9 @@ func (c cache) Replace(u *unstructured.Unstructured) {
 }
 
 type Resource struct {
-	Kind      string
-	Name      string
-	Namespace string
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
 }
 
 func (r *Resource) String() string {
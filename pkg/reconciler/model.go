@@ -43,8 +43,9 @@ type Task struct {
 	Metadata        keb.Metadata           `json:"metadata"`
 	CallbackURL     string                 `json:"callbackURL"` //CallbackURL is mandatory when component-reconciler runs in separate process
 	CorrelationID   string                 `json:"correlationID"`
+	CallbackSecret  string                 `json:"callbackSecret"` //CallbackSecret is used to sign the callbacks sent to the CallbackURL
 	Repository      *Repository            `json:"repository"`
-	Type            model.OperationType    `json:"type"` // Supported task types are: reconcile, delete
+	Type            model.OperationType    `json:"type"` // Supported task types are: reconcile, delete, plan
 
 	//These fields are not part of HTTP request coming from reconciler-controller:
 	CallbackFunc func(msg *CallbackMessage) error `json:"-"` //CallbackFunc is mandatory when component-reconciler runs embedded in another process
@@ -71,7 +72,7 @@ func (r *Task) Validate() error {
 		errFields = append(errFields, "Kubeconfig")
 	}
 	r.CallbackURL = strings.TrimSpace(r.CallbackURL)
-	if r.CallbackFunc == nil && r.CallbackURL == "" {
+	if r.CallbackFunc == nil && r.CallbackURL == "" && r.Type != model.OperationTypePlan { //plan results are returned synchronously
 		errFields = append(errFields, "CallbackFunc or CallbackURL")
 	}
 	r.CorrelationID = strings.TrimSpace(r.CorrelationID)
@@ -101,5 +102,20 @@ func (r *Repository) String() string {
 //Stringer implementation for CallbackMessage
 //CallbackMessage struct is generated by Swagger code-gen
 func (cb *CallbackMessage) String() string {
-	return fmt.Sprintf("CallbackMessage [status=%s,error=%s]", cb.Status, cb.Error)
+	return fmt.Sprintf("CallbackMessage [status=%s,error=%s]", cb.Status, cb.Reason())
+}
+
+//Reason returns the error of the callback message including the outcome of a rollback
+func (cb *CallbackMessage) Reason() string {
+	if cb.Rollback == nil {
+		return cb.Error
+	}
+	if cb.Rollback.Succeeded {
+		return fmt.Sprintf("%s (rolled back to version '%s')", cb.Error, cb.Rollback.Version)
+	}
+	rollbackErr := "unknown error"
+	if cb.Rollback.Error != nil {
+		rollbackErr = *cb.Rollback.Error
+	}
+	return fmt.Sprintf("%s (rollback to version '%s' failed: %s)", cb.Error, cb.Rollback.Version, rollbackErr)
 }
@@ -18,8 +18,16 @@ const (
 
 // CallbackMessage defines model for callbackMessage.
 type CallbackMessage struct {
-	Error  string `json:"error"`
-	Status Status `json:"status"`
+	Error    string    `json:"error"`
+	Rollback *Rollback `json:"rollback,omitempty"`
+	Status   Status    `json:"status"`
+}
+
+// Rollback defines model for rollback.
+type Rollback struct {
+	Error     *string `json:"error,omitempty"`
+	Succeeded bool    `json:"succeeded"`
+	Version   string  `json:"version"`
 }
 
 // Status defines model for status.
@@ -10,10 +10,14 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 type Install struct {
-	logger *zap.SugaredLogger
+	logger           *zap.SugaredLogger
+	deployedManifest string //manifest which was successfully deployed by this instance
 }
 
 func NewInstall(logger *zap.SugaredLogger) *Install {
@@ -45,30 +49,204 @@ func (r *Install) Invoke(ctx context.Context, chartProvider chart.Provider, task
 			r.logger.Warnf("Failed to delete manifests on target cluster: %s", err)
 			return err
 		}
+		if err := newInventoryStore(kubeClient, r.logger).Delete(ctx, task.Component); err != nil {
+			return err
+		}
+		if err := newManifestStore(kubeClient, r.logger).Delete(ctx, task.Component); err != nil {
+			return err
+		}
 	} else {
 		if task.Component == model.CleanupComponent {
 			return nil
 		}
-		resources, err := kubeClient.Deploy(ctx, manifest, task.Namespace,
-			&LabelsInterceptor{
-				Version: task.Version,
-			},
-			&AnnotationsInterceptor{},
-			&ServicesInterceptor{
-				kubeClient: kubeClient,
-			},
-			newClusterWideResourceInterceptor(),
-		)
+		resources, err := kubeClient.Deploy(ctx, manifest, task.Namespace, r.interceptors(task, kubeClient)...)
 		if err == nil {
 			r.logger.Debugf("Deployment of manifest finished successfully: %d resources deployed", len(resources))
 		} else {
 			r.logger.Warnf("Failed to deploy manifests on target cluster: %s", err)
 			return err
 		}
+		if err := r.prune(ctx, task, kubeClient, resources); err != nil {
+			r.logger.Warnf("Failed to prune resources which are no longer part of the manifest: %s", err)
+			return err
+		}
+		r.deployedManifest = manifest
 	}
 	return nil
 }
 
+//prune deletes resources which were deployed by a previous reconciliation but are no longer part of the manifest
+func (r *Install) prune(ctx context.Context, task *reconciler.Task, kubeClient kubernetes.Client, deployed []*kubernetes.Resource) error {
+	if task.Component == model.CRDComponent { //CRDs are never removed by the reconciler (deleting them drops all their CRs)
+		r.logger.Debugf("Skipping pruning of component '%s' because CRDs are never pruned", task.Component)
+		return nil
+	}
+	if len(deployed) == 0 { //never wipe out a component because of an empty manifest
+		r.logger.Debugf("Skipping pruning of component '%s' because no resources were deployed", task.Component)
+		return nil
+	}
+	store := newInventoryStore(kubeClient, r.logger)
+	inventory, err := store.Get(ctx, task.Component)
+	if err != nil {
+		return err
+	}
+	if inventory != nil {
+		for _, resource := range staleResources(inventory, deployed) {
+			live, err := kubeClient.Get(resource.Kind, resource.Name, resource.Namespace)
+			if err != nil {
+				if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+					continue
+				}
+				return err
+			}
+			if !ownedByVersion(live, inventory.Version) {
+				r.logger.Debugf("Skipping pruning of resource '%s' because it is not owned by version '%s' of component '%s'",
+					resource, inventory.Version, task.Component)
+				continue
+			}
+			r.logger.Infof("Pruning resource '%s' which was removed from component '%s' (deployed version: %s / new version: %s)",
+				resource, task.Component, inventory.Version, task.Version)
+			if _, err := kubeClient.DeleteResource(resource.Kind, resource.Name, resource.Namespace); err != nil {
+				return err
+			}
+		}
+	}
+	return store.Save(ctx, &ResourceInventory{
+		Component: task.Component,
+		Version:   task.Version,
+		Resources: deployed,
+	})
+}
+
+//Plan renders the manifest of a task and compares it with the live objects in the cluster without applying anything
+func (r *Install) Plan(ctx context.Context, chartProvider chart.Provider, task *reconciler.Task, kubeClient kubernetes.Client) ([]*kubernetes.ResourceChange, error) {
+	var err error
+	var manifest string
+	if task.Component == model.CRDComponent {
+		manifest, err = r.renderCRDs(chartProvider, task)
+	} else if task.Component != model.CleanupComponent {
+		manifest, err = r.renderManifest(chartProvider, task)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.plan(ctx, chartProvider, task, kubeClient, manifest)
+}
+
+func (r *Install) plan(ctx context.Context, chartProvider chart.Provider, task *reconciler.Task, kubeClient kubernetes.Client, manifest string) ([]*kubernetes.ResourceChange, error) {
+	if manifest == "" {
+		return nil, nil
+	}
+	changes, err := kubeClient.Plan(ctx, manifest, task.Namespace, r.interceptors(task, kubeClient)...)
+	if err != nil {
+		return nil, err
+	}
+	if task.Component == model.CRDComponent { //CRDs are never removed by the reconciler
+		return changes, nil
+	}
+	pruneChanges, err := r.planPrune(chartProvider, task, kubeClient, manifest)
+	if err != nil {
+		return nil, err
+	}
+	return append(changes, pruneChanges...), nil
+}
+
+//planPrune detects resources which were deployed by the previous version of a component
+//but are no longer part of its manifest
+func (r *Install) planPrune(chartProvider chart.Provider, task *reconciler.Task, kubeClient kubernetes.Client, manifest string) ([]*kubernetes.ResourceChange, error) {
+	unstructs, err := kubernetes.ToUnstructured([]byte(manifest), true)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory, err := newInventoryStore(kubeClient, r.logger).Get(context.Background(), task.Component)
+	if err != nil {
+		return nil, err
+	}
+	if inventory != nil {
+		return r.planInventoryPrune(task, kubeClient, inventory, unstructs)
+	}
+
+	//no inventory exists (component was deployed by an older reconciler): compare with manifest of deployed version
+	desired := kubernetes.NewResourceList(unstructs)
+	deployedVersion := r.deployedVersion(task, kubeClient, unstructs)
+	if deployedVersion == "" || deployedVersion == task.Version {
+		return nil, nil
+	}
+
+	deployedTask := *task
+	deployedTask.Version = deployedVersion
+	deployedManifest, err := r.renderManifest(chartProvider, &deployedTask)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to render manifest of deployed version '%s'", deployedVersion))
+	}
+	deployedUnstructs, err := kubernetes.ToUnstructured([]byte(deployedManifest), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*kubernetes.ResourceChange
+	for _, u := range deployedUnstructs {
+		namespace := kubernetes.ResolveNamespace(u, task.Namespace)
+		if isCRD(u.GetKind()) || desired.Get(u.GetKind(), u.GetName(), namespace) != nil { //CRDs are never pruned
+			continue
+		}
+		live, err := kubeClient.Get(u.GetKind(), u.GetName(), namespace)
+		if err != nil || live.GetLabels()[KymaVersionLabel] != deployedVersion { //only resources owned by the deployed version
+			continue
+		}
+		changes = append(changes, kubernetes.NewPruneChange(live))
+	}
+	return changes, nil
+}
+
+func (r *Install) planInventoryPrune(task *reconciler.Task, kubeClient kubernetes.Client, inventory *ResourceInventory, unstructs []*unstructured.Unstructured) ([]*kubernetes.ResourceChange, error) {
+	desired := make([]*kubernetes.Resource, 0, len(unstructs))
+	for _, u := range unstructs {
+		desired = append(desired, &kubernetes.Resource{
+			Kind:      u.GetKind(),
+			Name:      u.GetName(),
+			Namespace: kubernetes.ResolveNamespace(u, task.Namespace),
+		})
+	}
+	var changes []*kubernetes.ResourceChange
+	for _, resource := range staleResources(inventory, desired) {
+		live, err := kubeClient.Get(resource.Kind, resource.Name, resource.Namespace)
+		if err != nil || !ownedByVersion(live, inventory.Version) {
+			continue
+		}
+		changes = append(changes, kubernetes.NewPruneChange(live))
+	}
+	return changes, nil
+}
+
+//deployedVersion returns the version label of the first live resource which is part of the manifest
+func (r *Install) deployedVersion(task *reconciler.Task, kubeClient kubernetes.Client, unstructs []*unstructured.Unstructured) string {
+	for _, u := range unstructs {
+		live, err := kubeClient.Get(u.GetKind(), u.GetName(), kubernetes.ResolveNamespace(u, task.Namespace))
+		if err != nil {
+			continue
+		}
+		if version, ok := live.GetLabels()[KymaVersionLabel]; ok && version != "" {
+			return version
+		}
+	}
+	return ""
+}
+
+func (r *Install) interceptors(task *reconciler.Task, kubeClient kubernetes.Client) []kubernetes.ResourceInterceptor {
+	return []kubernetes.ResourceInterceptor{
+		&LabelsInterceptor{
+			Version: task.Version,
+		},
+		&AnnotationsInterceptor{},
+		&ServicesInterceptor{
+			kubeClient: kubeClient,
+		},
+		newClusterWideResourceInterceptor(),
+	}
+}
+
 func (r *Install) renderManifest(chartProvider chart.Provider, model *reconciler.Task) (string, error) {
 	component := chart.NewComponentBuilder(model.Version, model.Component).
 		WithProfile(model.Profile).
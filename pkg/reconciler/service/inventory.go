@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	inventoryNamespace    = "kube-system"
+	inventorySecretPrefix = "reconciler-inventory-"
+	inventoryVersionKey   = "version"
+	inventoryResourcesKey = "resources"
+	inventoryComponentKey = "reconciler.kyma-project.io/component"
+	kindCRD               = "CustomResourceDefinition"
+)
+
+//installOrder defines the order of kinds in which resources have to be created: resources are pruned in reverse order.
+//Kinds which are not listed (e.g. custom resources) are created at the end and pruned first.
+var installOrder = []string{
+	"Namespace",
+	"NetworkPolicy",
+	"ResourceQuota",
+	"LimitRange",
+	"PodSecurityPolicy",
+	"PodDisruptionBudget",
+	"ServiceAccount",
+	"Secret",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	kindCRD,
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicationController",
+	"ReplicaSet",
+	"Deployment",
+	"HorizontalPodAutoscaler",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"Ingress",
+	"APIService",
+	"MutatingWebhookConfiguration",
+	"ValidatingWebhookConfiguration",
+}
+
+//ResourceInventory contains the resources which were deployed for a component in a particular version
+type ResourceInventory struct {
+	Component string
+	Version   string
+	Resources []*kubernetes.Resource
+}
+
+//inventoryStore persists the resource inventory of each component as secret on the target cluster
+type inventoryStore struct {
+	kubeClient kubernetes.Client
+	logger     *zap.SugaredLogger
+}
+
+func newInventoryStore(kubeClient kubernetes.Client, logger *zap.SugaredLogger) *inventoryStore {
+	return &inventoryStore{
+		kubeClient: kubeClient,
+		logger:     logger,
+	}
+}
+
+//Get returns the inventory of a component or nil if no inventory exists
+func (s *inventoryStore) Get(ctx context.Context, component string) (*ResourceInventory, error) {
+	data, err := getComponentSecret(ctx, s.kubeClient, inventorySecretName(component))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to retrieve resource inventory of component '%s'", component))
+	}
+	if data == nil {
+		return nil, nil
+	}
+	inventory := &ResourceInventory{
+		Component: component,
+		Version:   string(data[inventoryVersionKey]),
+	}
+	if err := json.Unmarshal(data[inventoryResourcesKey], &inventory.Resources); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to unmarshal resource inventory of component '%s'", component))
+	}
+	return inventory, nil
+}
+
+//Save creates or updates the inventory of a component
+func (s *inventoryStore) Save(ctx context.Context, inventory *ResourceInventory) error {
+	var resources []*kubernetes.Resource
+	for _, resource := range inventory.Resources {
+		if resource != nil && resource.Kind != "Namespace" { //namespaces can be shared between components and are never pruned
+			resources = append(resources, resource)
+		}
+	}
+	resourcesJSON, err := json.Marshal(resources)
+	if err != nil {
+		return err
+	}
+	err = saveComponentSecret(ctx, s.kubeClient, inventorySecretName(inventory.Component), inventory.Component, map[string][]byte{
+		inventoryVersionKey:   []byte(inventory.Version),
+		inventoryResourcesKey: resourcesJSON,
+	})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to store resource inventory of component '%s'", inventory.Component))
+	}
+	s.logger.Debugf("Resource inventory of component '%s' in version '%s' stored: %d resources",
+		inventory.Component, inventory.Version, len(resources))
+	return nil
+}
+
+//Delete drops the inventory of a component
+func (s *inventoryStore) Delete(ctx context.Context, component string) error {
+	if err := deleteComponentSecret(ctx, s.kubeClient, inventorySecretName(component)); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to delete resource inventory of component '%s'", component))
+	}
+	return nil
+}
+
+func inventorySecretName(component string) string {
+	return inventorySecretPrefix + strings.ToLower(component)
+}
+
+//getComponentSecret returns the data of a secret which stores reconciler state on the target cluster (nil if not found)
+func getComponentSecret(ctx context.Context, kubeClient kubernetes.Client, name string) (map[string][]byte, error) {
+	clientSet, err := kubeClient.Clientset()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := clientSet.CoreV1().Secrets(inventoryNamespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if secret.Data == nil {
+		return map[string][]byte{}, nil
+	}
+	return secret.Data, nil
+}
+
+func saveComponentSecret(ctx context.Context, kubeClient kubernetes.Client, name, component string, data map[string][]byte) error {
+	clientSet, err := kubeClient.Clientset()
+	if err != nil {
+		return err
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: inventoryNamespace,
+			Labels: map[string]string{
+				ManagedByLabel:        LabelReconcilerValue,
+				inventoryComponentKey: component,
+			},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: data,
+	}
+	secrets := clientSet.CoreV1().Secrets(inventoryNamespace)
+	if _, err = secrets.Update(ctx, secret, metav1.UpdateOptions{}); k8serr.IsNotFound(err) {
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+	}
+	return err
+}
+
+func deleteComponentSecret(ctx context.Context, kubeClient kubernetes.Client, name string) error {
+	clientSet, err := kubeClient.Clientset()
+	if err != nil {
+		return err
+	}
+	err = clientSet.CoreV1().Secrets(inventoryNamespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !k8serr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+//staleResources returns the resources of an inventory which are not part of the desired resources anymore.
+//CRDs are never stale: deleting a CRD drops all its CRs, even if the CRD was removed from a regular component chart.
+//The result is sorted in the order the resources have to be pruned.
+func staleResources(inventory *ResourceInventory, desired []*kubernetes.Resource) []*kubernetes.Resource {
+	desiredNamespaces := make(map[string][]string, len(desired))
+	for _, resource := range desired {
+		key := resourceKey(resource)
+		desiredNamespaces[key] = append(desiredNamespaces[key], resource.Namespace)
+	}
+	isDesired := func(resource *kubernetes.Resource) bool {
+		for _, namespace := range desiredNamespaces[resourceKey(resource)] {
+			//an empty namespace indicates a cluster-wide resource
+			if namespace == resource.Namespace || namespace == "" || resource.Namespace == "" {
+				return true
+			}
+		}
+		return false
+	}
+
+	var result []*kubernetes.Resource
+	for _, resource := range inventory.Resources {
+		if !isCRD(resource.Kind) && !isDesired(resource) {
+			result = append(result, resource)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return installRank(result[i].Kind) > installRank(result[j].Kind)
+	})
+	return result
+}
+
+func isCRD(kind string) bool {
+	return strings.EqualFold(kind, kindCRD)
+}
+
+func resourceKey(resource *kubernetes.Resource) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(resource.Kind), resource.Name)
+}
+
+//installRank returns the position of a kind in the install order (unknown kinds are installed last)
+func installRank(kind string) int {
+	for idx, orderedKind := range installOrder {
+		if strings.EqualFold(orderedKind, kind) {
+			return idx
+		}
+	}
+	return len(installOrder)
+}
+
+//ownedByVersion verifies that a live resource was deployed by the reconciler for the given version
+func ownedByVersion(u *unstructured.Unstructured, version string) bool {
+	labels := u.GetLabels()
+	return labels[ManagedByLabel] == LabelReconcilerValue && labels[KymaVersionLabel] == version
+}
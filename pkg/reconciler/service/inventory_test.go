@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/stretchr/testify/require"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestInventoryStore(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Clientset").Return(fake.NewSimpleClientset(), nil)
+	store := newInventoryStore(kubeClient, logger.NewLogger(true))
+
+	inventory, err := store.Get(ctx, "CRDs")
+	require.NoError(t, err)
+	require.Nil(t, inventory)
+
+	//create and update inventory
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		require.NoError(t, store.Save(ctx, &ResourceInventory{
+			Component: "CRDs",
+			Version:   version,
+			Resources: []*kubernetes.Resource{
+				{Kind: "Namespace", Name: "kyma-system"},
+				{Kind: "CustomResourceDefinition", Name: "tests.kyma-project.io"},
+			},
+		}))
+		inventory, err = store.Get(ctx, "CRDs")
+		require.NoError(t, err)
+		require.Equal(t, &ResourceInventory{
+			Component: "CRDs",
+			Version:   version,
+			Resources: []*kubernetes.Resource{ //namespaces are never part of an inventory
+				{Kind: "CustomResourceDefinition", Name: "tests.kyma-project.io"},
+			},
+		}, inventory)
+	}
+
+	require.NoError(t, store.Delete(ctx, "CRDs"))
+	inventory, err = store.Get(ctx, "CRDs")
+	require.NoError(t, err)
+	require.Nil(t, inventory)
+}
+
+func TestStaleResources(t *testing.T) {
+	inventory := &ResourceInventory{
+		Resources: []*kubernetes.Resource{
+			{Kind: "ConfigMap", Name: "cm", Namespace: "kyma-system"},
+			{Kind: "Deployment", Name: "dpl", Namespace: "kyma-system"},
+			{Kind: "ClusterRole", Name: "role"},
+			{Kind: "Foo", Name: "custom", Namespace: "kyma-system"},
+			{Kind: "Service", Name: "svc", Namespace: "kyma-system"},
+			{Kind: "CustomResourceDefinition", Name: "foos.kyma-project.io"},
+		},
+	}
+	desired := []*kubernetes.Resource{
+		{Kind: "Service", Name: "svc", Namespace: "kyma-system"},
+		{Kind: "ClusterRole", Name: "role", Namespace: "kyma-system"}, //namespace of cluster-wide resources is ignored
+	}
+	require.Equal(t, []*kubernetes.Resource{
+		{Kind: "Foo", Name: "custom", Namespace: "kyma-system"},
+		{Kind: "Deployment", Name: "dpl", Namespace: "kyma-system"},
+		{Kind: "ConfigMap", Name: "cm", Namespace: "kyma-system"},
+	}, staleResources(inventory, desired)) //CRDs are never stale
+}
+
+func TestPrune(t *testing.T) {
+	ctx := context.Background()
+	task := &reconciler.Task{Component: "test", Version: "2.0.0"}
+	install := NewInstall(logger.NewLogger(true))
+
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Clientset").Return(fake.NewSimpleClientset(), nil)
+	store := newInventoryStore(kubeClient, logger.NewLogger(true))
+	require.NoError(t, store.Save(ctx, &ResourceInventory{
+		Component: "test",
+		Version:   "1.0.0",
+		Resources: []*kubernetes.Resource{
+			{Kind: "Deployment", Name: "kept", Namespace: "kyma-system"},
+			{Kind: "Deployment", Name: "removed", Namespace: "kyma-system"},
+			{Kind: "ConfigMap", Name: "foreign", Namespace: "kyma-system"},
+			{Kind: "ConfigMap", Name: "gone", Namespace: "kyma-system"},
+		},
+	}))
+
+	kubeClient.On("Get", "Deployment", "removed", "kyma-system").Return(liveResource("1.0.0"), nil)
+	kubeClient.On("Get", "ConfigMap", "foreign", "kyma-system").Return(liveResource("0.9.0"), nil)
+	kubeClient.On("Get", "ConfigMap", "gone", "kyma-system").
+		Return(nil, k8serr.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "gone"))
+	kubeClient.On("DeleteResource", "Deployment", "removed", "kyma-system").Return(nil, nil)
+
+	deployed := []*kubernetes.Resource{
+		{Kind: "Deployment", Name: "kept", Namespace: "kyma-system"},
+		{Kind: "Service", Name: "added", Namespace: "kyma-system"},
+	}
+	require.NoError(t, install.prune(ctx, task, kubeClient, deployed))
+
+	//only the resource owned by the previous version was pruned
+	kubeClient.AssertNumberOfCalls(t, "DeleteResource", 1)
+
+	inventory, err := store.Get(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0", inventory.Version)
+	require.Equal(t, deployed, inventory.Resources)
+}
+
+func TestPruneSkipsCRDs(t *testing.T) {
+	ctx := context.Background()
+	task := &reconciler.Task{Component: model.CRDComponent, Version: "2.0.0"}
+	install := NewInstall(logger.NewLogger(true))
+
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Clientset").Return(fake.NewSimpleClientset(), nil)
+	store := newInventoryStore(kubeClient, logger.NewLogger(true))
+	previous := &ResourceInventory{
+		Component: model.CRDComponent,
+		Version:   "1.0.0",
+		Resources: []*kubernetes.Resource{
+			{Kind: "CustomResourceDefinition", Name: "kept.kyma-project.io"},
+			{Kind: "CustomResourceDefinition", Name: "removed.kyma-project.io"},
+		},
+	}
+	require.NoError(t, store.Save(ctx, previous))
+
+	deployed := []*kubernetes.Resource{
+		{Kind: "CustomResourceDefinition", Name: "kept.kyma-project.io"},
+	}
+	require.NoError(t, install.prune(ctx, task, kubeClient, deployed))
+
+	//the CRD missing in the new manifest was not deleted and the inventory is unchanged
+	kubeClient.AssertNotCalled(t, "Get", "CustomResourceDefinition", "removed.kyma-project.io", "")
+	kubeClient.AssertNotCalled(t, "DeleteResource", "CustomResourceDefinition", "removed.kyma-project.io", "")
+	inventory, err := store.Get(ctx, model.CRDComponent)
+	require.NoError(t, err)
+	require.Equal(t, previous, inventory)
+}
+
+func TestPruneKeepsCRDsOfRegularComponents(t *testing.T) {
+	ctx := context.Background()
+	task := &reconciler.Task{Component: "test", Version: "2.0.0"}
+	install := NewInstall(logger.NewLogger(true))
+
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Clientset").Return(fake.NewSimpleClientset(), nil)
+	store := newInventoryStore(kubeClient, logger.NewLogger(true))
+	require.NoError(t, store.Save(ctx, &ResourceInventory{
+		Component: "test",
+		Version:   "1.0.0",
+		Resources: []*kubernetes.Resource{
+			{Kind: "Deployment", Name: "kept", Namespace: "kyma-system"},
+			{Kind: "CustomResourceDefinition", Name: "removed.kyma-project.io"},
+		},
+	}))
+
+	deployed := []*kubernetes.Resource{
+		{Kind: "Deployment", Name: "kept", Namespace: "kyma-system"},
+	}
+	require.NoError(t, install.prune(ctx, task, kubeClient, deployed))
+
+	//the CRD removed from the chart was not deleted
+	kubeClient.AssertNotCalled(t, "Get", "CustomResourceDefinition", "removed.kyma-project.io", "")
+	kubeClient.AssertNotCalled(t, "DeleteResource", "CustomResourceDefinition", "removed.kyma-project.io", "")
+}
+
+func liveResource(version string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetLabels(map[string]string{
+		ManagedByLabel:   LabelReconcilerValue,
+		KymaVersionLabel: version,
+	})
+	return u
+}
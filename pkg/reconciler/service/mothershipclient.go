@@ -0,0 +1,69 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+//mothershipClient calls the REST API of the mothership reconciler
+type mothershipClient struct {
+	url    string
+	token  string
+	client *http.Client
+	logger *zap.SugaredLogger
+}
+
+func newMothershipClient(mothershipURL string, logger *zap.SugaredLogger) *mothershipClient {
+	return &mothershipClient{
+		url:    strings.TrimSuffix(mothershipURL, "/"),
+		client: &http.Client{Timeout: 30 * time.Second},
+		logger: logger,
+	}
+}
+
+//call sends a request to the path of the mothership: the payload is sent as JSON if it isn't nil
+func (c *mothershipClient) call(ctx context.Context, method, path string, payload interface{}) (*http.Response, []byte, error) {
+	url := c.url + path
+	var reqBody io.Reader
+	if payload != nil {
+		jsonPayload, err := json.Marshal(payload)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to marshal HTTP payload")
+		}
+		reqBody = bytes.NewBuffer(jsonPayload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to call mothership (URL: %s)", url))
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			c.logger.Warnf("Failed to close HTTP response body of mothership: %s", err)
+		}
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read HTTP body")
+	}
+	return resp, body, nil
+}
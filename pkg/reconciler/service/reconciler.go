@@ -10,6 +10,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
 	"go.uber.org/zap"
 )
 
@@ -40,8 +41,9 @@ type ComponentReconciler struct {
 	deleteAction     Action
 	postDeleteAction Action
 	//retry:
-	maxRetries int
-	retryDelay time.Duration
+	maxRetries        int
+	retryDelay        time.Duration
+	rollbackOnFailure bool
 	//worker pool:
 	timeout time.Duration
 	workers int
@@ -188,6 +190,13 @@ func (r *ComponentReconciler) WithRetry(maxRetries int, retryDelay time.Duration
 	return r
 }
 
+//WithRollbackOnFailure redeploys the last successfully reconciled manifest if a reconciliation fails consistently.
+//Only manifests which were deployed by the default install action can be rolled back.
+func (r *ComponentReconciler) WithRollbackOnFailure() *ComponentReconciler {
+	r.rollbackOnFailure = true
+	return r
+}
+
 func (r *ComponentReconciler) WithWorkers(workers int, timeout time.Duration) *ComponentReconciler {
 	r.workers = workers
 	r.timeout = timeout
@@ -255,21 +264,36 @@ func (r *ComponentReconciler) StartLocal(ctx context.Context, model *reconciler.
 	return runnerFunc()
 }
 
+//Plan renders the manifest of a task and returns the changes which would be applied on the cluster
+func (r *ComponentReconciler) Plan(ctx context.Context, model *reconciler.Task, logger *zap.SugaredLogger) ([]*kubernetes.ResourceChange, error) {
+	if err := model.Validate(); err != nil {
+		return nil, err
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return (&runner{r, NewInstall(logger), logger}).plan(timeoutCtx, model)
+}
+
 func (r *ComponentReconciler) StartRemote(ctx context.Context) (*WorkerPool, error) {
 	if err := r.validate(); err != nil {
 		return nil, err
 	}
 	return newWorkerPoolBuilder(r.newRunnerFunc).
+		WithPlanFct(r.Plan).
 		WithPoolSize(r.workers).
 		WithDebug(r.debug).
 		Build(ctx)
 }
 
-func (r *ComponentReconciler) newRunnerFunc(ctx context.Context, model *reconciler.Task, callback callback.Handler, logger *zap.SugaredLogger) func() error {
+func (r *ComponentReconciler) newRunnerFunc(ctx context.Context, model *reconciler.Task, cbh callback.Handler, logger *zap.SugaredLogger) func() error {
 	r.logger.Debugf("Creating new runner closure with execution timeout of %.1f secs", r.timeout.Seconds())
 	return func() error {
 		timeoutCtx, cancel := context.WithTimeout(ctx, r.timeout)
 		defer cancel()
-		return (&runner{r, NewInstall(logger), logger}).Run(timeoutCtx, model, callback)
+		//the runner stops as soon as the mothership reports that the operation was cancelled
+		return (&runner{r, NewInstall(logger), logger}).Run(timeoutCtx, model, callback.NewCancellingHandler(cbh, cancel))
 	}
 }
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"go.uber.org/zap"
+)
+
+const deregistrationTimeout = 5 * time.Second
+
+//Registrar registers the component reconciler at the mothership reconciler and renews the registration periodically
+//(heartbeat): the mothership routes tasks only to instances with a recent heartbeat. The registration is removed
+//when the component reconciler stops.
+type Registrar struct {
+	registration *reconciler.HTTPReconcilerRegistration
+	mothership   *mothershipClient
+	interval     time.Duration
+	logger       *zap.SugaredLogger
+}
+
+//NewRegistrar creates a registrar: an instance ID is generated if the registration doesn't define one
+func NewRegistrar(registration *reconciler.HTTPReconcilerRegistration, mothershipURL string, interval time.Duration, logger *zap.SugaredLogger) *Registrar {
+	if registration.InstanceID == "" {
+		registration.InstanceID = uuid.NewString()
+	}
+	return &Registrar{
+		registration: registration,
+		mothership:   newMothershipClient(mothershipURL, logger),
+		interval:     interval,
+		logger:       logger,
+	}
+}
+
+//WithToken defines the bearer token which is sent to the mothership (required if its authentication is enabled)
+func (r *Registrar) WithToken(token string) *Registrar {
+	r.mothership.token = token
+	return r
+}
+
+//Run renews the registration until the context gets closed and deregisters the instance afterwards
+func (r *Registrar) Run(ctx context.Context) {
+	r.logger.Infof("Registrar registers instance '%s' of component reconciler '%s' (URL: %s) at mothership '%s' "+
+		"each %.1f secs", r.registration.InstanceID, r.registration.Name, r.registration.URL, r.mothership.url,
+		r.interval.Seconds())
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.register(ctx); err != nil {
+			r.logger.Warnf("Registrar failed to register instance '%s' of component reconciler '%s': %s",
+				r.registration.InstanceID, r.registration.Name, err)
+		}
+		select {
+		case <-ctx.Done():
+			r.deregister()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Registrar) register(ctx context.Context) error {
+	resp, body, err := r.mothership.call(ctx, http.MethodPost, "/v1/reconcilers", r.registration)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("mothership responded with HTTP code %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+//deregister removes the registration: if it fails, the mothership stops routing tasks to the instance after
+//the heartbeat timeout
+func (r *Registrar) deregister() {
+	ctx, cancel := context.WithTimeout(context.Background(), deregistrationTimeout)
+	defer cancel()
+	resp, body, err := r.mothership.call(ctx, http.MethodDelete,
+		fmt.Sprintf("/v1/reconcilers/%s", r.registration.InstanceID), nil)
+	if err == nil && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		err = fmt.Errorf("mothership responded with HTTP code %d: %s", resp.StatusCode, string(body))
+	}
+	if err != nil {
+		r.logger.Warnf("Registrar failed to deregister instance '%s' of component reconciler '%s': %s",
+			r.registration.InstanceID, r.registration.Name, err)
+		return
+	}
+	r.logger.Infof("Registrar deregistered instance '%s' of component reconciler '%s'",
+		r.registration.InstanceID, r.registration.Name)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+//mothershipDiscoveryMock records the registrations and deregistrations of the registrar
+type mothershipDiscoveryMock struct {
+	registrations []*reconciler.HTTPReconcilerRegistration
+	deregistered  []string
+	token         string
+	mu            sync.Mutex
+}
+
+func (m *mothershipDiscoveryMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.token = r.Header.Get("Authorization")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/reconcilers":
+		registration := &reconciler.HTTPReconcilerRegistration{}
+		if err := json.NewDecoder(r.Body).Decode(registration); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m.registrations = append(m.registrations, registration)
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodDelete:
+		m.deregistered = append(m.deregistered, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (m *mothershipDiscoveryMock) registered() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.registrations)
+}
+
+func TestRegistrar(t *testing.T) {
+	mothership := &mothershipDiscoveryMock{}
+	srv := httptest.NewServer(mothership)
+	defer srv.Close()
+
+	registrar := NewRegistrar(&reconciler.HTTPReconcilerRegistration{
+		Name:       "unittest",
+		URL:        "http://unittest:8080/v1/run",
+		Components: []string{"component1"},
+		Version:    "1.0.0",
+		Workers:    5,
+	}, srv.URL, 50*time.Millisecond, logger.NewLogger(true)).WithToken("token")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		registrar.Run(ctx)
+		close(stopped)
+	}()
+
+	//registration is renewed periodically
+	require.Eventually(t, func() bool {
+		return mothership.registered() >= 3
+	}, 2*time.Second, 10*time.Millisecond, "registration was not renewed")
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "registrar did not stop")
+	}
+
+	mothership.mu.Lock()
+	defer mothership.mu.Unlock()
+	registration := mothership.registrations[0]
+	require.NotEmpty(t, registration.InstanceID)
+	require.Equal(t, "unittest", registration.Name)
+	require.Equal(t, []string{"component1"}, registration.Components)
+	require.Equal(t, 5, registration.Workers)
+	require.Equal(t, "Bearer token", mothership.token)
+	require.Equal(t, []string{"/v1/reconcilers/" + registration.InstanceID}, mothership.deregistered)
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	rollbackSecretPrefix = "reconciler-rollback-"
+	rollbackVersionKey   = "version"
+	rollbackManifestKey  = "manifest" //gzip compressed and split into chunks which fit into secrets
+	rollbackChunksKey    = "chunks"   //number of secrets the compressed manifest is split into
+	//secrets are limited to 1MiB: a chunk leaves space for the other keys and the metadata of the secret
+	rollbackChunkSize = 900 * 1024
+	//manifests which don't fit into these chunks after compression are not stored (no rollback is possible)
+	rollbackMaxChunks = 10
+)
+
+//SuccessfulManifest is the last manifest of a component which was reconciled successfully
+type SuccessfulManifest struct {
+	Component string
+	Version   string
+	Manifest  string
+}
+
+//manifestStore persists the last successfully reconciled manifest of each component as secrets on the target cluster:
+//the first secret contains the version and the first chunk of the compressed manifest, further chunks are stored
+//in additional secrets
+type manifestStore struct {
+	kubeClient kubernetes.Client
+	logger     *zap.SugaredLogger
+}
+
+func newManifestStore(kubeClient kubernetes.Client, logger *zap.SugaredLogger) *manifestStore {
+	return &manifestStore{
+		kubeClient: kubeClient,
+		logger:     logger,
+	}
+}
+
+//Get returns the last successful manifest of a component or nil if no manifest was stored yet
+func (s *manifestStore) Get(ctx context.Context, component string) (*SuccessfulManifest, error) {
+	name := rollbackSecretName(component)
+	data, err := getComponentSecret(ctx, s.kubeClient, name)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to retrieve last successful manifest of component '%s'", component))
+	}
+	if data == nil {
+		return nil, nil
+	}
+	chunks, err := rollbackChunks(data)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to read last successful manifest of component '%s'", component))
+	}
+	compressed := append([]byte{}, data[rollbackManifestKey]...)
+	for idx := 1; idx < chunks; idx++ {
+		chunkData, err := getComponentSecret(ctx, s.kubeClient, rollbackChunkSecretName(name, idx))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to retrieve last successful manifest of component '%s'", component))
+		}
+		if chunkData == nil {
+			return nil, fmt.Errorf("chunk %d of last successful manifest of component '%s' is missing", idx, component)
+		}
+		compressed = append(compressed, chunkData[rollbackManifestKey]...)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to decompress last successful manifest of component '%s'", component))
+	}
+	manifest, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to decompress last successful manifest of component '%s'", component))
+	}
+	return &SuccessfulManifest{
+		Component: component,
+		Version:   string(data[rollbackVersionKey]),
+		Manifest:  string(manifest),
+	}, nil
+}
+
+//Save creates or updates the last successful manifest of a component
+func (s *manifestStore) Save(ctx context.Context, manifest *SuccessfulManifest) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(manifest.Manifest)); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	compressed := buf.Bytes()
+	if len(compressed) > rollbackMaxChunks*rollbackChunkSize {
+		return fmt.Errorf("last successful manifest of component '%s' cannot be stored: its compressed size of "+
+			"%d bytes exceeds the limit of %d bytes", manifest.Component, len(compressed), rollbackMaxChunks*rollbackChunkSize)
+	}
+	var chunks [][]byte
+	for len(compressed) > rollbackChunkSize {
+		chunks = append(chunks, compressed[:rollbackChunkSize])
+		compressed = compressed[rollbackChunkSize:]
+	}
+	chunks = append(chunks, compressed)
+
+	name := rollbackSecretName(manifest.Component)
+	previousChunks, err := s.storedChunks(ctx, name)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to store last successful manifest of component '%s'", manifest.Component))
+	}
+	//further chunks are stored first: the first secret references them (a mix of chunks of different
+	//manifests is detected by the checksum of the compression when the manifest is read)
+	for idx := 1; idx < len(chunks); idx++ {
+		err := saveComponentSecret(ctx, s.kubeClient, rollbackChunkSecretName(name, idx), manifest.Component, map[string][]byte{
+			rollbackManifestKey: chunks[idx],
+		})
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to store last successful manifest of component '%s'", manifest.Component))
+		}
+	}
+	err = saveComponentSecret(ctx, s.kubeClient, name, manifest.Component, map[string][]byte{
+		rollbackVersionKey:  []byte(manifest.Version),
+		rollbackChunksKey:   []byte(strconv.Itoa(len(chunks))),
+		rollbackManifestKey: chunks[0],
+	})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to store last successful manifest of component '%s'", manifest.Component))
+	}
+	//drop the chunks of a previous manifest which was bigger
+	for idx := len(chunks); idx < previousChunks; idx++ {
+		if err := deleteComponentSecret(ctx, s.kubeClient, rollbackChunkSecretName(name, idx)); err != nil {
+			s.logger.Warnf("Failed to delete outdated chunk %d of last successful manifest of component '%s': %s",
+				idx, manifest.Component, err)
+		}
+	}
+	s.logger.Debugf("Last successful manifest of component '%s' in version '%s' stored (%d chunks)",
+		manifest.Component, manifest.Version, len(chunks))
+	return nil
+}
+
+//Delete drops the last successful manifest of a component
+func (s *manifestStore) Delete(ctx context.Context, component string) error {
+	name := rollbackSecretName(component)
+	chunks, err := s.storedChunks(ctx, name)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to delete last successful manifest of component '%s'", component))
+	}
+	for idx := 1; idx < chunks; idx++ {
+		if err := deleteComponentSecret(ctx, s.kubeClient, rollbackChunkSecretName(name, idx)); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to delete last successful manifest of component '%s'", component))
+		}
+	}
+	if err := deleteComponentSecret(ctx, s.kubeClient, name); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to delete last successful manifest of component '%s'", component))
+	}
+	return nil
+}
+
+//storedChunks returns the number of chunks of the stored manifest (0 if no manifest is stored)
+func (s *manifestStore) storedChunks(ctx context.Context, name string) (int, error) {
+	data, err := getComponentSecret(ctx, s.kubeClient, name)
+	if err != nil || data == nil {
+		return 0, err
+	}
+	return rollbackChunks(data)
+}
+
+//rollbackChunks returns the number of chunks referenced by the first secret (manifests stored before
+//they were chunked consist of a single chunk)
+func rollbackChunks(data map[string][]byte) (int, error) {
+	value, ok := data[rollbackChunksKey]
+	if !ok {
+		return 1, nil
+	}
+	chunks, err := strconv.Atoi(string(value))
+	if err != nil || chunks < 1 {
+		return 0, fmt.Errorf("invalid number of chunks '%s'", string(value))
+	}
+	return chunks, nil
+}
+
+func rollbackSecretName(component string) string {
+	return rollbackSecretPrefix + strings.ToLower(component)
+}
+
+func rollbackChunkSecretName(name string, idx int) string {
+	return fmt.Sprintf("%s.%d", name, idx)
+}
+
+//Rollback redeploys the last successful manifest of the component and returns the outcome.
+//Nil is returned if no successful manifest exists for the component.
+func (r *Install) Rollback(ctx context.Context, task *reconciler.Task, kubeClient kubernetes.Client) *reconciler.Rollback {
+	lastManifest, err := newManifestStore(kubeClient, r.logger).Get(ctx, task.Component)
+	if err != nil {
+		r.logger.Warnf("Rollback of component '%s' not possible: %s", task.Component, err)
+		return nil
+	}
+	if lastManifest == nil {
+		r.logger.Infof("Rollback of component '%s' skipped: no successfully reconciled manifest found", task.Component)
+		return nil
+	}
+
+	r.logger.Infof("Rolling back component '%s' from version '%s' to last successful version '%s'",
+		task.Component, task.Version, lastManifest.Version)
+	rollbackTask := *task
+	rollbackTask.Version = lastManifest.Version
+	result := &reconciler.Rollback{
+		Version: lastManifest.Version,
+	}
+	if _, err := kubeClient.Deploy(ctx, lastManifest.Manifest, task.Namespace, r.interceptors(&rollbackTask, kubeClient)...); err != nil {
+		r.logger.Warnf("Rollback of component '%s' to version '%s' failed: %s", task.Component, lastManifest.Version, err)
+		errMsg := err.Error()
+		result.Error = &errMsg
+		return result
+	}
+	result.Succeeded = true
+	return result
+}
+
+//SaveSuccessfulManifest stores the manifest which was deployed by this install instance as rollback target
+func (r *Install) SaveSuccessfulManifest(ctx context.Context, task *reconciler.Task, kubeClient kubernetes.Client) error {
+	if r.deployedManifest == "" { //the component was reconciled by a custom action
+		return nil
+	}
+	return newManifestStore(kubeClient, r.logger).Save(ctx, &SuccessfulManifest{
+		Component: task.Component,
+		Version:   task.Version,
+		Manifest:  r.deployedManifest,
+	})
+}
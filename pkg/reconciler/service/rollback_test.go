@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestManifestStore(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Clientset").Return(fake.NewSimpleClientset(), nil)
+	store := newManifestStore(kubeClient, logger.NewLogger(true))
+
+	manifest, err := store.Get(ctx, "istio")
+	require.NoError(t, err)
+	require.Nil(t, manifest)
+
+	expected := &SuccessfulManifest{
+		Component: "istio",
+		Version:   "1.0.0",
+		Manifest:  strings.Repeat("apiVersion: v1\nkind: ConfigMap\n---\n", 1000),
+	}
+	require.NoError(t, store.Save(ctx, expected))
+	manifest, err = store.Get(ctx, "istio")
+	require.NoError(t, err)
+	require.Equal(t, expected, manifest)
+
+	require.NoError(t, store.Delete(ctx, "istio"))
+	manifest, err = store.Get(ctx, "istio")
+	require.NoError(t, err)
+	require.Nil(t, manifest)
+}
+
+func TestManifestStoreChunks(t *testing.T) {
+	ctx := context.Background()
+	clientSet := fake.NewSimpleClientset()
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Clientset").Return(clientSet, nil)
+	store := newManifestStore(kubeClient, logger.NewLogger(true))
+
+	//random data can't be compressed: the manifest exceeds the size limit of a single secret
+	randomManifest := func(size int) string {
+		data := make([]byte, size)
+		_, err := rand.New(rand.NewSource(1)).Read(data)
+		require.NoError(t, err)
+		return string(data)
+	}
+	secretNames := func() []string {
+		secrets, err := clientSet.CoreV1().Secrets(inventoryNamespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		var names []string
+		for _, secret := range secrets.Items {
+			size := 0
+			for _, value := range secret.Data {
+				size += len(value)
+			}
+			require.Less(t, size, 1024*1024, "secret '%s' exceeds the size limit", secret.Name)
+			names = append(names, secret.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	t.Run("Manifest exceeding a secret is split into chunks", func(t *testing.T) {
+		expected := &SuccessfulManifest{
+			Component: "istio",
+			Version:   "1.0.0",
+			Manifest:  randomManifest(2*rollbackChunkSize + 1024),
+		}
+		require.NoError(t, store.Save(ctx, expected))
+		require.Equal(t, []string{
+			"reconciler-rollback-istio", "reconciler-rollback-istio.1", "reconciler-rollback-istio.2",
+		}, secretNames())
+
+		manifest, err := store.Get(ctx, "istio")
+		require.NoError(t, err)
+		require.Equal(t, expected, manifest)
+	})
+
+	t.Run("Outdated chunks are dropped", func(t *testing.T) {
+		expected := &SuccessfulManifest{
+			Component: "istio",
+			Version:   "1.1.0",
+			Manifest:  randomManifest(rollbackChunkSize + 1024),
+		}
+		require.NoError(t, store.Save(ctx, expected))
+		require.Equal(t, []string{"reconciler-rollback-istio", "reconciler-rollback-istio.1"}, secretNames())
+
+		manifest, err := store.Get(ctx, "istio")
+		require.NoError(t, err)
+		require.Equal(t, expected, manifest)
+	})
+
+	t.Run("Manifest exceeding all chunks is rejected", func(t *testing.T) {
+		err := store.Save(ctx, &SuccessfulManifest{
+			Component: "istio",
+			Version:   "2.0.0",
+			Manifest:  randomManifest(rollbackMaxChunks*rollbackChunkSize + 1024),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exceeds the limit")
+
+		//the previously stored manifest is kept
+		manifest, err := store.Get(ctx, "istio")
+		require.NoError(t, err)
+		require.Equal(t, "1.1.0", manifest.Version)
+	})
+
+	t.Run("Delete drops all chunks", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, "istio"))
+		require.Empty(t, secretNames())
+	})
+}
+
+func TestRollback(t *testing.T) {
+	ctx := context.Background()
+	task := &reconciler.Task{Component: "istio", Namespace: "istio-system", Version: "2.0.0"}
+
+	newKubeClient := func(deployErr error) *mocks.Client {
+		kubeClient := &mocks.Client{}
+		kubeClient.On("Clientset").Return(fake.NewSimpleClientset(), nil)
+		kubeClient.On("Deploy", ctx, "old-manifest", "istio-system",
+			mock.MatchedBy(func(interceptor *LabelsInterceptor) bool { return interceptor.Version == "1.0.0" }),
+			mock.Anything, mock.Anything, mock.Anything).
+			Return([]*kubernetes.Resource{}, deployErr)
+		return kubeClient
+	}
+
+	t.Run("Skip rollback without successful manifest", func(t *testing.T) {
+		kubeClient := newKubeClient(nil)
+		require.Nil(t, NewInstall(logger.NewLogger(true)).Rollback(ctx, task, kubeClient))
+		kubeClient.AssertNotCalled(t, "Deploy")
+	})
+
+	t.Run("Successful rollback", func(t *testing.T) {
+		kubeClient := newKubeClient(nil)
+		install := &Install{logger: logger.NewLogger(true), deployedManifest: "old-manifest"}
+		require.NoError(t, install.SaveSuccessfulManifest(ctx, &reconciler.Task{Component: "istio", Version: "1.0.0"}, kubeClient))
+
+		rollback := NewInstall(logger.NewLogger(true)).Rollback(ctx, task, kubeClient)
+		require.Equal(t, &reconciler.Rollback{Version: "1.0.0", Succeeded: true}, rollback)
+
+		msg := &reconciler.CallbackMessage{Status: reconciler.StatusError, Error: "upgrade failed", Rollback: rollback}
+		require.Equal(t, "upgrade failed (rolled back to version '1.0.0')", msg.Reason())
+	})
+
+	t.Run("Failed rollback", func(t *testing.T) {
+		kubeClient := newKubeClient(errors.New("deploy failed"))
+		install := &Install{logger: logger.NewLogger(true), deployedManifest: "old-manifest"}
+		require.NoError(t, install.SaveSuccessfulManifest(ctx, &reconciler.Task{Component: "istio", Version: "1.0.0"}, kubeClient))
+
+		rollback := NewInstall(logger.NewLogger(true)).Rollback(ctx, task, kubeClient)
+		require.False(t, rollback.Succeeded)
+
+		msg := &reconciler.CallbackMessage{Status: reconciler.StatusError, Error: "upgrade failed", Rollback: rollback}
+		require.Equal(t, "upgrade failed (rollback to version '1.0.0' failed: deploy failed)", msg.Reason())
+	})
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"go.uber.org/zap"
@@ -10,6 +11,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/heartbeat"
 	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
 	"github.com/pkg/errors"
@@ -57,6 +59,9 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 	if err == nil {
 		r.logger.Infof("Runner: reconciliation of component '%s' for version '%s' finished successfully",
 			task.Component, task.Version)
+		if r.rollbackOnFailure && task.Type == model.OperationTypeReconcile {
+			r.saveSuccessfulManifest(ctx, task)
+		}
 		if err := heartbeatSender.Success(); err != nil {
 			return err
 		}
@@ -67,7 +72,13 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 	} else {
 		r.logger.Errorf("Runner: retryable reconciliation of component '%s' for version '%s' failed consistently: giving up",
 			task.Component, task.Version)
-		if heartbeatErr := heartbeatSender.Error(err); heartbeatErr != nil {
+		var heartbeatErr error
+		if r.rollbackOnFailure && task.Type == model.OperationTypeReconcile {
+			heartbeatErr = heartbeatSender.ErrorWithRollback(err, r.rollback(ctx, task))
+		} else {
+			heartbeatErr = heartbeatSender.Error(err)
+		}
+		if heartbeatErr != nil {
 			return errors.Wrap(err, heartbeatErr.Error())
 		}
 	}
@@ -76,17 +87,13 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 }
 
 func (r *runner) reconcile(ctx context.Context, task *reconciler.Task) error {
-	kubeClient, err := k8s.NewKubernetesClient(task.Kubeconfig, r.logger, &k8s.Config{
-		ProgressInterval: r.progressTrackerConfig.interval,
-		ProgressTimeout:  r.progressTrackerConfig.timeout,
-	})
-	if err != nil {
-		return err
+	//plan tasks are processed synchronously by Plan and must never change the cluster
+	if task.Type == model.OperationTypePlan {
+		return fmt.Errorf("plan task of component '%s' cannot be reconciled: plans are processed synchronously", task.Component)
 	}
-
-	chartProvider, err := r.newChartProvider(task.Repository)
+	kubeClient, chartProvider, err := r.clients(task)
 	if err != nil {
-		return errors.Wrap(err, "Failed to create chart provider instance")
+		return err
 	}
 
 	wsFactory, err := r.workspaceFactory(task.Repository)
@@ -141,3 +148,47 @@ func (r *runner) reconcile(ctx context.Context, task *reconciler.Task) error {
 
 	return nil
 }
+
+func (r *runner) saveSuccessfulManifest(ctx context.Context, task *reconciler.Task) {
+	kubeClient, _, err := r.clients(task)
+	if err == nil {
+		err = r.install.SaveSuccessfulManifest(ctx, task, kubeClient)
+	}
+	if err != nil { //the reconciliation itself was successful: a missing rollback target is no reason to fail
+		r.logger.Warnf("Runner: failed to store manifest of component '%s' in version '%s' as rollback target: %s",
+			task.Component, task.Version, err)
+	}
+}
+
+func (r *runner) rollback(ctx context.Context, task *reconciler.Task) *reconciler.Rollback {
+	kubeClient, _, err := r.clients(task)
+	if err != nil {
+		r.logger.Warnf("Runner: rollback of component '%s' not possible: %s", task.Component, err)
+		return nil
+	}
+	return r.install.Rollback(ctx, task, kubeClient)
+}
+
+func (r *runner) plan(ctx context.Context, task *reconciler.Task) ([]*k8s.ResourceChange, error) {
+	kubeClient, chartProvider, err := r.clients(task)
+	if err != nil {
+		return nil, err
+	}
+	return r.install.Plan(ctx, chartProvider, task, kubeClient)
+}
+
+func (r *runner) clients(task *reconciler.Task) (k8s.Client, *chart.DefaultProvider, error) {
+	kubeClient, err := k8s.NewKubernetesClient(task.Kubeconfig, r.logger, &k8s.Config{
+		ProgressInterval: r.progressTrackerConfig.interval,
+		ProgressTimeout:  r.progressTrackerConfig.timeout,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chartProvider, err := r.newChartProvider(task.Repository)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Failed to create chart provider instance")
+	}
+	return kubeClient, chartProvider, nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const minLeaseExtendInterval = 1 * time.Second
+
+//errLeaseLost is returned if the mothership rejected the extension or completion of a lease: the task was completed,
+//leased by someone else after the lease expired or replaced by a new invocation of the operation
+var errLeaseLost = errors.New("lease of task is lost")
+
+//TaskPuller leases the tasks of a component reconciler from the task queue of the mothership reconciler
+//as long as the worker pool has idle workers. The lease of a task is extended until its worker finished and the
+//task is completed afterwards: if the component reconciler stops, the lease expires and the task gets re-delivered.
+type TaskPuller struct {
+	workerPool *WorkerPool
+	reconciler string
+	mothership *mothershipClient
+	interval   time.Duration
+	logger     *zap.SugaredLogger
+}
+
+func NewTaskPuller(workerPool *WorkerPool, reconcilerName, mothershipURL string, interval time.Duration, logger *zap.SugaredLogger) *TaskPuller {
+	return &TaskPuller{
+		workerPool: workerPool,
+		reconciler: reconcilerName,
+		mothership: newMothershipClient(mothershipURL, logger),
+		interval:   interval,
+		logger:     logger,
+	}
+}
+
+//WithToken defines the bearer token which is sent to the mothership (required if its authentication is enabled)
+func (p *TaskPuller) WithToken(token string) *TaskPuller {
+	p.mothership.token = token
+	return p
+}
+
+//Run leases tasks until the context gets closed
+func (p *TaskPuller) Run(ctx context.Context) {
+	p.logger.Infof("Task puller starts leasing tasks of component reconciler '%s' from mothership '%s' each %.1f secs",
+		p.reconciler, p.mothership.url, p.interval.Seconds())
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.leaseTasks(ctx)
+		select {
+		case <-ctx.Done():
+			p.logger.Info("Task puller stopped leasing tasks because parent context got closed")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+//leaseTasks leases tasks until the queue is empty or all workers are busy
+func (p *TaskPuller) leaseTasks(ctx context.Context) {
+	for p.workerPool.Free() > 0 {
+		lease, err := p.lease(ctx)
+		if err != nil {
+			p.logger.Warnf("Task puller failed to lease task of component reconciler '%s': %s", p.reconciler, err)
+			return
+		}
+		if lease == nil {
+			return
+		}
+		p.process(ctx, lease)
+	}
+}
+
+func (p *TaskPuller) process(ctx context.Context, lease *reconciler.HTTPTaskLeaseResponse) {
+	finished := make(chan struct{})
+	if err := p.workerPool.AssignWorkerAndNotify(ctx, lease.Task, func() { close(finished) }); err != nil {
+		p.logger.Warnf("Task puller failed to assign worker to task '%s' (it will be re-delivered after its lease "+
+			"expired): %s", lease.Task, err)
+		return
+	}
+	p.logger.Infof("Task puller assigned worker to task '%s' (lease expires at %s)", lease.Task, lease.Expires)
+	go p.keepLease(ctx, lease, finished)
+}
+
+//keepLease extends the lease of a task until its worker finished and completes the task afterwards
+func (p *TaskPuller) keepLease(ctx context.Context, lease *reconciler.HTTPTaskLeaseResponse, finished <-chan struct{}) {
+	extendInterval := time.Until(lease.Expires) / 3
+	if extendInterval < minLeaseExtendInterval {
+		extendInterval = minLeaseExtendInterval
+	}
+	ticker := time.NewTicker(extendInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-finished:
+			if err := p.complete(ctx, lease); err != nil {
+				p.logger.Warnf("Task puller failed to complete task '%s': %s", lease.Task, err)
+			}
+			return
+		case <-ticker.C:
+			err := p.extend(ctx, lease)
+			if err == errLeaseLost {
+				p.logger.Warnf("Task puller stops extending lease of task '%s': %s", lease.Task, err)
+				return
+			}
+			if err != nil {
+				p.logger.Warnf("Task puller failed to extend lease of task '%s' (will retry): %s", lease.Task, err)
+			}
+		case <-ctx.Done():
+			return //lease expires and the task gets re-delivered
+		}
+	}
+}
+
+func (p *TaskPuller) lease(ctx context.Context) (*reconciler.HTTPTaskLeaseResponse, error) {
+	resp, body, err := p.mothership.call(ctx, http.MethodPost, fmt.Sprintf("/v1/tasks/%s/lease", p.reconciler), nil)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil, nil
+	case http.StatusOK:
+		lease := &reconciler.HTTPTaskLeaseResponse{}
+		if err := json.Unmarshal(body, lease); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal task lease")
+		}
+		if lease.Task == nil {
+			return nil, fmt.Errorf("mothership returned lease '%s' without task", lease.LeaseID)
+		}
+		return lease, nil
+	default:
+		return nil, fmt.Errorf("mothership responded with HTTP code %d: %s", resp.StatusCode, string(body))
+	}
+}
+
+func (p *TaskPuller) extend(ctx context.Context, lease *reconciler.HTTPTaskLeaseResponse) error {
+	return p.callLease(ctx, http.MethodPost, fmt.Sprintf("/v1/tasks/%s/%s/%s/extend",
+		p.reconciler, lease.Task.CorrelationID, lease.LeaseID))
+}
+
+func (p *TaskPuller) complete(ctx context.Context, lease *reconciler.HTTPTaskLeaseResponse) error {
+	return p.callLease(ctx, http.MethodDelete, fmt.Sprintf("/v1/tasks/%s/%s/%s",
+		p.reconciler, lease.Task.CorrelationID, lease.LeaseID))
+}
+
+func (p *TaskPuller) callLease(ctx context.Context, method, path string) error {
+	resp, body, err := p.mothership.call(ctx, method, path, nil)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusConflict:
+		return errLeaseLost
+	default:
+		return fmt.Errorf("mothership responded with HTTP code %d: %s", resp.StatusCode, string(body))
+	}
+}
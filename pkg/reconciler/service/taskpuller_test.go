@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+//mothershipQueueMock hands out one task and records the calls of the task puller
+type mothershipQueueMock struct {
+	leased bool
+	calls  []string
+	mu     sync.Mutex
+}
+
+func (m *mothershipQueueMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, r.Method+" "+r.URL.Path)
+	switch r.URL.Path {
+	case "/v1/tasks/unittest/lease":
+		if m.leased {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		m.leased = true
+		_ = json.NewEncoder(w).Encode(&reconciler.HTTPTaskLeaseResponse{
+			LeaseID: "lease1",
+			Expires: time.Now().Add(3 * time.Second),
+			Task: &reconciler.Task{
+				Component:     "component1",
+				CorrelationID: "correlation1",
+			},
+		})
+	case "/v1/tasks/unittest/correlation1/lease1/extend", "/v1/tasks/unittest/correlation1/lease1":
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (m *mothershipQueueMock) called(call string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.calls {
+		if c == call {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTaskPuller(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	processed := make(chan string, 1)
+	wp, err := newWorkerPoolBuilder(func(ctx context.Context, task *reconciler.Task, handler callback.Handler, logger *zap.SugaredLogger) func() error {
+		return func() error {
+			time.Sleep(1500 * time.Millisecond) //long enough to require an extension of the lease
+			processed <- task.CorrelationID
+			return nil
+		}
+	}).WithPoolSize(2).Build(ctx)
+	require.NoError(t, err)
+
+	mothership := &mothershipQueueMock{}
+	srv := httptest.NewServer(mothership)
+	defer srv.Close()
+
+	go NewTaskPuller(wp, "unittest", srv.URL+"/", 100*time.Millisecond, logger.NewLogger(true)).Run(ctx)
+
+	select {
+	case correlationID := <-processed:
+		require.Equal(t, "correlation1", correlationID)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "leased task was not processed")
+	}
+
+	require.Eventually(t, func() bool {
+		return mothership.called("DELETE /v1/tasks/unittest/correlation1/lease1")
+	}, 2*time.Second, 50*time.Millisecond, "task was not completed")
+	require.True(t, mothership.called("POST /v1/tasks/unittest/correlation1/lease1/extend"), "lease was not extended")
+}
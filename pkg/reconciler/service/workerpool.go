@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
 	"github.com/panjf2000/ants/v2"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -21,6 +23,7 @@ type WorkerPool struct {
 	logger       *zap.SugaredLogger
 	antsPool     *ants.Pool
 	newRunnerFct func(context.Context, *reconciler.Task, callback.Handler, *zap.SugaredLogger) func() error
+	planFct      func(context.Context, *reconciler.Task, *zap.SugaredLogger) ([]*kubernetes.ResourceChange, error)
 }
 
 func newWorkerPoolBuilder(newRunnerFct func(context.Context, *reconciler.Task, callback.Handler, *zap.SugaredLogger) func() error) *workPoolBuilder {
@@ -42,6 +45,11 @@ func (pb *workPoolBuilder) WithDebug(debug bool) *workPoolBuilder {
 	return pb
 }
 
+func (pb *workPoolBuilder) WithPlanFct(planFct func(context.Context, *reconciler.Task, *zap.SugaredLogger) ([]*kubernetes.ResourceChange, error)) *workPoolBuilder {
+	pb.workerPool.planFct = planFct
+	return pb
+}
+
 func (pb *workPoolBuilder) Build(ctx context.Context) (*WorkerPool, error) {
 	//add logger
 	log := logger.NewLogger(pb.workerPool.debug)
@@ -65,13 +73,19 @@ func (pb *workPoolBuilder) Build(ctx context.Context) (*WorkerPool, error) {
 }
 
 func (wa *WorkerPool) AssignWorker(ctx context.Context, model *reconciler.Task) error {
-	//enrich logger with correlation ID and component name
-	loggerNew := logger.NewLogger(wa.debug).With(
-		zap.Field{Key: "correlation-id", Type: zapcore.StringType, String: model.CorrelationID},
-		zap.Field{Key: "component-name", Type: zapcore.StringType, String: model.Component})
+	return wa.assignWorker(ctx, model, nil)
+}
+
+//AssignWorkerAndNotify assigns the task to a worker and calls done after the worker finished
+func (wa *WorkerPool) AssignWorkerAndNotify(ctx context.Context, model *reconciler.Task, done func()) error {
+	return wa.assignWorker(ctx, model, done)
+}
+
+func (wa *WorkerPool) assignWorker(ctx context.Context, model *reconciler.Task, done func()) error {
+	loggerNew := wa.taskLogger(model)
 
 	//create callback handler
-	remoteCbh, err := callback.NewRemoteCallbackHandler(model.CallbackURL, loggerNew)
+	remoteCbh, err := callback.NewRemoteCallbackHandler(model.CallbackURL, model.CorrelationID, model.CallbackSecret, loggerNew)
 	if err != nil {
 		wa.logger.Errorf("Failed to start reconciliation of model '%s'! "+
 			"Could not create remote callback handler - not able to process : %s", model, err)
@@ -80,6 +94,9 @@ func (wa *WorkerPool) AssignWorker(ctx context.Context, model *reconciler.Task)
 
 	//assign runner to worker
 	err = wa.antsPool.Submit(func() {
+		if done != nil {
+			defer done()
+		}
 		wa.logger.Debugf("Runner for model '%s' is assigned to worker", model)
 		runnerFunc := wa.newRunnerFct(ctx, model, remoteCbh, loggerNew)
 		if errRunner := runnerFunc(); errRunner != nil {
@@ -90,6 +107,29 @@ func (wa *WorkerPool) AssignWorker(ctx context.Context, model *reconciler.Task)
 	return err
 }
 
+//Plan processes a plan task synchronously without occupying a worker of the pool
+func (wa *WorkerPool) Plan(ctx context.Context, model *reconciler.Task) ([]*kubernetes.ResourceChange, error) {
+	if wa.planFct == nil {
+		return nil, fmt.Errorf("worker pool does not support plan tasks")
+	}
+	return wa.planFct(ctx, model, wa.taskLogger(model))
+}
+
+//taskLogger enriches the logger with correlation ID and component name
+func (wa *WorkerPool) taskLogger(model *reconciler.Task) *zap.SugaredLogger {
+	return logger.NewLogger(wa.debug).With(
+		zap.Field{Key: "correlation-id", Type: zapcore.StringType, String: model.CorrelationID},
+		zap.Field{Key: "component-name", Type: zapcore.StringType, String: model.Component})
+}
+
+//Free returns the number of idle workers
+func (wa *WorkerPool) Free() int {
+	if wa.antsPool == nil {
+		return 0
+	}
+	return wa.antsPool.Free()
+}
+
 func (wa *WorkerPool) IsClosed() bool {
 	if wa.antsPool == nil {
 		return true
@@ -29,3 +29,11 @@ func (r *Repository) TransactionalResult(dbOps func(tx *db.TxConnection) (interf
 func (r *Repository) Transactional(dbOps func(tx *db.TxConnection) error) error {
 	return db.Transaction(r.Conn, dbOps, r.Logger)
 }
+
+//Notify wakes up the subscribers of a notification channel. Failures are only logged because the
+//subscribers are also polling the database.
+func (r *Repository) Notify(channel string) {
+	if err := r.Conn.Notifier().Notify(channel); err != nil {
+		r.Logger.Warnf("Failed to send notification to channel '%s': %s", channel, err)
+	}
+}
@@ -0,0 +1,224 @@
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultFailureThreshold = 3
+	defaultProbeInterval    = 30 * time.Second
+	defaultProbeTimeout     = 5 * time.Second
+	readinessPath           = "/health/ready"
+)
+
+//State of the circuit of a component reconciler
+type State string
+
+const (
+	StateClosed   State = "closed"    //tasks are sent to the component reconciler
+	StateOpen     State = "open"      //tasks are rejected until a probe of the component reconciler succeeds
+	StateHalfOpen State = "half-open" //probe succeeded: the next task decides whether the circuit closes or opens again
+)
+
+type Config struct {
+	FailureThreshold int           //consecutive failures which open a circuit (0 = default)
+	ProbeInterval    time.Duration //interval the readiness of a component reconciler is probed while its circuit is open (0 = default)
+	ProbeTimeout     time.Duration //timeout of a readiness probe (0 = default)
+}
+
+func (c *Config) validate() error {
+	if c.FailureThreshold < 0 {
+		return fmt.Errorf("failure threshold cannot be < 0 (was %d)", c.FailureThreshold)
+	}
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = defaultFailureThreshold
+	}
+	if c.ProbeInterval < 0 {
+		return fmt.Errorf("probe interval cannot be < 0 (was %.1f sec)", c.ProbeInterval.Seconds())
+	}
+	if c.ProbeInterval == 0 {
+		c.ProbeInterval = defaultProbeInterval
+	}
+	if c.ProbeTimeout < 0 {
+		return fmt.Errorf("probe timeout cannot be < 0 (was %.1f sec)", c.ProbeTimeout.Seconds())
+	}
+	if c.ProbeTimeout == 0 {
+		c.ProbeTimeout = defaultProbeTimeout
+	}
+	return nil
+}
+
+type metricsCollector interface {
+	OnCircuitStateChange(url string, state string)
+}
+
+//prober verifies whether a component reconciler is ready to receive tasks
+type prober func(ctx context.Context, url string) error
+
+type circuit struct {
+	state     State
+	failures  int
+	nextProbe time.Time
+	probing   bool
+}
+
+//Breakers manages one circuit per URL of a component reconciler. A circuit opens after consecutive failures
+//and stays open until a probe of the readiness endpoint of the component reconciler succeeds.
+type Breakers struct {
+	config    *Config
+	collector metricsCollector
+	probe     prober
+	circuits  map[string]*circuit
+	mu        sync.Mutex
+	logger    *zap.SugaredLogger
+}
+
+//NewBreakers creates the circuit breakers (the metrics collector is optional)
+func NewBreakers(cfg *Config, collector metricsCollector, logger *zap.SugaredLogger) (*Breakers, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	b := &Breakers{
+		config:    cfg,
+		collector: collector,
+		circuits:  make(map[string]*circuit),
+		logger:    logger,
+	}
+	b.probe = b.probeReadiness
+	return b, nil
+}
+
+//Allow returns false if the circuit of the URL is open. An open circuit probes the component reconciler once
+//the probe interval passed: if the probe succeeds, the circuit becomes half-open and tasks are allowed again.
+func (b *Breakers) Allow(ctx context.Context, url string) bool {
+	b.mu.Lock()
+	c := b.circuit(url)
+	if c.state != StateOpen {
+		b.mu.Unlock()
+		return true
+	}
+	if c.probing || time.Now().Before(c.nextProbe) {
+		b.mu.Unlock()
+		return false
+	}
+	c.probing = true
+	b.mu.Unlock()
+
+	err := b.probe(ctx, url)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c.probing = false
+	if err != nil {
+		b.logger.Debugf("Circuit breaker keeps circuit of component reconciler '%s' open: probe failed: %s", url, err)
+		c.nextProbe = time.Now().Add(b.config.ProbeInterval)
+		return false
+	}
+	if c.state == StateOpen {
+		b.transition(url, c, StateHalfOpen)
+	}
+	return true
+}
+
+//Success closes the circuit of the URL
+func (b *Breakers) Success(url string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuit(url)
+	c.failures = 0
+	if c.state != StateClosed {
+		b.transition(url, c, StateClosed)
+	}
+}
+
+//Failure counts a failed call of the component reconciler and returns true if the circuit is open afterwards
+func (b *Breakers) Failure(url string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuit(url)
+	c.failures++
+	if c.state == StateHalfOpen || (c.state == StateClosed && c.failures >= b.config.FailureThreshold) {
+		c.nextProbe = time.Now().Add(b.config.ProbeInterval)
+		b.transition(url, c, StateOpen)
+	}
+	return c.state == StateOpen
+}
+
+//States returns the state of the circuit of each known URL
+func (b *Breakers) States() map[string]State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	result := make(map[string]State, len(b.circuits))
+	for url, c := range b.circuits {
+		result[url] = c.state
+	}
+	return result
+}
+
+//OpenCircuits returns the sorted URLs whose circuit is open
+func (b *Breakers) OpenCircuits() []string {
+	var result []string
+	for url, state := range b.States() {
+		if state == StateOpen {
+			result = append(result, url)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (b *Breakers) circuit(url string) *circuit {
+	c, ok := b.circuits[url]
+	if !ok {
+		c = &circuit{state: StateClosed}
+		b.circuits[url] = c
+		if b.collector != nil {
+			b.collector.OnCircuitStateChange(url, string(StateClosed))
+		}
+	}
+	return c
+}
+
+func (b *Breakers) transition(url string, c *circuit, state State) {
+	b.logger.Infof("Circuit breaker changes circuit of component reconciler '%s' from '%s' to '%s' "+
+		"(consecutive failures: %d)", url, c.state, state, c.failures)
+	c.state = state
+	if b.collector != nil {
+		b.collector.OnCircuitStateChange(url, string(state))
+	}
+}
+
+//probeReadiness calls the readiness endpoint of the component reconciler which is served on the same host
+func (b *Breakers) probeReadiness(ctx context.Context, reconcilerURL string) error {
+	parsedURL, err := url.Parse(reconcilerURL)
+	if err != nil {
+		return err
+	}
+	probeURL := fmt.Sprintf("%s://%s%s", parsedURL.Scheme, parsedURL.Host, readinessPath)
+
+	ctx, cancel := context.WithTimeout(ctx, b.config.ProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if err := resp.Body.Close(); err != nil {
+		b.logger.Warnf("Circuit breaker failed to close HTTP response body of readiness probe: %s", err)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode > 299 {
+		return fmt.Errorf("readiness probe '%s' responded with HTTP code %d", probeURL, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+type collectorMock struct {
+	states map[string]string
+	mu     sync.Mutex
+}
+
+func (c *collectorMock) OnCircuitStateChange(url string, state string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.states[url] = state
+}
+
+func TestBreakers(t *testing.T) {
+	const url = "http://component-reconciler:8080/v1/run"
+	ctx := context.Background()
+
+	newBreakers := func(t *testing.T, probeErr error) (*Breakers, *collectorMock) {
+		collector := &collectorMock{states: map[string]string{}}
+		breakers, err := NewBreakers(&Config{FailureThreshold: 2, ProbeInterval: 50 * time.Millisecond},
+			collector, logger.NewLogger(true))
+		require.NoError(t, err)
+		breakers.probe = func(ctx context.Context, url string) error {
+			return probeErr
+		}
+		return breakers, collector
+	}
+
+	t.Run("Open circuit after consecutive failures", func(t *testing.T) {
+		breakers, collector := newBreakers(t, nil)
+		require.True(t, breakers.Allow(ctx, url))
+		require.False(t, breakers.Failure(url))
+		breakers.Success(url) //resets the consecutive failures
+		require.False(t, breakers.Failure(url))
+		require.True(t, breakers.Failure(url))
+
+		require.False(t, breakers.Allow(ctx, url))
+		require.Equal(t, []string{url}, breakers.OpenCircuits())
+		require.Equal(t, string(StateOpen), collector.states[url])
+	})
+
+	t.Run("Close circuit after successful probe", func(t *testing.T) {
+		breakers, collector := newBreakers(t, nil)
+		breakers.Failure(url)
+		breakers.Failure(url)
+		require.False(t, breakers.Allow(ctx, url))
+
+		time.Sleep(60 * time.Millisecond) //probe interval passed
+		require.True(t, breakers.Allow(ctx, url))
+		require.Equal(t, StateHalfOpen, breakers.States()[url])
+
+		breakers.Success(url)
+		require.Equal(t, StateClosed, breakers.States()[url])
+		require.Equal(t, string(StateClosed), collector.states[url])
+		require.Empty(t, breakers.OpenCircuits())
+	})
+
+	t.Run("Re-open half-open circuit after failure", func(t *testing.T) {
+		breakers, _ := newBreakers(t, nil)
+		breakers.Failure(url)
+		breakers.Failure(url)
+		time.Sleep(60 * time.Millisecond)
+		require.True(t, breakers.Allow(ctx, url))
+
+		require.True(t, breakers.Failure(url))
+		require.False(t, breakers.Allow(ctx, url))
+	})
+
+	t.Run("Keep circuit open after failed probe", func(t *testing.T) {
+		breakers, _ := newBreakers(t, errors.New("not ready"))
+		breakers.Failure(url)
+		breakers.Failure(url)
+		time.Sleep(60 * time.Millisecond)
+		require.False(t, breakers.Allow(ctx, url))
+		require.Equal(t, StateOpen, breakers.States()[url])
+	})
+}
+
+func TestProbeReadiness(t *testing.T) {
+	ready := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != readinessPath || !ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	breakers, err := NewBreakers(&Config{}, nil, logger.NewLogger(true))
+	require.NoError(t, err)
+
+	require.NoError(t, breakers.probeReadiness(context.Background(), srv.URL+"/v1/run"))
+	ready = false
+	require.Error(t, breakers.probeReadiness(context.Background(), srv.URL+"/v1/run"))
+}
@@ -2,24 +2,302 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/pkg/errors"
 )
 
 const FallbackComponentReconciler = "base"
 
+//DispatchMode defines how tasks are delivered to a component reconciler
+type DispatchMode string
+
+const (
+	DispatchModePush  DispatchMode = "push"  //tasks are sent to the URL of the component reconciler (default)
+	DispatchModeQueue DispatchMode = "queue" //tasks are queued and the component reconciler leases them from the mothership
+)
+
 type ComponentReconciler struct {
-	URL string
+	URL                   string
+	MaxParallelOperations int           //operations processed in parallel by the component reconciler across all clusters (0 = unlimited)
+	Dispatch              DispatchMode  //empty = push
+	VisibilityTimeout     time.Duration //queued tasks are re-delivered if their lease wasn't extended within this timeout (0 = default)
+	MaxAttempts           int           //deliveries of a queued task before it's dead-lettered and its operation fails (0 = default)
+}
+
+//QueuesTasks returns true if the component reconciler leases its tasks from the task queue
+func (c *ComponentReconciler) QueuesTasks() bool {
+	return c.Dispatch == DispatchModeQueue
 }
 
 type SchedulerConfig struct {
-	PreComponents [][]string
-	Reconcilers   map[string]ComponentReconciler
+	PreComponents  [][]string
+	Dependencies   []ComponentDependencies
+	Reconcilers    map[string]ComponentReconciler
+	FairQueuing    FairQueuingConfig
+	Rollout        RolloutConfig
+	Drift          DriftConfig
+	Webhooks       WebhookConfig
+	LeaderElection LeaderElectionConfig
+	Discovery      DiscoveryConfig
+	CircuitBreaker CircuitBreakerConfig
+}
+
+//ReconcilerName returns the name of the component reconciler which is responsible for a component
+func (c *SchedulerConfig) ReconcilerName(component string) string {
+	if _, ok := c.Reconcilers[component]; ok {
+		return component
+	}
+	return FallbackComponentReconciler
+}
+
+//ComponentDependencies defines the components which have to be reconciled before a component.
+//They are used for all clusters whose component list doesn't declare dependencies for this component.
+type ComponentDependencies struct {
+	Component string
+	DependsOn []string
+}
+
+//ApplyDependencies adds the configured dependencies to the components which don't declare their own dependencies
+func (c *SchedulerConfig) ApplyDependencies(components []keb.Component) {
+	for idx := range components {
+		if components[idx].DependsOn != nil {
+			continue
+		}
+		for _, dependencies := range c.Dependencies {
+			if dependencies.Component == components[idx].Component {
+				dependsOn := append([]string{}, dependencies.DependsOn...)
+				components[idx].DependsOn = &dependsOn
+				break
+			}
+		}
+	}
+}
+
+//FairQueuingConfig defines the weights used to share the workers of the mothership between the running
+//reconciliations: if workers are scarce, a cluster with weight 2 gets twice as many workers as a cluster with weight 1.
+type FairQueuingConfig struct {
+	DefaultWeight int            //weight of clusters without a plan weight (0 = 1)
+	PlanWeights   map[string]int //service plan names and their weights (plan names are lower-cased when the config is loaded)
+}
+
+//Weight returns the weight of a cluster. A scheduling weight in the cluster metadata has precedence over the plan weight.
+func (c *FairQueuingConfig) Weight(metadata *keb.Metadata) int {
+	if metadata != nil {
+		if metadata.SchedulingWeight != nil && *metadata.SchedulingWeight > 0 {
+			return int(*metadata.SchedulingWeight)
+		}
+		if weight, ok := c.PlanWeights[strings.ToLower(metadata.ServicePlanName)]; ok {
+			return weight
+		}
+	}
+	if c.DefaultWeight > 0 {
+		return c.DefaultWeight
+	}
+	return 1
+}
+
+func (c *FairQueuingConfig) Validate() error {
+	if c.DefaultWeight < 0 {
+		return errors.New("default weight of fair queuing cannot be < 0")
+	}
+	for plan, weight := range c.PlanWeights {
+		if weight <= 0 {
+			return fmt.Errorf("weight of service plan '%s' has to be > 0 (was %d)", plan, weight)
+		}
+	}
+	return nil
+}
+
+//RolloutConfig limits how fast a new Kyma version spreads through the cluster fleet
+type RolloutConfig struct {
+	Enabled            bool
+	MaxClustersPerHour int //0 = unlimited
+	Canary             CanaryConfig
+	WaveSize           int     //0 = all remaining clusters are upgraded in one wave
+	ErrorRateThreshold float64 //rollout halts if the ratio of failed to finished clusters exceeds it (0 = disabled)
+	MinFinished        int     //number of finished clusters required before the error rate is evaluated
+}
+
+type CanaryConfig struct {
+	Metadata map[string][]string //cluster metadata fields (e.g. region) and the accepted values
+	Size     int                 //0 = no canary phase
+}
+
+func (c *RolloutConfig) Validate() error {
+	if c.MaxClustersPerHour < 0 {
+		return errors.New("max clusters per hour of rollout cannot be < 0")
+	}
+	if c.Canary.Size < 0 {
+		return errors.New("canary size of rollout cannot be < 0")
+	}
+	if c.WaveSize < 0 {
+		return errors.New("wave size of rollout cannot be < 0")
+	}
+	if c.ErrorRateThreshold < 0 || c.ErrorRateThreshold > 1 {
+		return fmt.Errorf("error rate threshold of rollout '%.2f' has to be between 0 and 1", c.ErrorRateThreshold)
+	}
+	if c.MinFinished < 0 {
+		return errors.New("min finished clusters of rollout cannot be < 0")
+	}
+	return nil
+}
+
+//DriftConfig enables the detection of resources which were changed on a cluster outside of the reconciler
+type DriftConfig struct {
+	Enabled               bool
+	Interval              time.Duration //0 = default interval of drift detection
+	DisableAutoCorrection bool          //ready clusters are no longer reconciled periodically (drift is only reported)
+}
+
+func (c *DriftConfig) Validate() error {
+	if c.Interval < 0 {
+		return errors.New("interval of drift detection cannot be < 0")
+	}
+	return nil
+}
+
+//WebhookConfig enables the delivery of cluster status changes to the webhooks registered by subscribers
+type WebhookConfig struct {
+	Enabled     bool
+	Interval    time.Duration //0 = default interval of the webhook dispatcher
+	Timeout     time.Duration //timeout of a single delivery attempt (0 = default timeout)
+	MaxAttempts int           //events are moved to the dead letters after the last failed attempt (0 = default)
+	RetryDelay  time.Duration //delay of the first retry which is doubled for each further retry (0 = default delay)
+	//internal networks (CIDR notation) webhooks can send events to: loopback, link-local and private addresses are rejected otherwise
+	AllowedNetworks []string
+}
+
+func (c *WebhookConfig) Validate() error {
+	if c.Interval < 0 {
+		return errors.New("interval of webhook dispatcher cannot be < 0")
+	}
+	if c.Timeout < 0 {
+		return errors.New("timeout of webhook deliveries cannot be < 0")
+	}
+	if c.MaxAttempts < 0 {
+		return errors.New("max attempts of webhook deliveries cannot be < 0")
+	}
+	if c.RetryDelay < 0 {
+		return errors.New("retry delay of webhook deliveries cannot be < 0")
+	}
+	for _, cidr := range c.AllowedNetworks {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("allowed network '%s' of webhooks is invalid", cidr))
+		}
+	}
+	return nil
+}
+
+//LeaderElectionConfig enables running multiple mothership replicas: only the elected leader runs the scheduler,
+//bookkeeper, cleaner and worker pool while all replicas serve the REST API and the callbacks
+type LeaderElectionConfig struct {
+	Enabled       bool
+	LeaseDuration time.Duration //lease of a leader which wasn't renewed within this duration is taken over (0 = default)
+	RenewInterval time.Duration //interval the leader renews its lease (0 = default)
+}
+
+func (c *LeaderElectionConfig) Validate() error {
+	if c.LeaseDuration < 0 {
+		return errors.New("lease duration of leader election cannot be < 0")
+	}
+	if c.RenewInterval < 0 {
+		return errors.New("renew interval of leader election cannot be < 0")
+	}
+	return nil
+}
+
+//DiscoveryConfig enables the registration of component reconcilers at the mothership: tasks are routed to the
+//healthy registered instances first and the statically configured reconcilers are used as fallback.
+//Reconcilers and components can be registered if they are configured, match the allowlist or the caller was granted
+//the scope of the reconciler or component. The authentication has to be enabled.
+type DiscoveryConfig struct {
+	Enabled          bool
+	HeartbeatTimeout time.Duration //instances which didn't renew their registration within this timeout are unhealthy (0 = default)
+	Allowlist        []string      //name patterns (e.g. 'istio-*') of reconcilers and components which can be registered without being configured
+}
+
+func (c *DiscoveryConfig) Validate() error {
+	if c.HeartbeatTimeout < 0 {
+		return errors.New("heartbeat timeout of reconciler discovery cannot be < 0")
+	}
+	for _, pattern := range c.Allowlist {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("allowlist pattern '%s' of reconciler discovery is invalid", pattern))
+		}
+	}
+	return nil
+}
+
+//Allows returns true if the name of a reconciler or component matches a pattern of the allowlist
+func (c *DiscoveryConfig) Allows(name string) bool {
+	for _, pattern := range c.Allowlist {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+//CircuitBreakerConfig stops sending tasks to a component reconciler which failed consecutively: operations are kept
+//in state 'new' until a probe of its readiness endpoint succeeds
+type CircuitBreakerConfig struct {
+	Enabled          bool
+	FailureThreshold int           //consecutive failures which open the circuit of a component reconciler (0 = default)
+	ProbeInterval    time.Duration //interval the readiness of a component reconciler is probed while its circuit is open (0 = default)
+	ProbeTimeout     time.Duration //timeout of a readiness probe (0 = default)
+}
+
+func (c *CircuitBreakerConfig) Validate() error {
+	if c.FailureThreshold < 0 {
+		return errors.New("failure threshold of circuit breaker cannot be < 0")
+	}
+	if c.ProbeInterval < 0 {
+		return errors.New("probe interval of circuit breaker cannot be < 0")
+	}
+	if c.ProbeTimeout < 0 {
+		return errors.New("probe timeout of circuit breaker cannot be < 0")
+	}
+	return nil
+}
+
+//AuthConfig enables the authentication (JWT) and the scope-based authorization of requests to the REST API
+type AuthConfig struct {
+	Enabled             bool
+	Issuers             []string      //accepted issuers of tokens
+	Audience            string        //optional: tokens have to be issued for this audience
+	JWKSFile            string        //JWKS used to verify the signatures of tokens (either file or URL is required)
+	JWKSURL             string        //JWKS is cached and reloaded after the refresh interval or if a key is unknown
+	JWKSRefreshInterval time.Duration //0 = default refresh interval
+	ScopeClaim          string        //name of the token claim containing the scopes (empty = "scope")
+	AnonymousScopes     []string      //scopes granted to requests without token (e.g. callbacks of component reconcilers)
+}
+
+func (c *AuthConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if len(c.Issuers) == 0 {
+		return errors.New("issuers of authentication are not configured")
+	}
+	if (c.JWKSFile == "") == (c.JWKSURL == "") {
+		return errors.New("either JWKS file or JWKS URL of authentication has to be configured")
+	}
+	if c.JWKSRefreshInterval < 0 {
+		return errors.New("JWKS refresh interval of authentication cannot be < 0")
+	}
+	return nil
 }
 
 type Config struct {
 	Scheme    string
 	Host      string
 	Port      int
+	Auth      AuthConfig
 	Scheduler SchedulerConfig
 }
 
@@ -39,5 +317,53 @@ func (c *Config) Validate() error {
 	if len(c.Scheduler.PreComponents) == 0 {
 		return errors.New("pre-components for mothership scheduler are not configured")
 	}
-	return nil
+	for name, reconciler := range c.Scheduler.Reconcilers {
+		if reconciler.MaxParallelOperations < 0 {
+			return fmt.Errorf("max parallel operations of component reconciler '%s' cannot be < 0", name)
+		}
+		if reconciler.Dispatch != "" && reconciler.Dispatch != DispatchModePush && reconciler.Dispatch != DispatchModeQueue {
+			return fmt.Errorf("dispatch mode '%s' of component reconciler '%s' is not supported (supported are '%s' and '%s')",
+				reconciler.Dispatch, name, DispatchModePush, DispatchModeQueue)
+		}
+		if reconciler.VisibilityTimeout < 0 {
+			return fmt.Errorf("visibility timeout of component reconciler '%s' cannot be < 0", name)
+		}
+		if reconciler.MaxAttempts < 0 {
+			return fmt.Errorf("max attempts of component reconciler '%s' cannot be < 0", name)
+		}
+		if reconciler.QueuesTasks() && !c.Auth.Enabled {
+			return fmt.Errorf("dispatch mode '%s' of component reconciler '%s' requires authentication to be enabled",
+				DispatchModeQueue, name)
+		}
+	}
+	for _, dependencies := range c.Scheduler.Dependencies {
+		if dependencies.Component == "" {
+			return errors.New("component of dependencies for mothership scheduler is not configured")
+		}
+	}
+	if err := c.Auth.Validate(); err != nil {
+		return err
+	}
+	if err := c.Scheduler.FairQueuing.Validate(); err != nil {
+		return err
+	}
+	if err := c.Scheduler.Rollout.Validate(); err != nil {
+		return err
+	}
+	if err := c.Scheduler.Drift.Validate(); err != nil {
+		return err
+	}
+	if err := c.Scheduler.Webhooks.Validate(); err != nil {
+		return err
+	}
+	if err := c.Scheduler.LeaderElection.Validate(); err != nil {
+		return err
+	}
+	if err := c.Scheduler.Discovery.Validate(); err != nil {
+		return err
+	}
+	if c.Scheduler.Discovery.Enabled && !c.Auth.Enabled {
+		return errors.New("discovery of component reconcilers requires authentication to be enabled")
+	}
+	return c.Scheduler.CircuitBreaker.Validate()
 }
@@ -1,10 +1,12 @@
 package config
 
 import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/test"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestConfig(t *testing.T) {
@@ -17,5 +19,165 @@ func TestConfig(t *testing.T) {
 	require.NoError(t, viper.ReadInConfig())
 
 	require.NoError(t, viper.UnmarshalKey("mothership", cfg))
+	require.NoError(t, cfg.Auth.Validate())
+	require.Equal(t, []string{"https://issuer.kyma.local"}, cfg.Auth.Issuers)
+	require.Equal(t, []string{"operations:callback"}, cfg.Auth.AnonymousScopes)
 	require.NotEmpty(t, cfg.Scheduler.Reconcilers[FallbackComponentReconciler])
+	require.Equal(t, 10, cfg.Scheduler.Reconcilers[FallbackComponentReconciler].MaxParallelOperations)
+	require.NoError(t, cfg.Scheduler.FairQueuing.Validate())
+	require.Equal(t, 2, cfg.Scheduler.FairQueuing.PlanWeights["azure"])
+	require.NoError(t, cfg.Scheduler.Rollout.Validate())
+	require.NoError(t, cfg.Scheduler.Drift.Validate())
+	require.Equal(t, time.Hour, cfg.Scheduler.Drift.Interval)
+	require.NoError(t, cfg.Scheduler.Webhooks.Validate())
+	require.Equal(t, 3, cfg.Scheduler.Webhooks.MaxAttempts)
+	require.NoError(t, cfg.Scheduler.LeaderElection.Validate())
+	require.Equal(t, 10*time.Second, cfg.Scheduler.LeaderElection.LeaseDuration)
+}
+
+func TestComponentReconcilerConfig(t *testing.T) {
+	newConfig := func(reconciler ComponentReconciler) *Config {
+		return &Config{
+			Scheme: "http",
+			Host:   "localhost",
+			Port:   8080,
+			Scheduler: SchedulerConfig{
+				PreComponents: [][]string{{"cluster-essentials"}},
+				Reconcilers:   map[string]ComponentReconciler{FallbackComponentReconciler: reconciler},
+			},
+		}
+	}
+
+	withAuth := func(cfg *Config) *Config {
+		cfg.Auth = AuthConfig{Enabled: true, Issuers: []string{"issuer"}, JWKSFile: "jwks.json"}
+		return cfg
+	}
+
+	t.Run("Push and queue dispatch are supported", func(t *testing.T) {
+		require.NoError(t, newConfig(ComponentReconciler{URL: "http://localhost:8081/v1/run"}).Validate())
+		require.NoError(t, newConfig(ComponentReconciler{Dispatch: DispatchModePush}).Validate())
+		require.NoError(t, withAuth(newConfig(ComponentReconciler{Dispatch: DispatchModeQueue, VisibilityTimeout: time.Minute})).Validate())
+		require.Error(t, newConfig(ComponentReconciler{Dispatch: "carrier-pigeon"}).Validate())
+	})
+
+	t.Run("Queue dispatch requires authentication", func(t *testing.T) {
+		require.Error(t, newConfig(ComponentReconciler{Dispatch: DispatchModeQueue}).Validate())
+	})
+
+	t.Run("Visibility timeout and max attempts cannot be negative", func(t *testing.T) {
+		require.Error(t, withAuth(newConfig(ComponentReconciler{Dispatch: DispatchModeQueue, VisibilityTimeout: -time.Second})).Validate())
+		require.Error(t, withAuth(newConfig(ComponentReconciler{Dispatch: DispatchModeQueue, MaxAttempts: -1})).Validate())
+	})
+}
+
+func TestDiscoveryConfig(t *testing.T) {
+	cfg := &Config{
+		Scheme: "http",
+		Host:   "localhost",
+		Port:   8080,
+		Scheduler: SchedulerConfig{
+			PreComponents: [][]string{{"cluster-essentials"}},
+		},
+	}
+
+	t.Run("Reconciler mapping is required if discovery is enabled", func(t *testing.T) {
+		cfg.Scheduler.Discovery.Enabled = true
+		cfg.Auth = AuthConfig{Enabled: true, Issuers: []string{"issuer"}, JWKSFile: "jwks.json"}
+		require.Error(t, cfg.Validate())
+		cfg.Scheduler.Reconcilers = map[string]ComponentReconciler{"base": {URL: "http://base:8080/v1/run"}}
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("Authentication is required if discovery is enabled", func(t *testing.T) {
+		cfg.Auth.Enabled = false
+		require.Error(t, cfg.Validate())
+		cfg.Auth.Enabled = true
+	})
+
+	t.Run("Allowlist patterns have to be valid", func(t *testing.T) {
+		cfg.Scheduler.Discovery.Allowlist = []string{"istio-["}
+		require.Error(t, cfg.Validate())
+		cfg.Scheduler.Discovery.Allowlist = []string{"istio-*"}
+		require.NoError(t, cfg.Validate())
+		require.True(t, cfg.Scheduler.Discovery.Allows("istio-configuration"))
+		require.False(t, cfg.Scheduler.Discovery.Allows("istio"))
+	})
+
+	t.Run("Heartbeat timeout cannot be negative", func(t *testing.T) {
+		cfg.Scheduler.Discovery.HeartbeatTimeout = -time.Second
+		require.Error(t, cfg.Validate())
+	})
+}
+
+func TestCircuitBreakerConfig(t *testing.T) {
+	require.NoError(t, (&CircuitBreakerConfig{}).Validate())
+	require.NoError(t, (&CircuitBreakerConfig{Enabled: true, FailureThreshold: 3, ProbeInterval: time.Second}).Validate())
+	require.Error(t, (&CircuitBreakerConfig{FailureThreshold: -1}).Validate())
+	require.Error(t, (&CircuitBreakerConfig{ProbeInterval: -time.Second}).Validate())
+	require.Error(t, (&CircuitBreakerConfig{ProbeTimeout: -time.Second}).Validate())
+}
+
+func TestAuthConfig(t *testing.T) {
+	t.Run("Disabled auth is not validated", func(t *testing.T) {
+		require.NoError(t, (&AuthConfig{}).Validate())
+	})
+
+	t.Run("Issuers are required", func(t *testing.T) {
+		require.Error(t, (&AuthConfig{Enabled: true, JWKSFile: "jwks.json"}).Validate())
+	})
+
+	t.Run("Either JWKS file or URL is required", func(t *testing.T) {
+		require.Error(t, (&AuthConfig{Enabled: true, Issuers: []string{"issuer"}}).Validate())
+		require.Error(t, (&AuthConfig{Enabled: true, Issuers: []string{"issuer"}, JWKSFile: "jwks.json", JWKSURL: "https://issuer/jwks"}).Validate())
+		require.NoError(t, (&AuthConfig{Enabled: true, Issuers: []string{"issuer"}, JWKSURL: "https://issuer/jwks"}).Validate())
+	})
+}
+
+func TestApplyDependencies(t *testing.T) {
+	cfg := &SchedulerConfig{
+		Dependencies: []ComponentDependencies{
+			{Component: "comp1", DependsOn: []string{"comp0"}},
+			{Component: "comp2", DependsOn: []string{"comp0"}},
+		},
+	}
+	ownDependencies := []string{"comp1"}
+	components := []keb.Component{
+		{Component: "comp1"},
+		{Component: "comp2", DependsOn: &ownDependencies},
+		{Component: "comp3"},
+	}
+	cfg.ApplyDependencies(components)
+
+	require.Equal(t, []string{"comp0"}, *components[0].DependsOn)
+	require.Equal(t, []string{"comp1"}, *components[1].DependsOn)
+	require.Nil(t, components[2].DependsOn)
+}
+
+func TestFairQueuingWeight(t *testing.T) {
+	cfg := &FairQueuingConfig{
+		PlanWeights: map[string]int{"azure": 3},
+	}
+	schedulingWeight := int64(5)
+
+	require.Equal(t, 1, cfg.Weight(nil))
+	require.Equal(t, 1, cfg.Weight(&keb.Metadata{ServicePlanName: "trial"}))
+	require.Equal(t, 3, cfg.Weight(&keb.Metadata{ServicePlanName: "Azure"}))
+	require.Equal(t, 5, cfg.Weight(&keb.Metadata{ServicePlanName: "azure", SchedulingWeight: &schedulingWeight}))
+
+	cfg.DefaultWeight = 2
+	require.Equal(t, 2, cfg.Weight(&keb.Metadata{ServicePlanName: "trial"}))
+
+	cfg.PlanWeights["trial"] = 0
+	require.Error(t, cfg.Validate())
+}
+
+func TestReconcilerName(t *testing.T) {
+	cfg := &SchedulerConfig{
+		Reconcilers: map[string]ComponentReconciler{
+			FallbackComponentReconciler: {URL: "http://base"},
+			"istio":                     {URL: "http://istio"},
+		},
+	}
+	require.Equal(t, "istio", cfg.ReconcilerName("istio"))
+	require.Equal(t, FallbackComponentReconciler, cfg.ReconcilerName("serverless"))
 }
@@ -0,0 +1,138 @@
+package discovery
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+)
+
+//ErrOwnerMismatch is returned if an instance is registered by another identity than the caller
+var ErrOwnerMismatch = errors.New("instance is registered by another identity")
+
+//Repository stores the component reconciler instances which registered themselves at the mothership. An instance
+//renews its registration periodically (heartbeat): instances without a recent heartbeat are considered as unhealthy.
+type Repository interface {
+	//Register adds an instance or updates its registration and sets its heartbeat to now. An existing registration
+	//can only be updated by its owner (ErrOwnerMismatch is returned otherwise).
+	Register(instance *model.ReconcilerInstanceEntity) error
+	//Deregister removes an instance of the owner (returns false if the instance wasn't registered and
+	//ErrOwnerMismatch if it's registered by another owner)
+	Deregister(instanceID, owner string) (bool, error)
+	//GetAll returns all registered instances
+	GetAll() ([]*model.ReconcilerInstanceEntity, error)
+	//GetHealthy returns the instances whose latest heartbeat is younger than the timeout
+	GetHealthy(heartbeatTimeout time.Duration) ([]*model.ReconcilerInstanceEntity, error)
+}
+
+type PersistentRepository struct {
+	*repository.Repository
+}
+
+func NewPersistentRepository(conn db.Connection, debug bool) (Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &PersistentRepository{repo}, nil
+}
+
+func (r *PersistentRepository) Register(instance *model.ReconcilerInstanceEntity) error {
+	dbOps := func(tx *db.TxConnection) error {
+		instance.Heartbeat = time.Now().UnixNano()
+		qSelect, err := db.NewQuery(tx, &model.ReconcilerInstanceEntity{}, r.Logger)
+		if err != nil {
+			return err
+		}
+		existing, err := qSelect.Select().Where(map[string]interface{}{"InstanceID": instance.InstanceID}).GetOne()
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		registered := err == nil
+		if registered && existing.(*model.ReconcilerInstanceEntity).Owner != instance.Owner {
+			return ErrOwnerMismatch
+		}
+		q, err := db.NewQuery(tx, instance, r.Logger)
+		if err != nil {
+			return err
+		}
+		if !registered {
+			r.Logger.Infof("Discovery registers new instance '%s' of component reconciler '%s' (URL: %s)",
+				instance.InstanceID, instance.Name, instance.URL)
+			return q.Insert().Exec()
+		}
+		return q.Update().Where(map[string]interface{}{"InstanceID": instance.InstanceID}).Exec()
+	}
+	return r.Transactional(dbOps)
+}
+
+func (r *PersistentRepository) Deregister(instanceID, owner string) (bool, error) {
+	var deregistered bool
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, &model.ReconcilerInstanceEntity{}, r.Logger)
+		if err != nil {
+			return err
+		}
+		existing, err := q.Select().Where(map[string]interface{}{"InstanceID": instanceID}).GetOne()
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if existing.(*model.ReconcilerInstanceEntity).Owner != owner {
+			return ErrOwnerMismatch
+		}
+		deleted, err := q.Delete().Where(map[string]interface{}{"InstanceID": instanceID}).Exec()
+		deregistered = deleted > 0
+		return err
+	}
+	return deregistered, r.Transactional(dbOps)
+}
+
+func (r *PersistentRepository) GetAll() ([]*model.ReconcilerInstanceEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.ReconcilerInstanceEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().OrderBy(map[string]string{"Name": "ASC"}).GetMany()
+	if err != nil {
+		return nil, err
+	}
+	return toInstances(entities), nil
+}
+
+func (r *PersistentRepository) GetHealthy(heartbeatTimeout time.Duration) ([]*model.ReconcilerInstanceEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.ReconcilerInstanceEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	colHandler, err := db.NewColumnHandler(&model.ReconcilerInstanceEntity{}, r.Conn, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	heartbeatCol, err := colHandler.ColumnName("Heartbeat")
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		WhereRaw(fmt.Sprintf("%s>$1", heartbeatCol), time.Now().Add(-heartbeatTimeout).UnixNano()).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	return toInstances(entities), nil
+}
+
+func toInstances(entities []db.DatabaseEntity) []*model.ReconcilerInstanceEntity {
+	result := make([]*model.ReconcilerInstanceEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.ReconcilerInstanceEntity))
+	}
+	return result
+}
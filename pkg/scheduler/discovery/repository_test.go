@@ -0,0 +1,106 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentRepository(t *testing.T) {
+	repo, err := NewPersistentRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+
+	register := func(t *testing.T, name string, workers int64, components ...string) *model.ReconcilerInstanceEntity {
+		instance := &model.ReconcilerInstanceEntity{
+			InstanceID: uuid.NewString(),
+			Name:       name,
+			URL:        "http://localhost:8080/v1/run",
+			Components: components,
+			Version:    "1.0.0",
+			Workers:    workers,
+			Owner:      "owner",
+		}
+		require.NoError(t, repo.Register(instance))
+		return instance
+	}
+
+	find := func(instances []*model.ReconcilerInstanceEntity, instanceID string) *model.ReconcilerInstanceEntity {
+		for _, instance := range instances {
+			if instance.InstanceID == instanceID {
+				return instance
+			}
+		}
+		return nil
+	}
+
+	t.Run("Register and deregister instance", func(t *testing.T) {
+		instance := register(t, "base", 5, "component1", "component2")
+
+		instances, err := repo.GetAll()
+		require.NoError(t, err)
+		registered := find(instances, instance.InstanceID)
+		require.NotNil(t, registered)
+		require.Equal(t, []string{"component1", "component2"}, registered.Components)
+		require.Equal(t, int64(5), registered.Workers)
+
+		deregistered, err := repo.Deregister(instance.InstanceID, "owner")
+		require.NoError(t, err)
+		require.True(t, deregistered)
+
+		deregistered, err = repo.Deregister(instance.InstanceID, "owner")
+		require.NoError(t, err)
+		require.False(t, deregistered)
+	})
+
+	t.Run("Renew registration", func(t *testing.T) {
+		instance := register(t, "istio", 1)
+		heartbeat := instance.Heartbeat
+
+		time.Sleep(10 * time.Millisecond)
+		instance.URL = "http://istio:8080/v1/run"
+		instance.Workers = 10
+		require.NoError(t, repo.Register(instance))
+
+		instances, err := repo.GetAll()
+		require.NoError(t, err)
+		registered := find(instances, instance.InstanceID)
+		require.NotNil(t, registered)
+		require.Equal(t, "http://istio:8080/v1/run", registered.URL)
+		require.Equal(t, int64(10), registered.Workers)
+		require.Greater(t, registered.Heartbeat, heartbeat)
+	})
+
+	t.Run("Registration cannot be overwritten or removed by another owner", func(t *testing.T) {
+		instance := register(t, "base", 1)
+
+		hijacked := *instance
+		hijacked.URL = "http://attacker:8080/v1/run"
+		hijacked.Owner = "attacker"
+		require.Equal(t, ErrOwnerMismatch, repo.Register(&hijacked))
+
+		deregistered, err := repo.Deregister(instance.InstanceID, "attacker")
+		require.Equal(t, ErrOwnerMismatch, err)
+		require.False(t, deregistered)
+
+		instances, err := repo.GetAll()
+		require.NoError(t, err)
+		registered := find(instances, instance.InstanceID)
+		require.NotNil(t, registered)
+		require.Equal(t, "http://localhost:8080/v1/run", registered.URL)
+	})
+
+	t.Run("Get healthy instances", func(t *testing.T) {
+		stale := register(t, "base", 1)
+		time.Sleep(50 * time.Millisecond)
+		healthy := register(t, "base", 1)
+
+		instances, err := repo.GetHealthy(30 * time.Millisecond)
+		require.NoError(t, err)
+		require.NotNil(t, find(instances, healthy.InstanceID))
+		require.Nil(t, find(instances, stale.InstanceID))
+	})
+}
@@ -0,0 +1,76 @@
+package discovery
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+//DefaultHeartbeatTimeout is used if no heartbeat timeout is configured: component reconcilers renew their
+//registration more often than this by default
+const DefaultHeartbeatTimeout = 30 * time.Second
+
+//Router selects the registered instance which receives the next task of a component
+type Router struct {
+	repo             Repository
+	heartbeatTimeout time.Duration
+	random           *rand.Rand
+	mu               sync.Mutex
+}
+
+func NewRouter(repo Repository, heartbeatTimeout time.Duration) *Router {
+	if heartbeatTimeout <= 0 {
+		heartbeatTimeout = DefaultHeartbeatTimeout
+	}
+	return &Router{
+		repo:             repo,
+		heartbeatTimeout: heartbeatTimeout,
+		random:           rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec //no crypto usage
+	}
+}
+
+//Route returns a healthy instance which handles the component (nil if no such instance is registered).
+//Tasks are balanced across the replicas of a component reconciler: the more workers an instance has,
+//the more tasks it gets.
+func (r *Router) Route(component string) (*model.ReconcilerInstanceEntity, error) {
+	instances, err := r.repo.GetHealthy(r.heartbeatTimeout)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*model.ReconcilerInstanceEntity
+	var totalWeight int64
+	for _, instance := range instances {
+		if instance.Handles(component) {
+			candidates = append(candidates, instance)
+			totalWeight += weight(instance)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	pick := r.random.Int63n(totalWeight)
+	r.mu.Unlock()
+	for _, candidate := range candidates {
+		pick -= weight(candidate)
+		if pick < 0 {
+			return candidate, nil
+		}
+	}
+	return candidates[len(candidates)-1], nil
+}
+
+//HeartbeatTimeout returns the timeout after which an instance without heartbeat is considered as unhealthy
+func (r *Router) HeartbeatTimeout() time.Duration {
+	return r.heartbeatTimeout
+}
+
+func weight(instance *model.ReconcilerInstanceEntity) int64 {
+	if instance.Workers > 0 {
+		return instance.Workers
+	}
+	return 1
+}
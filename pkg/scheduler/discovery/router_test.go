@@ -0,0 +1,57 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+type repositoryMock struct {
+	Repository
+	instances []*model.ReconcilerInstanceEntity
+}
+
+func (r *repositoryMock) GetHealthy(_ time.Duration) ([]*model.ReconcilerInstanceEntity, error) {
+	return r.instances, nil
+}
+
+func TestRouter(t *testing.T) {
+	router := NewRouter(&repositoryMock{
+		instances: []*model.ReconcilerInstanceEntity{
+			{InstanceID: "base1", Name: "base", Workers: 1},
+			{InstanceID: "base2", Name: "base", Workers: 3},
+			{InstanceID: "istio1", Name: "istio", Components: []string{"istio-configuration"}},
+		},
+	}, 0)
+
+	t.Run("Use default heartbeat timeout", func(t *testing.T) {
+		require.Equal(t, DefaultHeartbeatTimeout, router.HeartbeatTimeout())
+	})
+
+	t.Run("Route to instance handling the component", func(t *testing.T) {
+		for _, component := range []string{"istio", "istio-configuration"} {
+			instance, err := router.Route(component)
+			require.NoError(t, err)
+			require.Equal(t, "istio1", instance.InstanceID)
+		}
+	})
+
+	t.Run("No instance handles the component", func(t *testing.T) {
+		instance, err := router.Route("serverless")
+		require.NoError(t, err)
+		require.Nil(t, instance)
+	})
+
+	t.Run("Balance tasks by workers of replicas", func(t *testing.T) {
+		routed := map[string]int{}
+		for i := 0; i < 1000; i++ {
+			instance, err := router.Route("base")
+			require.NoError(t, err)
+			routed[instance.InstanceID]++
+		}
+		require.Len(t, routed, 2)
+		require.Greater(t, routed["base2"], routed["base1"])
+	})
+}
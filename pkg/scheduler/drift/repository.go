@@ -0,0 +1,104 @@
+package drift
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+)
+
+type Repository interface {
+	Save(runtimeID string, drifts []*model.ClusterDriftEntity) error
+	Get(runtimeID string) ([]*model.ClusterDriftEntity, error)
+	GetAll() ([]*model.ClusterDriftEntity, error)
+	Remove(runtimeID string) error
+}
+
+type PersistentRepository struct {
+	*repository.Repository
+}
+
+func NewPersistentRepository(conn db.Connection, debug bool) (Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &PersistentRepository{repo}, nil
+}
+
+//Save replaces the drifts of a cluster with the result of the latest detection
+func (r *PersistentRepository) Save(runtimeID string, drifts []*model.ClusterDriftEntity) error {
+	dbOps := func(tx *db.TxConnection) error {
+		if err := r.remove(tx, runtimeID); err != nil {
+			return err
+		}
+		for _, drift := range drifts {
+			q, err := db.NewQuery(tx, drift, r.Logger)
+			if err != nil {
+				return err
+			}
+			if err := q.Insert().Exec(); err != nil {
+				r.Logger.Errorf("DriftRepo failed to store drift of component '%s' of cluster '%s': %s",
+					drift.Component, runtimeID, err)
+				return err
+			}
+		}
+		return nil
+	}
+	return r.Transactional(dbOps)
+}
+
+//Get returns the drifts of a cluster (an empty list is returned if no drift detection happened yet)
+func (r *PersistentRepository) Get(runtimeID string) ([]*model.ClusterDriftEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.ClusterDriftEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(map[string]interface{}{"RuntimeID": runtimeID}).
+		OrderBy(map[string]string{"Component": "ASC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	return r.toDriftEntities(entities), nil
+}
+
+func (r *PersistentRepository) GetAll() ([]*model.ClusterDriftEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.ClusterDriftEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		OrderBy(map[string]string{"RuntimeID": "ASC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	return r.toDriftEntities(entities), nil
+}
+
+func (r *PersistentRepository) Remove(runtimeID string) error {
+	return r.remove(r.Conn, runtimeID)
+}
+
+func (r *PersistentRepository) remove(conn db.Connection, runtimeID string) error {
+	q, err := db.NewQuery(conn, &model.ClusterDriftEntity{}, r.Logger)
+	if err != nil {
+		return err
+	}
+	deleted, err := q.Delete().
+		Where(map[string]interface{}{"RuntimeID": runtimeID}).
+		Exec()
+	if err == nil {
+		r.Logger.Debugf("DriftRepo deleted %d drifts of cluster '%s'", deleted, runtimeID)
+	}
+	return err
+}
+
+func (r *PersistentRepository) toDriftEntities(entities []db.DatabaseEntity) []*model.ClusterDriftEntity {
+	result := make([]*model.ClusterDriftEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.ClusterDriftEntity))
+	}
+	return result
+}
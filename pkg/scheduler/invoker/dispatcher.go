@@ -0,0 +1,20 @@
+package invoker
+
+import (
+	"context"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+)
+
+//Dispatcher delivers a task to the component reconciler which is responsible for the component of an operation
+type Dispatcher interface {
+	//Dispatch returns a TaskRejectedError if the component reconciler refused to process the task
+	Dispatch(ctx context.Context, target *Target, params *Params, task *reconciler.Task) error
+}
+
+//Target is the component reconciler which receives a task
+type Target struct {
+	Name string
+	config.ComponentReconciler
+}
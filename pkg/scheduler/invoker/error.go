@@ -2,6 +2,8 @@ package invoker
 
 import (
 	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 )
 
@@ -17,3 +19,35 @@ func IsNoFallbackReconcilerDefinedError(err error) bool {
 	_, ok := err.(*NoFallbackReconcilerDefinedError)
 	return ok
 }
+
+//TaskRejectedError is returned by a dispatcher if the component reconciler refused to process a task:
+//the operation is moved to the given state
+type TaskRejectedError struct {
+	State  model.OperationState
+	Reason string
+}
+
+func (err *TaskRejectedError) Error() string {
+	return fmt.Sprintf("component reconciler rejected task (operation state: %s): %s", err.State, err.Reason)
+}
+
+func IsTaskRejectedError(err error) bool {
+	_, ok := err.(*TaskRejectedError)
+	return ok
+}
+
+//CircuitOpenError is returned if the circuit of the component reconciler is open: the operation stays in state 'new'
+//and gets invoked again once the component reconciler is ready
+type CircuitOpenError struct {
+	Reconciler string
+	URL        string
+}
+
+func (err *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit of component reconciler '%s' (URL: %s) is open", err.Reconciler, err.URL)
+}
+
+func IsCircuitOpenError(err error) bool {
+	_, ok := err.(*CircuitOpenError)
+	return ok
+}
@@ -0,0 +1,124 @@
+package invoker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+//httpDispatcher pushes a task synchronously to the URL of the component reconciler
+type httpDispatcher struct {
+	logger *zap.SugaredLogger
+}
+
+func (d *httpDispatcher) Dispatch(ctx context.Context, target *Target, params *Params, task *reconciler.Task) error {
+	resp, err := d.sendHTTPRequest(ctx, target, params, task)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			d.logger.Errorf("Error while closing HTTP response body: %s", err)
+		}
+	}()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read HTTP body")
+	}
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode <= 299 {
+		//component-reconciler started reconciliation
+		respModel := &reconciler.HTTPReconciliationResponse{}
+		err := d.unmarshalHTTPResponse(body, respModel, params)
+		if err == nil {
+			return nil //request successfully fired
+		}
+		d.reportUnmarshalError(resp.StatusCode, body, err)
+	}
+
+	if resp.StatusCode >= 400 && resp.StatusCode <= 499 {
+		//component-reconciler can not start because dependencies are missing
+		respModel := &reconciler.HTTPErrorResponse{}
+		err := d.unmarshalHTTPResponse(body, respModel, params)
+		if err == nil {
+			return &TaskRejectedError{State: model.OperationStateFailed, Reason: respModel.Error}
+		}
+		d.reportUnmarshalError(resp.StatusCode, body, err)
+	}
+
+	//component-reconciler responded an error: try to handle it as an error response
+	respModel := &reconciler.HTTPErrorResponse{}
+	var errorReason string
+
+	err = d.unmarshalHTTPResponse(body, respModel, params)
+	if err == nil {
+		errorReason = respModel.Error
+	} else {
+		d.reportUnmarshalError(resp.StatusCode, body, err)
+		errorReason = fmt.Sprintf("received unsupported reconciler response (HTTP code: %d): %s",
+			resp.StatusCode, string(body))
+	}
+
+	return &TaskRejectedError{State: model.OperationStateClientError, Reason: errorReason}
+}
+
+func (d *httpDispatcher) sendHTTPRequest(ctx context.Context, target *Target, params *Params, task *reconciler.Task) (*http.Response, error) {
+	component := params.ComponentToReconcile.Component
+
+	jsonPayload, err := json.Marshal(task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal HTTP payload to call reconciler of component '%s': %s", component, err)
+	}
+
+	d.logger.Debugf("Remote invoker is calling remote reconciler via HTTP (URL: %s) "+
+		"for component '%s' (schedulingID:%s/correlationID:%s)",
+		target.URL, component, params.SchedulingID, params.CorrelationID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err == nil {
+		respDump, err := httputil.DumpResponse(resp, true)
+		if err == nil {
+			d.logger.Debugf("Remote invoker received HTTP response from reconciler of component '%s' with status '%s' [%d] "+
+				"(schedulingID:%s/correlationID:%s): %s",
+				component, resp.Status, resp.StatusCode,
+				params.SchedulingID, params.CorrelationID, string(respDump))
+		} else {
+			d.logger.Warnf("Remote invoker failed to dump HTTP response from component reconciler: %s", err)
+		}
+	} else {
+		d.logger.Warnf("Remote invoker failed to send HTTP request to component reconciler '%s': %s",
+			target.URL, err)
+		return resp, errors.Wrap(err, fmt.Sprintf("failed to call remote reconciler (URL: %s)", target.URL))
+	}
+
+	d.logger.Infof("Remote invoker triggered reconciliation of component '%s' on remote component reconciler '%s': %d",
+		component, target.URL, resp.StatusCode)
+
+	return resp, nil
+}
+
+func (d *httpDispatcher) reportUnmarshalError(httpCode int, body []byte, err error) {
+	d.logger.Warnf("Remote invoker: Failed to unmarshal reconciler response (HTTP-code: %d / Body: %s): %s",
+		httpCode, string(body), err)
+}
+
+func (d *httpDispatcher) unmarshalHTTPResponse(body []byte, respModel interface{}, params *Params) error {
+	return unmarshalHTTPResponse(d.logger, body, respModel, params)
+}
@@ -29,12 +29,19 @@ func (p *Params) newLocalTask(callbackFunc func(msg *reconciler.CallbackMessage)
 	return model
 }
 
-func (p *Params) newRemoteTask(callbackURL string) *reconciler.Task {
+func (p *Params) newRemoteTask(callbackURL, callbackSecret string) *reconciler.Task {
 	model := p.newTask()
 	model.CallbackURL = callbackURL
+	model.CallbackSecret = callbackSecret
 	return model
 }
 
+func (p *Params) newPlanTask() *reconciler.Task {
+	task := p.newTask()
+	task.Type = model.OperationTypePlan
+	return task
+}
+
 func (p *Params) newTask() *reconciler.Task {
 	version := p.ClusterState.Configuration.KymaVersion
 	// version := p.ComponentToReconcile.Version
@@ -45,7 +52,7 @@ func (p *Params) newTask() *reconciler.Task {
 		version = p.ComponentToReconcile.Version
 	}
 
-	configuration := p.ComponentToReconcile.ConfigurationAsMap()
+	configuration := p.configuration()
 	tokenNamespace := configuration["repo.token.namespace"]
 	if tokenNamespace == nil {
 		tokenNamespace = ""
@@ -74,3 +81,15 @@ func (p *Params) newTask() *reconciler.Task {
 		Type: taskType,
 	}
 }
+
+//configuration merges the component configuration defined by KEB on top of the KV-bucket values of the cluster
+func (p *Params) configuration() map[string]interface{} {
+	result := make(map[string]interface{}, len(p.ClusterState.Configuration.BucketValues)+len(p.ComponentToReconcile.Configuration))
+	for key, value := range p.ClusterState.Configuration.BucketValues {
+		result[key] = value
+	}
+	for key, value := range p.ComponentToReconcile.ConfigurationAsMap() {
+		result[key] = value
+	}
+	return result
+}
@@ -3,7 +3,9 @@ package invoker
 import (
 	"testing"
 
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,4 +34,39 @@ func TestInvoker(t *testing.T) {
 		model := params.newTask()
 		assert.Equal(t, "", model.Repository.TokenNamespace)
 	})
+
+	t.Run("Should merge KEB configuration on top of bucket values", func(t *testing.T) {
+		params := Params{
+			ComponentToReconcile: &keb.Component{
+				Component: "TestComp1",
+				Configuration: []keb.Configuration{
+					{
+						Key:   "overridden",
+						Value: "keb",
+					},
+					{
+						Key:   "keb",
+						Value: "keb",
+					},
+				},
+			},
+			ClusterState: &cluster.State{
+				Cluster: clusterStateMock.Cluster,
+				Configuration: &model.ClusterConfigurationEntity{
+					BucketValues: map[string]interface{}{
+						"overridden": "bucket",
+						"bucket":     "bucket",
+					},
+				},
+				Status: clusterStateMock.Status,
+			},
+		}
+
+		model := params.newTask()
+		assert.Equal(t, map[string]interface{}{
+			"overridden": "keb",
+			"keb":        "keb",
+			"bucket":     "bucket",
+		}, model.Configuration)
+	})
 }
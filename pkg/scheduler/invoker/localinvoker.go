@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
 	reconRegistry "github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 	"go.uber.org/zap"
 )
@@ -104,11 +105,15 @@ func (i *LocalReconcilerInvoker) updateOperationState(msg *reconciler.CallbackMe
 	if msg.Error == "" {
 		i.logger.Debugf(errMsg, params.SchedulingID, params.CorrelationID, state)
 	} else {
-		i.logger.Debugf(errMsg+": %s", params.SchedulingID, params.CorrelationID, state, msg.Error)
+		i.logger.Debugf(errMsg+": %s", params.SchedulingID, params.CorrelationID, state, msg.Reason())
 	}
 
-	err := i.reconRepo.UpdateOperationState(params.SchedulingID, params.CorrelationID, state, true, msg.Error)
+	err := i.reconRepo.UpdateOperationState(params.SchedulingID, params.CorrelationID, state, true, msg.Reason())
 	if err != nil {
+		op, getErr := i.reconRepo.GetOperation(params.SchedulingID, params.CorrelationID)
+		if getErr == nil && op != nil && op.State == model.OperationStateCancelled {
+			return &callback.OperationCancelledError{}
+		}
 		//return only the error if it's not caused by a redundant update
 		return errors.Wrap(err, fmt.Sprintf("local invoker failed to update operation "+
 			"(schedulingID:%s/correlationID:%s) to state '%s'",
@@ -56,7 +56,7 @@ func runLocalReconciler(t *testing.T, simulateError bool) (reconciliation.Reposi
 	reconRepo := reconciliation.NewInMemoryReconciliationRepository()
 
 	//create reconciliation entity
-	reconEntity, err := reconRepo.CreateReconciliation(clusterStateMock, nil)
+	reconEntity, err := reconRepo.CreateReconciliation(clusterStateMock, nil, nil)
 	require.NoError(t, err)
 
 	//retrieve ops of reconciliation entity
@@ -0,0 +1,38 @@
+package invoker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/taskqueue"
+	"go.uber.org/zap"
+)
+
+//queueDispatcher stores a task in the task queue: the component reconciler leases it from there when it has
+//capacity, which makes the dispatching independent of the availability of the component reconciler
+type queueDispatcher struct {
+	queue  taskqueue.Repository
+	logger *zap.SugaredLogger
+}
+
+func (d *queueDispatcher) Dispatch(_ context.Context, target *Target, params *Params, task *reconciler.Task) error {
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task of component '%s': %s", task.Component, err)
+	}
+	err = d.queue.Enqueue(&model.TaskEntity{
+		CorrelationID: params.CorrelationID,
+		SchedulingID:  params.SchedulingID,
+		Reconciler:    target.Name,
+		Payload:       string(payload),
+	})
+	if err != nil {
+		return err
+	}
+	d.logger.Infof("Remote invoker queued reconciliation of component '%s' for component reconciler '%s' "+
+		"(schedulingID:%s/correlationID:%s)", task.Component, target.Name, params.SchedulingID, params.CorrelationID)
+	return nil
+}
@@ -7,23 +7,35 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"strings"
+	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/breaker"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/discovery"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/taskqueue"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
 const callbackURLTemplate = "%s://%s:%d/v1/operations/%s/callback/%s"
 
+//DefaultPlanTimeout limits how long a component reconciler can take to plan the changes of a component
+const DefaultPlanTimeout = 1 * time.Minute
+
 type RemoteReconcilerInvoker struct {
-	reconRepo reconciliation.Repository
-	config    *config.Config
-	logger    *zap.SugaredLogger
+	reconRepo   reconciliation.Repository
+	config      *config.Config
+	logger      *zap.SugaredLogger
+	dispatchers map[config.DispatchMode]Dispatcher
+	router      *discovery.Router
+	breakers    *breaker.Breakers
+	planClient  *http.Client
 }
 
 func NewRemoteReoncilerInvoker(reconRepo reconciliation.Repository, cfg *config.Config, logger *zap.SugaredLogger) *RemoteReconcilerInvoker {
@@ -31,69 +43,127 @@ func NewRemoteReoncilerInvoker(reconRepo reconciliation.Repository, cfg *config.
 		reconRepo: reconRepo,
 		config:    cfg,
 		logger:    logger,
+		dispatchers: map[config.DispatchMode]Dispatcher{
+			config.DispatchModePush: &httpDispatcher{logger: logger},
+		},
+		planClient: &http.Client{Timeout: DefaultPlanTimeout},
 	}
 }
 
-func (i *RemoteReconcilerInvoker) Invoke(_ context.Context, params *Params) error {
+//WithTaskQueue enables the dispatching of tasks to component reconcilers which lease their tasks from the queue
+func (i *RemoteReconcilerInvoker) WithTaskQueue(queue taskqueue.Repository) *RemoteReconcilerInvoker {
+	i.dispatchers[config.DispatchModeQueue] = &queueDispatcher{queue: queue, logger: i.logger}
+	return i
+}
+
+//WithDiscovery routes tasks to the healthy instances which registered themselves at the mothership
+func (i *RemoteReconcilerInvoker) WithDiscovery(router *discovery.Router) *RemoteReconcilerInvoker {
+	i.router = router
+	return i
+}
+
+//WithCircuitBreakers stops pushing tasks to component reconcilers which failed consecutively
+func (i *RemoteReconcilerInvoker) WithCircuitBreakers(breakers *breaker.Breakers) *RemoteReconcilerInvoker {
+	i.breakers = breakers
+	return i
+}
+
+func (i *RemoteReconcilerInvoker) Invoke(ctx context.Context, params *Params) error {
 	if err := i.ensureOperationNotInProgress(params); err != nil {
 		return err
 	}
 
+	target, dispatcher, resolveErr := i.dispatcher(params.ComponentToReconcile.Component)
+	if resolveErr == nil && !i.allow(ctx, target) {
+		//operation stays in state 'new' and is picked up again when the component reconciler is ready
+		return &CircuitOpenError{Reconciler: target.Name, URL: target.URL}
+	}
+
 	//mark the operation to be in progress (required to avoid that other invokers will also pick it up)
 	if err := i.updateOperationState(params, model.OperationStateInProgress); err != nil {
 		return err
 	}
 
-	resp, err := i.sendHTTPRequest(params)
+	//each invocation gets a new secret: callbacks of previous invocations are not accepted anymore
+	callbackSecret, err := i.issueCallbackSecret(params)
 	if err != nil {
-		return i.fireError("send HTTP request", params, err)
+		return i.fireError("issue callback secret", params, err)
 	}
 
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			i.logger.Errorf("Error while closing HTTP response body: %s", err)
-		}
-	}()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return i.fireError("read HTTP body", params, err)
+	if resolveErr != nil {
+		return i.fireError("resolve component reconciler", params, resolveErr)
 	}
 
-	if resp.StatusCode >= http.StatusOK && resp.StatusCode <= 299 {
-		//component-reconciler started reconciliation
-		respModel := &reconciler.HTTPReconciliationResponse{}
-		err := i.unmarshalHTTPResponse(body, respModel, params)
-		if err == nil {
-			return nil //request successfully fired
+	callbackURL := fmt.Sprintf(callbackURLTemplate,
+		i.config.Scheme,
+		i.config.Host,
+		i.config.Port,
+		params.SchedulingID,
+		params.CorrelationID)
+	err = dispatcher.Dispatch(ctx, target, params, params.newRemoteTask(callbackURL, callbackSecret))
+	if err == nil {
+		i.succeeded(target)
+		return nil
+	}
+	rejectedErr, rejected := err.(*TaskRejectedError)
+	if rejected && rejectedErr.State == model.OperationStateFailed {
+		//component reconciler is available but refused the task
+		i.succeeded(target)
+		return i.updateOperationState(params, rejectedErr.State, rejectedErr.Reason)
+	}
+	if i.failed(target) {
+		i.logger.Warnf("Remote invoker resets operation (schedulingID:%s/correlationID:%s) to state '%s' because "+
+			"circuit of component reconciler '%s' opened: %s", params.SchedulingID, params.CorrelationID,
+			model.OperationStateNew, target.Name, err)
+		if err := i.updateOperationState(params, model.OperationStateNew); err != nil {
+			return err
 		}
-		i.reportUnmarshalError(resp.StatusCode, body, err)
+		return &CircuitOpenError{Reconciler: target.Name, URL: target.URL}
+	}
+	if rejected {
+		return i.updateOperationState(params, rejectedErr.State, rejectedErr.Reason)
 	}
+	return i.fireError("dispatch task", params, err)
+}
 
-	if resp.StatusCode >= 400 && resp.StatusCode <= 499 {
-		//component-reconciler can not start because dependencies are missing
-		respModel := &reconciler.HTTPErrorResponse{}
-		err := i.unmarshalHTTPResponse(body, respModel, params)
-		if err == nil {
-			return i.updateOperationState(params, model.OperationStateFailed, respModel.Error)
-		}
-		i.reportUnmarshalError(resp.StatusCode, body, err)
+//allow returns false if tasks are pushed to the component reconciler and its circuit is open
+func (i *RemoteReconcilerInvoker) allow(ctx context.Context, target *Target) bool {
+	if i.breakers == nil || target.QueuesTasks() {
+		return true
 	}
+	return i.breakers.Allow(ctx, target.URL)
+}
 
-	//component-reconciler responded an error: try to handle it as an error response
-	respModel := &reconciler.HTTPErrorResponse{}
-	var errorReason string
+func (i *RemoteReconcilerInvoker) succeeded(target *Target) {
+	if i.breakers != nil && !target.QueuesTasks() {
+		i.breakers.Success(target.URL)
+	}
+}
 
-	err = i.unmarshalHTTPResponse(body, respModel, params)
-	if err == nil {
-		errorReason = respModel.Error
-	} else {
-		i.reportUnmarshalError(resp.StatusCode, body, err)
-		errorReason = fmt.Sprintf("received unsupported reconciler response (HTTP code: %d): %s",
-			resp.StatusCode, string(body))
+//failed returns true if the circuit of the component reconciler is open afterwards
+func (i *RemoteReconcilerInvoker) failed(target *Target) bool {
+	if i.breakers == nil || target.QueuesTasks() {
+		return false
 	}
+	return i.breakers.Failure(target.URL)
+}
 
-	return i.updateOperationState(params, model.OperationStateClientError, errorReason)
+//dispatcher returns the component reconciler of the component and the dispatcher which delivers tasks to it
+func (i *RemoteReconcilerInvoker) dispatcher(component string) (*Target, Dispatcher, error) {
+	target, err := i.target(component)
+	if err != nil {
+		return nil, nil, err
+	}
+	mode := target.Dispatch
+	if mode == "" {
+		mode = config.DispatchModePush
+	}
+	dispatcher, ok := i.dispatchers[mode]
+	if !ok {
+		return nil, nil, fmt.Errorf("dispatch mode '%s' of component reconciler '%s' is not available "+
+			"(is a task queue configured?)", mode, target.Name)
+	}
+	return target, dispatcher, nil
 }
 
 func (i *RemoteReconcilerInvoker) ensureOperationNotInProgress(params *Params) error {
@@ -109,71 +179,127 @@ func (i *RemoteReconcilerInvoker) ensureOperationNotInProgress(params *Params) e
 	return nil
 }
 
-func (i *RemoteReconcilerInvoker) reportUnmarshalError(httpCode int, body []byte, err error) {
-	i.logger.Warnf("Remote invoker: Failed to unmarshal reconciler response (HTTP-code: %d / Body: %s): %s",
-		httpCode, string(body), err)
+func (i *RemoteReconcilerInvoker) issueCallbackSecret(params *Params) (string, error) {
+	secret, err := callback.NewSecret()
+	if err != nil {
+		return "", err
+	}
+	if err := i.reconRepo.UpdateOperationCallbackSecret(params.SchedulingID, params.CorrelationID, secret); err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("remote invoker failed to store callback secret of operation "+
+			"(schedulingID:%s/correlationID:%s)", params.SchedulingID, params.CorrelationID))
+	}
+	return secret, nil
 }
 
-func (i *RemoteReconcilerInvoker) sendHTTPRequest(params *Params) (*http.Response, error) {
+//Plan asks the component reconciler which changes a reconciliation of the component would apply on the cluster
+func (i *RemoteReconcilerInvoker) Plan(ctx context.Context, params *Params) ([]*kubernetes.ResourceChange, error) {
 	component := params.ComponentToReconcile.Component
 
-	callbackURL := fmt.Sprintf(callbackURLTemplate,
-		i.config.Scheme,
-		i.config.Host,
-		i.config.Port,
-		params.SchedulingID,
-		params.CorrelationID)
-	payload := params.newRemoteTask(callbackURL)
-
-	jsonPayload, err := json.Marshal(payload)
+	jsonPayload, err := json.Marshal(params.newPlanTask())
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal HTTP payload to call reconciler of component '%s': %s", component, err)
 	}
 
-	compRecon, ok := i.config.Scheduler.Reconcilers[component]
-	if ok {
-		i.logger.Debugf("Remote invoker found dedicated reconciler for component '%s'", component)
-	} else {
-		i.logger.Debugf("Remote invoker found no dedicated reconciler for component '%s': "+
-			"using '%s' component reconciler as fallback", component, config.FallbackComponentReconciler)
-		compRecon, ok = i.config.Scheduler.Reconcilers[config.FallbackComponentReconciler]
-		if !ok {
-			i.logger.Errorf("Remote invoker could not find fallback reconciler '%s' in scheduler configuration",
-				config.FallbackComponentReconciler)
-			return nil, &NoFallbackReconcilerDefinedError{}
+	compRecon, err := i.target(component)
+	if err != nil {
+		return nil, err
+	}
+	if compRecon.URL == "" {
+		return nil, fmt.Errorf("component reconciler of component '%s' has no URL: plans are only "+
+			"supported by component reconcilers which are reachable via HTTP", component)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, compRecon.URL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := i.planClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to call remote reconciler (URL: %s)", compRecon.URL))
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			i.logger.Errorf("Error while closing HTTP response body: %s", err)
 		}
+	}()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode > 299 {
+		errModel := &reconciler.HTTPErrorResponse{}
+		if err := i.unmarshalHTTPResponse(body, errModel, params); err != nil {
+			return nil, fmt.Errorf("received unsupported reconciler response (HTTP code: %d): %s",
+				resp.StatusCode, string(body))
+		}
+		return nil, errors.New(errModel.Error)
 	}
 
-	i.logger.Debugf("Remote invoker is calling remote reconciler via HTTP (URL: %s) "+
-		"for component '%s' (schedulingID:%s/correlationID:%s)",
-		compRecon.URL, params.ComponentToReconcile.Component, params.SchedulingID, params.CorrelationID)
+	respModel := &reconciler.HTTPPlanResponse{}
+	if err := i.unmarshalHTTPResponse(body, respModel, params); err != nil {
+		return nil, err
+	}
+	return respModel.Changes, nil
+}
 
-	resp, err := http.Post(compRecon.URL, "application/json", bytes.NewBuffer(jsonPayload))
-	if err == nil {
-		respDump, err := httputil.DumpResponse(resp, true)
-		if err == nil {
-			i.logger.Debugf("Remote invoker received HTTP response from reconciler of component '%s' with status '%s' [%d] "+
-				"(schedulingID:%s/correlationID:%s): %s",
-				params.ComponentToReconcile.Component, resp.Status, resp.StatusCode,
-				params.SchedulingID, params.CorrelationID, string(respDump))
+//target resolves the component reconciler of a component. A reconciler dedicated to the component is preferred over
+//the fallback reconciler and, for both, registered instances are preferred over the statically configured reconciler.
+func (i *RemoteReconcilerInvoker) target(component string) (*Target, error) {
+	for _, name := range []string{component, config.FallbackComponentReconciler} {
+		target, err := i.registeredTarget(name)
+		if err != nil {
+			return nil, err
+		}
+		if target == nil {
+			if compRecon, ok := i.config.Scheduler.Reconcilers[name]; ok {
+				target = &Target{Name: name, ComponentReconciler: compRecon}
+			}
+		}
+		if target == nil {
+			continue
+		}
+		if name == component {
+			i.logger.Debugf("Remote invoker found dedicated reconciler '%s' for component '%s' (URL: %s)",
+				target.Name, component, target.URL)
 		} else {
-			i.logger.Warnf("Remote invoker failed to dump HTTP response from component reconciler: %s", err)
+			i.logger.Debugf("Remote invoker found no dedicated reconciler for component '%s': "+
+				"using '%s' component reconciler as fallback (URL: %s)", component, target.Name, target.URL)
 		}
-	} else {
-		i.logger.Warnf("Remote invoker failed to send HTTP request to component reconciler '%s': %s",
-			compRecon.URL, err)
-		return resp, errors.Wrap(err, fmt.Sprintf("failed to call remote reconciler (URL: %s)", compRecon.URL))
+		return target, nil
 	}
+	i.logger.Errorf("Remote invoker could neither find a registered nor a configured fallback reconciler '%s'",
+		config.FallbackComponentReconciler)
+	return nil, &NoFallbackReconcilerDefinedError{}
+}
 
-	i.logger.Infof("Remote invoker triggered reconciliation of component '%s' on remote component reconciler '%s': %d",
-		component, compRecon.URL, resp.StatusCode)
-
-	return resp, nil
+//registeredTarget returns a healthy registered instance which handles the component (nil if discovery is disabled
+//or no such instance is registered). The mothership authorized the reconciler and its components when they were registered.
+func (i *RemoteReconcilerInvoker) registeredTarget(component string) (*Target, error) {
+	if i.router == nil {
+		return nil, nil
+	}
+	instance, err := i.router.Route(component)
+	if err != nil || instance == nil {
+		return nil, err
+	}
+	//the scheduler settings (e.g. the dispatch mode) of a configured reconciler are taken from the configuration,
+	//reconcilers which are not configured use the defaults
+	compRecon := i.config.Scheduler.Reconcilers[instance.Name]
+	compRecon.URL = instance.URL
+	return &Target{Name: instance.Name, ComponentReconciler: compRecon}, nil
 }
 
 func (i *RemoteReconcilerInvoker) unmarshalHTTPResponse(body []byte, respModel interface{}, params *Params) error {
+	return unmarshalHTTPResponse(i.logger, body, respModel, params)
+}
+
+func unmarshalHTTPResponse(logger *zap.SugaredLogger, body []byte, respModel interface{}, params *Params) error {
 	if err := json.Unmarshal(body, respModel); err != nil {
-		i.logger.Errorf("Remote invoker failed to unmarshal HTTP response of reconciler for component '%s': %s",
+		logger.Errorf("Remote invoker failed to unmarshal HTTP response of reconciler for component '%s': %s",
 			params.ComponentToReconcile.Component, err)
 		return err
 	}
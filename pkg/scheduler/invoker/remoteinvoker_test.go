@@ -3,18 +3,25 @@ package invoker
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/kyma-incubator/reconciler/internal/cli/test"
+	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/breaker"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/discovery"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/taskqueue"
 	"github.com/kyma-incubator/reconciler/pkg/server"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -24,7 +31,7 @@ func TestRemoteInvoker(t *testing.T) {
 	reconRepo := reconciliation.NewInMemoryReconciliationRepository()
 
 	//create reconciliation entity
-	reconEntity, err := reconRepo.CreateReconciliation(clusterStateMock, nil)
+	reconEntity, err := reconRepo.CreateReconciliation(clusterStateMock, nil, nil)
 	require.NoError(t, err)
 
 	//retrieve ops of reconciliation entity
@@ -90,6 +97,11 @@ func TestRemoteInvoker(t *testing.T) {
 		require.NoError(t, err)
 
 		requireOperationState(t, reconRepo, opEntities[2], model.OperationStateInProgress)
+
+		//callback secret was issued for the operation
+		op, err := reconRepo.GetOperation(opEntities[2].SchedulingID, opEntities[2].CorrelationID)
+		require.NoError(t, err)
+		require.NotEmpty(t, op.CallbackSecret)
 	})
 
 	t.Run("Invoke component-reconciler: return 400 error", func(t *testing.T) {
@@ -151,6 +163,281 @@ func TestRemoteInvoker(t *testing.T) {
 
 		requireOperationState(t, reconRepo, opEntities[5], model.OperationStateClientError)
 	})
+
+	t.Run("Plan component-reconciler: happy path", func(t *testing.T) {
+		changes, err := planRemoteInvoker(reconRepo, "http://127.0.0.1:5555/plan")
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		require.Equal(t, kubernetes.ChangeTypeCreate, changes[0].Type)
+
+		//plan requests don't touch the operations
+		requireOperationState(t, reconRepo, opEntities[6], model.OperationStateNew)
+	})
+
+	t.Run("Plan component-reconciler: return 500 error with error JSON response", func(t *testing.T) {
+		_, err := planRemoteInvoker(reconRepo, "http://127.0.0.1:5555/500nice")
+		require.EqualError(t, err, "Simulating a controlled failure situation in component reconciler")
+	})
+
+	t.Run("Plan component-reconciler: slow reconciler times out", func(t *testing.T) {
+		release := make(chan struct{})
+		slowReconciler := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer slowReconciler.Close()
+		defer close(release)
+
+		cfg := &config.Config{
+			Scheduler: config.SchedulerConfig{
+				Reconcilers: map[string]config.ComponentReconciler{
+					"base": {URL: slowReconciler.URL},
+				},
+			},
+		}
+		invoker := NewRemoteReoncilerInvoker(reconRepo, cfg, logger.NewLogger(true))
+		invoker.planClient.Timeout = 100 * time.Millisecond
+		start := time.Now()
+		_, err := invoker.Plan(context.Background(), &Params{
+			ComponentToReconcile: &keb.Component{Component: model.CRDComponent, Version: "1.2.3"},
+			ClusterState:         clusterStateMock,
+			CorrelationID:        "plan-correlation-id",
+		})
+		require.Error(t, err)
+		require.Less(t, int64(time.Since(start)), int64(5*time.Second))
+	})
+}
+
+func TestRemoteInvokerQueueDispatch(t *testing.T) {
+	reconRepo := reconciliation.NewInMemoryReconciliationRepository()
+	reconEntity, err := reconRepo.CreateReconciliation(clusterStateMock, nil, nil)
+	require.NoError(t, err)
+	opEntities, err := reconRepo.GetOperations(reconEntity.SchedulingID)
+	require.NoError(t, err)
+
+	queue, err := taskqueue.NewPersistentRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		Scheme: "https",
+		Host:   "mothership-reconciler",
+		Port:   443,
+		Scheduler: config.SchedulerConfig{
+			Reconcilers: map[string]config.ComponentReconciler{
+				"base": {
+					URL: "http://127.0.0.1:5555/200",
+				},
+				model.CRDComponent: {
+					Dispatch: config.DispatchModeQueue,
+				},
+			},
+		},
+	}
+	t.Run("Invoke without task queue", func(t *testing.T) {
+		err := invokeRemoteInvoker(reconRepo, opEntities[0], cfg)
+		require.Error(t, err)
+		requireOperationState(t, reconRepo, opEntities[0], model.OperationStateError)
+	})
+
+	t.Run("Invoke component-reconciler: task is queued", func(t *testing.T) {
+		op := opEntities[1]
+		invoker := NewRemoteReoncilerInvoker(reconRepo, cfg, logger.NewLogger(true)).WithTaskQueue(queue)
+		err := invoker.Invoke(context.Background(), &Params{
+			ComponentToReconcile: &keb.Component{
+				Component: model.CRDComponent,
+				Version:   "1.2.3",
+			},
+			ClusterState:  clusterStateMock,
+			SchedulingID:  op.SchedulingID,
+			CorrelationID: op.CorrelationID,
+		})
+		require.NoError(t, err)
+		requireOperationState(t, reconRepo, op, model.OperationStateInProgress)
+
+		//task can be leased by the component reconciler of the component
+		leased, err := queue.Lease(model.CRDComponent, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, op.CorrelationID, leased.CorrelationID)
+		require.Equal(t, op.SchedulingID, leased.SchedulingID)
+
+		task := &reconciler.Task{}
+		require.NoError(t, json.Unmarshal([]byte(leased.Payload), task))
+		require.Equal(t, model.CRDComponent, task.Component)
+		require.NotEmpty(t, task.CallbackSecret)
+		require.Equal(t, fmt.Sprintf("https://mothership-reconciler:443/v1/operations/%s/callback/%s",
+			op.SchedulingID, op.CorrelationID), task.CallbackURL)
+		require.NoError(t, queue.Complete(leased.CorrelationID, leased.LeaseID))
+	})
+}
+
+func TestRemoteInvokerDiscovery(t *testing.T) {
+	discoveryRepo, err := discovery.NewPersistentRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+
+	register := func(t *testing.T, instanceID, name string, components ...string) {
+		require.NoError(t, discoveryRepo.Register(&model.ReconcilerInstanceEntity{
+			InstanceID: instanceID,
+			Name:       name,
+			URL:        fmt.Sprintf("http://%s:8080/v1/run", instanceID),
+			Components: components,
+			Owner:      "owner",
+		}))
+		t.Cleanup(func() {
+			_, err := discoveryRepo.Deregister(instanceID, "owner")
+			require.NoError(t, err)
+		})
+	}
+
+	newInvoker := func(reconcilers map[string]config.ComponentReconciler) *RemoteReconcilerInvoker {
+		cfg := &config.Config{
+			Scheduler: config.SchedulerConfig{
+				Reconcilers: reconcilers,
+				Discovery:   config.DiscoveryConfig{Enabled: true},
+			},
+		}
+		return NewRemoteReoncilerInvoker(reconciliation.NewInMemoryReconciliationRepository(), cfg, logger.NewLogger(true)).
+			WithDiscovery(discovery.NewRouter(discoveryRepo, time.Minute))
+	}
+
+	requireTarget := func(t *testing.T, invoker *RemoteReconcilerInvoker, component, name, url string) {
+		target, err := invoker.target(component)
+		require.NoError(t, err)
+		require.Equal(t, name, target.Name)
+		require.Equal(t, url, target.URL)
+	}
+
+	t.Run("No reconciler registered or configured", func(t *testing.T) {
+		_, err := newInvoker(nil).target("istio")
+		require.True(t, IsNoFallbackReconcilerDefinedError(err))
+	})
+
+	t.Run("Registered fallback reconciler is used", func(t *testing.T) {
+		register(t, "base1", config.FallbackComponentReconciler)
+		invoker := newInvoker(map[string]config.ComponentReconciler{
+			config.FallbackComponentReconciler: {URL: "http://base-static:8080/v1/run"},
+		})
+		requireTarget(t, invoker, "istio", config.FallbackComponentReconciler, "http://base1:8080/v1/run")
+	})
+
+	t.Run("Registered reconciler which is not configured is used", func(t *testing.T) {
+		register(t, "unknown1", "unknown", "unknown-configuration")
+		invoker := newInvoker(nil)
+		requireTarget(t, invoker, "unknown", "unknown", "http://unknown1:8080/v1/run")
+		requireTarget(t, invoker, "unknown-configuration", "unknown", "http://unknown1:8080/v1/run")
+
+		target, err := invoker.target("unknown")
+		require.NoError(t, err)
+		require.False(t, target.QueuesTasks())
+	})
+
+	t.Run("Configured dedicated reconciler is preferred over registered fallback reconciler", func(t *testing.T) {
+		register(t, "base1", config.FallbackComponentReconciler)
+		invoker := newInvoker(map[string]config.ComponentReconciler{
+			"istio": {URL: "http://istio-static:8080/v1/run"},
+		})
+		requireTarget(t, invoker, "istio", "istio", "http://istio-static:8080/v1/run")
+	})
+
+	t.Run("Registered dedicated reconciler is preferred over configured reconcilers", func(t *testing.T) {
+		register(t, "istio1", "istio", "istio-configuration")
+		invoker := newInvoker(map[string]config.ComponentReconciler{
+			"istio":                            {URL: "http://istio-static:8080/v1/run"},
+			"istio-configuration":              {URL: "http://istio-static:8080/v1/run"},
+			config.FallbackComponentReconciler: {URL: "http://base-static:8080/v1/run"},
+		})
+		requireTarget(t, invoker, "istio", "istio", "http://istio1:8080/v1/run")
+		requireTarget(t, invoker, "istio-configuration", "istio", "http://istio1:8080/v1/run")
+		requireTarget(t, invoker, "serverless", config.FallbackComponentReconciler, "http://base-static:8080/v1/run")
+	})
+
+	t.Run("Configured dispatch mode applies to registered reconciler", func(t *testing.T) {
+		register(t, "istio1", "istio")
+		invoker := newInvoker(map[string]config.ComponentReconciler{
+			"istio": {Dispatch: config.DispatchModeQueue},
+		})
+		target, err := invoker.target("istio")
+		require.NoError(t, err)
+		require.True(t, target.QueuesTasks())
+	})
+}
+
+func TestRemoteInvokerCircuitBreaker(t *testing.T) {
+	reconRepo := reconciliation.NewInMemoryReconciliationRepository()
+	reconEntity, err := reconRepo.CreateReconciliation(clusterStateMock, nil, nil)
+	require.NoError(t, err)
+	opEntities, err := reconRepo.GetOperations(reconEntity.SchedulingID)
+	require.NoError(t, err)
+
+	breakers, err := breaker.NewBreakers(&breaker.Config{FailureThreshold: 2, ProbeInterval: time.Hour}, nil,
+		logger.NewLogger(true))
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		Scheme: "https",
+		Host:   "mothership-reconciler",
+		Port:   443,
+		Scheduler: config.SchedulerConfig{
+			Reconcilers: map[string]config.ComponentReconciler{
+				"base": {
+					URL: "http://127.0.0.1:5556/v1/run", //component reconciler is not reachable
+				},
+			},
+		},
+	}
+	invoker := NewRemoteReoncilerInvoker(reconRepo, cfg, logger.NewLogger(true)).WithCircuitBreakers(breakers)
+	invoke := func(op *model.OperationEntity) error {
+		return invoker.Invoke(context.Background(), &Params{
+			ComponentToReconcile: &keb.Component{
+				Component: model.CRDComponent,
+				Version:   "1.2.3",
+			},
+			ClusterState:  clusterStateMock,
+			SchedulingID:  op.SchedulingID,
+			CorrelationID: op.CorrelationID,
+		})
+	}
+
+	t.Run("Failure below threshold marks operation as failed", func(t *testing.T) {
+		err := invoke(opEntities[0])
+		require.Error(t, err)
+		require.False(t, IsCircuitOpenError(err))
+		requireOperationState(t, reconRepo, opEntities[0], model.OperationStateError)
+	})
+
+	t.Run("Failure which opens the circuit resets operation", func(t *testing.T) {
+		err := invoke(opEntities[1])
+		require.True(t, IsCircuitOpenError(err))
+		requireOperationState(t, reconRepo, opEntities[1], model.OperationStateNew)
+		require.Equal(t, []string{"http://127.0.0.1:5556/v1/run"}, breakers.OpenCircuits())
+	})
+
+	t.Run("Operations stay new while circuit is open", func(t *testing.T) {
+		for _, op := range opEntities[1:3] {
+			err := invoke(op)
+			require.True(t, IsCircuitOpenError(err))
+			requireOperationState(t, reconRepo, op, model.OperationStateNew)
+		}
+	})
+}
+
+func planRemoteInvoker(reconRepo reconciliation.Repository, url string) ([]*kubernetes.ResourceChange, error) {
+	cfg := &config.Config{
+		Scheduler: config.SchedulerConfig{
+			Reconcilers: map[string]config.ComponentReconciler{
+				"base": {
+					URL: url,
+				},
+			},
+		},
+	}
+	invoker := NewRemoteReoncilerInvoker(reconRepo, cfg, logger.NewLogger(true))
+	return invoker.Plan(context.Background(), &Params{
+		ComponentToReconcile: &keb.Component{
+			Component: model.CRDComponent,
+			Version:   "1.2.3",
+		},
+		ClusterState:  clusterStateMock,
+		CorrelationID: "plan-correlation-id",
+	})
 }
 
 func invokeRemoteInvoker(reconRepo reconciliation.Repository, op *model.OperationEntity, cfg *config.Config) error {
@@ -186,6 +473,13 @@ func startServer(ctx context.Context, t *testing.T) {
 		router.HandleFunc(
 			"/200",
 			func(w http.ResponseWriter, r *http.Request) {
+				task := &reconciler.Task{}
+				if err := json.NewDecoder(r.Body).Decode(task); err != nil || task.CallbackSecret == "" {
+					server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+						Error: "task with callback secret expected",
+					})
+					return
+				}
 				w.Header().Set("content-type", "application/json")
 				if err := json.NewEncoder(w).Encode(&reconciler.HTTPReconciliationResponse{}); err != nil {
 					server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
@@ -215,6 +509,29 @@ func startServer(ctx context.Context, t *testing.T) {
 			}).
 			Methods("PUT", "POST")
 
+		router.HandleFunc(
+			"/plan",
+			func(w http.ResponseWriter, r *http.Request) {
+				task := &reconciler.Task{}
+				if err := json.NewDecoder(r.Body).Decode(task); err != nil || task.Type != model.OperationTypePlan {
+					server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+						Error: "plan task expected",
+					})
+					return
+				}
+				w.Header().Set("content-type", "application/json")
+				if err := json.NewEncoder(w).Encode(&reconciler.HTTPPlanResponse{
+					Changes: []*kubernetes.ResourceChange{
+						{Kind: "ConfigMap", APIVersion: "v1", Name: "test", Namespace: "default", Type: kubernetes.ChangeTypeCreate},
+					},
+				}); err != nil {
+					server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+						Error: errors.Wrap(err, "failed to encode response payload to JSON").Error(),
+					})
+				}
+			}).
+			Methods("PUT", "POST")
+
 		router.HandleFunc(
 			"/500bad",
 			func(w http.ResponseWriter, r *http.Request) {
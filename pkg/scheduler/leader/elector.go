@@ -0,0 +1,197 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	tblLeases            = "scheduler_leases"
+	DefaultLeaseName     = "mothership"
+	DefaultLeaseDuration = 15 * time.Second
+	DefaultRenewInterval = 5 * time.Second
+)
+
+//Config of the leader election
+type Config struct {
+	Name          string        //name of the lease: all replicas competing for the leadership have to use the same name
+	Identity      string        //unique ID of the replica (default: hostname with random suffix)
+	LeaseDuration time.Duration //a lease which wasn't renewed within this duration can be taken over by another replica
+	RenewInterval time.Duration //interval the leader renews its lease (has to be smaller than the lease duration)
+}
+
+type metricsCollector interface {
+	OnLeadershipChange(identity string, leader bool)
+}
+
+//Elector competes with the other replicas for a lease stored in the database: the replica holding the lease is
+//the leader. The leader renews its lease periodically and another replica takes the lease over if it expired.
+type Elector struct {
+	conn      db.Connection
+	config    *Config
+	collector metricsCollector
+	logger    *zap.SugaredLogger
+	leader    int32 //1 if this replica is the leader (has to be accessed atomically)
+}
+
+func NewElector(conn db.Connection, cfg *Config, collector metricsCollector, logger *zap.SugaredLogger) (*Elector, error) {
+	config := *cfg
+	if config.Name == "" {
+		config.Name = DefaultLeaseName
+	}
+	if config.Identity == "" {
+		identity, err := newIdentity()
+		if err != nil {
+			return nil, err
+		}
+		config.Identity = identity
+	}
+	if config.LeaseDuration == 0 {
+		config.LeaseDuration = DefaultLeaseDuration
+	}
+	if config.RenewInterval == 0 {
+		config.RenewInterval = DefaultRenewInterval
+	}
+	if config.RenewInterval < 0 || config.RenewInterval >= config.LeaseDuration {
+		return nil, fmt.Errorf("renew interval of leader election (%s) has to be > 0 and smaller than the lease duration (%s)",
+			config.RenewInterval, config.LeaseDuration)
+	}
+	return &Elector{
+		conn:      conn,
+		config:    &config,
+		collector: collector,
+		logger:    logger,
+	}, nil
+}
+
+func newIdentity() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve hostname for identity of leader election")
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8]), nil
+}
+
+//Identity returns the ID this replica uses in the leader election
+func (e *Elector) Identity() string {
+	return e.config.Identity
+}
+
+//IsLeader returns true if this replica holds the lease
+func (e *Elector) IsLeader() bool {
+	return atomic.LoadInt32(&e.leader) == 1
+}
+
+//Run competes for the leadership until the context is closed. Whenever this replica becomes the leader, lead is
+//started with a context which is closed as soon as the leadership gets lost (e.g. the lease could not be renewed).
+//A new lead is only started after the previous one returned.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	e.logger.Infof("Replica '%s' joins leader election of lease '%s' (lease duration: %s, renew interval: %s)",
+		e.config.Identity, e.config.Name, e.config.LeaseDuration, e.config.RenewInterval)
+	e.setLeader(false)
+
+	var cancelLead context.CancelFunc
+	var leadDone chan struct{}
+	stepDown := func() {
+		cancelLead()
+		cancelLead = nil
+		e.setLeader(false)
+		<-leadDone //wait until the previous lead finished before competing again
+	}
+
+	ticker := time.NewTicker(e.config.RenewInterval)
+	defer ticker.Stop()
+	for {
+		acquired, err := e.tryAcquire()
+		if err != nil {
+			e.logger.Warnf("Replica '%s' failed to acquire or renew lease '%s': %s", e.config.Identity, e.config.Name, err)
+		}
+		if acquired && cancelLead == nil {
+			var leadCtx context.Context
+			leadCtx, cancelLead = context.WithCancel(ctx)
+			leadDone = make(chan struct{})
+			e.setLeader(true)
+			e.logger.Infof("Replica '%s' became leader of lease '%s'", e.config.Identity, e.config.Name)
+			go func(done chan struct{}) {
+				defer close(done)
+				lead(leadCtx)
+			}(leadDone)
+		} else if !acquired && cancelLead != nil {
+			//step down immediately: another replica can take the lease over after it expired
+			stepDown()
+			e.logger.Warnf("Replica '%s' lost leadership of lease '%s'", e.config.Identity, e.config.Name)
+		}
+
+		select {
+		case <-ctx.Done():
+			if cancelLead != nil {
+				stepDown()
+				if err := e.release(); err != nil {
+					e.logger.Warnf("Replica '%s' failed to release lease '%s': %s", e.config.Identity, e.config.Name, err)
+				} else {
+					e.logger.Infof("Replica '%s' released lease '%s'", e.config.Identity, e.config.Name)
+				}
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) setLeader(leader bool) {
+	var value int32
+	if leader {
+		value = 1
+	}
+	atomic.StoreInt32(&e.leader, value)
+	if e.collector != nil {
+		e.collector.OnLeadershipChange(e.config.Identity, leader)
+	}
+}
+
+//tryAcquire creates or renews the lease if it's held by this replica or expired. It returns true if this replica holds the lease.
+//Expiration times are always calculated by the database clock to be independent of clock skews between the replicas.
+func (e *Elector) tryAcquire() (bool, error) {
+	//ensure the lease exists (an expired lease without holder) to let the upsert below always run into the conflict clause
+	if _, err := e.conn.Exec(fmt.Sprintf("INSERT INTO %s (name, holder, expires) VALUES ($1, $2, $3) "+
+		"ON CONFLICT (name) DO NOTHING", tblLeases), e.config.Name, "", 0); err != nil {
+		return false, err
+	}
+	now := e.nowExpression()
+	result, err := e.conn.Exec(fmt.Sprintf("INSERT INTO %s (name, holder, expires) VALUES ($1, $2, $3) "+
+		"ON CONFLICT (name) DO UPDATE SET holder=excluded.holder, expires=%s+$4 "+
+		"WHERE %s.holder=excluded.holder OR %s.expires<%s",
+		tblLeases, now, tblLeases, tblLeases, now),
+		e.config.Name, e.config.Identity, 0, e.config.LeaseDuration.Nanoseconds())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+//nowExpression returns the SQL expression which resolves the current time of the database in Unix nanoseconds
+func (e *Elector) nowExpression() string {
+	if e.conn.Type() == db.SQLite {
+		return "CAST((julianday('now') - 2440587.5) * 86400000000000 AS INTEGER)"
+	}
+	return "CAST(EXTRACT(EPOCH FROM now()) * 1000000000 AS BIGINT)"
+}
+
+//release expires the lease of this replica which allows other replicas to take over without waiting
+func (e *Elector) release() error {
+	_, err := e.conn.Exec(fmt.Sprintf("UPDATE %s SET expires=$1 WHERE name=$2 AND holder=$3", tblLeases),
+		0, e.config.Name, e.config.Identity)
+	return err
+}
@@ -0,0 +1,160 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+type collectorMock struct {
+	sync.Mutex
+	changes []bool
+}
+
+func (c *collectorMock) OnLeadershipChange(identity string, leader bool) {
+	c.Lock()
+	defer c.Unlock()
+	c.changes = append(c.changes, leader)
+}
+
+func newTestElector(t *testing.T, conn db.Connection, lease, identity string) *Elector {
+	elector, err := NewElector(conn, &Config{
+		Name:          lease,
+		Identity:      identity,
+		LeaseDuration: 500 * time.Millisecond,
+		RenewInterval: 50 * time.Millisecond,
+	}, &collectorMock{}, logger.NewLogger(true))
+	require.NoError(t, err)
+	return elector
+}
+
+func TestElector(t *testing.T) {
+	conn := db.NewTestConnection(t)
+
+	t.Run("Validate config", func(t *testing.T) {
+		_, err := NewElector(conn, &Config{LeaseDuration: time.Second, RenewInterval: time.Second}, nil, logger.NewLogger(true))
+		require.Error(t, err)
+
+		elector, err := NewElector(conn, &Config{}, nil, logger.NewLogger(true))
+		require.NoError(t, err)
+		require.NotEmpty(t, elector.Identity())
+		require.Equal(t, DefaultLeaseName, elector.config.Name)
+	})
+
+	t.Run("Only one replica holds the lease", func(t *testing.T) {
+		lease := fmt.Sprintf("lease-%d", time.Now().UnixNano())
+		replica1 := newTestElector(t, conn, lease, "replica1")
+		replica2 := newTestElector(t, conn, lease, "replica2")
+
+		acquired, err := replica1.tryAcquire()
+		require.NoError(t, err)
+		require.True(t, acquired)
+		acquired, err = replica2.tryAcquire()
+		require.NoError(t, err)
+		require.False(t, acquired)
+
+		//leader renews its lease
+		acquired, err = replica1.tryAcquire()
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		//expired lease is taken over
+		time.Sleep(600 * time.Millisecond)
+		acquired, err = replica2.tryAcquire()
+		require.NoError(t, err)
+		require.True(t, acquired)
+		acquired, err = replica1.tryAcquire()
+		require.NoError(t, err)
+		require.False(t, acquired)
+
+		//released lease is taken over immediately
+		require.NoError(t, replica2.release())
+		acquired, err = replica1.tryAcquire()
+		require.NoError(t, err)
+		require.True(t, acquired)
+	})
+
+	t.Run("Leadership is handed over when the leader stops", func(t *testing.T) {
+		lease := fmt.Sprintf("lease-%d", time.Now().UnixNano())
+		replica1 := newTestElector(t, conn, lease, "replica1")
+		replica2 := newTestElector(t, conn, lease, "replica2")
+
+		leading := make(chan string, 2)
+		lead := func(identity string) func(ctx context.Context) {
+			return func(ctx context.Context) {
+				leading <- identity
+				<-ctx.Done()
+			}
+		}
+
+		ctx1, cancel1 := context.WithCancel(context.Background())
+		done1 := make(chan struct{})
+		go func() {
+			replica1.Run(ctx1, lead("replica1"))
+			close(done1)
+		}()
+		require.Equal(t, "replica1", <-leading)
+		require.True(t, replica1.IsLeader())
+
+		ctx2, cancel2 := context.WithCancel(context.Background())
+		defer cancel2()
+		go replica2.Run(ctx2, lead("replica2"))
+		time.Sleep(200 * time.Millisecond)
+		require.False(t, replica2.IsLeader())
+
+		cancel1()
+		<-done1
+		require.False(t, replica1.IsLeader())
+		select {
+		case identity := <-leading:
+			require.Equal(t, "replica2", identity)
+		case <-time.After(2 * time.Second):
+			require.Fail(t, "replica2 did not become leader")
+		}
+		require.True(t, replica2.IsLeader())
+		require.Equal(t, []bool{false, true, false}, replica1.collector.(*collectorMock).changes)
+	})
+
+	t.Run("New lead is not started while the previous lead is running", func(t *testing.T) {
+		lease := fmt.Sprintf("lease-%d", time.Now().UnixNano())
+		replica := newTestElector(t, conn, lease, "replica")
+
+		var running, overlapping int32
+		started := make(chan struct{}, 2)
+		lead := func(ctx context.Context) {
+			if atomic.AddInt32(&running, 1) > 1 {
+				atomic.StoreInt32(&overlapping, 1)
+			}
+			defer atomic.AddInt32(&running, -1)
+			started <- struct{}{}
+			<-ctx.Done()
+			time.Sleep(300 * time.Millisecond) //lead needs some time to stop its processes
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go replica.Run(ctx, lead)
+		<-started
+
+		//another replica takes the lease over: the replica loses and later re-acquires the leadership
+		_, err := conn.Exec(fmt.Sprintf("UPDATE %s SET holder=$1 WHERE name=$2", tblLeases), "other", lease)
+		require.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+		_, err = conn.Exec(fmt.Sprintf("UPDATE %s SET expires=$1 WHERE name=$2", tblLeases), 0, lease)
+		require.NoError(t, err)
+
+		select {
+		case <-started:
+		case <-time.After(2 * time.Second):
+			require.Fail(t, "replica did not become leader again")
+		}
+		require.Equal(t, int32(0), atomic.LoadInt32(&overlapping))
+	})
+}
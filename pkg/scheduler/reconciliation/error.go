@@ -5,6 +5,7 @@ import (
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
 )
 
 type DuplicateClusterReconciliationError struct {
@@ -47,3 +48,45 @@ func IsEmptyComponentsReconciliationError(err error) bool {
 	_, ok := err.(*EmptyComponentsReconciliationError)
 	return ok
 }
+
+type FinishedReconciliationError struct {
+	schedulingID string
+}
+
+func (err *FinishedReconciliationError) Error() string {
+	return fmt.Sprintf("reconciliation with schedulingID '%s' is already finished", err.schedulingID)
+}
+
+func newFinishedReconciliationError(entity *model.ReconciliationEntity) error {
+	return &FinishedReconciliationError{
+		schedulingID: entity.SchedulingID,
+	}
+}
+
+func IsFinishedReconciliationError(err error) bool {
+	_, ok := err.(*FinishedReconciliationError)
+	return ok
+}
+
+type OperationNotFailedError struct {
+	component string
+	state     model.OperationState
+}
+
+func (err *OperationNotFailedError) Error() string {
+	return fmt.Sprintf("operation of component '%s' cannot be retried or skipped because it is in state '%s' "+
+		"(only operations in state '%s' are accepted)", err.component, err.state, model.OperationStateError)
+}
+
+func newOperationNotFailedError(op *model.OperationEntity) error {
+	return &OperationNotFailedError{
+		component: op.Component,
+		state:     op.State,
+	}
+}
+
+//IsOperationNotFailedError returns true if the error (or the error it wraps) is an OperationNotFailedError
+func IsOperationNotFailedError(err error) bool {
+	var notFailedErr *OperationNotFailedError
+	return errors.As(err, &notFailedErr)
+}
@@ -16,6 +16,7 @@ import (
 type InMemoryReconciliationRepository struct {
 	reconciliations map[string]*model.ReconciliationEntity       //key: clusterName
 	operations      map[string]map[string]*model.OperationEntity //key1:schedulingID, key2:correlationID
+	notifier        db.Notifier
 	mu              sync.Mutex
 }
 
@@ -23,14 +24,23 @@ func NewInMemoryReconciliationRepository() Repository {
 	return &InMemoryReconciliationRepository{
 		reconciliations: make(map[string]*model.ReconciliationEntity),
 		operations:      make(map[string]map[string]*model.OperationEntity),
+		notifier:        db.NewInProcessNotifier(),
 	}
 }
 
+func (r *InMemoryReconciliationRepository) Notifier() db.Notifier {
+	return r.notifier
+}
+
+func (r *InMemoryReconciliationRepository) notify() {
+	_ = r.notifier.Notify(NotificationOperations) //in-process notifier never fails
+}
+
 func (r *InMemoryReconciliationRepository) WithTx(tx *db.TxConnection) (Repository, error) {
 	return r, nil
 }
 
-func (r *InMemoryReconciliationRepository) CreateReconciliation(state *cluster.State, preComponents [][]string) (*model.ReconciliationEntity, error) {
+func (r *InMemoryReconciliationRepository) CreateReconciliation(state *cluster.State, preComponents [][]string, onDemand *OnDemand) (*model.ReconciliationEntity, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -45,6 +55,15 @@ func (r *InMemoryReconciliationRepository) CreateReconciliation(state *cluster.S
 		}
 	}
 
+	reconSeq, err := reconciliationSequence(state, preComponents, onDemand)
+	if err != nil {
+		return nil, err
+	}
+	depGraph, err := reconSeq.DependencyGraph()
+	if err != nil {
+		return nil, err
+	}
+
 	//create reconciliation
 	reconEntity := &model.ReconciliationEntity{
 		Lock:                state.Cluster.RuntimeID,
@@ -53,11 +72,12 @@ func (r *InMemoryReconciliationRepository) CreateReconciliation(state *cluster.S
 		ClusterConfigStatus: state.Status.ID,
 		SchedulingID:        fmt.Sprintf("%s--%s", state.Cluster.RuntimeID, uuid.NewString()),
 	}
+	if onDemand != nil {
+		reconEntity.Reason = onDemand.Reason
+	}
 	r.reconciliations[state.Cluster.RuntimeID] = reconEntity
 
 	//create operations
-	reconSeq := state.Configuration.GetReconciliationSequence(preComponents)
-
 	if _, ok := r.operations[reconEntity.SchedulingID]; !ok {
 		r.operations[reconEntity.SchedulingID] = make(map[string]*model.OperationEntity)
 	}
@@ -67,18 +87,18 @@ func (r *InMemoryReconciliationRepository) CreateReconciliation(state *cluster.S
 		opType = model.OperationTypeDelete
 	}
 
-	for idx, components := range reconSeq.Queue {
-		priority := idx + 1
+	for _, components := range reconSeq.Queue {
 		for _, component := range components {
 			correlationID := fmt.Sprintf("%s--%s", state.Cluster.RuntimeID, uuid.NewString())
 
 			r.operations[reconEntity.SchedulingID][correlationID] = &model.OperationEntity{
-				Priority:      int64(priority),
+				Priority:      depGraph.Priorities[component.Component],
 				SchedulingID:  reconEntity.SchedulingID,
 				CorrelationID: correlationID,
 				RuntimeID:     reconEntity.RuntimeID,
 				ClusterConfig: state.Configuration.Version,
 				Component:     component.Component,
+				DependsOn:     depGraph.Dependencies[component.Component],
 				State:         model.OperationStateNew,
 				Type:          opType,
 				Created:       time.Now().UTC(),
@@ -87,6 +107,7 @@ func (r *InMemoryReconciliationRepository) CreateReconciliation(state *cluster.S
 		}
 	}
 
+	r.notify()
 	return reconEntity, nil
 }
 
@@ -137,6 +158,75 @@ func (r *InMemoryReconciliationRepository) FinishReconciliation(schedulingID str
 		"cannot finish reconciliation", schedulingID)
 }
 
+func (r *InMemoryReconciliationRepository) PauseReconciliation(schedulingID string) error {
+	return r.updatePaused(schedulingID, true)
+}
+
+func (r *InMemoryReconciliationRepository) ResumeReconciliation(schedulingID string) error {
+	return r.updatePaused(schedulingID, false)
+}
+
+func (r *InMemoryReconciliationRepository) updatePaused(schedulingID string, paused bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, recon := range r.reconciliations {
+		if recon.SchedulingID == schedulingID {
+			if recon.Finished {
+				return newFinishedReconciliationError(recon)
+			}
+			recon.Paused = paused
+			recon.Updated = time.Now().UTC()
+			r.notify()
+			return nil
+		}
+	}
+	return &repository.EntityNotFoundError{}
+}
+
+func (r *InMemoryReconciliationRepository) CancelReconciliation(schedulingID, reason string) error {
+	recon, err := r.GetReconciliation(schedulingID)
+	if err != nil {
+		return err
+	}
+	if recon.Finished {
+		return newFinishedReconciliationError(recon)
+	}
+	ops, err := r.GetOperations(schedulingID)
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if op.State.IsFinal() {
+			continue
+		}
+		if err := r.UpdateOperationState(schedulingID, op.CorrelationID, model.OperationStateCancelled, false, reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) ReopenReconciliation(schedulingID string, status *model.ClusterStatusEntity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, recon := range r.reconciliations {
+		if recon.SchedulingID == schedulingID {
+			if !recon.Finished {
+				return fmt.Errorf("cannot reopen reconciliation with schedulingID '%s': it is still running", schedulingID)
+			}
+			recon.Lock = recon.RuntimeID
+			recon.Finished = false
+			recon.ClusterConfigStatus = status.ID
+			recon.Updated = time.Now().UTC()
+			r.notify()
+			return nil
+		}
+	}
+	return &repository.EntityNotFoundError{}
+}
+
 func (r *InMemoryReconciliationRepository) GetReconciliations(filter Filter) ([]*model.ReconciliationEntity, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -192,7 +282,27 @@ func (r *InMemoryReconciliationRepository) GetProcessableOperations(maxParallelO
 	if err != nil {
 		return nil, err
 	}
-	return findProcessableOperations(allOps, maxParallelOpsPerRecon), nil
+	return findProcessableOperations(r.skipPaused(allOps), maxParallelOpsPerRecon), nil
+}
+
+//skipPaused removes the operations of paused reconciliations
+func (r *InMemoryReconciliationRepository) skipPaused(ops []*model.OperationEntity) []*model.OperationEntity {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	paused := make(map[string]bool)
+	for _, recon := range r.reconciliations {
+		if recon.Paused {
+			paused[recon.SchedulingID] = true
+		}
+	}
+	var result []*model.OperationEntity
+	for _, op := range ops {
+		if !paused[op.SchedulingID] {
+			result = append(result, op)
+		}
+	}
+	return result
 }
 
 func (r *InMemoryReconciliationRepository) GetReconcilingOperations() ([]*model.OperationEntity, error) {
@@ -243,5 +353,47 @@ func (r *InMemoryReconciliationRepository) UpdateOperationState(schedulingID, co
 
 	r.operations[schedulingID][correlationID] = &opCopy
 
+	r.notify()
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) ResolveFailedOperation(schedulingID, correlationID string, state model.OperationState, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	op, ok := r.operations[schedulingID][correlationID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+	if err := verifyFailedOperationResolution(op, state, reason); err != nil {
+		return err
+	}
+
+	// copy the operation to avoid having data races while writing
+	opCopy := *op
+	opCopy.State = state
+	opCopy.Reason = reason
+	opCopy.Updated = time.Now().UTC()
+	r.operations[schedulingID][correlationID] = &opCopy
+
+	r.notify()
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) UpdateOperationCallbackSecret(schedulingID, correlationID, secret string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	op, ok := r.operations[schedulingID][correlationID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+
+	// copy the operation to avoid having data races while writing
+	opCopy := *op
+	opCopy.CallbackSecret = secret
+	opCopy.Updated = time.Now().UTC()
+	r.operations[schedulingID][correlationID] = &opCopy
+
 	return nil
 }
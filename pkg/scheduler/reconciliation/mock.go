@@ -12,14 +12,21 @@ type MockRepository struct {
 	GetReconciliationResult        *model.ReconciliationEntity
 	GetReconciliationsResult       []*model.ReconciliationEntity
 	FinishReconciliationResult     error
+	PauseReconciliationResult      error
+	ResumeReconciliationResult     error
+	CancelReconciliationResult     error
+	ReopenReconciliationResult     error
 	GetOperationsResult            []*model.OperationEntity
 	GetOperationResult             *model.OperationEntity
 	GetProcessableOperationsResult []*model.OperationEntity
 	GetReconcilingOperationsResult []*model.OperationEntity
 	UpdateOperationStateResult     error
+	ResolveFailedOperationResult   error
+	UpdateCallbackSecretResult     error
+	notifier                       db.Notifier
 }
 
-func (mr *MockRepository) CreateReconciliation(state *cluster.State, preComponents [][]string) (*model.ReconciliationEntity, error) {
+func (mr *MockRepository) CreateReconciliation(state *cluster.State, preComponents [][]string, onDemand *OnDemand) (*model.ReconciliationEntity, error) {
 	return mr.CreateReconciliationResult, nil
 }
 
@@ -39,6 +46,22 @@ func (mr *MockRepository) FinishReconciliation(schedulingID string, status *mode
 	return mr.FinishReconciliationResult
 }
 
+func (mr *MockRepository) PauseReconciliation(schedulingID string) error {
+	return mr.PauseReconciliationResult
+}
+
+func (mr *MockRepository) ResumeReconciliation(schedulingID string) error {
+	return mr.ResumeReconciliationResult
+}
+
+func (mr *MockRepository) CancelReconciliation(schedulingID, reason string) error {
+	return mr.CancelReconciliationResult
+}
+
+func (mr *MockRepository) ReopenReconciliation(schedulingID string, status *model.ClusterStatusEntity) error {
+	return mr.ReopenReconciliationResult
+}
+
 func (mr *MockRepository) GetOperations(schedulingID string, state ...model.OperationState) ([]*model.OperationEntity, error) {
 	return mr.GetOperationsResult, nil
 }
@@ -59,6 +82,21 @@ func (mr *MockRepository) UpdateOperationState(schedulingID, correlationID strin
 	return mr.UpdateOperationStateResult
 }
 
+func (mr *MockRepository) ResolveFailedOperation(schedulingID, correlationID string, state model.OperationState, reason string) error {
+	return mr.ResolveFailedOperationResult
+}
+
+func (mr *MockRepository) UpdateOperationCallbackSecret(schedulingID, correlationID, secret string) error {
+	return mr.UpdateCallbackSecretResult
+}
+
 func (mr *MockRepository) WithTx(tx *db.TxConnection) (Repository, error) {
 	return mr, nil
 }
+
+func (mr *MockRepository) Notifier() db.Notifier {
+	if mr.notifier == nil {
+		mr.notifier = db.NewInProcessNotifier()
+	}
+	return mr.notifier
+}
@@ -30,11 +30,37 @@ func (r *PersistentReconciliationRepository) WithTx(tx *db.TxConnection) (Reposi
 	return NewPersistedReconciliationRepository(tx, r.Debug)
 }
 
-func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster.State, preComponents [][]string) (*model.ReconciliationEntity, error) {
+//Notifier returns the notifier of the database connection
+func (r *PersistentReconciliationRepository) Notifier() db.Notifier {
+	return r.Conn.Notifier()
+}
+
+//transactionWithNotification wakes up the subscribers of the operations channel after the transaction was committed
+func (r *PersistentReconciliationRepository) transactionWithNotification(dbOps func(tx *db.TxConnection) error) error {
+	if err := db.Transaction(r.Conn, dbOps, r.Logger); err != nil {
+		return err
+	}
+	r.Notify(NotificationOperations)
+	return nil
+}
+
+func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster.State, preComponents [][]string, onDemand *OnDemand) (*model.ReconciliationEntity, error) {
 	if len(state.Configuration.Components) == 0 {
 		return nil, newEmptyComponentsReconciliationError(state)
 	}
 
+	//get reconciliation sequence and the dependencies between its components
+	reconSeq, err := reconciliationSequence(state, preComponents, onDemand)
+	if err != nil {
+		r.Logger.Errorf("ReconRepo cannot create reconciliation for runtime '%s': %s", state.Cluster.RuntimeID, err)
+		return nil, err
+	}
+	depGraph, err := reconSeq.DependencyGraph()
+	if err != nil {
+		r.Logger.Errorf("ReconRepo cannot create reconciliation for runtime '%s': %s", state.Cluster.RuntimeID, err)
+		return nil, err
+	}
+
 	dbOps := func(tx *db.TxConnection) (interface{}, error) {
 		reconEntity := &model.ReconciliationEntity{
 			Lock:                state.Cluster.RuntimeID,
@@ -44,6 +70,9 @@ func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster
 			SchedulingID:        fmt.Sprintf("%s--%s", state.Cluster.RuntimeID, uuid.NewString()),
 			Status:              state.Status.Status,
 		}
+		if onDemand != nil {
+			reconEntity.Reason = onDemand.Reason
+		}
 
 		//find existing reconciliation for this cluster
 		existingReconQ, err := db.NewQuery(tx, reconEntity, r.Logger)
@@ -81,9 +110,6 @@ func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster
 		r.Logger.Debugf("ReconRepo created new reconciliation for runtime '%s' with schedulingID '%s'",
 			state.Cluster.RuntimeID, reconEntity.SchedulingID)
 
-		//get reconciliation sequence
-		reconSeq := state.Configuration.GetReconciliationSequence(preComponents)
-
 		opType := model.OperationTypeReconcile
 		if state.Status.Status.IsDeletion() {
 			opType = model.OperationTypeDelete
@@ -92,16 +118,17 @@ func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster
 		//iterate over reconciliation sequence and create operations with proper priorities
 		var opsList bytes.Buffer
 
-		for idx, components := range reconSeq.Queue {
-			priority := idx + 1
+		for _, components := range reconSeq.Queue {
 			for _, component := range components {
+				priority := depGraph.Priorities[component.Component]
 				createOpQ, err := db.NewQuery(tx, &model.OperationEntity{
-					Priority:      int64(priority),
+					Priority:      priority,
 					SchedulingID:  reconEntity.SchedulingID,
 					CorrelationID: fmt.Sprintf("%s--%s", state.Cluster.RuntimeID, uuid.NewString()),
 					RuntimeID:     reconEntity.RuntimeID,
 					ClusterConfig: reconEntity.ClusterConfig,
 					Component:     component.Component,
+					DependsOn:     depGraph.Dependencies[component.Component],
 					State:         model.OperationStateNew,
 					Type:          opType,
 					Updated:       time.Now().UTC(),
@@ -134,6 +161,7 @@ func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster
 	if err != nil {
 		return nil, err
 	}
+	r.Notify(NotificationOperations)
 	return result.(*model.ReconciliationEntity), nil
 }
 
@@ -226,6 +254,133 @@ func (r *PersistentReconciliationRepository) FinishReconciliation(schedulingID s
 	return db.Transaction(r.Conn, dbOps, r.Logger)
 }
 
+func (r *PersistentReconciliationRepository) PauseReconciliation(schedulingID string) error {
+	return r.updatePaused(schedulingID, true)
+}
+
+func (r *PersistentReconciliationRepository) ResumeReconciliation(schedulingID string) error {
+	return r.updatePaused(schedulingID, false)
+}
+
+func (r *PersistentReconciliationRepository) updatePaused(schedulingID string, paused bool) error {
+	dbOps := func(tx *db.TxConnection) error {
+		rTx, err := r.WithTx(tx)
+		if err != nil {
+			return err
+		}
+		reconEntity, err := rTx.GetReconciliation(schedulingID)
+		if err != nil {
+			return err
+		}
+		if reconEntity.Finished {
+			return newFinishedReconciliationError(reconEntity)
+		}
+		if reconEntity.Paused == paused {
+			return nil
+		}
+
+		reconEntity.Paused = paused
+		reconEntity.Updated = time.Now().UTC()
+		updReconQ, err := db.NewQuery(tx, reconEntity, r.Logger)
+		if err != nil {
+			return err
+		}
+		cnt, err := updReconQ.Update().
+			Where(
+				map[string]interface{}{
+					"SchedulingID": schedulingID,
+					"Finished":     false,
+				}).
+			ExecCount()
+		if err != nil {
+			return err
+		}
+		if cnt == 0 {
+			return fmt.Errorf("failed to update paused flag of reconciliation with schedulingID '%s' "+
+				"(maybe finished by parallel running process)", schedulingID)
+		}
+		r.Logger.Infof("ReconRepo updated paused flag of reconciliation with schedulingID '%s' to %t", schedulingID, paused)
+		return nil
+	}
+	return r.transactionWithNotification(dbOps)
+}
+
+func (r *PersistentReconciliationRepository) CancelReconciliation(schedulingID, reason string) error {
+	dbOps := func(tx *db.TxConnection) error {
+		rTx, err := r.WithTx(tx)
+		if err != nil {
+			return err
+		}
+		reconEntity, err := rTx.GetReconciliation(schedulingID)
+		if err != nil {
+			return err
+		}
+		if reconEntity.Finished {
+			return newFinishedReconciliationError(reconEntity)
+		}
+
+		ops, err := rTx.GetOperations(schedulingID)
+		if err != nil {
+			return err
+		}
+		for _, op := range ops {
+			if op.State.IsFinal() {
+				continue
+			}
+			if err := rTx.UpdateOperationState(schedulingID, op.CorrelationID, model.OperationStateCancelled, false, reason); err != nil {
+				return errors.Wrapf(err, "failed to cancel operation '%s'", op)
+			}
+		}
+		r.Logger.Infof("ReconRepo cancelled reconciliation with schedulingID '%s': %s", schedulingID, reason)
+		return nil
+	}
+	return r.transactionWithNotification(dbOps)
+}
+
+func (r *PersistentReconciliationRepository) ReopenReconciliation(schedulingID string, status *model.ClusterStatusEntity) error {
+	dbOps := func(tx *db.TxConnection) error {
+		rTx, err := r.WithTx(tx)
+		if err != nil {
+			return err
+		}
+		reconEntity, err := rTx.GetReconciliation(schedulingID)
+		if err != nil {
+			return err
+		}
+		if !reconEntity.Finished {
+			return fmt.Errorf("cannot reopen reconciliation with schedulingID '%s': it is still running", schedulingID)
+		}
+
+		//restore lock: fails if another reconciliation of the cluster is running
+		reconEntity.Lock = reconEntity.RuntimeID
+		reconEntity.Finished = false
+		reconEntity.ClusterConfigStatus = status.ID
+		reconEntity.Status = status.Status
+		reconEntity.Updated = time.Now().UTC()
+		updReconQ, err := db.NewQuery(tx, reconEntity, r.Logger)
+		if err != nil {
+			return err
+		}
+		cnt, err := updReconQ.Update().
+			Where(
+				map[string]interface{}{
+					"SchedulingID": schedulingID,
+					"Finished":     true,
+				}).
+			ExecCount()
+		if err != nil {
+			return err
+		}
+		if cnt == 0 {
+			return fmt.Errorf("failed to reopen reconciliation with schedulingID '%s' "+
+				"(maybe updated by parallel running process)", schedulingID)
+		}
+		r.Logger.Infof("ReconRepo reopened reconciliation with schedulingID '%s'", schedulingID)
+		return nil
+	}
+	return r.transactionWithNotification(dbOps)
+}
+
 func (r *PersistentReconciliationRepository) GetReconciliations(filter Filter) ([]*model.ReconciliationEntity, error) {
 	q, err := db.NewQuery(r.Conn, &model.ReconciliationEntity{}, r.Logger)
 	if err != nil {
@@ -309,7 +464,7 @@ func (r *PersistentReconciliationRepository) GetOperation(schedulingID, correlat
 }
 
 func (r *PersistentReconciliationRepository) GetProcessableOperations(maxParallelOpsPerRecon int) ([]*model.OperationEntity, error) {
-	opEntities, err := r.GetReconcilingOperations()
+	opEntities, err := r.getReconcilingOperations(true)
 	if err != nil {
 		return nil, err
 	}
@@ -317,6 +472,10 @@ func (r *PersistentReconciliationRepository) GetProcessableOperations(maxParalle
 }
 
 func (r *PersistentReconciliationRepository) GetReconcilingOperations() ([]*model.OperationEntity, error) {
+	return r.getReconcilingOperations(false)
+}
+
+func (r *PersistentReconciliationRepository) getReconcilingOperations(skipPaused bool) ([]*model.OperationEntity, error) {
 	//retrieve all non-finished operations
 	reconEntity := &model.ReconciliationEntity{}
 	colHdr, err := db.NewColumnHandler(reconEntity, r.Conn, r.Logger)
@@ -331,15 +490,23 @@ func (r *PersistentReconciliationRepository) GetReconcilingOperations() ([]*mode
 	if err != nil {
 		return nil, err
 	}
+	//consider only operations which are part of a running reconciliations
+	subQuery := fmt.Sprintf("SELECT %s FROM %s WHERE %s=$1", schedulingIDCol, reconEntity.Table(), FinishedCol)
+	args := []interface{}{false}
+	if skipPaused {
+		pausedCol, err := colHdr.ColumnName("Paused")
+		if err != nil {
+			return nil, err
+		}
+		subQuery = fmt.Sprintf("%s AND %s=$2", subQuery, pausedCol)
+		args = append(args, false)
+	}
 	q, err := db.NewQuery(r.Conn, &model.OperationEntity{}, r.Logger)
 	if err != nil {
 		return nil, err
 	}
 	ops, err := q.Select().
-		WhereIn(
-			"SchedulingID",
-			//consider only operations which are part of a running reconciliations
-			fmt.Sprintf("SELECT %s FROM %s WHERE %s=$1", schedulingIDCol, reconEntity.Table(), FinishedCol), false).
+		WhereIn("SchedulingID", subQuery, args...).
 		GetMany()
 	if err != nil {
 		return nil, err
@@ -411,5 +578,82 @@ func (r *PersistentReconciliationRepository) UpdateOperationState(schedulingID,
 
 		return nil
 	}
+	return r.transactionWithNotification(dbOps)
+}
+
+func (r *PersistentReconciliationRepository) ResolveFailedOperation(schedulingID, correlationID string, state model.OperationState, reason string) error {
+	dbOps := func(tx *db.TxConnection) error {
+		rTx, err := r.WithTx(tx)
+		if err != nil {
+			return err
+		}
+		op, err := rTx.GetOperation(schedulingID, correlationID)
+		if err != nil {
+			return err
+		}
+		if err := verifyFailedOperationResolution(op, state, reason); err != nil {
+			return err
+		}
+
+		op.State = state
+		op.Reason = reason
+		op.Updated = time.Now().UTC()
+		q, err := db.NewQuery(tx, op, r.Logger)
+		if err != nil {
+			return err
+		}
+		cnt, err := q.Update().
+			Where(map[string]interface{}{
+				"CorrelationID": correlationID,
+				"SchedulingID":  schedulingID,
+				"State":         model.OperationStateError, //ensure the operation was not resolved in between
+			}).
+			ExecCount()
+		if err != nil {
+			return err
+		}
+		if cnt == 0 {
+			return fmt.Errorf("update of failed operation '%s' to state '%s' failed: no row was updated "+
+				"(probably race-condition: operation does no longer match where-conditions)", op, state)
+		}
+		r.Logger.Infof("ReconRepo set failed operation '%s' to state '%s'", op, state)
+		return nil
+	}
+	return r.transactionWithNotification(dbOps)
+}
+
+func (r *PersistentReconciliationRepository) UpdateOperationCallbackSecret(schedulingID, correlationID, secret string) error {
+	dbOps := func(tx *db.TxConnection) error {
+		rTx, err := r.WithTx(tx)
+		if err != nil {
+			return err
+		}
+		op, err := rTx.GetOperation(schedulingID, correlationID)
+		if err != nil {
+			return err
+		}
+
+		op.CallbackSecret = secret
+		op.Updated = time.Now().UTC()
+		q, err := db.NewQuery(tx, op, r.Logger)
+		if err != nil {
+			return err
+		}
+		cnt, err := q.Update().
+			Where(map[string]interface{}{
+				"CorrelationID": correlationID,
+				"SchedulingID":  schedulingID,
+				"State":         op.State, //ensure the operation was not updated in between
+			}).
+			ExecCount()
+		if err != nil {
+			return err
+		}
+		if cnt == 0 {
+			return fmt.Errorf("update of callback secret of operation '%s' failed: no row was updated "+
+				"(probably race-condition: operation does no longer match where-conditions)", op)
+		}
+		return nil
+	}
 	return db.Transaction(r.Conn, dbOps, r.Logger)
 }
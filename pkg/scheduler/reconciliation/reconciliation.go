@@ -10,65 +10,138 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/model"
 )
 
+//NotificationOperations is the notification channel which wakes up the processes waiting for operations
+//(e.g. the worker pool) whenever operations were created or changed their state
+const NotificationOperations = "reconciler_operations"
+
 type Filter interface {
 	FilterByQuery(q *db.Select) error
 	FilterByInstance(i *model.ReconciliationEntity) *model.ReconciliationEntity //return nil to ignore instance in result
 }
 
+//OnDemand defines a reconciliation which was explicitly requested (e.g. by a support engineer)
+type OnDemand struct {
+	Components []string //reconcile only these components and the components they require (empty = all components)
+	Reason     string
+}
+
 type Repository interface {
-	CreateReconciliation(state *cluster.State, preComponents [][]string) (*model.ReconciliationEntity, error)
+	//CreateReconciliation creates a reconciliation with operations for the components of the cluster
+	//(onDemand is nil for scheduled reconciliations)
+	CreateReconciliation(state *cluster.State, preComponents [][]string, onDemand *OnDemand) (*model.ReconciliationEntity, error)
 	RemoveReconciliation(schedulingID string) error
 	GetReconciliation(schedulingID string) (*model.ReconciliationEntity, error)
 	GetReconciliations(filter Filter) ([]*model.ReconciliationEntity, error)
 	FinishReconciliation(schedulingID string, status *model.ClusterStatusEntity) error
+	//PauseReconciliation stops the assignment of further operations of a running reconciliation to workers
+	PauseReconciliation(schedulingID string) error
+	//ResumeReconciliation continues the assignment of operations of a paused reconciliation
+	ResumeReconciliation(schedulingID string) error
+	//CancelReconciliation sets all unfinished operations of a running reconciliation to state 'cancelled'
+	CancelReconciliation(schedulingID, reason string) error
+	//ReopenReconciliation continues a finished reconciliation (e.g. after one of its failed operations was resolved)
+	ReopenReconciliation(schedulingID string, status *model.ClusterStatusEntity) error
 	GetOperations(schedulingID string, state ...model.OperationState) ([]*model.OperationEntity, error)
 	GetOperation(schedulingID, correlationID string) (*model.OperationEntity, error)
-	//GetProcessableOperations returns all operations which can be assigned to a worker
+	//GetProcessableOperations returns all operations which can be assigned to a worker (paused reconciliations are ignored)
 	GetProcessableOperations(maxParallelOpsPerRecon int) ([]*model.OperationEntity, error)
 	//GetReconcilingOperations returns all operations which are part of currently running reconciliations
 	GetReconcilingOperations() ([]*model.OperationEntity, error)
 	UpdateOperationState(schedulingID, correlationID string, state model.OperationState, allowInState bool, reasons ...string) error
+	//ResolveFailedOperation moves an operation out of state 'error': to state 'new' if it has to be retried
+	//or to state 'skipped' if the remaining operations have to be processed without it
+	ResolveFailedOperation(schedulingID, correlationID string, state model.OperationState, reason string) error
+	//UpdateOperationCallbackSecret stores the secret which the component reconciler has to use for signing its callbacks
+	UpdateOperationCallbackSecret(schedulingID, correlationID, secret string) error
+	//Notifier returns the notifier used to announce changes of operations on the channel NotificationOperations
+	Notifier() db.Notifier
 	WithTx(tx *db.TxConnection) (Repository, error)
 }
 
+//reconciliationSequence returns the sequence of the components to reconcile: if components were selected for an
+//on-demand reconciliation, only these and their required components are part of the sequence
+func reconciliationSequence(state *cluster.State, preComponents [][]string, onDemand *OnDemand) (*model.ReconciliationSequence, error) {
+	reconSeq := state.Configuration.GetReconciliationSequence(preComponents)
+	if onDemand == nil || len(onDemand.Components) == 0 {
+		return reconSeq, nil
+	}
+	return reconSeq.Select(onDemand.Components)
+}
+
 //findProcessableOperations returns all operations in all running reconciliations which are ready to be processed.
-//The priority of an operation is considered (1=highest priority, 2-x=lower priorities).
-//An operation with a high priority has first to be finished before operations with a lower priority
+//Operations with dependencies are processable as soon as all operations they depend on are done.
+//Older operations without dependencies are processed by their priority (1=highest priority, 2-x=lower priorities):
+//an operation with a high priority has first to be finished before operations with a lower priority
 //are considered as processable.
-// For deletion operations, the priority is reversed, as deletion has to be done backwards.
+// For deletion operations, the order is reversed, as deletion has to be done backwards.
 func findProcessableOperations(ops []*model.OperationEntity, maxParallelOpsPerRecon int) []*model.OperationEntity {
-	//group ops per reconciliation and their prio
-	groupedByReconAndPrio := make(map[string]map[int64][]*model.OperationEntity) //key1:schedulingID, key2:prio
+	//group ops per reconciliation
+	groupedByRecon := make(map[string][]*model.OperationEntity) //key:schedulingID
 	for _, op := range ops {
-		if _, ok := groupedByReconAndPrio[op.SchedulingID]; !ok {
-			groupedByReconAndPrio[op.SchedulingID] = make(map[int64][]*model.OperationEntity)
+		groupedByRecon[op.SchedulingID] = append(groupedByRecon[op.SchedulingID], op)
+	}
+
+	var result []*model.OperationEntity
+	for _, reconOps := range groupedByRecon { //iterate of reconciliations
+		if graph := model.NewOperationGraph(reconOps); graph != nil {
+			result = append(result, findProcessableOperationsInGraph(reconOps, graph, maxParallelOpsPerRecon)...)
+			continue
 		}
-		samePrioGroup, ok := groupedByReconAndPrio[op.SchedulingID][op.Priority]
-		if ok {
-			samePrioGroup = append(samePrioGroup, op)
-		} else {
-			samePrioGroup = []*model.OperationEntity{op}
+		result = append(result, findProcessableOperationsByPrio(reconOps, maxParallelOpsPerRecon)...)
+	}
+	return result
+}
+
+//findProcessableOperationsInGraph returns the operations whose predecessors are all done (or skipped). An operation
+//in error state blocks only the operations which depend on it: independent operations are still processed.
+func findProcessableOperationsInGraph(ops []*model.OperationEntity, graph *model.OperationGraph, maxParallelOpsPerRecon int) []*model.OperationEntity {
+	var opsInProgress int
+	var processables []*model.OperationEntity
+
+	for _, op := range ops {
+		switch op.State {
+		case model.OperationStateDone, model.OperationStateSkipped, model.OperationStateError, model.OperationStateCancelled:
+			continue
+		case model.OperationStateInProgress, model.OperationStateFailed:
+			opsInProgress++
+			continue
+		}
+		if predecessorsDone(graph.Predecessors(op)) {
+			processables = append(processables, op)
 		}
-		groupedByReconAndPrio[op.SchedulingID][op.Priority] = samePrioGroup
 	}
 
-	//find per reconciliation the processable ops in a prio-group
+	return throttle(processables, opsInProgress, maxParallelOpsPerRecon)
+}
+
+func predecessorsDone(predecessors []*model.OperationEntity) bool {
+	for _, predecessor := range predecessors {
+		if !predecessor.State.IsCompleted() {
+			return false
+		}
+	}
+	return true
+}
+
+func findProcessableOperationsByPrio(ops []*model.OperationEntity, maxParallelOpsPerRecon int) []*model.OperationEntity {
+	//group ops by their prio
+	opsWithSamePrio := make(map[int64][]*model.OperationEntity)
+	for _, op := range ops {
+		opsWithSamePrio[op.Priority] = append(opsWithSamePrio[op.Priority], op)
+	}
+
+	//find the processable ops in a prio-group
 	// Reconciliation: searching from highest to lowest prio-group.
 	// Deletion: searching from lowest to highest prio-group.
-	var result []*model.OperationEntity
-
-	for _, opsWithSamePrio := range groupedByReconAndPrio { //iterate of reconciliations
-		reverse := opGroupType(opsWithSamePrio) == model.OperationTypeDelete // in case of deletion priorities are reversed.
-		for _, prio := range prios(opsWithSamePrio, reverse) {               //iterate over prio-groups
-			processable, checkNextGroup := findProcessableOperationsInGroup(opsWithSamePrio[prio], maxParallelOpsPerRecon)
-			if checkNextGroup {
-				continue
-			}
-			result = append(result, processable...)
-			break
+	reverse := opGroupType(opsWithSamePrio) == model.OperationTypeDelete // in case of deletion priorities are reversed.
+	for _, prio := range prios(opsWithSamePrio, reverse) {               //iterate over prio-groups
+		processable, checkNextGroup := findProcessableOperationsInGroup(opsWithSamePrio[prio], maxParallelOpsPerRecon)
+		if checkNextGroup {
+			continue
 		}
+		return processable
 	}
-	return result
+	return nil
 }
 
 // prios sorts the priorities in the map. If reverse is provided, priorities will go from lower to higher.
@@ -109,12 +182,12 @@ func findProcessableOperationsInGroup(ops []*model.OperationEntity, maxParallelO
 	var processables []*model.OperationEntity
 
 	for _, op := range ops {
-		//if one of the components is in error state, stop processing of remaining tasks
-		if op.State == model.OperationStateError {
+		//if one of the components is in error state or was cancelled, stop processing of remaining tasks
+		if op.State == model.OperationStateError || op.State == model.OperationStateCancelled {
 			return nil, false
 		}
-		//ignore component which were already successfully processed
-		if op.State == model.OperationStateDone {
+		//ignore component which were already successfully processed or skipped by a user
+		if op.State.IsCompleted() {
 			continue
 		}
 		//ignore operations which are currently in progress
@@ -126,7 +199,11 @@ func findProcessableOperationsInGroup(ops []*model.OperationEntity, maxParallelO
 		processables = append(processables, op)
 	}
 
-	//throttle amount of parallel processed ops in a reconciliation
+	return throttle(processables, opsInProgress, maxParallelOpsPerRecon), opsInProgress == 0 && len(processables) == 0
+}
+
+//throttle limits the amount of parallel processed ops in a reconciliation
+func throttle(processables []*model.OperationEntity, opsInProgress, maxParallelOpsPerRecon int) []*model.OperationEntity {
 	if maxParallelOpsPerRecon > 0 {
 		if (len(processables) + opsInProgress) > maxParallelOpsPerRecon { //start throttling
 			freeCapacity := maxParallelOpsPerRecon - opsInProgress
@@ -137,8 +214,7 @@ func findProcessableOperationsInGroup(ops []*model.OperationEntity, maxParallelO
 			}
 		}
 	}
-
-	return processables, opsInProgress == 0 && len(processables) == 0
+	return processables
 }
 
 func concatStateReasons(state model.OperationState, reasons []string) (string, error) {
@@ -148,6 +224,25 @@ func concatStateReasons(state model.OperationState, reasons []string) (string, e
 	return strings.Join(reasons, ", "), nil
 }
 
+//verifyFailedOperationResolution checks that a failed operation gets retried or skipped (skipping requires a reason)
+func verifyFailedOperationResolution(op *model.OperationEntity, state model.OperationState, reason string) error {
+	if op.State != model.OperationStateError {
+		return newOperationNotFailedError(op)
+	}
+	switch state {
+	case model.OperationStateNew:
+		return nil
+	case model.OperationStateSkipped:
+		if strings.TrimSpace(reason) == "" {
+			return fmt.Errorf("cannot skip operation '%s' without providing a reason", op.Component)
+		}
+		return nil
+	default:
+		return fmt.Errorf("failed operation '%s' can only be retried or skipped but not set to state '%s'",
+			op.Component, state)
+	}
+}
+
 func operationAlreadyInState(op *model.OperationEntity, state model.OperationState) error {
 	if op.State == state {
 		return newAlreadyInStateError(op)
@@ -239,6 +239,24 @@ func TestReconciliationFindProcessableOps(t *testing.T) {
 			opsGot := findProcessableOperations(ops, 0)
 			require.Empty(t, opsGot)
 		},
+		"Find with skipped operations at reconcile prio 1 and delete prio 3": func(t *testing.T) {
+			ops[0].State = model.OperationStateSkipped
+			ops[6].State = model.OperationStateDone
+			ops[7].State = model.OperationStateSkipped
+			ops[8].State = model.OperationStateDone
+			ops[11].State = model.OperationStateSkipped
+			opsGot := findProcessableOperations(ops, 0)
+			require.ElementsMatch(t, []*model.OperationEntity{ops[1], ops[10]}, opsGot)
+		},
+		"Find with cancelled operations": func(t *testing.T) {
+			for _, op := range ops {
+				op.State = model.OperationStateCancelled
+			}
+			ops[0].State = model.OperationStateDone
+			ops[6].State = model.OperationStateDone
+			opsGot := findProcessableOperations(ops, 0)
+			require.Empty(t, opsGot)
+		},
 	}
 
 	for name, testCaseFct := range testCases {
@@ -248,6 +266,72 @@ func TestReconciliationFindProcessableOps(t *testing.T) {
 
 }
 
+func TestReconciliationFindProcessableOpsWithDependencies(t *testing.T) {
+	newOp := func(component string, opType model.OperationType, dependsOn ...string) *model.OperationEntity {
+		return &model.OperationEntity{
+			SchedulingID:  string(opType),
+			CorrelationID: fmt.Sprintf("%s.%s", opType, component),
+			Component:     component,
+			DependsOn:     dependsOn,
+			State:         model.OperationStateNew,
+			Type:          opType,
+		}
+	}
+	//istio <- serverless <- eventing, monitoring is independent
+	var ops []*model.OperationEntity
+	for _, opType := range []model.OperationType{model.OperationTypeReconcile, model.OperationTypeDelete} {
+		ops = append(ops,
+			newOp("istio", opType),
+			newOp("serverless", opType, "istio"),
+			newOp("eventing", opType, "serverless"),
+			newOp("monitoring", opType))
+	}
+	reconcileOps, deleteOps := ops[:4], ops[4:]
+
+	testCases := map[string]func(t *testing.T){
+		"Find operations without dependencies": func(t *testing.T) {
+			require.ElementsMatch(t, []*model.OperationEntity{reconcileOps[0], reconcileOps[3], deleteOps[2], deleteOps[3]},
+				findProcessableOperations(ops, 0))
+		},
+		"Find operations whose dependencies are done": func(t *testing.T) {
+			reconcileOps[0].State = model.OperationStateDone
+			reconcileOps[3].State = model.OperationStateInProgress
+			deleteOps[2].State = model.OperationStateDone
+			require.ElementsMatch(t, []*model.OperationEntity{reconcileOps[1], deleteOps[1], deleteOps[3]},
+				findProcessableOperations(ops, 0))
+		},
+		"Failed operation blocks only its dependants": func(t *testing.T) {
+			reconcileOps[0].State = model.OperationStateError
+			deleteOps[2].State = model.OperationStateError
+			require.ElementsMatch(t, []*model.OperationEntity{reconcileOps[3], deleteOps[3]},
+				findProcessableOperations(ops, 0))
+		},
+		"Skipped operation unblocks its dependants": func(t *testing.T) {
+			reconcileOps[0].State = model.OperationStateSkipped
+			reconcileOps[3].State = model.OperationStateDone
+			deleteOps[2].State = model.OperationStateSkipped
+			require.ElementsMatch(t, []*model.OperationEntity{reconcileOps[1], deleteOps[1], deleteOps[3]},
+				findProcessableOperations(ops, 0))
+		},
+		"Cancelled operations are not processable": func(t *testing.T) {
+			for _, op := range ops {
+				op.State = model.OperationStateCancelled
+			}
+			require.Empty(t, findProcessableOperations(ops, 0))
+		},
+		"Find operations with throttling": func(t *testing.T) {
+			reconcileOps[3].State = model.OperationStateInProgress
+			require.Empty(t, findProcessableOperations(reconcileOps, 1))
+			require.Equal(t, []*model.OperationEntity{reconcileOps[0]}, findProcessableOperations(reconcileOps, 2))
+		},
+	}
+
+	for name, testCaseFct := range testCases {
+		t.Run(name, testCaseFct)
+		resetOperationState(ops)
+	}
+}
+
 func resetOperationState(ops []*model.OperationEntity) {
 	for _, op := range ops {
 		op.State = model.OperationStateNew
@@ -259,7 +343,7 @@ func TestReconciliationRepository(t *testing.T) {
 		{
 			name: "Create reconciliation",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil)
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
 
 				require.NoError(t, err)
 				require.NotEmpty(t, reconEntity.SchedulingID)
@@ -271,7 +355,7 @@ func TestReconciliationRepository(t *testing.T) {
 		{
 			name: "Get existing reconciliation",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil)
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
 				require.NoError(t, err)
 				reconGot, err := reconRepo.GetReconciliation(reconEntity.SchedulingID)
 				require.NoError(t, err)
@@ -289,10 +373,10 @@ func TestReconciliationRepository(t *testing.T) {
 		{
 			name: "Create duplicate reconciliation",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				_, err := reconRepo.CreateReconciliation(stateMock1, nil)
+				_, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
 				require.NoError(t, err)
 
-				_, err = reconRepo.CreateReconciliation(stateMock1, nil)
+				_, err = reconRepo.CreateReconciliation(stateMock1, nil, nil)
 				require.Error(t, err)
 				require.True(t, IsDuplicateClusterReconciliationError(err))
 			},
@@ -300,7 +384,7 @@ func TestReconciliationRepository(t *testing.T) {
 		{
 			name: "Finish reconciliation",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil)
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
 				require.NoError(t, err)
 
 				err = reconRepo.FinishReconciliation(reconEntity.SchedulingID, stateMock1.Status)
@@ -314,9 +398,9 @@ func TestReconciliationRepository(t *testing.T) {
 		{
 			name: "Get reconciliations with and without filter",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				reconEntity1, err := reconRepo.CreateReconciliation(stateMock1, nil)
+				reconEntity1, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
 				require.NoError(t, err)
-				reconEntity2, err := reconRepo.CreateReconciliation(stateMock2, nil)
+				reconEntity2, err := reconRepo.CreateReconciliation(stateMock2, nil, nil)
 				require.NoError(t, err)
 
 				all, err := reconRepo.GetReconciliations(nil)
@@ -345,7 +429,7 @@ func TestReconciliationRepository(t *testing.T) {
 		{
 			name: "Remove reconciliation",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil)
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
 				require.NoError(t, err)
 
 				err = reconRepo.RemoveReconciliation(reconEntity.SchedulingID)
@@ -359,7 +443,7 @@ func TestReconciliationRepository(t *testing.T) {
 		{
 			name: "Get operations",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, [][]string{{"comp3"}})
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, [][]string{{"comp3"}}, nil)
 				require.NoError(t, err)
 
 				opsEntities, err := reconRepo.GetOperations(reconEntity.SchedulingID)
@@ -393,10 +477,83 @@ func TestReconciliationRepository(t *testing.T) {
 				require.Empty(t, opsEntities)
 			},
 		},
+		{
+			name: "Create on-demand reconciliation for a subset of components",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				//unknown components are rejected
+				_, err := reconRepo.CreateReconciliation(stateMock1, [][]string{{"comp3"}},
+					&OnDemand{Components: []string{"unknown"}, Reason: "test"})
+				require.Error(t, err)
+
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, [][]string{{"comp3"}},
+					&OnDemand{Components: []string{"comp1"}, Reason: "comp1 is broken"})
+				require.NoError(t, err)
+				require.Equal(t, "comp1 is broken", reconEntity.Reason)
+
+				reconEntity, err = reconRepo.GetReconciliation(reconEntity.SchedulingID)
+				require.NoError(t, err)
+				require.Equal(t, "comp1 is broken", reconEntity.Reason)
+
+				//selected component and its required components (pre-component, CRDs and cleaner)
+				opsEntities, err := reconRepo.GetOperations(reconEntity.SchedulingID)
+				require.NoError(t, err)
+				priorities := make(map[string]int64)
+				for _, opEntity := range opsEntities {
+					priorities[opEntity.Component] = opEntity.Priority
+				}
+				require.Equal(t, map[string]int64{"cleaner": 1, "CRDs": 2, "comp3": 3, "comp1": 4}, priorities)
+			},
+		},
+		{
+			name: "Create reconciliation with dependencies",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				stateMock1.Configuration.Components[1].DependsOn = &[]string{"comp1", "unknown"} //comp2
+				stateMock1.Configuration.Components[2].DependsOn = &[]string{}                   //comp3
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, [][]string{{"comp1"}}, nil)
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(reconEntity.SchedulingID)
+				require.NoError(t, err)
+				require.Len(t, opsEntities, 5)
+				for _, opEntity := range opsEntities {
+					switch opEntity.Component {
+					case "cleaner":
+						require.Empty(t, opEntity.DependsOn)
+						require.Equal(t, int64(1), opEntity.Priority)
+					case "CRDs":
+						require.Equal(t, []string{"cleaner"}, opEntity.DependsOn)
+					case "comp1":
+						require.Equal(t, []string{"CRDs"}, opEntity.DependsOn)
+						require.Equal(t, int64(3), opEntity.Priority)
+					case "comp2":
+						require.Equal(t, []string{"CRDs", "comp1"}, opEntity.DependsOn)
+						require.Equal(t, int64(4), opEntity.Priority)
+					case "comp3": //doesn't wait for the pre-component comp1
+						require.Equal(t, []string{"CRDs"}, opEntity.DependsOn)
+						require.Equal(t, int64(3), opEntity.Priority)
+					}
+				}
+			},
+		},
+		{
+			name: "Reject reconciliation with dependency cycle",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				stateMock1.Configuration.Components[0].DependsOn = &[]string{"comp3"}
+				stateMock1.Configuration.Components[1].DependsOn = &[]string{"comp1"}
+				stateMock1.Configuration.Components[2].DependsOn = &[]string{"comp2"}
+				_, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
+				require.Error(t, err)
+				require.True(t, model.IsDependencyCycleError(err))
+
+				recons, err := reconRepo.GetReconciliations(nil)
+				require.NoError(t, err)
+				require.Empty(t, recons)
+			},
+		},
 		{
 			name: "Get operations with filter",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil)
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
 				require.NoError(t, err)
 
 				opsEntitiesAll, err := reconRepo.GetOperations(reconEntity.SchedulingID)
@@ -454,7 +611,7 @@ func TestReconciliationRepository(t *testing.T) {
 		{
 			name: "Get processable operations using 1 reconciliation",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, [][]string{{"comp1"}})
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, [][]string{{"comp1"}}, nil)
 				require.NoError(t, err)
 
 				//get existing operations
@@ -493,9 +650,9 @@ func TestReconciliationRepository(t *testing.T) {
 		{
 			name: "Get processable operations using 2 reconciliation",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				reconEntity1, err := reconRepo.CreateReconciliation(stateMock1, [][]string{{"comp1"}})
+				reconEntity1, err := reconRepo.CreateReconciliation(stateMock1, [][]string{{"comp1"}}, nil)
 				require.NoError(t, err)
-				reconEntity2, err := reconRepo.CreateReconciliation(stateMock2, nil)
+				reconEntity2, err := reconRepo.CreateReconciliation(stateMock2, nil, nil)
 				require.NoError(t, err)
 
 				//get existing operations
@@ -543,9 +700,9 @@ func TestReconciliationRepository(t *testing.T) {
 		{
 			name: "Get reconciling operations",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				_, err := reconRepo.CreateReconciliation(stateMock1, [][]string{{"comp1"}})
+				_, err := reconRepo.CreateReconciliation(stateMock1, [][]string{{"comp1"}}, nil)
 				require.NoError(t, err)
-				_, err = reconRepo.CreateReconciliation(stateMock2, nil)
+				_, err = reconRepo.CreateReconciliation(stateMock2, nil, nil)
 				require.NoError(t, err)
 
 				//get existing operations
@@ -557,7 +714,7 @@ func TestReconciliationRepository(t *testing.T) {
 		{
 			name: "Set operation states",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
-				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil)
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
 				require.NoError(t, err)
 
 				opsEntities, err := reconRepo.GetOperations(reconEntity.SchedulingID)
@@ -597,6 +754,155 @@ func TestReconciliationRepository(t *testing.T) {
 				verifyOperationState(t, op, model.OperationStateError, "operation error reason")
 			},
 		},
+		{
+			name: "Pause and resume reconciliation",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity1, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
+				require.NoError(t, err)
+				reconEntity2, err := reconRepo.CreateReconciliation(stateMock2, nil, nil)
+				require.NoError(t, err)
+
+				//operations of paused reconciliations are not processable
+				require.NoError(t, reconRepo.PauseReconciliation(reconEntity1.SchedulingID))
+				reconEntity1, err = reconRepo.GetReconciliation(reconEntity1.SchedulingID)
+				require.NoError(t, err)
+				require.True(t, reconEntity1.Paused)
+				opsEntities, err := reconRepo.GetProcessableOperations(0)
+				require.NoError(t, err)
+				require.NotEmpty(t, opsEntities)
+				for _, op := range opsEntities {
+					require.Equal(t, reconEntity2.SchedulingID, op.SchedulingID)
+				}
+
+				//paused reconciliations are still reconciling
+				opsEntities, err = reconRepo.GetReconcilingOperations()
+				require.NoError(t, err)
+				require.Len(t, opsEntities, 8)
+
+				require.NoError(t, reconRepo.ResumeReconciliation(reconEntity1.SchedulingID))
+				opsEntities, err = reconRepo.GetProcessableOperations(0)
+				require.NoError(t, err)
+				require.Len(t, opsEntities, 2)
+
+				//finished reconciliations can neither be paused nor resumed
+				require.NoError(t, reconRepo.FinishReconciliation(reconEntity1.SchedulingID, stateMock1.Status))
+				require.True(t, IsFinishedReconciliationError(reconRepo.PauseReconciliation(reconEntity1.SchedulingID)))
+				require.True(t, IsFinishedReconciliationError(reconRepo.ResumeReconciliation(reconEntity1.SchedulingID)))
+			},
+		},
+		{
+			name: "Cancel reconciliation",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(reconEntity.SchedulingID)
+				require.NoError(t, err)
+				require.Len(t, opsEntities, 5)
+				sID := opsEntities[0].SchedulingID
+				require.NoError(t, reconRepo.UpdateOperationState(sID, opsEntities[0].CorrelationID, model.OperationStateDone, false))
+				require.NoError(t, reconRepo.UpdateOperationState(sID, opsEntities[1].CorrelationID, model.OperationStateInProgress, false))
+
+				require.NoError(t, reconRepo.CancelReconciliation(reconEntity.SchedulingID, "stopped by operator"))
+				op, err := reconRepo.GetOperation(sID, opsEntities[0].CorrelationID)
+				require.NoError(t, err)
+				verifyOperationState(t, op, model.OperationStateDone)
+				cancelledOps, err := reconRepo.GetOperations(reconEntity.SchedulingID, model.OperationStateCancelled)
+				require.NoError(t, err)
+				require.Len(t, cancelledOps, 4)
+				for _, op := range cancelledOps {
+					verifyOperationState(t, op, model.OperationStateCancelled, "stopped by operator")
+				}
+
+				//cancelled operations can't be updated by component reconcilers anymore
+				require.Error(t, reconRepo.UpdateOperationState(sID, opsEntities[1].CorrelationID, model.OperationStateInProgress, true))
+
+				require.NoError(t, reconRepo.FinishReconciliation(reconEntity.SchedulingID, stateMock1.Status))
+				require.True(t, IsFinishedReconciliationError(reconRepo.CancelReconciliation(reconEntity.SchedulingID, "too late")))
+			},
+		},
+		{
+			name: "Retry and skip failed operations",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(reconEntity.SchedulingID)
+				require.NoError(t, err)
+				sID := opsEntities[0].SchedulingID
+				cID1, cID2 := opsEntities[0].CorrelationID, opsEntities[1].CorrelationID
+				require.NoError(t, reconRepo.UpdateOperationState(sID, cID1, model.OperationStateError, false, "failed"))
+				require.NoError(t, reconRepo.UpdateOperationState(sID, cID2, model.OperationStateError, false, "failed"))
+
+				//only failed operations can be resolved
+				err = reconRepo.ResolveFailedOperation(sID, opsEntities[2].CorrelationID, model.OperationStateNew, "")
+				require.True(t, IsOperationNotFailedError(err))
+				require.Error(t, reconRepo.ResolveFailedOperation(sID, cID1, model.OperationStateDone, ""))
+
+				//skipping requires a reason
+				require.Error(t, reconRepo.ResolveFailedOperation(sID, cID1, model.OperationStateSkipped, ""))
+				require.NoError(t, reconRepo.ResolveFailedOperation(sID, cID1, model.OperationStateSkipped, "not required"))
+				op, err := reconRepo.GetOperation(sID, cID1)
+				require.NoError(t, err)
+				verifyOperationState(t, op, model.OperationStateSkipped, "not required")
+				require.True(t, IsOperationNotFailedError(reconRepo.ResolveFailedOperation(sID, cID1, model.OperationStateNew, "")))
+
+				//retried operations are processed again
+				require.NoError(t, reconRepo.ResolveFailedOperation(sID, cID2, model.OperationStateNew, ""))
+				op, err = reconRepo.GetOperation(sID, cID2)
+				require.NoError(t, err)
+				verifyOperationState(t, op, model.OperationStateNew)
+			},
+		},
+		{
+			name: "Reopen finished reconciliation",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
+				require.NoError(t, err)
+				require.Error(t, reconRepo.ReopenReconciliation(reconEntity.SchedulingID, stateMock1.Status))
+
+				require.NoError(t, reconRepo.FinishReconciliation(reconEntity.SchedulingID, stateMock1.Status))
+				reconEntity, err = reconRepo.GetReconciliation(reconEntity.SchedulingID)
+				require.NoError(t, err)
+				require.True(t, reconEntity.Finished)
+
+				require.NoError(t, reconRepo.ReopenReconciliation(reconEntity.SchedulingID, stateMock1.Status))
+				reconEntity, err = reconRepo.GetReconciliation(reconEntity.SchedulingID)
+				require.NoError(t, err)
+				require.False(t, reconEntity.Finished)
+				require.Equal(t, stateMock1.Cluster.RuntimeID, reconEntity.Lock)
+
+				//a running reconciliation blocks further reconciliations of the cluster
+				_, err = reconRepo.CreateReconciliation(stateMock1, nil, nil)
+				require.True(t, IsDuplicateClusterReconciliationError(err))
+			},
+		},
+		{
+			name: "Set callback secret of operation",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, nil, nil)
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(reconEntity.SchedulingID)
+				require.NoError(t, err)
+				sID := opsEntities[0].SchedulingID
+				cID := opsEntities[0].CorrelationID
+				require.Empty(t, opsEntities[0].CallbackSecret)
+
+				require.NoError(t, reconRepo.UpdateOperationCallbackSecret(sID, cID, "secret1"))
+				op, err := reconRepo.GetOperation(sID, cID)
+				require.NoError(t, err)
+				require.Equal(t, "secret1", op.CallbackSecret)
+
+				//a new invocation replaces the secret
+				require.NoError(t, reconRepo.UpdateOperationCallbackSecret(sID, cID, "secret2"))
+				op, err = reconRepo.GetOperation(sID, cID)
+				require.NoError(t, err)
+				require.Equal(t, "secret2", op.CallbackSecret)
+
+				require.Error(t, reconRepo.UpdateOperationCallbackSecret(sID, "I-dont-exist", "secret3"))
+			},
+		},
 	}
 
 	repos := map[string]Repository{
@@ -713,9 +1019,9 @@ func TestTransaction(t *testing.T) {
 			require.NoError(t, err)
 
 			//create two clusters
-			_, err = reconRepoTx.CreateReconciliation(clusterState, nil)
+			_, err = reconRepoTx.CreateReconciliation(clusterState, nil, nil)
 			require.NoError(t, err)
-			_, err = reconRepoTx.CreateReconciliation(clusterState2, nil)
+			_, err = reconRepoTx.CreateReconciliation(clusterState2, nil, nil)
 			require.NoError(t, err)
 
 			//check if reconciliations are created
@@ -754,7 +1060,7 @@ func TestReconciliationParallel(t *testing.T) {
 				return nil, nil
 			},
 			mainFunc: func(repo Repository, state *cluster.State, reconEntity *model.ReconciliationEntity, entities []*model.OperationEntity) error {
-				_, err := repo.CreateReconciliation(state, nil)
+				_, err := repo.CreateReconciliation(state, nil, nil)
 				return err
 			},
 			check: func(repo Repository, threadCnt int, errChannel chan error) {
@@ -767,7 +1073,7 @@ func TestReconciliationParallel(t *testing.T) {
 		},
 		{name: "Update single operation state in multiple parallel threads",
 			preparationFunc: func(repo Repository, state *cluster.State) (*model.ReconciliationEntity, []*model.OperationEntity) {
-				recon, err := repo.CreateReconciliation(state, nil)
+				recon, err := repo.CreateReconciliation(state, nil, nil)
 				require.NoError(t, err)
 				allOperations, err := repo.GetOperations(recon.SchedulingID)
 				require.NoError(t, err)
@@ -790,7 +1096,7 @@ func TestReconciliationParallel(t *testing.T) {
 		},
 		{name: "Mark single reconciliation as finished in multiple parallel threads",
 			preparationFunc: func(repo Repository, state *cluster.State) (*model.ReconciliationEntity, []*model.OperationEntity) {
-				recon, err := repo.CreateReconciliation(state, nil)
+				recon, err := repo.CreateReconciliation(state, nil, nil)
 				require.NoError(t, err)
 				return recon, nil
 			},
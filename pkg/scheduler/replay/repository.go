@@ -0,0 +1,72 @@
+package replay
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+)
+
+const (
+	tblNonces = "callback_nonces"
+	//expired nonces are purged at most once within this interval
+	purgeInterval = time.Minute
+)
+
+//Repository stores the nonces of the accepted callbacks in the database: a nonce can only be used once per operation,
+//even if the callbacks are received by different mothership replicas or after a restart.
+type Repository interface {
+	//Use stores the nonce of an operation and returns false if it was already used. The nonce is kept until it expires.
+	Use(correlationID, nonce string, expires time.Time) (bool, error)
+}
+
+type PersistentRepository struct {
+	*repository.Repository
+	lastPurge time.Time
+	mu        sync.Mutex
+}
+
+func NewPersistentRepository(conn db.Connection, debug bool) (Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &PersistentRepository{Repository: repo}, nil
+}
+
+func (r *PersistentRepository) Use(correlationID, nonce string, expires time.Time) (bool, error) {
+	if err := r.purge(time.Now()); err != nil {
+		r.Logger.Warnf("Replay repository failed to purge expired nonces: %s", err)
+	}
+	//the primary key rejects a nonce which was already used
+	result, err := r.Conn.Exec(fmt.Sprintf("INSERT INTO %s (correlation_id, nonce, expires) VALUES ($1, $2, $3) "+
+		"ON CONFLICT (correlation_id, nonce) DO NOTHING", tblNonces), correlationID, nonce, expires.UnixNano())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+//purge deletes the nonces whose callbacks would be rejected anyway because of their timestamp
+func (r *PersistentRepository) purge(now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now.Sub(r.lastPurge) < purgeInterval {
+		return nil
+	}
+	result, err := r.Conn.Exec(fmt.Sprintf("DELETE FROM %s WHERE expires<$1", tblNonces), now.UnixNano())
+	if err != nil {
+		return err
+	}
+	r.lastPurge = now
+	if purged, err := result.RowsAffected(); err == nil && purged > 0 {
+		r.Logger.Debugf("Replay repository purged %d expired nonces", purged)
+	}
+	return nil
+}
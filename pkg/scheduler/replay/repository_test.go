@@ -0,0 +1,65 @@
+package replay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentRepository(t *testing.T) {
+	conn := db.NewTestConnection(t)
+
+	t.Run("Nonce can only be used once per operation", func(t *testing.T) {
+		repo, err := NewPersistentRepository(conn, true)
+		require.NoError(t, err)
+		correlationID := uuid.NewString()
+		nonce := uuid.NewString()
+
+		used, err := repo.Use(correlationID, nonce, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, used)
+		used, err = repo.Use(correlationID, nonce, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.False(t, used)
+
+		//nonce of another operation
+		used, err = repo.Use(uuid.NewString(), nonce, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, used)
+	})
+
+	t.Run("Used nonces are shared between replicas", func(t *testing.T) {
+		replica1, err := NewPersistentRepository(conn, true)
+		require.NoError(t, err)
+		replica2, err := NewPersistentRepository(conn, true)
+		require.NoError(t, err)
+		correlationID := uuid.NewString()
+		nonce := uuid.NewString()
+
+		used, err := replica1.Use(correlationID, nonce, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, used)
+		used, err = replica2.Use(correlationID, nonce, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.False(t, used)
+	})
+
+	t.Run("Expired nonces are purged", func(t *testing.T) {
+		repo, err := NewPersistentRepository(conn, true)
+		require.NoError(t, err)
+		correlationID := uuid.NewString()
+		nonce := uuid.NewString()
+
+		used, err := repo.Use(correlationID, nonce, time.Now().Add(-time.Second))
+		require.NoError(t, err)
+		require.True(t, used)
+
+		require.NoError(t, repo.(*PersistentRepository).purge(time.Now().Add(purgeInterval)))
+		used, err = repo.Use(correlationID, nonce, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, used)
+	})
+}
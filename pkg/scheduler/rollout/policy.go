@@ -0,0 +1,416 @@
+package rollout
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+//DefaultStatusCacheTTL is the max. age of the cached rollout state which is used to admit clusters
+const DefaultStatusCacheTTL = 10 * time.Second
+
+type Phase string
+
+const (
+	PhaseCanary  Phase = "canary"
+	PhaseWaves   Phase = "waves"
+	PhaseHalted  Phase = "halted"
+	PhaseAborted Phase = "aborted"
+)
+
+var (
+	//ErrRolloutNotFound is returned if no cluster was admitted to the rollout of a Kyma version
+	ErrRolloutNotFound = errors.New("rollout not found")
+	//ErrRolloutNotHalted is returned if a rollout is resumed which is neither halted nor aborted
+	ErrRolloutNotHalted = errors.New("rollout is neither halted nor aborted")
+)
+
+//Status summarizes the rollout of a Kyma version
+type Status struct {
+	KymaVersion     string
+	Phase           Phase
+	Wave            int64 //current wave (0 = canary)
+	Pending         int
+	Succeeded       int
+	Failed          int
+	Superseded      int
+	ErrorRate       float64
+	StartedLastHour int
+	Reason          string //why the rollout was halted or aborted
+}
+
+func (s *Status) String() string {
+	return fmt.Sprintf("RolloutStatus [KymaVersion=%s,Phase=%s,Wave=%d,Pending=%d,Succeeded=%d,Failed=%d,Reason=%s]",
+		s.KymaVersion, s.Phase, s.Wave, s.Pending, s.Succeeded, s.Failed, s.Reason)
+}
+
+func (s *Status) exists() bool {
+	return s.Pending+s.Succeeded+s.Failed+s.Superseded > 0
+}
+
+//Policy decides whether a cluster can be upgraded to a new Kyma version or has to wait
+//until the rollout of this version has progressed far enough.
+//The rollout state of a Kyma version is cached: all clusters checked within the cache TTL (e.g. during one
+//scheduler run) are admitted based on the same state instead of refreshing it for each cluster.
+type Policy struct {
+	config    *config.RolloutConfig
+	repo      Repository
+	inventory cluster.Inventory
+	logger    *zap.SugaredLogger
+	cacheTTL  time.Duration
+	cache     map[string]*cachedStatus //key is the Kyma version
+	mu        sync.Mutex
+}
+
+type cachedStatus struct {
+	clusters []*model.RolloutClusterEntity
+	rollout  *model.RolloutEntity
+	status   *Status
+	expires  time.Time
+}
+
+func NewPolicy(cfg *config.RolloutConfig, repo Repository, inventory cluster.Inventory, logger *zap.SugaredLogger) *Policy {
+	return &Policy{
+		config:    cfg,
+		repo:      repo,
+		inventory: inventory,
+		logger:    logger,
+		cacheTTL:  DefaultStatusCacheTTL,
+		cache:     make(map[string]*cachedStatus),
+	}
+}
+
+//WithStatusCacheTTL defines how long the rollout state is reused for admitting clusters (0 disables the cache)
+func (p *Policy) WithStatusCacheTTL(ttl time.Duration) *Policy {
+	p.cacheTTL = ttl
+	return p
+}
+
+//Admit returns true if the cluster can be reconciled. Only upgrades to another Kyma version are throttled:
+//new clusters, deletions and periodic reconciliations of the current version are always admitted.
+func (p *Policy) Admit(state *cluster.State) (bool, error) {
+	if state.Status.Status.IsDeletion() {
+		return true, nil
+	}
+	kymaVersion := state.Configuration.KymaVersion
+	runtimeID := state.Cluster.RuntimeID
+
+	_, err := p.repo.Get(kymaVersion, runtimeID)
+	if err == nil { //cluster was already admitted to this rollout
+		return true, nil
+	}
+	if !repository.IsNotFoundError(err) {
+		return false, err
+	}
+
+	readyVersion, err := p.repo.ReadyKymaVersion(runtimeID)
+	if err != nil {
+		return false, err
+	}
+	if readyVersion == "" || readyVersion == kymaVersion {
+		return true, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	cached, err := p.cachedStatus(kymaVersion)
+	if err != nil {
+		return false, err
+	}
+	wave, reason := p.nextWave(state, cached.clusters, cached.status)
+	if reason != "" {
+		p.logger.Debugf("Rollout policy postponed upgrade of cluster '%s' from Kyma version '%s' to '%s': %s",
+			runtimeID, readyVersion, kymaVersion, reason)
+		return false, nil
+	}
+	rolloutCluster, err := p.repo.Add(kymaVersion, runtimeID, wave)
+	if err != nil {
+		return false, err
+	}
+	//keep the cached state up to date: it's used to admit further clusters
+	cached.clusters = append(cached.clusters, rolloutCluster)
+	cached.status = p.evaluate(kymaVersion, cached.clusters, cached.rollout)
+	p.logger.Infof("Rollout policy admitted upgrade of cluster '%s' from Kyma version '%s' to '%s' (wave %d)",
+		runtimeID, readyVersion, kymaVersion, wave)
+	return true, nil
+}
+
+//cachedStatus returns the cached rollout state of a Kyma version and refreshes it if it's expired
+func (p *Policy) cachedStatus(kymaVersion string) (*cachedStatus, error) {
+	now := time.Now()
+	if cached, ok := p.cache[kymaVersion]; ok && now.Before(cached.expires) {
+		return cached, nil
+	}
+	for version, cached := range p.cache { //drop states of finished rollouts
+		if !now.Before(cached.expires) {
+			delete(p.cache, version)
+		}
+	}
+	clusters, rollout, status, err := p.status(kymaVersion)
+	if err != nil {
+		return nil, err
+	}
+	cached := &cachedStatus{
+		clusters: clusters,
+		rollout:  rollout,
+		status:   status,
+		expires:  now.Add(p.cacheTTL),
+	}
+	p.cache[kymaVersion] = cached
+	return cached, nil
+}
+
+//Status returns the current rollout state of a Kyma version (the cache is not used)
+func (p *Policy) Status(kymaVersion string) (*Status, error) {
+	_, _, status, err := p.status(kymaVersion)
+	return status, err
+}
+
+//Resume continues a halted or aborted rollout. Clusters which failed before are not considered anymore
+//when the rollout decides whether it has to halt again.
+func (p *Policy) Resume(kymaVersion string) (*Status, error) {
+	status, err := p.Status(kymaVersion)
+	if err != nil {
+		return nil, err
+	}
+	if !status.exists() {
+		return nil, ErrRolloutNotFound
+	}
+	if status.Phase != PhaseHalted && status.Phase != PhaseAborted {
+		return nil, ErrRolloutNotHalted
+	}
+	if _, err := p.repo.SetRolloutState(kymaVersion, model.RolloutStateActive, ""); err != nil {
+		return nil, err
+	}
+	p.invalidate(kymaVersion)
+	p.logger.Infof("Rollout of Kyma version '%s' resumed (was %s: %s)", kymaVersion, status.Phase, status.Reason)
+	return p.Status(kymaVersion)
+}
+
+//Abort stops the rollout: no further clusters are upgraded to the Kyma version until the rollout is resumed
+func (p *Policy) Abort(kymaVersion, reason string) (*Status, error) {
+	status, err := p.Status(kymaVersion)
+	if err != nil {
+		return nil, err
+	}
+	if !status.exists() {
+		return nil, ErrRolloutNotFound
+	}
+	if status.Phase == PhaseAborted {
+		return status, nil
+	}
+	if _, err := p.repo.SetRolloutState(kymaVersion, model.RolloutStateAborted, reason); err != nil {
+		return nil, err
+	}
+	p.invalidate(kymaVersion)
+	p.logger.Infof("Rollout of Kyma version '%s' aborted: %s", kymaVersion, reason)
+	return p.Status(kymaVersion)
+}
+
+func (p *Policy) invalidate(kymaVersion string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.cache, kymaVersion)
+}
+
+//Statuses returns the rollout state of all Kyma versions
+func (p *Policy) Statuses() ([]*Status, error) {
+	kymaVersions, err := p.repo.GetKymaVersions()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Status, 0, len(kymaVersions))
+	for _, kymaVersion := range kymaVersions {
+		status, err := p.Status(kymaVersion)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+func (p *Policy) status(kymaVersion string) ([]*model.RolloutClusterEntity, *model.RolloutEntity, *Status, error) {
+	clusters, err := p.repo.GetClusters(kymaVersion)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, rolloutCluster := range clusters {
+		if err := p.refresh(rolloutCluster); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	rollout, err := p.repo.GetRollout(kymaVersion)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	status := p.evaluate(kymaVersion, clusters, rollout)
+	if status.Phase == PhaseHalted && (rollout == nil || rollout.State != model.RolloutStateHalted) {
+		//a halted rollout stays halted until it gets resumed (even if the failed clusters recover in between)
+		if rollout, err = p.repo.SetRolloutState(kymaVersion, model.RolloutStateHalted, status.Reason); err != nil {
+			return nil, nil, nil, err
+		}
+		p.logger.Warnf("Rollout of Kyma version '%s' halted: %s", kymaVersion, status.Reason)
+	}
+	return clusters, rollout, status, nil
+}
+
+//refresh updates the rollout status of a cluster by checking its latest state in the inventory
+func (p *Policy) refresh(rolloutCluster *model.RolloutClusterEntity) error {
+	if rolloutCluster.Status == model.RolloutStatusSuccess || rolloutCluster.Status == model.RolloutStatusSuperseded {
+		return nil
+	}
+
+	newStatus := rolloutCluster.Status
+	state, err := p.inventory.GetLatest(rolloutCluster.RuntimeID)
+	switch {
+	case repository.IsNotFoundError(err): //cluster was deleted in between
+		newStatus = model.RolloutStatusSuperseded
+	case err != nil:
+		return err
+	case state.Configuration.KymaVersion != rolloutCluster.KymaVersion:
+		newStatus = model.RolloutStatusSuperseded
+	case state.Status.Status == model.ClusterStatusReady:
+		newStatus = model.RolloutStatusSuccess
+	case state.Status.Status == model.ClusterStatusReconcileError:
+		newStatus = model.RolloutStatusFailed
+	}
+
+	if newStatus == rolloutCluster.Status {
+		return nil
+	}
+	return p.repo.UpdateStatus(rolloutCluster, newStatus)
+}
+
+func (p *Policy) evaluate(kymaVersion string, clusters []*model.RolloutClusterEntity, rollout *model.RolloutEntity) *Status {
+	status := &Status{
+		KymaVersion: kymaVersion,
+		Phase:       PhaseCanary,
+	}
+
+	//clusters which finished before the rollout was resumed cannot halt the rollout again
+	var resumed time.Time
+	if rollout != nil && rollout.State == model.RolloutStateActive {
+		resumed = rollout.Updated
+	}
+	var canariesSucceeded, finishedSinceResume, failedSinceResume int
+	var failedCanary string
+	for _, rolloutCluster := range clusters {
+		sinceResume := rolloutCluster.Updated.After(resumed)
+		switch rolloutCluster.Status {
+		case model.RolloutStatusPending:
+			status.Pending++
+		case model.RolloutStatusSuccess:
+			status.Succeeded++
+			if rolloutCluster.Wave == 0 {
+				canariesSucceeded++
+			}
+			if sinceResume {
+				finishedSinceResume++
+			}
+		case model.RolloutStatusFailed:
+			status.Failed++
+			if sinceResume {
+				finishedSinceResume++
+				failedSinceResume++
+				if rolloutCluster.Wave == 0 {
+					failedCanary = rolloutCluster.RuntimeID
+				}
+			}
+		case model.RolloutStatusSuperseded:
+			status.Superseded++
+		}
+		if rolloutCluster.Wave > status.Wave {
+			status.Wave = rolloutCluster.Wave
+		}
+		if time.Since(rolloutCluster.Created) < time.Hour {
+			status.StartedLastHour++
+		}
+	}
+
+	finished := status.Succeeded + status.Failed
+	if finished > 0 {
+		status.ErrorRate = float64(status.Failed) / float64(finished)
+	}
+
+	var errorRateSinceResume float64
+	if finishedSinceResume > 0 {
+		errorRateSinceResume = float64(failedSinceResume) / float64(finishedSinceResume)
+	}
+
+	switch {
+	case rollout != nil && rollout.State == model.RolloutStateAborted:
+		status.Phase = PhaseAborted
+		status.Reason = rollout.Reason
+	case rollout != nil && rollout.State == model.RolloutStateHalted:
+		status.Phase = PhaseHalted
+		status.Reason = rollout.Reason
+	case failedCanary != "":
+		status.Phase = PhaseHalted
+		status.Reason = fmt.Sprintf("canary cluster '%s' failed", failedCanary)
+	case p.config.ErrorRateThreshold > 0 && finishedSinceResume > 0 && finishedSinceResume >= p.config.MinFinished &&
+		errorRateSinceResume > p.config.ErrorRateThreshold:
+		status.Phase = PhaseHalted
+		status.Reason = fmt.Sprintf("error rate %.2f exceeds threshold %.2f", errorRateSinceResume, p.config.ErrorRateThreshold)
+	case canariesSucceeded >= p.config.Canary.Size || status.Wave > 0:
+		status.Phase = PhaseWaves
+	}
+	return status
+}
+
+//nextWave returns the wave the cluster gets assigned to or the reason why the cluster has to wait
+func (p *Policy) nextWave(state *cluster.State, clusters []*model.RolloutClusterEntity, status *Status) (int64, string) {
+	switch status.Phase {
+	case PhaseHalted:
+		return 0, fmt.Sprintf("rollout halted: %s", status.Reason)
+	case PhaseAborted:
+		return 0, fmt.Sprintf("rollout aborted: %s", status.Reason)
+	}
+	if p.config.MaxClustersPerHour > 0 && status.StartedLastHour >= p.config.MaxClustersPerHour {
+		return 0, fmt.Sprintf("limit of %d upgraded clusters per hour reached", p.config.MaxClustersPerHour)
+	}
+
+	if status.Phase == PhaseCanary {
+		if !state.Cluster.Metadata.Matches(p.config.Canary.Metadata) { //all clusters match an empty canary selector
+			return 0, "waiting for canary clusters"
+		}
+		if activeClusters(clusters, 0) >= p.config.Canary.Size {
+			return 0, fmt.Sprintf("%d canary clusters are already upgraded", p.config.Canary.Size)
+		}
+		return 0, ""
+	}
+
+	if status.Wave == 0 {
+		return 1, ""
+	}
+	if p.config.WaveSize == 0 || activeClusters(clusters, status.Wave) < p.config.WaveSize {
+		return status.Wave, ""
+	}
+	for _, rolloutCluster := range clusters {
+		if rolloutCluster.Wave == status.Wave && rolloutCluster.Status == model.RolloutStatusPending {
+			return 0, fmt.Sprintf("wave %d is not finished", status.Wave)
+		}
+	}
+	return status.Wave + 1, ""
+}
+
+//activeClusters counts the clusters of a wave which were neither superseded by a newer configuration nor failed
+//(e.g. a failed canary is replaced by another cluster after the rollout was resumed)
+func activeClusters(clusters []*model.RolloutClusterEntity, wave int64) int {
+	var count int
+	for _, rolloutCluster := range clusters {
+		if rolloutCluster.Wave == wave && rolloutCluster.Status != model.RolloutStatusSuperseded &&
+			rolloutCluster.Status != model.RolloutStatusFailed {
+			count++
+		}
+	}
+	return count
+}
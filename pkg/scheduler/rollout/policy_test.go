@@ -0,0 +1,287 @@
+package rollout
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb/test"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+type testFixture struct {
+	t         *testing.T
+	inventory cluster.Inventory
+	repo      Repository
+}
+
+func newTestFixture(t *testing.T) *testFixture {
+	dbConn := db.NewTestConnection(t)
+	inventory, err := cluster.NewInventory(dbConn, true, cluster.MetricsCollectorMock{})
+	require.NoError(t, err)
+	repo, err := NewPersistentRepository(dbConn, true)
+	require.NoError(t, err)
+	return &testFixture{t: t, inventory: inventory, repo: repo}
+}
+
+func (f *testFixture) newPolicy(cfg *config.RolloutConfig) *Policy {
+	return NewPolicy(cfg, f.repo, f.inventory, logger.NewLogger(true)).WithStatusCacheTTL(0)
+}
+
+//countingRepository counts the retrievals of the rollout clusters
+type countingRepository struct {
+	Repository
+	getClustersCalls int
+}
+
+func (r *countingRepository) GetClusters(kymaVersion string) ([]*model.RolloutClusterEntity, error) {
+	r.getClustersCalls++
+	return r.Repository.GetClusters(kymaVersion)
+}
+
+func (f *testFixture) createCluster(runtimeID, kymaVersion, region string, status model.Status) *cluster.State {
+	kebCluster := test.NewCluster(f.t, runtimeID, 1, false, test.OneComponentDummy)
+	kebCluster.KymaConfig.Version = kymaVersion
+	kebCluster.Metadata.Region = region
+	state, err := f.inventory.CreateOrUpdate(1, kebCluster)
+	require.NoError(f.t, err)
+	state, err = f.inventory.UpdateStatus(state, status)
+	require.NoError(f.t, err)
+	return state
+}
+
+func (f *testFixture) updateStatus(state *cluster.State, status model.Status) {
+	_, err := f.inventory.UpdateStatus(state, status)
+	require.NoError(f.t, err)
+}
+
+func (f *testFixture) requireAdmitted(policy *Policy, state *cluster.State, expected bool) {
+	admitted, err := policy.Admit(state)
+	require.NoError(f.t, err)
+	require.Equal(f.t, expected, admitted, fmt.Sprintf("unexpected admission of cluster '%s'", state.Cluster.RuntimeID))
+}
+
+func TestPolicy(t *testing.T) {
+	t.Run("Canary and waves", func(t *testing.T) {
+		f := newTestFixture(t)
+		policy := f.newPolicy(&config.RolloutConfig{
+			Enabled: true,
+			Canary: config.CanaryConfig{
+				Metadata: map[string][]string{"region": {"canary-region"}},
+				Size:     1,
+			},
+			WaveSize:           2,
+			ErrorRateThreshold: 0.2,
+			MinFinished:        2,
+		})
+		oldVersion := fmt.Sprintf("1.0.0-%s", uuid.NewString())
+		newVersion := fmt.Sprintf("2.0.0-%s", uuid.NewString())
+
+		//clusters which were never ready are not throttled
+		f.requireAdmitted(policy, f.createCluster(uuid.NewString(), newVersion, "region", model.ClusterStatusReconcilePending), true)
+
+		var clusters []*cluster.State
+		for i := 0; i < 5; i++ {
+			region := "region"
+			if i == 0 {
+				region = "canary-region"
+			}
+			runtimeID := uuid.NewString()
+			state := f.createCluster(runtimeID, oldVersion, region, model.ClusterStatusReady)
+			f.requireAdmitted(policy, state, true) //periodic reconciliation of current version
+			clusters = append(clusters, f.createCluster(runtimeID, newVersion, region, model.ClusterStatusReconcilePending))
+		}
+
+		//canary phase
+		f.requireAdmitted(policy, clusters[1], false)
+		f.requireAdmitted(policy, clusters[0], true)
+		f.requireAdmitted(policy, clusters[0], true) //already admitted
+		f.requireAdmitted(policy, clusters[1], false)
+		status, err := policy.Status(newVersion)
+		require.NoError(t, err)
+		require.Equal(t, PhaseCanary, status.Phase)
+		require.Equal(t, 1, status.Pending)
+
+		//first wave starts after the canary succeeded
+		f.updateStatus(clusters[0], model.ClusterStatusReady)
+		f.requireAdmitted(policy, clusters[1], true)
+		f.requireAdmitted(policy, clusters[2], true)
+		f.requireAdmitted(policy, clusters[3], false)
+		status, err = policy.Status(newVersion)
+		require.NoError(t, err)
+		require.Equal(t, PhaseWaves, status.Phase)
+		require.Equal(t, int64(1), status.Wave)
+		require.Equal(t, 2, status.Pending)
+		require.Equal(t, 1, status.Succeeded)
+
+		//second wave starts after the first wave finished
+		f.updateStatus(clusters[1], model.ClusterStatusReady)
+		f.updateStatus(clusters[2], model.ClusterStatusReady)
+		f.requireAdmitted(policy, clusters[3], true)
+		status, err = policy.Status(newVersion)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), status.Wave)
+
+		//rollout halts if the error rate exceeds the threshold
+		f.updateStatus(clusters[3], model.ClusterStatusReconcileError)
+		f.requireAdmitted(policy, clusters[4], false)
+		status, err = policy.Status(newVersion)
+		require.NoError(t, err)
+		require.Equal(t, PhaseHalted, status.Phase)
+		require.Equal(t, 3, status.Succeeded)
+		require.Equal(t, 1, status.Failed)
+		require.Equal(t, 0.25, status.ErrorRate)
+		require.Equal(t, "error rate 0.25 exceeds threshold 0.20", status.Reason)
+
+		statuses, err := policy.Statuses()
+		require.NoError(t, err)
+		require.Contains(t, statuses, status)
+	})
+
+	t.Run("Failed canary halts the rollout until it is resumed or aborted", func(t *testing.T) {
+		f := newTestFixture(t)
+		policy := f.newPolicy(&config.RolloutConfig{
+			Enabled: true,
+			Canary:  config.CanaryConfig{Size: 1},
+		})
+		oldVersion := fmt.Sprintf("1.0.0-%s", uuid.NewString())
+		newVersion := fmt.Sprintf("2.0.0-%s", uuid.NewString())
+
+		var clusters []*cluster.State
+		for i := 0; i < 4; i++ {
+			runtimeID := uuid.NewString()
+			f.createCluster(runtimeID, oldVersion, "region", model.ClusterStatusReady)
+			clusters = append(clusters, f.createCluster(runtimeID, newVersion, "region", model.ClusterStatusReconcilePending))
+		}
+
+		//rollout cannot be resumed or aborted before it started
+		_, err := policy.Resume(newVersion)
+		require.Equal(t, ErrRolloutNotFound, err)
+		_, err = policy.Abort(newVersion, "test")
+		require.Equal(t, ErrRolloutNotFound, err)
+
+		//failed canary halts the rollout
+		f.requireAdmitted(policy, clusters[0], true)
+		_, err = policy.Resume(newVersion)
+		require.Equal(t, ErrRolloutNotHalted, err)
+		f.updateStatus(clusters[0], model.ClusterStatusReconcileError)
+		status, err := policy.Status(newVersion)
+		require.NoError(t, err)
+		require.Equal(t, PhaseHalted, status.Phase)
+		require.Contains(t, status.Reason, clusters[0].Cluster.RuntimeID)
+		f.requireAdmitted(policy, clusters[1], false)
+
+		//rollout stays halted even if the canary recovers
+		f.updateStatus(clusters[0], model.ClusterStatusReconcilePending)
+		status, err = policy.Status(newVersion)
+		require.NoError(t, err)
+		require.Equal(t, PhaseHalted, status.Phase)
+		f.updateStatus(clusters[0], model.ClusterStatusReconcileError)
+
+		//resumed rollout ignores the failed canary and admits another canary
+		status, err = policy.Resume(newVersion)
+		require.NoError(t, err)
+		require.Equal(t, PhaseCanary, status.Phase)
+		require.Empty(t, status.Reason)
+		require.Equal(t, 1, status.Failed)
+		f.requireAdmitted(policy, clusters[1], true)
+		f.requireAdmitted(policy, clusters[2], false)
+		f.updateStatus(clusters[1], model.ClusterStatusReady)
+		f.requireAdmitted(policy, clusters[2], true)
+		status, err = policy.Status(newVersion)
+		require.NoError(t, err)
+		require.Equal(t, PhaseWaves, status.Phase)
+
+		//aborted rollout does not admit further clusters
+		status, err = policy.Abort(newVersion, "broken release")
+		require.NoError(t, err)
+		require.Equal(t, PhaseAborted, status.Phase)
+		require.Equal(t, "broken release", status.Reason)
+		f.requireAdmitted(policy, clusters[3], false)
+
+		//aborted rollout can be resumed
+		status, err = policy.Resume(newVersion)
+		require.NoError(t, err)
+		require.Equal(t, PhaseWaves, status.Phase)
+		f.requireAdmitted(policy, clusters[3], true)
+	})
+
+	t.Run("Clusters per hour", func(t *testing.T) {
+		f := newTestFixture(t)
+		policy := f.newPolicy(&config.RolloutConfig{
+			Enabled:            true,
+			MaxClustersPerHour: 1,
+		})
+		oldVersion := fmt.Sprintf("1.0.0-%s", uuid.NewString())
+		newVersion := fmt.Sprintf("2.0.0-%s", uuid.NewString())
+
+		var clusters []*cluster.State
+		for i := 0; i < 2; i++ {
+			runtimeID := uuid.NewString()
+			f.createCluster(runtimeID, oldVersion, "region", model.ClusterStatusReady)
+			clusters = append(clusters, f.createCluster(runtimeID, newVersion, "region", model.ClusterStatusReconcilePending))
+		}
+
+		f.requireAdmitted(policy, clusters[0], true)
+		f.updateStatus(clusters[0], model.ClusterStatusReady)
+		f.requireAdmitted(policy, clusters[1], false)
+		status, err := policy.Status(newVersion)
+		require.NoError(t, err)
+		require.Equal(t, 1, status.StartedLastHour)
+	})
+
+	t.Run("Rollout state is cached", func(t *testing.T) {
+		f := newTestFixture(t)
+		repo := &countingRepository{Repository: f.repo}
+		policy := NewPolicy(&config.RolloutConfig{
+			Enabled: true,
+			Canary:  config.CanaryConfig{Size: 1},
+		}, repo, f.inventory, logger.NewLogger(true)).WithStatusCacheTTL(time.Minute)
+		oldVersion := fmt.Sprintf("1.0.0-%s", uuid.NewString())
+		newVersion := fmt.Sprintf("2.0.0-%s", uuid.NewString())
+
+		var clusters []*cluster.State
+		for i := 0; i < 3; i++ {
+			runtimeID := uuid.NewString()
+			f.createCluster(runtimeID, oldVersion, "region", model.ClusterStatusReady)
+			clusters = append(clusters, f.createCluster(runtimeID, newVersion, "region", model.ClusterStatusReconcilePending))
+		}
+
+		//admitted clusters are added to the cached state
+		f.requireAdmitted(policy, clusters[0], true)
+		f.requireAdmitted(policy, clusters[1], false)
+		f.requireAdmitted(policy, clusters[2], false)
+		require.Equal(t, 1, repo.getClustersCalls)
+
+		//expired state is refreshed
+		policy.cache[newVersion].expires = time.Now()
+		f.updateStatus(clusters[0], model.ClusterStatusReady)
+		f.requireAdmitted(policy, clusters[1], true)
+		require.Equal(t, 2, repo.getClustersCalls)
+	})
+
+	t.Run("Superseded clusters", func(t *testing.T) {
+		f := newTestFixture(t)
+		policy := f.newPolicy(&config.RolloutConfig{
+			Enabled: true,
+			Canary:  config.CanaryConfig{Size: 1},
+		})
+		runtimeID := uuid.NewString()
+		f.createCluster(runtimeID, "1.0.0", "region", model.ClusterStatusReady)
+		newVersion := fmt.Sprintf("2.0.0-%s", uuid.NewString())
+		f.requireAdmitted(policy, f.createCluster(runtimeID, newVersion, "region", model.ClusterStatusReconcilePending), true)
+
+		//cluster gets a newer configuration before the upgrade finished
+		f.createCluster(runtimeID, fmt.Sprintf("3.0.0-%s", uuid.NewString()), "region", model.ClusterStatusReconcilePending)
+		status, err := policy.Status(newVersion)
+		require.NoError(t, err)
+		require.Equal(t, 1, status.Superseded)
+		require.Equal(t, 0, status.Pending)
+	})
+}
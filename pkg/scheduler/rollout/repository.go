@@ -0,0 +1,229 @@
+package rollout
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+)
+
+type Repository interface {
+	Get(kymaVersion, runtimeID string) (*model.RolloutClusterEntity, error)
+	GetClusters(kymaVersion string) ([]*model.RolloutClusterEntity, error)
+	GetKymaVersions() ([]string, error)
+	Add(kymaVersion, runtimeID string, wave int64) (*model.RolloutClusterEntity, error)
+	UpdateStatus(entity *model.RolloutClusterEntity, status model.RolloutStatus) error
+	ReadyKymaVersion(runtimeID string) (string, error)
+	//GetRollout returns the state of a rollout or nil if the rollout was never halted, aborted or resumed
+	GetRollout(kymaVersion string) (*model.RolloutEntity, error)
+	SetRolloutState(kymaVersion string, state model.RolloutState, reason string) (*model.RolloutEntity, error)
+}
+
+type PersistentRepository struct {
+	*repository.Repository
+}
+
+func NewPersistentRepository(conn db.Connection, debug bool) (Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &PersistentRepository{repo}, nil
+}
+
+func (r *PersistentRepository) Get(kymaVersion, runtimeID string) (*model.RolloutClusterEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.RolloutClusterEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{
+		"KymaVersion": kymaVersion,
+		"RuntimeID":   runtimeID,
+	}
+	entity, err := q.Select().
+		Where(whereCond).
+		GetOne()
+	if err != nil {
+		return nil, r.MapError(err, entity, whereCond)
+	}
+	return entity.(*model.RolloutClusterEntity), nil
+}
+
+func (r *PersistentRepository) GetClusters(kymaVersion string) ([]*model.RolloutClusterEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.RolloutClusterEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(map[string]interface{}{"KymaVersion": kymaVersion}).
+		OrderBy(map[string]string{"Created": "ASC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	var result []*model.RolloutClusterEntity
+	for _, entity := range entities {
+		result = append(result, entity.(*model.RolloutClusterEntity))
+	}
+	return result, nil
+}
+
+//GetKymaVersions returns all Kyma versions which have a rollout (oldest rollout first)
+func (r *PersistentRepository) GetKymaVersions() ([]string, error) {
+	q, err := db.NewQuery(r.Conn, &model.RolloutClusterEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		OrderBy(map[string]string{"Created": "ASC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	known := make(map[string]bool)
+	for _, entity := range entities {
+		kymaVersion := entity.(*model.RolloutClusterEntity).KymaVersion
+		if !known[kymaVersion] {
+			known[kymaVersion] = true
+			result = append(result, kymaVersion)
+		}
+	}
+	return result, nil
+}
+
+func (r *PersistentRepository) Add(kymaVersion, runtimeID string, wave int64) (*model.RolloutClusterEntity, error) {
+	entity := &model.RolloutClusterEntity{
+		KymaVersion: kymaVersion,
+		RuntimeID:   runtimeID,
+		Wave:        wave,
+		Status:      model.RolloutStatusPending,
+		Updated:     time.Now().UTC(),
+	}
+	q, err := db.NewQuery(r.Conn, entity, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.Insert().Exec(); err != nil {
+		r.Logger.Errorf("RolloutRepo failed to add cluster '%s' to rollout of Kyma version '%s': %s",
+			runtimeID, kymaVersion, err)
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (r *PersistentRepository) UpdateStatus(entity *model.RolloutClusterEntity, status model.RolloutStatus) error {
+	entity.Status = status
+	entity.Updated = time.Now().UTC()
+	q, err := db.NewQuery(r.Conn, entity, r.Logger)
+	if err != nil {
+		return err
+	}
+	return q.Update().
+		Where(map[string]interface{}{
+			"KymaVersion": entity.KymaVersion,
+			"RuntimeID":   entity.RuntimeID,
+		}).
+		Exec()
+}
+
+//ReadyKymaVersion returns the Kyma version a cluster was successfully reconciled with the last time.
+//An empty string is returned if the cluster was never ready.
+func (r *PersistentRepository) ReadyKymaVersion(runtimeID string) (string, error) {
+	statusQ, err := db.NewQuery(r.Conn, &model.ClusterStatusEntity{}, r.Logger)
+	if err != nil {
+		return "", err
+	}
+	statusEntity, err := statusQ.Select().
+		Where(map[string]interface{}{
+			"RuntimeID": runtimeID,
+			"Status":    string(model.ClusterStatusReady),
+		}).
+		OrderBy(map[string]string{"ID": "DESC"}).
+		Limit(1).
+		GetOne()
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	configQ, err := db.NewQuery(r.Conn, &model.ClusterConfigurationEntity{}, r.Logger)
+	if err != nil {
+		return "", err
+	}
+	whereCond := map[string]interface{}{
+		"RuntimeID": runtimeID,
+		"Version":   statusEntity.(*model.ClusterStatusEntity).ConfigVersion,
+	}
+	configEntity, err := configQ.Select().
+		Where(whereCond).
+		GetOne()
+	if err != nil {
+		return "", r.MapError(err, configEntity, whereCond)
+	}
+	return configEntity.(*model.ClusterConfigurationEntity).KymaVersion, nil
+}
+
+func (r *PersistentRepository) GetRollout(kymaVersion string) (*model.RolloutEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.RolloutEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := q.Select().
+		Where(map[string]interface{}{"KymaVersion": kymaVersion}).
+		GetOne()
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entity.(*model.RolloutEntity), nil
+}
+
+func (r *PersistentRepository) SetRolloutState(kymaVersion string, state model.RolloutState, reason string) (*model.RolloutEntity, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		q, err := db.NewQuery(tx, &model.RolloutEntity{}, r.Logger)
+		if err != nil {
+			return nil, err
+		}
+		exists := true
+		if _, err := q.Select().Where(map[string]interface{}{"KymaVersion": kymaVersion}).GetOne(); err != nil {
+			if err != sql.ErrNoRows {
+				return nil, err
+			}
+			exists = false
+		}
+
+		entity := &model.RolloutEntity{
+			KymaVersion: kymaVersion,
+			State:       state,
+			Reason:      reason,
+			Updated:     time.Now().UTC(),
+		}
+		q, err = db.NewQuery(tx, entity, r.Logger)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			err = q.Update().Where(map[string]interface{}{"KymaVersion": kymaVersion}).Exec()
+		} else {
+			err = q.Insert().Exec()
+		}
+		if err != nil {
+			r.Logger.Errorf("RolloutRepo failed to set state of rollout of Kyma version '%s' to '%s': %s",
+				kymaVersion, state, err)
+			return nil, err
+		}
+		return entity, nil
+	}
+	entity, err := r.TransactionalResult(dbOps)
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*model.RolloutEntity), nil
+}
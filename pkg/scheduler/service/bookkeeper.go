@@ -84,37 +84,20 @@ func (bk *bookkeeper) Run(ctx context.Context, tasks ...BookkeepingTask) error {
 	//reconciler in case of a mothership-reconciler downtime. If bookkeeper runs directly, it would mark all ongoing
 	//operations as orphan if mothership-reconciler was down for a few minutes.
 
+	//Notifications about changed operations are ignored until the first ticker was fired for the same reason.
+	subscription := bk.repo.Notifier().Subscribe(reconciliation.NotificationOperations)
+	defer subscription.Close()
+	var notifications <-chan struct{}
+
 	ticker := time.NewTicker(bk.config.OperationsWatchInterval)
 	for {
 		select {
 		case <-ticker.C:
-			recons, err := bk.repo.GetReconciliations(&reconciliation.CurrentlyReconciling{})
-			if err != nil {
-				bk.logger.Errorf("Bookkeeper failed to retrieve currently running reconciliations: %s", err)
-				continue
-			}
-
-			for _, recon := range recons {
-				reconResult, err := bk.newReconciliationResult(recon)
-				if err == nil {
-					bk.logger.Debugf("Bookkeeper evaluated reconciliation (schedulingID:%s) for cluster '%s' "+
-						"to cluster status '%s': Done=%s / Error=%s / Other=%s",
-						recon.SchedulingID, recon.RuntimeID, reconResult.GetResult(),
-						bk.componentList(reconResult.done, false),
-						bk.componentList(reconResult.error, true),
-						bk.componentList(reconResult.other, true))
-				} else {
-					bk.logger.Errorf("Bookkeeper failed to retrieve operations for reconciliation '%s' "+
-						"(but will continue processing): %s", recon, err)
-					continue
-				}
-				for i := range tasks {
-					if err := tasks[i].Apply(reconResult, bk.config); err != nil {
-						bk.logger.Errorf("BookkeepingTask reported error: %s", err)
-					}
-				}
-
-			}
+			notifications = subscription.C
+			bk.processReconciliations(tasks)
+		case <-notifications:
+			bk.logger.Debug("Bookkeeper was notified about changed operations")
+			bk.processReconciliations(tasks)
 		case <-ctx.Done():
 			bk.logger.Info("Stopping bookkeeper because parent context got closed")
 			ticker.Stop()
@@ -123,6 +106,35 @@ func (bk *bookkeeper) Run(ctx context.Context, tasks ...BookkeepingTask) error {
 	}
 }
 
+func (bk *bookkeeper) processReconciliations(tasks []BookkeepingTask) {
+	recons, err := bk.repo.GetReconciliations(&reconciliation.CurrentlyReconciling{})
+	if err != nil {
+		bk.logger.Errorf("Bookkeeper failed to retrieve currently running reconciliations: %s", err)
+		return
+	}
+
+	for _, recon := range recons {
+		reconResult, err := bk.newReconciliationResult(recon)
+		if err == nil {
+			bk.logger.Debugf("Bookkeeper evaluated reconciliation (schedulingID:%s) for cluster '%s' "+
+				"to cluster status '%s': Done=%s / Error=%s / Other=%s",
+				recon.SchedulingID, recon.RuntimeID, reconResult.GetResult(),
+				bk.componentList(reconResult.done, false),
+				bk.componentList(reconResult.error, true),
+				bk.componentList(reconResult.other, true))
+		} else {
+			bk.logger.Errorf("Bookkeeper failed to retrieve operations for reconciliation '%s' "+
+				"(but will continue processing): %s", recon, err)
+			continue
+		}
+		for i := range tasks {
+			if err := tasks[i].Apply(reconResult, bk.config); err != nil {
+				bk.logger.Errorf("BookkeepingTask reported error: %s", err)
+			}
+		}
+	}
+}
+
 func (bk *bookkeeper) newReconciliationResult(recon *model.ReconciliationEntity) (*ReconciliationResult, error) {
 	ops, err := bk.repo.GetOperations(recon.SchedulingID)
 	if err != nil {
@@ -25,7 +25,7 @@ func TestBookkeeper(t *testing.T) {
 	//trigger reconciliation for cluster
 	reconRepo, err := reconciliation.NewPersistedReconciliationRepository(dbConn, true)
 	require.NoError(t, err)
-	reconEntity, err := reconRepo.CreateReconciliation(clusterState, nil)
+	reconEntity, err := reconRepo.CreateReconciliation(clusterState, nil, nil)
 	require.NoError(t, err)
 	require.NotEmpty(t, reconEntity.Lock)
 	require.False(t, reconEntity.Finished)
@@ -52,7 +52,7 @@ func TestBookkeeper(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second) //stop bookkeeper after 5 sec
 	defer cancel()
 
-	transition := newClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
+	transition := NewClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
 	start := time.Now()
 	require.NoError(t, bk.Run(ctx,
 		markOrphanOperation{transition: transition, logger: transition.logger},
@@ -50,7 +50,7 @@ func TestBookkeepingtask(t *testing.T) {
 			//trigger reconciliation for cluster
 			reconRepo, err := reconciliation.NewPersistedReconciliationRepository(dbConn, true)
 			require.NoError(t, err)
-			reconEntity, err := reconRepo.CreateReconciliation(clusterState, nil)
+			reconEntity, err := reconRepo.CreateReconciliation(clusterState, nil, nil)
 			require.NoError(t, err)
 			require.NotEmpty(t, reconEntity.Lock)
 			require.False(t, reconEntity.Finished)
@@ -66,7 +66,7 @@ func TestBookkeepingtask(t *testing.T) {
 			}
 
 			//setup bookkeeper task
-			transition := newClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
+			transition := NewClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
 
 			//initialize bookkeeper
 			bk := newBookkeeper(
@@ -142,7 +142,7 @@ func TestBookkeepingtaskParallel(t *testing.T) {
 			//trigger reconciliation for cluster
 			reconRepo, err := reconciliation.NewPersistedReconciliationRepository(dbConn, true)
 			require.NoError(t, err)
-			reconEntity, err := reconRepo.CreateReconciliation(clusterState, nil)
+			reconEntity, err := reconRepo.CreateReconciliation(clusterState, nil, nil)
 			require.NoError(t, err)
 			require.NotEmpty(t, reconEntity.Lock)
 			require.False(t, reconEntity.Finished)
@@ -158,7 +158,7 @@ func TestBookkeepingtaskParallel(t *testing.T) {
 			}
 
 			//setup bookkeeper task
-			transition := newClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
+			transition := NewClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
 
 			//initialize bookkeeper
 			bk := newBookkeeper(
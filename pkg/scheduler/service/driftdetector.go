@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/drift"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/invoker"
+	"go.uber.org/zap"
+)
+
+const defaultDriftDetectionInterval = 1 * time.Hour
+
+//planner renders the manifest of a component and compares it with the live objects in the cluster
+type planner interface {
+	Plan(ctx context.Context, params *invoker.Params) ([]*kubernetes.ResourceChange, error)
+}
+
+//driftDetector periodically checks ready clusters for resources which were modified or deleted outside of the
+//reconciler. Drifts are only reported: the detector never changes anything on a cluster.
+type driftDetector struct {
+	config        *config.DriftConfig
+	preComponents [][]string
+	inventory     cluster.Inventory
+	repo          drift.Repository
+	planner       planner
+	logger        *zap.SugaredLogger
+}
+
+func newDriftDetector(cfg *config.DriftConfig, preComponents [][]string, inventory cluster.Inventory,
+	repo drift.Repository, planner planner, logger *zap.SugaredLogger) *driftDetector {
+	return &driftDetector{
+		config:        cfg,
+		preComponents: preComponents,
+		inventory:     inventory,
+		repo:          repo,
+		planner:       planner,
+		logger:        logger,
+	}
+}
+
+func (d *driftDetector) Run(ctx context.Context) error {
+	interval := d.config.Interval
+	if interval == 0 {
+		interval = defaultDriftDetectionInterval
+	}
+	d.logger.Infof("Starting drift detection with an interval of %.1f min", interval.Minutes())
+
+	d.detectAll(ctx)
+	ticker := time.NewTicker(interval)
+	for {
+		select {
+		case <-ticker.C:
+			d.detectAll(ctx)
+		case <-ctx.Done():
+			d.logger.Info("Stopping drift detection because parent context got closed")
+			ticker.Stop()
+			return nil
+		}
+	}
+}
+
+func (d *driftDetector) detectAll(ctx context.Context) {
+	clusterStates, err := d.inventory.ClustersReady()
+	if err != nil {
+		d.logger.Errorf("Drift detection failed to fetch ready clusters from inventory: %s", err)
+		return
+	}
+
+	readyClusters := make(map[string]bool, len(clusterStates))
+	for _, clusterState := range clusterStates {
+		if ctx.Err() != nil {
+			return
+		}
+		readyClusters[clusterState.Cluster.RuntimeID] = true
+		if _, err := d.detect(ctx, clusterState); err != nil {
+			d.logger.Errorf("Drift detection failed for cluster '%s': %s", clusterState.Cluster.RuntimeID, err)
+		}
+	}
+
+	//drifts of clusters which are deleted or currently reconciled are outdated
+	drifts, err := d.repo.GetAll()
+	if err != nil {
+		d.logger.Errorf("Drift detection failed to fetch stored drifts: %s", err)
+		return
+	}
+	removed := make(map[string]bool)
+	for _, clusterDrift := range drifts {
+		if readyClusters[clusterDrift.RuntimeID] || removed[clusterDrift.RuntimeID] {
+			continue
+		}
+		if err := d.repo.Remove(clusterDrift.RuntimeID); err != nil {
+			d.logger.Errorf("Drift detection failed to remove outdated drifts of cluster '%s': %s", clusterDrift.RuntimeID, err)
+		}
+		removed[clusterDrift.RuntimeID] = true
+	}
+}
+
+//detect compares the components of the cluster configuration with the live objects and stores the result
+func (d *driftDetector) detect(ctx context.Context, clusterState *cluster.State) ([]*model.ClusterDriftEntity, error) {
+	runtimeID := clusterState.Cluster.RuntimeID
+	var drifts []*model.ClusterDriftEntity
+	for _, components := range clusterState.Configuration.GetReconciliationSequence(d.preComponents).Queue {
+		for _, component := range components {
+			if component.Component == model.CleanupComponent {
+				continue
+			}
+			componentDrift := &model.ClusterDriftEntity{
+				RuntimeID:     runtimeID,
+				ConfigVersion: clusterState.Configuration.Version,
+				Component:     component.Component,
+			}
+			changes, err := d.planner.Plan(ctx, &invoker.Params{
+				ComponentToReconcile: component,
+				ClusterState:         clusterState,
+				CorrelationID:        fmt.Sprintf("drift-%s", uuid.NewString()),
+			})
+			if err == nil {
+				componentDrift.Resources = converters.ConvertResourceChanges(drifted(changes))
+			} else {
+				d.logger.Warnf("Drift detection failed to plan component '%s' of cluster '%s': %s",
+					component.Component, runtimeID, err)
+				componentDrift.Error = err.Error()
+			}
+			drifts = append(drifts, componentDrift)
+		}
+	}
+
+	if err := d.repo.Save(runtimeID, drifts); err != nil {
+		return nil, err
+	}
+	d.logger.Debugf("Drift detection checked %d components of cluster '%s'", len(drifts), runtimeID)
+	return drifts, nil
+}
+
+//drifted filters the changes which were caused by modifications on the cluster. Prunes are ignored as they
+//refer to resources which were removed from the charts and not to resources changed by someone else.
+func drifted(changes []*kubernetes.ResourceChange) []*kubernetes.ResourceChange {
+	var result []*kubernetes.ResourceChange
+	for _, change := range changes {
+		if change != nil && change.Type != kubernetes.ChangeTypePrune {
+			result = append(result, change)
+		}
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/keb/test"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/drift"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/invoker"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPlanner struct {
+	changes map[string][]*kubernetes.ResourceChange
+	err     error
+}
+
+func (p *mockPlanner) Plan(_ context.Context, params *invoker.Params) ([]*kubernetes.ResourceChange, error) {
+	return p.changes[params.ComponentToReconcile.Component], p.err
+}
+
+func TestDriftDetector(t *testing.T) {
+	dbConn := db.NewTestConnection(t)
+	inventory, err := cluster.NewInventory(dbConn, true, cluster.MetricsCollectorMock{})
+	require.NoError(t, err)
+	repo, err := drift.NewPersistentRepository(dbConn, true)
+	require.NoError(t, err)
+
+	newCluster := func(status model.Status) *cluster.State {
+		state, err := inventory.CreateOrUpdate(1, test.NewCluster(t, uuid.NewString(), 1, false, test.OneComponentDummy))
+		require.NoError(t, err)
+		state, err = inventory.UpdateStatus(state, status)
+		require.NoError(t, err)
+		return state
+	}
+
+	planner := &mockPlanner{
+		changes: map[string][]*kubernetes.ResourceChange{
+			"dummy": {
+				{Kind: "Deployment", APIVersion: "apps/v1", Name: "dep", Namespace: "kyma-system",
+					Type: kubernetes.ChangeTypeUpdate, Patch: map[string]interface{}{"spec": map[string]interface{}{"replicas": 1.0}}},
+				{Kind: "ConfigMap", APIVersion: "v1", Name: "obsolete", Namespace: "kyma-system",
+					Type: kubernetes.ChangeTypePrune},
+			},
+		},
+	}
+	detector := newDriftDetector(&config.DriftConfig{Enabled: true}, nil, inventory, repo, planner, logger.NewLogger(true))
+
+	t.Run("Detect drift of ready cluster", func(t *testing.T) {
+		state := newCluster(model.ClusterStatusReady)
+		_, err := detector.detect(context.Background(), state)
+		require.NoError(t, err)
+
+		drifts, err := repo.Get(state.Cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Len(t, drifts, 2) //one entry per component (sorted by name)
+		require.Equal(t, "CRDs", drifts[0].Component)
+		require.Empty(t, drifts[0].Resources)
+		require.Equal(t, "dummy", drifts[1].Component)
+		require.Empty(t, drifts[1].Error)
+		require.Len(t, drifts[1].Resources, 1) //prunes are not reported as drift
+		require.Equal(t, "dep", drifts[1].Resources[0].Name)
+		require.Equal(t, keb.ResourceChangeTypeUpdate, drifts[1].Resources[0].Type)
+		require.NotNil(t, drifts[1].Resources[0].Patch)
+	})
+
+	t.Run("Record failed drift detection", func(t *testing.T) {
+		state := newCluster(model.ClusterStatusReady)
+		detector := newDriftDetector(&config.DriftConfig{Enabled: true}, nil, inventory, repo,
+			&mockPlanner{err: errors.New("reconciler not reachable")}, logger.NewLogger(true))
+		_, err := detector.detect(context.Background(), state)
+		require.NoError(t, err)
+
+		drifts, err := repo.Get(state.Cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Len(t, drifts, 2)
+		for _, componentDrift := range drifts {
+			require.Equal(t, "reconciler not reachable", componentDrift.Error)
+			require.Empty(t, componentDrift.Resources)
+		}
+	})
+
+	t.Run("Remove drifts of clusters which are no longer ready", func(t *testing.T) {
+		state := newCluster(model.ClusterStatusReady)
+		detector.detectAll(context.Background())
+		drifts, err := repo.Get(state.Cluster.RuntimeID)
+		require.NoError(t, err)
+		require.NotEmpty(t, drifts)
+
+		_, err = inventory.UpdateStatus(state, model.ClusterStatusReconciling)
+		require.NoError(t, err)
+		detector.detectAll(context.Background())
+		drifts, err = repo.Get(state.Cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Empty(t, drifts)
+	})
+}
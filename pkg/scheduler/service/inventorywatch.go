@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
 	"go.uber.org/zap"
 )
 
@@ -22,6 +24,13 @@ type inventoryWatcher struct {
 	inventory cluster.Inventory
 	config    *SchedulerConfig
 	logger    *zap.SugaredLogger
+	notifier  db.Notifier
+}
+
+//withNotifier wakes up the watcher whenever a cluster is waiting for a reconciliation (nil = only the watch interval is used)
+func (w *inventoryWatcher) withNotifier(notifier db.Notifier) *inventoryWatcher {
+	w.notifier = notifier
+	return w
 }
 
 func (w *inventoryWatcher) Inventory() cluster.Inventory {
@@ -32,12 +41,22 @@ func (w *inventoryWatcher) Run(ctx context.Context, queue inventoryQueue) error
 	w.logger.Infof("Starting inventory watcher with an watch-interval of %.1f secs",
 		w.config.InventoryWatchInterval.Seconds())
 
+	var notifications <-chan struct{} //stays nil without notifier: a nil channel is never selected
+	if w.notifier != nil {
+		subscription := w.notifier.Subscribe(cluster.NotificationClusters)
+		defer subscription.Close()
+		notifications = subscription.C
+	}
+
 	w.processClustersToReconcile(queue) //check for clusters now, otherwise first check would be trigger by ticker
 	ticker := time.NewTicker(w.config.InventoryWatchInterval)
 	for {
 		select {
 		case <-ticker.C:
 			w.processClustersToReconcile(queue)
+		case <-notifications:
+			w.logger.Debug("Inventory watcher was notified about clusters waiting for a reconciliation")
+			w.processClustersToReconcile(queue)
 		case <-ctx.Done():
 			w.logger.Info("Stopping inventory watcher because parent context got closed")
 			ticker.Stop()
@@ -61,6 +80,11 @@ func (w *inventoryWatcher) processClustersToReconcile(queue inventoryQueue) {
 			w.logger.Warn("Inventory watcher found nil cluster state when processing the list of clusters to reconcile")
 			continue
 		}
+		if w.config.DisableAutoCorrection && clusterState.Status.Status == model.ClusterStatusReady {
+			w.logger.Debugf("Inventory watcher skipped periodic reconciliation of runtime '%s' "+
+				"because auto-correction of drifts is disabled", clusterState.Cluster.RuntimeID)
+			continue
+		}
 		w.logger.Infof("Inventory watcher added runtime '%s' to scheduling queue "+
 			"(clusterVersion:%d/configVersion:%d/status:%s)",
 			clusterState.Cluster.RuntimeID,
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/stretchr/testify/require"
 )
@@ -74,3 +75,68 @@ func TestInventoryWatch_ShouldStopOnCtxClose(t *testing.T) {
 	require.NoError(t, inventoryWatch.Run(ctx, queue))
 	require.WithinDuration(t, startTime, time.Now(), 2*time.Second)
 }
+
+func TestInventoryWatch_DisableAutoCorrection(t *testing.T) {
+	newState := func(runtimeID string, status model.Status) *cluster.State {
+		return &cluster.State{
+			Cluster:       &model.ClusterEntity{RuntimeID: runtimeID},
+			Configuration: &model.ClusterConfigurationEntity{RuntimeID: runtimeID},
+			Status:        &model.ClusterStatusEntity{RuntimeID: runtimeID, Status: status},
+		}
+	}
+	inventory := &cluster.MockInventory{}
+	inventory.ClustersToReconcileResult = []*cluster.State{
+		newState("readyCluster", model.ClusterStatusReady),
+		newState("pendingCluster", model.ClusterStatusReconcilePending),
+	}
+	queue := make(chan *cluster.State, 2)
+
+	inventoryWatch := newInventoryWatch(
+		inventory,
+		logger.NewLogger(true),
+		&SchedulerConfig{
+			DisableAutoCorrection: true,
+		})
+	inventoryWatch.processClustersToReconcile(queue)
+
+	require.Len(t, queue, 1)
+	require.Equal(t, "pendingCluster", (<-queue).Cluster.RuntimeID)
+}
+
+func TestInventoryWatch_WakeUpByNotification(t *testing.T) {
+	inventory := &cluster.MockInventory{}
+	inventory.ClustersToReconcileResult = []*cluster.State{
+		{
+			Cluster:       &model.ClusterEntity{RuntimeID: "testCluster"},
+			Configuration: &model.ClusterConfigurationEntity{RuntimeID: "testCluster"},
+			Status:        &model.ClusterStatusEntity{RuntimeID: "testCluster", Status: model.ClusterStatusReconcilePending},
+		},
+	}
+	queue := make(chan *cluster.State, 1)
+	notifier := db.NewInProcessNotifier()
+
+	inventoryWatch := newInventoryWatch(
+		inventory,
+		logger.NewLogger(true),
+		&SchedulerConfig{
+			InventoryWatchInterval: time.Hour,
+		}).withNotifier(notifier)
+
+	ctx, cancelFn := context.WithCancel(context.TODO())
+	defer cancelFn()
+	go func(ctx context.Context, queue chan *cluster.State) {
+		require.NoError(t, inventoryWatch.Run(ctx, queue))
+	}(ctx, queue)
+
+	//first check happens when the watcher starts
+	<-queue
+
+	//second check is triggered by the notification (long before the watch interval is over)
+	require.NoError(t, notifier.Notify(cluster.NotificationClusters))
+	select {
+	case clusterState := <-queue:
+		require.Equal(t, "testCluster", clusterState.Cluster.RuntimeID)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "inventory watcher was not woken up by notification")
+	}
+}
@@ -14,6 +14,7 @@ type ReconciliationResult struct {
 	reconEntity *model.ReconciliationEntity
 	done        []*model.OperationEntity
 	error       []*model.OperationEntity
+	cancelled   []*model.OperationEntity
 	other       []*model.OperationEntity
 }
 
@@ -44,10 +45,12 @@ func (rs *ReconciliationResult) AddOperation(op *model.OperationEntity) error {
 	}
 
 	switch op.State {
-	case model.OperationStateDone:
+	case model.OperationStateDone, model.OperationStateSkipped: //skipped operations were accepted by a user
 		rs.done = append(rs.done, op)
 	case model.OperationStateError:
 		rs.error = append(rs.error, op)
+	case model.OperationStateCancelled:
+		rs.cancelled = append(rs.cancelled, op)
 	default:
 		rs.other = append(rs.other, op)
 	}
@@ -59,6 +62,7 @@ func (rs *ReconciliationResult) GetOperations() []*model.OperationEntity {
 	var result []*model.OperationEntity
 	result = append(result, rs.other...)
 	result = append(result, rs.done...)
+	result = append(result, rs.cancelled...)
 	return append(result, rs.error...)
 }
 
@@ -70,7 +74,13 @@ func (rs *ReconciliationResult) GetResult() model.Status {
 			break
 		}
 	}
-	if len(rs.error) > 0 {
+	if len(rs.cancelled) > 0 { //remaining operations were cancelled by the user
+		if isDelete {
+			return model.ClusterStatusDeleteCancelled
+		}
+		return model.ClusterStatusReconcileCancelled
+	}
+	if len(rs.error) > 0 && !rs.independentOperationsRunning() {
 		if isDelete {
 			return model.ClusterStatusDeleteError
 		}
@@ -92,6 +102,20 @@ func (rs *ReconciliationResult) GetResult() model.Status {
 	return model.ClusterStatusReconcileError
 }
 
+//independentOperationsRunning returns true if operations which don't depend on a failed operation are not finished yet
+func (rs *ReconciliationResult) independentOperationsRunning() bool {
+	graph := model.NewOperationGraph(rs.GetOperations())
+	if graph == nil { //operations without dependencies: reconciliation fails as soon as any operation failed
+		return false
+	}
+	for _, op := range rs.other {
+		if !graph.IsBlocked(op) {
+			return true
+		}
+	}
+	return false
+}
+
 func (rs *ReconciliationResult) GetOrphans(timeout time.Duration) []*model.OperationEntity {
 	var orphaned []*model.OperationEntity
 	for _, op := range rs.other {
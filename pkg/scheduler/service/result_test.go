@@ -140,6 +140,23 @@ func TestReconciliationResult(t *testing.T) {
 			},
 			expectedResult: model.ClusterStatusReady,
 		},
+		{
+			operations: []*model.OperationEntity{
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.1",
+					State:         model.OperationStateSkipped,
+				},
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.2",
+					State:         model.OperationStateDone,
+				},
+			},
+			expectedResult: model.ClusterStatusReady,
+		},
 		{
 			operations: []*model.OperationEntity{
 				{
@@ -157,6 +174,94 @@ func TestReconciliationResult(t *testing.T) {
 			},
 			expectedResult: model.ClusterStatusReconcileError,
 		},
+		{ //failed operation doesn't block independent operations
+			operations: []*model.OperationEntity{
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.1",
+					Component:     "comp1",
+					DependsOn:     []string{},
+					State:         model.OperationStateError,
+				},
+				{
+					Priority:      2,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.2",
+					Component:     "comp2",
+					DependsOn:     []string{"comp1"},
+					State:         model.OperationStateNew,
+				},
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.3",
+					Component:     "comp3",
+					DependsOn:     []string{},
+					State:         model.OperationStateInProgress,
+					Updated:       time.Now(),
+				},
+			},
+			expectedResult: model.ClusterStatusReconciling,
+		},
+		{ //all remaining operations depend on a failed operation
+			operations: []*model.OperationEntity{
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.1",
+					Component:     "comp1",
+					DependsOn:     []string{},
+					State:         model.OperationStateError,
+				},
+				{
+					Priority:      2,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.2",
+					Component:     "comp2",
+					DependsOn:     []string{"comp1"},
+					State:         model.OperationStateNew,
+				},
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.3",
+					Component:     "comp3",
+					DependsOn:     []string{},
+					State:         model.OperationStateDone,
+				},
+			},
+			expectedResult: model.ClusterStatusReconcileError,
+		},
+		{ //reconciliation was cancelled by the user
+			operations: []*model.OperationEntity{
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.1",
+					State:         model.OperationStateDone,
+				},
+				{
+					Priority:      2,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.2",
+					State:         model.OperationStateCancelled,
+				},
+			},
+			expectedResult: model.ClusterStatusReconcileCancelled,
+		},
+		{
+			operations: []*model.OperationEntity{
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.1",
+					Type:          model.OperationTypeDelete,
+					State:         model.OperationStateCancelled,
+				},
+			},
+			expectedResult: model.ClusterStatusDeleteCancelled,
+		},
 	}
 
 	for _, testCase := range testCases {
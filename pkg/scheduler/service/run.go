@@ -3,14 +3,23 @@ package service
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/breaker"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/discovery"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/drift"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/invoker"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/leader"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/rollout"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/taskqueue"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/webhook"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/worker"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -54,7 +63,15 @@ func (rb *RuntimeBuilder) RunRemote(
 	inventory cluster.Inventory,
 	config *config.Config) *RunRemote {
 
-	runR := &RunRemote{rb, conn, inventory, config, &SchedulerConfig{}, &BookkeeperConfig{}, &CleanerConfig{}}
+	runR := &RunRemote{
+		runtimeBuilder:   rb,
+		conn:             conn,
+		inventory:        inventory,
+		config:           config,
+		schedulerConfig:  &SchedulerConfig{},
+		bookkeeperConfig: &BookkeeperConfig{},
+		cleanerConfig:    &CleanerConfig{},
+	}
 	runR.runtimeBuilder.preComponents = config.Scheduler.PreComponents
 	return runR
 }
@@ -142,6 +159,13 @@ type RunRemote struct {
 	schedulerConfig  *SchedulerConfig
 	bookkeeperConfig *BookkeeperConfig
 	cleanerConfig    *CleanerConfig
+	rolloutPolicy    *rollout.Policy
+	driftRepo        drift.Repository
+	webhookRepo      webhook.Repository
+	taskQueue        taskqueue.Repository
+	discoveryRepo    discovery.Repository
+	breakers         *breaker.Breakers
+	leaderElector    *leader.Elector
 }
 
 func (r *RunRemote) logger() *zap.SugaredLogger { //convenient function
@@ -172,23 +196,111 @@ func (r *RunRemote) WithCleanerConfig(cfg *CleanerConfig) *RunRemote {
 	return r
 }
 
+func (r *RunRemote) WithRolloutPolicy(policy *rollout.Policy) *RunRemote {
+	r.rolloutPolicy = policy
+	return r
+}
+
+//WithDriftRepository defines where detected drifts are stored (required if the drift detection is enabled)
+func (r *RunRemote) WithDriftRepository(repo drift.Repository) *RunRemote {
+	r.driftRepo = repo
+	return r
+}
+
+//WithWebhookRepository defines where webhooks and their deliveries are stored (required if webhooks are enabled)
+func (r *RunRemote) WithWebhookRepository(repo webhook.Repository) *RunRemote {
+	r.webhookRepo = repo
+	return r
+}
+
+//WithTaskQueue defines where tasks for component reconcilers are queued (required if a component reconciler
+//leases its tasks instead of receiving them via HTTP)
+func (r *RunRemote) WithTaskQueue(queue taskqueue.Repository) *RunRemote {
+	r.taskQueue = queue
+	return r
+}
+
+//WithDiscoveryRepository defines where the component reconcilers register themselves (required if the discovery
+//is enabled)
+func (r *RunRemote) WithDiscoveryRepository(repo discovery.Repository) *RunRemote {
+	r.discoveryRepo = repo
+	return r
+}
+
+//WithCircuitBreakers stops pushing tasks to component reconcilers which failed consecutively
+func (r *RunRemote) WithCircuitBreakers(breakers *breaker.Breakers) *RunRemote {
+	r.breakers = breakers
+	return r
+}
+
+//WithLeaderElector lets the replica compete for the leadership: the bookkeeper, worker pool, scheduler, cleaner,
+//drift detection and webhook dispatcher are only running while this replica is the leader
+func (r *RunRemote) WithLeaderElector(elector *leader.Elector) *RunRemote {
+	r.leaderElector = elector
+	return r
+}
+
 func (r *RunRemote) Run(ctx context.Context) error {
 	if err := r.config.Validate(); err != nil {
 		return err
 	}
+	if r.config.Scheduler.Drift.Enabled && r.driftRepo == nil {
+		return errors.New("drift detection is enabled but no drift repository was configured")
+	}
+	if r.config.Scheduler.Webhooks.Enabled && r.webhookRepo == nil {
+		return errors.New("webhooks are enabled but no webhook repository was configured")
+	}
+	if r.config.Scheduler.Discovery.Enabled && r.discoveryRepo == nil {
+		return errors.New("discovery is enabled but no discovery repository was configured")
+	}
+	for name, compRecon := range r.config.Scheduler.Reconcilers {
+		if compRecon.QueuesTasks() && r.taskQueue == nil {
+			return fmt.Errorf("component reconciler '%s' leases its tasks but no task queue was configured", name)
+		}
+	}
+	if r.leaderElector == nil {
+		go r.startLoops(ctx)
+		return nil
+	}
+	go r.leaderElector.Run(ctx, r.startLoops)
+	return nil
+}
+
+//startLoops runs all background processes of the mothership until the context gets closed. It returns after all
+//processes have exited: a new leader term cannot start while the processes of the previous term are still running.
+func (r *RunRemote) startLoops(ctx context.Context) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	run := func(loop func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loop()
+		}()
+	}
+
 	//start bookkeeper
-	go func() {
-		transition := newClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
+	run(func() {
+		transition := NewClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
 		if err := newBookkeeper(transition.reconRepo, r.bookkeeperConfig, r.logger()).Run(ctx,
 			markOrphanOperation{transition: transition, logger: r.logger()},
 			finishOperation{transition: transition, logger: r.logger()}); err != nil {
 			r.logger().Fatalf("Bookkeeper returned an error: %s", err)
 		}
-	}()
+	})
 
 	//start worker pool
-	go func() {
+	run(func() {
 		remoteInvoker := invoker.NewRemoteReoncilerInvoker(r.reconciliationRepository(), r.config, r.logger())
+		if r.taskQueue != nil {
+			remoteInvoker.WithTaskQueue(r.taskQueue)
+		}
+		if r.config.Scheduler.Discovery.Enabled {
+			remoteInvoker.WithDiscovery(discovery.NewRouter(r.discoveryRepo, r.config.Scheduler.Discovery.HeartbeatTimeout))
+		}
+		if r.breakers != nil {
+			remoteInvoker.WithCircuitBreakers(r.breakers)
+		}
 		workerPool, err := r.runtimeBuilder.newWorkerPool(&worker.InventoryRetriever{Inventory: r.inventory}, remoteInvoker)
 		if err == nil {
 			r.logger().Info("Worker pool created")
@@ -199,23 +311,46 @@ func (r *RunRemote) Run(ctx context.Context) error {
 		if err := workerPool.Run(ctx); err != nil {
 			r.logger().Fatalf("Worker pool returned an error: %s", err)
 		}
-	}()
+	})
 
 	//start scheduler
-	go func() {
-		transition := newClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
-		if err := r.runtimeBuilder.newScheduler().Run(ctx, transition, r.schedulerConfig); err != nil {
+	run(func() {
+		transition := NewClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
+		if err := r.runtimeBuilder.newScheduler().withRolloutPolicy(r.rolloutPolicy).Run(ctx, transition, r.schedulerConfig); err != nil {
 			r.logger().Fatalf("Remote scheduler returned an error: %s", err)
 		}
-	}()
+	})
 
 	//start cleaner
-	go func() {
-		transition := newClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
+	run(func() {
+		transition := NewClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
 		if err := r.runtimeBuilder.newCleaner().Run(ctx, transition, r.cleanerConfig); err != nil {
 			r.logger().Fatalf("Cleaner returned an error: %s", err)
 		}
-	}()
+	})
 
-	return nil
+	//start drift detection
+	if r.config.Scheduler.Drift.Enabled {
+		run(func() {
+			remoteInvoker := invoker.NewRemoteReoncilerInvoker(r.reconciliationRepository(), r.config, r.logger())
+			detector := newDriftDetector(&r.config.Scheduler.Drift, r.runtimeBuilder.preComponents, r.inventory,
+				r.driftRepo, remoteInvoker, r.logger())
+			if err := detector.Run(ctx); err != nil {
+				r.logger().Fatalf("Drift detection returned an error: %s", err)
+			}
+		})
+	}
+
+	//start webhook dispatcher
+	if r.config.Scheduler.Webhooks.Enabled {
+		run(func() {
+			dispatcher, err := newWebhookDispatcher(&r.config.Scheduler.Webhooks, r.inventory, r.webhookRepo, r.logger())
+			if err != nil {
+				r.logger().Fatalf("Webhook dispatcher could not be created: %s", err)
+			}
+			if err := dispatcher.Run(ctx); err != nil {
+				r.logger().Fatalf("Webhook dispatcher returned an error: %s", err)
+			}
+		})
+	}
 }
@@ -286,3 +286,59 @@ func countStatus(status reconciler.Status, receivedUpdates []*reconciler.Callbac
 	}
 	return count
 }
+
+func TestRunRemoteStartLoops(t *testing.T) {
+	runtimeBuilder := NewRuntimeBuilder(reconciliation.NewInMemoryReconciliationRepository(), logger.NewLogger(debugLogging))
+	remoteRunner := runtimeBuilder.RunRemote(db.NewTestConnection(t), &cluster.MockInventory{}, &config.Config{
+		Scheme: "https",
+		Host:   "localhost",
+		Port:   8080,
+		Scheduler: config.SchedulerConfig{
+			Reconcilers: map[string]config.ComponentReconciler{
+				"base": {
+					URL: "https://localhost:8080/v1/run",
+				},
+			},
+		},
+	})
+	remoteRunner.WithBookkeeperConfig(&BookkeeperConfig{
+		OperationsWatchInterval: 100 * time.Millisecond,
+		OrphanOperationTimeout:  time.Second,
+	})
+	remoteRunner.WithWorkerPoolConfig(&worker.Config{
+		PoolSize:               1,
+		OperationCheckInterval: 100 * time.Millisecond,
+		InvokerMaxRetries:      1,
+		InvokerRetryDelay:      100 * time.Millisecond,
+	})
+	remoteRunner.WithSchedulerConfig(&SchedulerConfig{
+		InventoryWatchInterval:   100 * time.Millisecond,
+		ClusterReconcileInterval: time.Minute,
+	})
+	remoteRunner.WithCleanerConfig(&CleanerConfig{
+		PurgeEntitiesOlderThan: time.Minute,
+		CleanerInterval:        100 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		remoteRunner.startLoops(ctx)
+		close(done)
+	}()
+
+	//loops are running until the context gets closed
+	select {
+	case <-done:
+		require.Fail(t, "startLoops returned before the context was closed")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	//startLoops returns only after all loops have exited
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "startLoops did not return after the context was closed")
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/rollout"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -20,6 +22,7 @@ type SchedulerConfig struct {
 	InventoryWatchInterval   time.Duration
 	ClusterReconcileInterval time.Duration
 	ClusterQueueSize         int
+	DisableAutoCorrection    bool //ready clusters are not reconciled periodically (used if drifts are only reported)
 }
 
 func (wc *SchedulerConfig) validate() error {
@@ -47,6 +50,7 @@ func (wc *SchedulerConfig) validate() error {
 type scheduler struct {
 	logger        *zap.SugaredLogger
 	preComponents [][]string
+	rolloutPolicy *rollout.Policy
 }
 
 func newScheduler(preComponents [][]string, logger *zap.SugaredLogger) *scheduler {
@@ -56,9 +60,15 @@ func newScheduler(preComponents [][]string, logger *zap.SugaredLogger) *schedule
 	}
 }
 
+//withRolloutPolicy defines the policy which throttles the rollout of new Kyma versions (nil = no throttling)
+func (s *scheduler) withRolloutPolicy(policy *rollout.Policy) *scheduler {
+	s.rolloutPolicy = policy
+	return s
+}
+
 func (s *scheduler) RunOnce(clusterState *cluster.State, reconRepo reconciliation.Repository) error {
 	s.logger.Debugf("Starting local scheduler")
-	reconEntity, err := reconRepo.CreateReconciliation(clusterState, s.preComponents)
+	reconEntity, err := reconRepo.CreateReconciliation(clusterState, s.preComponents, nil)
 	if err == nil {
 		s.logger.Debugf("Scheduler created reconciliation entity: '%s", reconEntity)
 	}
@@ -71,11 +81,14 @@ func (s *scheduler) Run(ctx context.Context, transition *ClusterStatusTransition
 	}
 
 	queue := make(chan *cluster.State, config.ClusterQueueSize)
-	s.startInventoryWatcher(ctx, transition.Inventory(), config, queue)
+	s.startInventoryWatcher(ctx, transition.Inventory(), transition.conn.Notifier(), config, queue)
 
 	for {
 		select {
 		case clusterState := <-queue:
+			if !s.admit(clusterState) {
+				continue
+			}
 			if err := transition.StartReconciliation(clusterState.Cluster.RuntimeID, clusterState.Configuration.Version, s.preComponents); err == nil {
 				s.logger.Infof("Scheduler triggered reconciliation for cluster '%s' "+
 					"(clusterVersion:%d/configVersion:%d/status:%s/last status update:%.2f min)", clusterState.Cluster.RuntimeID,
@@ -92,7 +105,20 @@ func (s *scheduler) Run(ctx context.Context, transition *ClusterStatusTransition
 
 }
 
-func (s *scheduler) startInventoryWatcher(ctx context.Context, inventory cluster.Inventory, config *SchedulerConfig, queue chan *cluster.State) {
+func (s *scheduler) admit(clusterState *cluster.State) bool {
+	if s.rolloutPolicy == nil {
+		return true
+	}
+	admitted, err := s.rolloutPolicy.Admit(clusterState)
+	if err != nil {
+		s.logger.Warnf("Scheduler failed to evaluate rollout policy for cluster '%s': %s",
+			clusterState.Cluster.RuntimeID, err)
+		return false
+	}
+	return admitted
+}
+
+func (s *scheduler) startInventoryWatcher(ctx context.Context, inventory cluster.Inventory, notifier db.Notifier, config *SchedulerConfig, queue chan *cluster.State) {
 	s.logger.Infof("Starting inventory watcher")
 
 	go func(ctx context.Context,
@@ -101,7 +127,7 @@ func (s *scheduler) startInventoryWatcher(ctx context.Context, inventory cluster
 		queue chan *cluster.State,
 		cfg *SchedulerConfig) {
 
-		watcher := newInventoryWatch(clInv, logger, cfg)
+		watcher := newInventoryWatch(clInv, logger, cfg).withNotifier(notifier)
 		if err := watcher.Run(ctx, queue); err != nil {
 			logger.Errorf("Inventory watcher returned an error: %s", err)
 		}
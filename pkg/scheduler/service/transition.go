@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -18,7 +19,7 @@ type ClusterStatusTransition struct {
 	logger    *zap.SugaredLogger
 }
 
-func newClusterStatusTransition(
+func NewClusterStatusTransition(
 	conn db.Connection,
 	inventory cluster.Inventory,
 	reconRepo reconciliation.Repository,
@@ -40,8 +41,23 @@ func (t *ClusterStatusTransition) ReconciliationRepository() reconciliation.Repo
 }
 
 func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVersion int64, preComponents [][]string) error {
+	_, err := t.startReconciliation(runtimeID, configVersion, preComponents, nil)
+	return err
+}
+
+//StartOnDemandReconciliation starts a reconciliation independently of the reconcile interval. Beside clusters which
+//are a candidate for a reconciliation, also clusters in a final error or cancelled state are accepted.
+func (t *ClusterStatusTransition) StartOnDemandReconciliation(runtimeID string, configVersion int64, preComponents [][]string, onDemand *reconciliation.OnDemand) (*model.ReconciliationEntity, error) {
+	if onDemand == nil {
+		onDemand = &reconciliation.OnDemand{}
+	}
+	return t.startReconciliation(runtimeID, configVersion, preComponents, onDemand)
+}
+
+func (t *ClusterStatusTransition) startReconciliation(runtimeID string, configVersion int64, preComponents [][]string, onDemand *reconciliation.OnDemand) (*model.ReconciliationEntity, error) {
 	var oldClusterState *cluster.State
 	var newClusterState *cluster.State
+	var reconEntity *model.ReconciliationEntity
 	dbOp := func(tx *db.TxConnection) error {
 		inventoryTx, err := t.inventory.WithTx(tx)
 		if err != nil {
@@ -60,8 +76,8 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 			return errors.Wrapf(err, "failed to retrieve reconciliations for runtimeID '%s'", runtimeID)
 		}
 		if len(recons) > 0 {
-			return fmt.Errorf("cannot start reconciliation for cluster '%s': cluster is already enqueued "+
-				"with schedulingID '%s'", runtimeID, recons[0].SchedulingID)
+			return newClusterStateConflictError(fmt.Sprintf("cannot start reconciliation for cluster '%s': "+
+				"cluster is already enqueued with schedulingID '%s'", runtimeID, recons[0].SchedulingID))
 		}
 
 		oldClusterState, err = inventoryTx.Get(runtimeID, configVersion)
@@ -73,13 +89,14 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 
 		//set cluster status to reconciling or deleting depending on previous state
 		var targetState model.Status
-		if oldClusterState.Status.Status.IsDeleteCandidate() {
+		if oldClusterState.Status.Status.IsDeleteCandidate() && onDemand == nil {
 			targetState = model.ClusterStatusDeleting
-		} else if oldClusterState.Status.Status.IsReconcileCandidate() {
+		} else if oldClusterState.Status.Status.IsReconcileCandidate() ||
+			(onDemand != nil && isOnDemandReconcileCandidate(oldClusterState.Status.Status)) {
 			targetState = model.ClusterStatusReconciling
 		} else {
-			return fmt.Errorf("cannot start reconciliation of cluster %s because cluster is in state '%s'",
-				oldClusterState.Cluster.RuntimeID, oldClusterState.Status.Status)
+			return newClusterStateConflictError(fmt.Sprintf("cannot start reconciliation of cluster %s "+
+				"because cluster is in state '%s'", oldClusterState.Cluster.RuntimeID, oldClusterState.Status.Status))
 		}
 
 		newClusterState, err = inventoryTx.UpdateStatus(oldClusterState, targetState)
@@ -92,10 +109,14 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 			newClusterState.Cluster.RuntimeID, model.ClusterStatusReconciling)
 
 		//create reconciliation entity
-		reconEntity, err := reconRepoTx.CreateReconciliation(newClusterState, preComponents)
+		reconEntity, err = reconRepoTx.CreateReconciliation(newClusterState, preComponents, onDemand)
 		if err == nil {
 			t.logger.Infof("Starting reconciliation for cluster '%s' succeeded: reconciliation successfully enqueued "+
 				"(scheudlingID: %s)", newClusterState.Cluster.RuntimeID, reconEntity.SchedulingID)
+			if onDemand != nil {
+				t.logger.Infof("Reconciliation '%s' of cluster '%s' was requested on demand (components: %v): %s",
+					reconEntity.SchedulingID, newClusterState.Cluster.RuntimeID, onDemand.Components, onDemand.Reason)
+			}
 			return nil
 		}
 
@@ -118,10 +139,19 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 		if updateErr != nil {
 			t.logger.Errorf("Error updating cluster '%s': could not update cluster status to '%s': %s",
 				oldClusterState.Cluster.RuntimeID, model.ClusterStatusReconcileError, updateErr)
-			return errors.Wrap(updateErr, err.Error())
+			return nil, errors.Wrap(updateErr, err.Error())
 		}
 	}
-	return err
+	if err != nil {
+		return nil, err
+	}
+	return reconEntity, nil
+}
+
+//isOnDemandReconcileCandidate returns true for cluster states which are not reconciled automatically
+//but can be reconciled if it was explicitly requested
+func isOnDemandReconcileCandidate(status model.Status) bool {
+	return status == model.ClusterStatusReconcileError || status == model.ClusterStatusReconcileCancelled
 }
 
 func (t *ClusterStatusTransition) FinishReconciliation(schedulingID string, status model.Status) error {
@@ -192,3 +222,123 @@ func (t *ClusterStatusTransition) FinishReconciliation(schedulingID string, stat
 	}
 	return db.Transaction(t.conn, dbOp, t.logger)
 }
+
+//RetryOperation resets a failed operation to state 'new' so that it gets processed again
+func (t *ClusterStatusTransition) RetryOperation(schedulingID, correlationID string) error {
+	return t.resolveFailedOperation(schedulingID, correlationID, model.OperationStateNew, "")
+}
+
+//SkipOperation sets a failed operation to state 'skipped': the remaining operations of the reconciliation
+//are processed as if the skipped operation was successfully finished
+func (t *ClusterStatusTransition) SkipOperation(schedulingID, correlationID, reason string) error {
+	return t.resolveFailedOperation(schedulingID, correlationID, model.OperationStateSkipped, reason)
+}
+
+//resolveFailedOperation updates the state of a failed operation. If the reconciliation was already finished
+//because of the failed operation, the reconciliation is reopened and the cluster is set back to reconciling.
+func (t *ClusterStatusTransition) resolveFailedOperation(schedulingID, correlationID string, state model.OperationState, reason string) error {
+	dbOp := func(tx *db.TxConnection) error {
+		inventoryTx, err := t.inventory.WithTx(tx)
+		if err != nil {
+			return err
+		}
+
+		reconRepoTx, err := t.reconRepo.WithTx(tx)
+		if err != nil {
+			return err
+		}
+
+		reconEntity, err := reconRepoTx.GetReconciliation(schedulingID)
+		if err != nil {
+			return err
+		}
+		op, err := reconRepoTx.GetOperation(schedulingID, correlationID)
+		if err != nil {
+			return err
+		}
+		if op == nil {
+			return &repository.EntityNotFoundError{}
+		}
+
+		//the operation state is verified by the repository: reopen only reconciliations which were finished because of it
+		if reconEntity.Finished && op.State == model.OperationStateError {
+			if err := t.reopenReconciliation(inventoryTx, reconRepoTx, reconEntity); err != nil {
+				return err
+			}
+		}
+
+		if err := reconRepoTx.ResolveFailedOperation(schedulingID, correlationID, state, reason); err != nil {
+			return err
+		}
+		t.logger.Infof("Failed operation of component '%s' (schedulingID:%s/correlationID:%s) was set to state '%s'",
+			op.Component, schedulingID, correlationID, state)
+		return nil
+	}
+	return db.Transaction(t.conn, dbOp, t.logger)
+}
+
+func (t *ClusterStatusTransition) reopenReconciliation(inventory cluster.Inventory, reconRepo reconciliation.Repository,
+	reconEntity *model.ReconciliationEntity) error {
+	recons, err := reconRepo.GetReconciliations(&reconciliation.CurrentlyReconcilingWithRuntimeID{
+		RuntimeID: reconEntity.RuntimeID,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to retrieve reconciliations for runtimeID '%s'", reconEntity.RuntimeID)
+	}
+	if len(recons) > 0 {
+		return newClusterStateConflictError(fmt.Sprintf("cannot reopen reconciliation '%s': "+
+			"cluster '%s' is already enqueued with schedulingID '%s'",
+			reconEntity.SchedulingID, reconEntity.RuntimeID, recons[0].SchedulingID))
+	}
+
+	clusterState, err := inventory.GetLatest(reconEntity.RuntimeID)
+	if err != nil {
+		return err
+	}
+	if clusterState.Configuration.Version != reconEntity.ClusterConfig {
+		return newClusterStateConflictError(fmt.Sprintf("cannot reopen reconciliation '%s': "+
+			"cluster '%s' has a newer configuration (version %d)",
+			reconEntity.SchedulingID, reconEntity.RuntimeID, clusterState.Configuration.Version))
+	}
+
+	var targetState model.Status
+	switch clusterState.Status.Status {
+	case model.ClusterStatusReconcileError, model.ClusterStatusReconcileErrorRetryable:
+		targetState = model.ClusterStatusReconciling
+	case model.ClusterStatusDeleteError, model.ClusterStatusDeleteErrorRetryable:
+		targetState = model.ClusterStatusDeleting
+	default:
+		return newClusterStateConflictError(fmt.Sprintf("cannot reopen reconciliation '%s' "+
+			"because cluster '%s' is in state '%s'",
+			reconEntity.SchedulingID, reconEntity.RuntimeID, clusterState.Status.Status))
+	}
+
+	newClusterState, err := inventory.UpdateStatus(clusterState, targetState)
+	if err != nil {
+		return err
+	}
+	if err := reconRepo.ReopenReconciliation(reconEntity.SchedulingID, newClusterState.Status); err != nil {
+		return err
+	}
+	t.logger.Infof("Reopened reconciliation '%s' of cluster '%s': set cluster status to '%s'",
+		reconEntity.SchedulingID, reconEntity.RuntimeID, targetState)
+	return nil
+}
+
+//ClusterStateConflictError is returned if the current state of a cluster doesn't allow to start a reconciliation
+type ClusterStateConflictError struct {
+	msg string
+}
+
+func (err *ClusterStateConflictError) Error() string {
+	return err.msg
+}
+
+func newClusterStateConflictError(msg string) error {
+	return &ClusterStateConflictError{msg: msg}
+}
+
+func IsClusterStateConflictError(err error) bool {
+	var conflictErr *ClusterStateConflictError
+	return errors.As(err, &conflictErr)
+}
@@ -42,7 +42,7 @@ func TestTransition(t *testing.T) {
 	require.NoError(t, err)
 
 	//create transition which will change cluster states
-	transition := newClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
+	transition := NewClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
 
 	//cleanup at the end of the execution
 	defer func() {
@@ -106,9 +106,79 @@ func TestTransition(t *testing.T) {
 		require.Equal(t, clusterState.Status.Status, model.ClusterStatusReady)
 	})
 
+	t.Run("Start On-Demand Reconciliation", func(t *testing.T) {
+		currentClusterState, err := inventory.GetLatest(clusterState.Cluster.RuntimeID)
+		require.NoError(t, err)
+		_, err = inventory.UpdateStatus(currentClusterState, model.ClusterStatusReconcileError)
+		require.NoError(t, err)
+
+		//clusters in error state are only reconciled on demand
+		err = transition.StartReconciliation(clusterState.Cluster.RuntimeID, clusterState.Configuration.Version, nil)
+		require.True(t, IsClusterStateConflictError(err))
+
+		reconEntity, err := transition.StartOnDemandReconciliation(clusterState.Cluster.RuntimeID,
+			clusterState.Configuration.Version, nil, &reconciliation.OnDemand{
+				Components: []string{"TestComp1"},
+				Reason:     "TestComp1 is broken",
+			})
+		require.NoError(t, err)
+		require.Equal(t, "TestComp1 is broken", reconEntity.Reason)
+
+		//starting another reconciliation is not allowed
+		_, err = transition.StartOnDemandReconciliation(clusterState.Cluster.RuntimeID,
+			clusterState.Configuration.Version, nil, nil)
+		require.True(t, IsClusterStateConflictError(err))
+
+		//verify cluster status
+		currentClusterState, err = inventory.GetLatest(clusterState.Cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Equal(t, model.ClusterStatusReconciling, currentClusterState.Status.Status)
+
+		require.NoError(t, transition.FinishReconciliation(reconEntity.SchedulingID, model.ClusterStatusReady))
+	})
+
+	t.Run("Retry and Skip Failed Operation", func(t *testing.T) {
+		reconEntity, err := transition.StartOnDemandReconciliation(clusterState.Cluster.RuntimeID,
+			clusterState.Configuration.Version, nil, &reconciliation.OnDemand{Reason: "test"})
+		require.NoError(t, err)
+		ops, err := reconRepo.GetOperations(reconEntity.SchedulingID)
+		require.NoError(t, err)
+		require.NotEmpty(t, ops)
+		op := ops[0]
+		require.NoError(t, reconRepo.UpdateOperationState(op.SchedulingID, op.CorrelationID, model.OperationStateError, false, "failed"))
+
+		//reconciliation was finished because of the failed operation
+		require.NoError(t, transition.FinishReconciliation(reconEntity.SchedulingID, model.ClusterStatusReconcileError))
+
+		//retrying the operation reopens the reconciliation
+		require.NoError(t, transition.RetryOperation(op.SchedulingID, op.CorrelationID))
+		reconEntity, err = reconRepo.GetReconciliation(reconEntity.SchedulingID)
+		require.NoError(t, err)
+		require.False(t, reconEntity.Finished)
+		currentClusterState, err := inventory.GetLatest(clusterState.Cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Equal(t, model.ClusterStatusReconciling, currentClusterState.Status.Status)
+		op, err = reconRepo.GetOperation(op.SchedulingID, op.CorrelationID)
+		require.NoError(t, err)
+		require.Equal(t, model.OperationStateNew, op.State)
+
+		//skipping requires a failed operation and a reason
+		err = transition.SkipOperation(op.SchedulingID, op.CorrelationID, "not required")
+		require.True(t, reconciliation.IsOperationNotFailedError(err))
+		require.NoError(t, reconRepo.UpdateOperationState(op.SchedulingID, op.CorrelationID, model.OperationStateError, false, "failed again"))
+		require.Error(t, transition.SkipOperation(op.SchedulingID, op.CorrelationID, ""))
+		require.NoError(t, transition.SkipOperation(op.SchedulingID, op.CorrelationID, "not required"))
+		op, err = reconRepo.GetOperation(op.SchedulingID, op.CorrelationID)
+		require.NoError(t, err)
+		require.Equal(t, model.OperationStateSkipped, op.State)
+		require.Equal(t, "not required", op.Reason)
+
+		require.NoError(t, transition.FinishReconciliation(reconEntity.SchedulingID, model.ClusterStatusReady))
+	})
+
 	t.Run("Finish Reconciliation When Cluster is not in progress", func(t *testing.T) {
 		//get reconciliation entity
-		reconEntity, err := reconRepo.CreateReconciliation(clusterState, nil)
+		reconEntity, err := reconRepo.CreateReconciliation(clusterState, nil, nil)
 		require.NoError(t, err)
 		require.NotNil(t, reconEntity)
 		require.False(t, reconEntity.Finished)
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/webhook"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWebhookInterval    = 10 * time.Second
+	defaultWebhookTimeout     = 10 * time.Second
+	defaultWebhookMaxAttempts = 10
+	defaultWebhookRetryDelay  = 30 * time.Second
+	maxWebhookRetryDelay      = 1 * time.Hour
+	webhookStatusBatchSize    = 500
+)
+
+//webhookDispatcher pushes the status changes of clusters to the registered webhooks. The statuses recorded
+//by the inventory are used as outbox: each webhook remembers the last status which was checked for it.
+type webhookDispatcher struct {
+	config    *config.WebhookConfig
+	inventory cluster.Inventory
+	repo      webhook.Repository
+	client    *http.Client
+	lease     time.Duration //claimed deliveries are not retried by other dispatchers within this duration
+	logger    *zap.SugaredLogger
+}
+
+func newWebhookDispatcher(cfg *config.WebhookConfig, inventory cluster.Inventory, repo webhook.Repository,
+	logger *zap.SugaredLogger) (*webhookDispatcher, error) {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultWebhookTimeout
+	}
+	guard, err := webhook.NewAddressGuard(cfg.AllowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+	//the addresses of the webhooks are verified when a connection is dialed (proxies would bypass this check)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = guard.DialContext(&net.Dialer{Timeout: timeout})
+	return &webhookDispatcher{
+		config:    cfg,
+		inventory: inventory,
+		repo:      repo,
+		client:    &http.Client{Timeout: timeout, Transport: transport},
+		lease:     2 * timeout,
+		logger:    logger,
+	}, nil
+}
+
+func (d *webhookDispatcher) Run(ctx context.Context) error {
+	interval := d.config.Interval
+	if interval == 0 {
+		interval = defaultWebhookInterval
+	}
+	d.logger.Infof("Starting webhook dispatcher with an interval of %.1f secs", interval.Seconds())
+
+	d.dispatch(ctx)
+	ticker := time.NewTicker(interval)
+	for {
+		select {
+		case <-ticker.C:
+			d.dispatch(ctx)
+		case <-ctx.Done():
+			d.logger.Info("Stopping webhook dispatcher because parent context got closed")
+			ticker.Stop()
+			return nil
+		}
+	}
+}
+
+func (d *webhookDispatcher) dispatch(ctx context.Context) {
+	webhooks, err := d.repo.GetAll()
+	if err != nil {
+		d.logger.Errorf("Webhook dispatcher failed to retrieve webhooks: %s", err)
+		return
+	}
+	metadataCache := make(map[int64]*keb.Metadata) //key: cluster version
+	webhooksByID := make(map[string]*model.WebhookEntity, len(webhooks))
+	for _, wh := range webhooks {
+		webhooksByID[wh.ID] = wh
+		if err := d.enqueue(wh, metadataCache); err != nil {
+			d.logger.Errorf("Webhook dispatcher failed to enqueue events for webhook '%s': %s", wh.ID, err)
+		}
+	}
+
+	deliveries, err := d.repo.GetPendingDeliveries()
+	if err != nil {
+		d.logger.Errorf("Webhook dispatcher failed to retrieve pending deliveries: %s", err)
+		return
+	}
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return
+		}
+		wh, ok := webhooksByID[delivery.WebhookID]
+		if !ok || delivery.NextAttempt.After(time.Now().UTC()) {
+			continue
+		}
+		if err := d.deliver(ctx, wh, delivery); err != nil {
+			d.logger.Errorf("Webhook dispatcher failed to update state of delivery '%s': %s", delivery.ID, err)
+		}
+	}
+}
+
+//enqueue creates deliveries for all status changes the webhook subscribed to
+func (d *webhookDispatcher) enqueue(wh *model.WebhookEntity, metadataCache map[int64]*keb.Metadata) error {
+	statuses, err := d.repo.GetClusterStatuses(wh.LastStatusID, webhookStatusBatchSize)
+	if err != nil || len(statuses) == 0 {
+		return err
+	}
+	var deliveries []*model.WebhookDeliveryEntity
+	for _, status := range statuses {
+		event, err := d.newEvent(status, metadataCache)
+		if err != nil {
+			return err
+		}
+		if !webhook.Matches(wh, event) {
+			continue
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, &model.WebhookDeliveryEntity{
+			StatusID:  status.ID,
+			RuntimeID: event.RuntimeID,
+			Payload:   string(payload),
+		})
+	}
+	if err := d.repo.Enqueue(wh, deliveries, statuses[len(statuses)-1].ID); err != nil {
+		return err
+	}
+	if len(deliveries) > 0 {
+		d.logger.Debugf("Webhook dispatcher enqueued %d events for webhook '%s'", len(deliveries), wh.ID)
+	}
+	return nil
+}
+
+func (d *webhookDispatcher) newEvent(status *model.ClusterStatusEntity, metadataCache map[int64]*keb.Metadata) (*keb.WebhookEvent, error) {
+	metadata, ok := metadataCache[status.ClusterVersion]
+	if !ok {
+		clusterState, err := d.inventory.Get(status.RuntimeID, status.ConfigVersion)
+		switch {
+		case repository.IsNotFoundError(err): //event is sent without metadata
+		case err != nil:
+			return nil, err
+		default:
+			metadata = clusterState.Cluster.Metadata
+		}
+		metadataCache[status.ClusterVersion] = metadata
+	}
+	kebStatus, err := status.GetKEBClusterStatus()
+	if err != nil {
+		return nil, err
+	}
+	return &keb.WebhookEvent{
+		EventID:        fmt.Sprintf("%d", status.ID),
+		RuntimeID:      webhook.RuntimeID(status),
+		ClusterVersion: status.ClusterVersion,
+		ConfigVersion:  status.ConfigVersion,
+		Status:         kebStatus,
+		Metadata:       metadata,
+		Created:        status.Created,
+	}, nil
+}
+
+//deliver sends the event to the webhook and schedules a retry if the delivery failed. The delivery is claimed
+//before as the dispatchers of other mothership replicas could try to deliver it at the same time.
+func (d *webhookDispatcher) deliver(ctx context.Context, wh *model.WebhookEntity, delivery *model.WebhookDeliveryEntity) error {
+	claimed, err := d.repo.ClaimDelivery(delivery, d.lease)
+	if err != nil || !claimed {
+		return err
+	}
+	if err := d.post(ctx, wh, delivery); err == nil {
+		d.logger.Debugf("Webhook dispatcher delivered event of cluster status %d to webhook '%s'",
+			delivery.StatusID, wh.ID)
+		delivery.State = model.WebhookDeliveryStateDelivered
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = err.Error()
+		if int(delivery.Attempts) >= d.maxAttempts() {
+			d.logger.Warnf("Webhook dispatcher moved event of cluster status %d for webhook '%s' to the dead letters "+
+				"after %d attempts: %s", delivery.StatusID, wh.ID, delivery.Attempts, err)
+			delivery.State = model.WebhookDeliveryStateDead
+		} else {
+			delivery.NextAttempt = time.Now().UTC().Add(d.retryDelay(delivery.Attempts))
+			d.logger.Infof("Webhook dispatcher failed to deliver event of cluster status %d to webhook '%s' "+
+				"(attempt %d) and will retry at %s: %s", delivery.StatusID, wh.ID, delivery.Attempts,
+				delivery.NextAttempt.Format(time.RFC3339), err)
+		}
+	}
+	return d.repo.UpdateDelivery(delivery)
+}
+
+func (d *webhookDispatcher) post(ctx context.Context, wh *model.WebhookEntity, delivery *model.WebhookDeliveryEntity) error {
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.EventIDHeader, fmt.Sprintf("%d", delivery.StatusID))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(wh.Secret, payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		if err := resp.Body.Close(); err != nil {
+			d.logger.Warnf("Webhook dispatcher failed to close response body: %s", err)
+		}
+	}()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with HTTP code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (d *webhookDispatcher) maxAttempts() int {
+	if d.config.MaxAttempts == 0 {
+		return defaultWebhookMaxAttempts
+	}
+	return d.config.MaxAttempts
+}
+
+//retryDelay doubles the configured delay for each failed attempt
+func (d *webhookDispatcher) retryDelay(attempts int64) time.Duration {
+	delay := d.config.RetryDelay
+	if delay == 0 {
+		delay = defaultWebhookRetryDelay
+	}
+	for i := int64(1); i < attempts && delay < maxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxWebhookRetryDelay {
+		return maxWebhookRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/keb/test"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookReceiver struct {
+	sync.Mutex
+	secret     string
+	statusCode int
+	events     []*keb.WebhookEvent
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+	payload, err := ioutil.ReadAll(req.Body)
+	if err != nil || !webhook.VerifySignature(r.secret, payload, req.Header.Get(webhook.SignatureHeader)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.statusCode != http.StatusOK {
+		w.WriteHeader(r.statusCode)
+		return
+	}
+	event := &keb.WebhookEvent{}
+	if err := json.Unmarshal(payload, event); err != nil || event.EventID != req.Header.Get(webhook.EventIDHeader) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.events = append(r.events, event)
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	dbConn := db.NewTestConnection(t)
+	inventory, err := cluster.NewInventory(dbConn, true, cluster.MetricsCollectorMock{})
+	require.NoError(t, err)
+	repo, err := webhook.NewPersistentRepository(dbConn, true)
+	require.NoError(t, err)
+
+	receiver := &webhookReceiver{secret: "secret", statusCode: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	wh, err := repo.Create(&model.WebhookEntity{
+		URL:          server.URL,
+		Secret:       "secret",
+		StatusFilter: []model.Status{model.ClusterStatusReady},
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, repo.Delete(wh.ID))
+	}()
+
+	newClusterStatus := func(status model.Status) *cluster.State {
+		state, err := inventory.CreateOrUpdate(1, test.NewCluster(t, uuid.NewString(), 1, false, test.OneComponentDummy))
+		require.NoError(t, err)
+		state, err = inventory.UpdateStatus(state, status)
+		require.NoError(t, err)
+		return state
+	}
+
+	dispatcher, err := newWebhookDispatcher(&config.WebhookConfig{
+		MaxAttempts:     2,
+		AllowedNetworks: []string{"127.0.0.0/8"}, //test server listens on loopback
+	}, inventory, repo, logger.NewLogger(true))
+	require.NoError(t, err)
+
+	t.Run("Deliver signed events", func(t *testing.T) {
+		state := newClusterStatus(model.ClusterStatusReady)
+		newClusterStatus(model.ClusterStatusReconcileError) //filtered by status
+
+		dispatcher.dispatch(context.Background())
+
+		require.Len(t, receiver.events, 1)
+		require.Equal(t, state.Cluster.RuntimeID, receiver.events[0].RuntimeID)
+		require.Equal(t, keb.StatusReady, receiver.events[0].Status)
+		require.NotNil(t, receiver.events[0].Metadata)
+
+		pending, err := repo.GetPendingDeliveries()
+		require.NoError(t, err)
+		require.Empty(t, pending)
+		delivered, err := repo.GetDeliveries(wh.ID, model.WebhookDeliveryStateDelivered)
+		require.NoError(t, err)
+		require.Len(t, delivered, 1)
+	})
+
+	t.Run("Move failed events to dead letters", func(t *testing.T) {
+		receiver.statusCode = http.StatusServiceUnavailable
+		newClusterStatus(model.ClusterStatusReady)
+
+		dispatcher.dispatch(context.Background())
+		pending, err := repo.GetPendingDeliveries()
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		require.Equal(t, int64(1), pending[0].Attempts)
+		require.Contains(t, pending[0].LastError, "503")
+
+		//retry is scheduled for later: make it due
+		require.NoError(t, dispatcher.deliver(context.Background(), wh, pending[0]))
+
+		pending, err = repo.GetPendingDeliveries()
+		require.NoError(t, err)
+		require.Empty(t, pending)
+		dead, err := repo.GetDeliveries(wh.ID, model.WebhookDeliveryStateDead)
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+		require.Equal(t, int64(2), dead[0].Attempts)
+		require.Len(t, receiver.events, 1)
+	})
+
+	t.Run("Reject internal addresses when dialing", func(t *testing.T) {
+		receiver.statusCode = http.StatusOK
+		guardedDispatcher, err := newWebhookDispatcher(&config.WebhookConfig{}, inventory, repo, logger.NewLogger(true))
+		require.NoError(t, err)
+
+		delivery := &model.WebhookDeliveryEntity{StatusID: 1, Payload: `{"eventID":"1"}`}
+		err = guardedDispatcher.post(context.Background(), wh, delivery)
+		require.Error(t, err)
+		require.True(t, webhook.IsForbiddenAddressError(err), err.Error())
+		require.Len(t, receiver.events, 1)
+	})
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	dispatcher, err := newWebhookDispatcher(&config.WebhookConfig{}, nil, nil, logger.NewLogger(true))
+	require.NoError(t, err)
+	require.Equal(t, defaultWebhookRetryDelay, dispatcher.retryDelay(1))
+	require.Equal(t, 4*defaultWebhookRetryDelay, dispatcher.retryDelay(3))
+	require.Equal(t, maxWebhookRetryDelay, dispatcher.retryDelay(20))
+}
@@ -0,0 +1,31 @@
+package taskqueue
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+//LeaseLostError is returned if a task isn't leased anymore by the caller: either the task was completed or its lease
+//expired and the task was leased by someone else or replaced by a new invocation of the operation
+type LeaseLostError struct {
+	correlationID string
+	leaseID       string
+}
+
+func (err *LeaseLostError) Error() string {
+	return fmt.Sprintf("lease '%s' of task of operation '%s' is lost", err.leaseID, err.correlationID)
+}
+
+func newLeaseLostError(correlationID, leaseID string) error {
+	return &LeaseLostError{
+		correlationID: correlationID,
+		leaseID:       leaseID,
+	}
+}
+
+//IsLeaseLostError returns true if the error (or the error it wraps) is a LeaseLostError
+func IsLeaseLostError(err error) bool {
+	var leaseLostErr *LeaseLostError
+	return errors.As(err, &leaseLostErr)
+}
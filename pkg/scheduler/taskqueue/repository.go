@@ -0,0 +1,222 @@
+package taskqueue
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+)
+
+const (
+	DefaultVisibilityTimeout = 2 * time.Minute
+	DefaultMaxAttempts       = 5
+	//a lease can fail if another mothership replica leased the same task in the meantime: the next visible task is tried
+	maxLeaseAttempts = 3
+)
+
+//Repository is the queue of the tasks which are pulled by component reconcilers. A leased task is invisible for other
+//leases until its visibility timeout expired: a component reconciler has to extend the lease as long as it processes
+//the task and completes the task afterwards. Otherwise the task becomes visible again and gets re-delivered.
+type Repository interface {
+	//Enqueue adds a task to the queue of its component reconciler (a queued task of the same operation is replaced)
+	Enqueue(task *model.TaskEntity) error
+	//Lease returns the longest visible task of the component reconciler (nil if no task is visible) and hides it
+	//for the visibility timeout
+	Lease(reconciler string, visibilityTimeout time.Duration) (*model.TaskEntity, error)
+	//Extend hides a leased task for another visibility timeout
+	Extend(correlationID, leaseID string, visibilityTimeout time.Duration) error
+	//Complete removes a leased task from the queue
+	Complete(correlationID, leaseID string) error
+	//DeadLetter moves a leased task to the dead-letter queue: it's kept for analysis but no longer delivered
+	DeadLetter(correlationID, leaseID string) error
+}
+
+type PersistentRepository struct {
+	*repository.Repository
+}
+
+func NewPersistentRepository(conn db.Connection, debug bool) (Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &PersistentRepository{repo}, nil
+}
+
+func (r *PersistentRepository) Enqueue(task *model.TaskEntity) error {
+	dbOps := func(tx *db.TxConnection) error {
+		qDelete, err := db.NewQuery(tx, &model.TaskEntity{}, r.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := qDelete.Delete().Where(map[string]interface{}{"CorrelationID": task.CorrelationID}).Exec(); err != nil {
+			return err
+		}
+		task.LeaseID = ""
+		task.Attempts = 0
+		task.DeadLetter = false
+		task.VisibleAt = time.Now().UnixNano()
+		qInsert, err := db.NewQuery(tx, task, r.Logger)
+		if err != nil {
+			return err
+		}
+		if err := qInsert.Insert().Exec(); err != nil {
+			r.Logger.Errorf("TaskQueue failed to enqueue task of operation '%s' for component reconciler '%s': %s",
+				task.CorrelationID, task.Reconciler, err)
+			return err
+		}
+		return nil
+	}
+	return r.Transactional(dbOps)
+}
+
+func (r *PersistentRepository) Lease(reconciler string, visibilityTimeout time.Duration) (*model.TaskEntity, error) {
+	for attempt := 0; attempt < maxLeaseAttempts; attempt++ {
+		now := time.Now()
+		task, err := r.nextVisibleTask(reconciler, now)
+		if err != nil || task == nil {
+			return nil, err
+		}
+
+		//the visibility is compared to detect whether another replica leased the task in the meantime
+		previouslyVisibleAt := task.VisibleAt
+		task.LeaseID = uuid.NewString()
+		task.Attempts++
+		task.VisibleAt = now.Add(visibilityTimeout).UnixNano()
+		q, err := db.NewQuery(r.Conn, task, r.Logger)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := q.Update().
+			Where(map[string]interface{}{"CorrelationID": task.CorrelationID, "VisibleAt": previouslyVisibleAt}).
+			ExecCount()
+		if err != nil {
+			return nil, err
+		}
+		if updated == 1 {
+			return task, nil
+		}
+		r.Logger.Debugf("TaskQueue lost race for task of operation '%s': another lease was faster", task.CorrelationID)
+	}
+	return nil, nil
+}
+
+func (r *PersistentRepository) nextVisibleTask(reconciler string, now time.Time) (*model.TaskEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.TaskEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	colHandler, err := db.NewColumnHandler(&model.TaskEntity{}, r.Conn, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	reconcilerCol, err := colHandler.ColumnName("Reconciler")
+	if err != nil {
+		return nil, err
+	}
+	visibleAtCol, err := colHandler.ColumnName("VisibleAt")
+	if err != nil {
+		return nil, err
+	}
+	deadLetterCol, err := colHandler.ColumnName("DeadLetter")
+	if err != nil {
+		return nil, err
+	}
+	entity, err := q.Select().
+		WhereRaw(fmt.Sprintf("%s=$1 AND %s<$2 AND %s=$3", reconcilerCol, visibleAtCol, deadLetterCol),
+			reconciler, now.UnixNano(), false).
+		OrderBy(map[string]string{"VisibleAt": "ASC"}).
+		Limit(1).
+		GetOne()
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entity.(*model.TaskEntity), nil
+}
+
+func (r *PersistentRepository) Extend(correlationID, leaseID string, visibilityTimeout time.Duration) error {
+	task, err := r.getLeased(correlationID, leaseID)
+	if err != nil {
+		return err
+	}
+	task.VisibleAt = time.Now().Add(visibilityTimeout).UnixNano()
+	q, err := db.NewQuery(r.Conn, task, r.Logger)
+	if err != nil {
+		return err
+	}
+	updated, err := q.Update().
+		Where(map[string]interface{}{"CorrelationID": correlationID, "LeaseID": leaseID}).
+		ExecCount()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return newLeaseLostError(correlationID, leaseID)
+	}
+	return nil
+}
+
+func (r *PersistentRepository) Complete(correlationID, leaseID string) error {
+	q, err := db.NewQuery(r.Conn, &model.TaskEntity{}, r.Logger)
+	if err != nil {
+		return err
+	}
+	deleted, err := q.Delete().
+		Where(map[string]interface{}{"CorrelationID": correlationID, "LeaseID": leaseID}).
+		Exec()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return newLeaseLostError(correlationID, leaseID)
+	}
+	return nil
+}
+
+func (r *PersistentRepository) DeadLetter(correlationID, leaseID string) error {
+	task, err := r.getLeased(correlationID, leaseID)
+	if err != nil {
+		return err
+	}
+	task.DeadLetter = true
+	q, err := db.NewQuery(r.Conn, task, r.Logger)
+	if err != nil {
+		return err
+	}
+	updated, err := q.Update().
+		Where(map[string]interface{}{"CorrelationID": correlationID, "LeaseID": leaseID}).
+		ExecCount()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return newLeaseLostError(correlationID, leaseID)
+	}
+	r.Logger.Warnf("TaskQueue moved task of operation '%s' to the dead-letter queue after %d attempts",
+		correlationID, task.Attempts)
+	return nil
+}
+
+func (r *PersistentRepository) getLeased(correlationID, leaseID string) (*model.TaskEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.TaskEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := q.Select().
+		Where(map[string]interface{}{"CorrelationID": correlationID, "LeaseID": leaseID}).
+		GetOne()
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, newLeaseLostError(correlationID, leaseID)
+		}
+		return nil, err
+	}
+	return entity.(*model.TaskEntity), nil
+}
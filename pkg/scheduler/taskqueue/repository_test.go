@@ -0,0 +1,134 @@
+package taskqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentRepository(t *testing.T) {
+	repo, err := NewPersistentRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+
+	enqueue := func(t *testing.T, reconciler string) *model.TaskEntity {
+		task := &model.TaskEntity{
+			CorrelationID: uuid.NewString(),
+			SchedulingID:  uuid.NewString(),
+			Reconciler:    reconciler,
+			Payload:       `{"kubeconfig":"secret"}`,
+		}
+		require.NoError(t, repo.Enqueue(task))
+		return task
+	}
+
+	t.Run("Lease tasks of a component reconciler", func(t *testing.T) {
+		reconciler := uuid.NewString()
+		task1 := enqueue(t, reconciler)
+		time.Sleep(10 * time.Millisecond) //tasks are leased in the order they became visible
+		task2 := enqueue(t, reconciler)
+		enqueue(t, uuid.NewString()) //task of another component reconciler
+
+		leased, err := repo.Lease(reconciler, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, task1.CorrelationID, leased.CorrelationID)
+		require.Equal(t, task1.Payload, leased.Payload)
+		require.NotEmpty(t, leased.LeaseID)
+		require.Equal(t, int64(1), leased.Attempts)
+
+		leased, err = repo.Lease(reconciler, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, task2.CorrelationID, leased.CorrelationID)
+
+		//all tasks are leased
+		leased, err = repo.Lease(reconciler, time.Minute)
+		require.NoError(t, err)
+		require.Nil(t, leased)
+	})
+
+	t.Run("Re-deliver task after lease expired", func(t *testing.T) {
+		reconciler := uuid.NewString()
+		task := enqueue(t, reconciler)
+
+		leased1, err := repo.Lease(reconciler, 10*time.Millisecond)
+		require.NoError(t, err)
+		require.Equal(t, task.CorrelationID, leased1.CorrelationID)
+
+		time.Sleep(20 * time.Millisecond)
+		leased2, err := repo.Lease(reconciler, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, task.CorrelationID, leased2.CorrelationID)
+		require.NotEqual(t, leased1.LeaseID, leased2.LeaseID)
+		require.Equal(t, int64(2), leased2.Attempts)
+
+		//first lease is lost
+		err = repo.Extend(leased1.CorrelationID, leased1.LeaseID, time.Minute)
+		require.True(t, IsLeaseLostError(err))
+		err = repo.Complete(leased1.CorrelationID, leased1.LeaseID)
+		require.True(t, IsLeaseLostError(err))
+
+		require.NoError(t, repo.Complete(leased2.CorrelationID, leased2.LeaseID))
+		leased, err := repo.Lease(reconciler, time.Minute)
+		require.NoError(t, err)
+		require.Nil(t, leased)
+	})
+
+	t.Run("Extend lease", func(t *testing.T) {
+		reconciler := uuid.NewString()
+		enqueue(t, reconciler)
+
+		leased, err := repo.Lease(reconciler, 10*time.Millisecond)
+		require.NoError(t, err)
+		require.NoError(t, repo.Extend(leased.CorrelationID, leased.LeaseID, time.Minute))
+
+		time.Sleep(20 * time.Millisecond)
+		notVisible, err := repo.Lease(reconciler, time.Minute)
+		require.NoError(t, err)
+		require.Nil(t, notVisible)
+
+		require.NoError(t, repo.Complete(leased.CorrelationID, leased.LeaseID))
+	})
+
+	t.Run("Dead-lettered task is no longer delivered", func(t *testing.T) {
+		reconciler := uuid.NewString()
+		task := enqueue(t, reconciler)
+
+		leased, err := repo.Lease(reconciler, 10*time.Millisecond)
+		require.NoError(t, err)
+		require.NoError(t, repo.DeadLetter(leased.CorrelationID, leased.LeaseID))
+
+		time.Sleep(20 * time.Millisecond)
+		leased, err = repo.Lease(reconciler, time.Minute)
+		require.NoError(t, err)
+		require.Nil(t, leased)
+
+		//operation was invoked again: the new task is delivered
+		require.NoError(t, repo.Enqueue(task))
+		leased, err = repo.Lease(reconciler, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, task.CorrelationID, leased.CorrelationID)
+		require.NoError(t, repo.Complete(leased.CorrelationID, leased.LeaseID))
+	})
+
+	t.Run("Enqueue replaces task of operation", func(t *testing.T) {
+		reconciler := uuid.NewString()
+		task := enqueue(t, reconciler)
+		leased, err := repo.Lease(reconciler, time.Minute)
+		require.NoError(t, err)
+
+		//operation was invoked again: the new task is visible immediately
+		task.Payload = `{"kubeconfig":"new"}`
+		require.NoError(t, repo.Enqueue(task))
+		err = repo.Extend(leased.CorrelationID, leased.LeaseID, time.Minute)
+		require.True(t, IsLeaseLostError(err))
+
+		leased, err = repo.Lease(reconciler, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, `{"kubeconfig":"new"}`, leased.Payload)
+		require.Equal(t, int64(1), leased.Attempts)
+		require.NoError(t, repo.Complete(leased.CorrelationID, leased.LeaseID))
+	})
+}
@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+//blockedNetworks are internal networks which webhooks cannot target: otherwise subscribers could use the mothership
+//to send requests to internal services (e.g. the cloud metadata endpoint or the Kubernetes API server)
+var blockedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",      //'this' network
+	"10.0.0.0/8",     //private
+	"100.64.0.0/10",  //shared address space (carrier-grade NAT)
+	"127.0.0.0/8",    //loopback
+	"169.254.0.0/16", //link-local (e.g. cloud metadata endpoints)
+	"172.16.0.0/12",  //private
+	"192.168.0.0/16", //private
+	"::/128",         //unspecified
+	"::1/128",        //loopback
+	"fc00::/7",       //unique local
+	"fe80::/10",      //link-local
+)
+
+//ForbiddenAddressError is returned if the host of a webhook resolves to an internal address
+type ForbiddenAddressError struct {
+	Host string
+	IP   net.IP
+}
+
+func (e *ForbiddenAddressError) Error() string {
+	return fmt.Sprintf("host '%s' of webhook resolves to the internal address '%s'", e.Host, e.IP)
+}
+
+func IsForbiddenAddressError(err error) bool {
+	var addrErr *ForbiddenAddressError
+	return errors.As(err, &addrErr)
+}
+
+//AddressGuard rejects webhooks whose host resolves to a loopback, link-local or private address. The addresses are
+//verified when a webhook is registered and again whenever a connection is dialed (the DNS record could change).
+type AddressGuard struct {
+	allowedNetworks []*net.IPNet
+	resolver        *net.Resolver
+}
+
+//NewAddressGuard creates a guard: addresses of the allowed networks (CIDR notation) are accepted even if they are internal
+func NewAddressGuard(allowedNetworks []string) (*AddressGuard, error) {
+	guard := &AddressGuard{resolver: net.DefaultResolver}
+	for _, cidr := range allowedNetworks {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("allowed network '%s' of webhooks is invalid", cidr))
+		}
+		guard.allowedNetworks = append(guard.allowedNetworks, network)
+	}
+	return guard, nil
+}
+
+//Allowed returns true if webhooks can send events to the address
+func (g *AddressGuard) Allowed(ip net.IP) bool {
+	for _, network := range g.allowedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	if ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+//Verify resolves the host of the webhook URL and fails if any of its addresses is not allowed
+func (g *AddressGuard) Verify(ctx context.Context, webhookURL string) error {
+	endpoint, err := url.Parse(webhookURL)
+	if err != nil {
+		return err
+	}
+	_, err = g.resolve(ctx, endpoint.Hostname())
+	return err
+}
+
+//DialContext dials only allowed addresses: the connection is established to the verified address and not
+//to the result of a second DNS lookup. It's used as dialer of the HTTP transport which delivers the events.
+func (g *AddressGuard) DialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		ips, err := g.resolve(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var dialErr error
+		for _, ip := range ips {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			dialErr = err
+		}
+		return nil, dialErr
+	}
+}
+
+func (g *AddressGuard) resolve(ctx context.Context, host string) ([]net.IP, error) {
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := g.resolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to resolve host '%s' of webhook", host))
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("host '%s' of webhook has no address", host)
+	}
+	for _, ip := range ips {
+		if !g.Allowed(ip) {
+			return nil, &ForbiddenAddressError{Host: host, IP: ip}
+		}
+	}
+	return ips, nil
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressGuard(t *testing.T) {
+	guard, err := NewAddressGuard(nil)
+	require.NoError(t, err)
+
+	t.Run("Internal addresses are rejected", func(t *testing.T) {
+		for _, ip := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+			"100.64.0.1", "0.0.0.0", "::1", "fd00::1", "fe80::1", "::ffff:127.0.0.1", "224.0.0.1"} {
+			require.False(t, guard.Allowed(net.ParseIP(ip)), ip)
+		}
+	})
+
+	t.Run("Public addresses are accepted", func(t *testing.T) {
+		for _, ip := range []string{"8.8.8.8", "172.32.0.1", "2001:4860:4860::8888"} {
+			require.True(t, guard.Allowed(net.ParseIP(ip)), ip)
+		}
+	})
+
+	t.Run("Verify URLs", func(t *testing.T) {
+		require.NoError(t, guard.Verify(context.Background(), "https://8.8.8.8/events"))
+		err := guard.Verify(context.Background(), "http://169.254.169.254/latest/meta-data")
+		require.True(t, IsForbiddenAddressError(err))
+		err = guard.Verify(context.Background(), "http://[::1]:8080/events")
+		require.True(t, IsForbiddenAddressError(err))
+	})
+
+	t.Run("Allowed networks", func(t *testing.T) {
+		allowingGuard, err := NewAddressGuard([]string{"10.0.0.0/16"})
+		require.NoError(t, err)
+		require.True(t, allowingGuard.Allowed(net.ParseIP("10.0.1.1")))
+		require.False(t, allowingGuard.Allowed(net.ParseIP("10.1.1.1")))
+
+		_, err = NewAddressGuard([]string{"10.0.0.0"})
+		require.Error(t, err)
+	})
+}
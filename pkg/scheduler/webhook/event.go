@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+const (
+	SignatureHeader = "X-Reconciler-Signature"
+	EventIDHeader   = "X-Reconciler-Event-ID"
+	signaturePrefix = "sha256="
+)
+
+//Sign returns the value of the signature header of an event (HMAC-SHA256 of the payload)
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+//VerifySignature can be used by subscribers to verify that an event was sent by the mothership
+func VerifySignature(secret string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
+
+//Validate verifies the registration of a webhook
+func Validate(webhook *model.WebhookEntity) error {
+	endpoint, err := url.Parse(webhook.URL)
+	if err != nil {
+		return err
+	}
+	if (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return fmt.Errorf("URL '%s' of webhook is not an absolute HTTP(S) URL", webhook.URL)
+	}
+	if webhook.Secret == "" {
+		return fmt.Errorf("secret of webhook is undefined")
+	}
+	for _, status := range webhook.StatusFilter {
+		if _, err := model.NewClusterStatus(status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Matches returns true if the webhook subscribed to the status change of the cluster
+func Matches(webhook *model.WebhookEntity, event *keb.WebhookEvent) bool {
+	if len(webhook.RuntimeIDFilter) > 0 && !contains(webhook.RuntimeIDFilter, event.RuntimeID) {
+		return false
+	}
+	if len(webhook.StatusFilter) > 0 {
+		var found bool
+		for _, status := range webhook.StatusFilter {
+			if string(status) == string(event.Status) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(webhook.MetadataFilter) > 0 {
+		return event.Metadata.Matches(webhook.MetadataFilter)
+	}
+	return true
+}
+
+//RuntimeID returns the runtime ID of a cluster status: deleted clusters get a prefix which is removed
+func RuntimeID(status *model.ClusterStatusEntity) string {
+	if status.Deleted && strings.HasPrefix(status.RuntimeID, "deleted_") {
+		if parts := strings.SplitN(status.RuntimeID, "_", 3); len(parts) == 3 {
+			return parts[2]
+		}
+	}
+	return status.RuntimeID
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignature(t *testing.T) {
+	payload := []byte(`{"eventID":"1"}`)
+	signature := Sign("secret", payload)
+	require.Regexp(t, "^sha256=[0-9a-f]{64}$", signature)
+	require.True(t, VerifySignature("secret", payload, signature))
+	require.False(t, VerifySignature("other", payload, signature))
+	require.False(t, VerifySignature("secret", []byte(`{"eventID":"2"}`), signature))
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate(&model.WebhookEntity{URL: "https://keb.local/events", Secret: "s"}))
+	require.Error(t, Validate(&model.WebhookEntity{URL: "keb.local/events", Secret: "s"}))
+	require.Error(t, Validate(&model.WebhookEntity{URL: "ftp://keb.local", Secret: "s"}))
+	require.Error(t, Validate(&model.WebhookEntity{URL: "https://keb.local/events"}))
+	require.Error(t, Validate(&model.WebhookEntity{URL: "https://keb.local/events", Secret: "s",
+		StatusFilter: []model.Status{"unknown"}}))
+}
+
+func TestMatches(t *testing.T) {
+	event := &keb.WebhookEvent{
+		RuntimeID: "runtime1",
+		Status:    keb.StatusReady,
+		Metadata:  &keb.Metadata{Region: "westeurope", ServicePlanName: "azure"},
+	}
+
+	tests := []struct {
+		name     string
+		webhook  *model.WebhookEntity
+		expected bool
+	}{
+		{"No filters", &model.WebhookEntity{}, true},
+		{"Runtime matches", &model.WebhookEntity{RuntimeIDFilter: []string{"runtime1"}}, true},
+		{"Runtime differs", &model.WebhookEntity{RuntimeIDFilter: []string{"runtime2"}}, false},
+		{"Status matches", &model.WebhookEntity{StatusFilter: []model.Status{model.ClusterStatusReady}}, true},
+		{"Status differs", &model.WebhookEntity{StatusFilter: []model.Status{model.ClusterStatusReconcileError}}, false},
+		{"Metadata matches", &model.WebhookEntity{MetadataFilter: map[string][]string{
+			"region": {"eastus", "westeurope"}, "ServicePlanName": {"azure"}}}, true},
+		{"Metadata differs", &model.WebhookEntity{MetadataFilter: map[string][]string{"region": {"eastus"}}}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Matches(tc.webhook, event))
+		})
+	}
+
+	require.False(t, Matches(&model.WebhookEntity{MetadataFilter: map[string][]string{"region": {"eastus"}}},
+		&keb.WebhookEvent{RuntimeID: "runtime1"}))
+}
+
+func TestRuntimeID(t *testing.T) {
+	require.Equal(t, "runtime1", RuntimeID(&model.ClusterStatusEntity{RuntimeID: "runtime1"}))
+	require.Equal(t, "runtime1", RuntimeID(&model.ClusterStatusEntity{RuntimeID: "deleted_1634567890_runtime1", Deleted: true}))
+}
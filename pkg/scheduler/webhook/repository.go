@@ -0,0 +1,271 @@
+package webhook
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+)
+
+type Repository interface {
+	//Create registers a webhook: it gets notified about all status changes which happen after its registration
+	Create(webhook *model.WebhookEntity) (*model.WebhookEntity, error)
+	Get(webhookID string) (*model.WebhookEntity, error)
+	GetAll() ([]*model.WebhookEntity, error)
+	Delete(webhookID string) error
+	//GetClusterStatuses returns the cluster statuses which were recorded after the status with the given ID
+	GetClusterStatuses(afterStatusID int64, limit int) ([]*model.ClusterStatusEntity, error)
+	//Enqueue stores the deliveries of a webhook and remembers the last cluster status which was checked for it.
+	//Nothing is stored if another dispatcher (e.g. of another mothership replica) enqueued these statuses before.
+	Enqueue(webhook *model.WebhookEntity, deliveries []*model.WebhookDeliveryEntity, lastStatusID int64) error
+	GetPendingDeliveries() ([]*model.WebhookDeliveryEntity, error)
+	//ClaimDelivery reserves a pending delivery for the next attempt: its attempts are increased and the next attempt
+	//is postponed by the lease (in case the dispatcher dies during the attempt). Returns false if another dispatcher
+	//claimed the delivery before.
+	ClaimDelivery(delivery *model.WebhookDeliveryEntity, lease time.Duration) (bool, error)
+	GetDeliveries(webhookID string, state model.WebhookDeliveryState) ([]*model.WebhookDeliveryEntity, error)
+	UpdateDelivery(delivery *model.WebhookDeliveryEntity) error
+}
+
+type PersistentRepository struct {
+	*repository.Repository
+}
+
+func NewPersistentRepository(conn db.Connection, debug bool) (Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &PersistentRepository{repo}, nil
+}
+
+func (r *PersistentRepository) Create(webhook *model.WebhookEntity) (*model.WebhookEntity, error) {
+	lastStatusID, err := r.lastClusterStatusID()
+	if err != nil {
+		return nil, err
+	}
+	webhook.ID = uuid.NewString()
+	webhook.LastStatusID = lastStatusID
+	q, err := db.NewQuery(r.Conn, webhook, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.Insert().Exec(); err != nil {
+		r.Logger.Errorf("WebhookRepo failed to create webhook for URL '%s': %s", webhook.URL, err)
+		return nil, err
+	}
+	return r.Get(webhook.ID)
+}
+
+func (r *PersistentRepository) lastClusterStatusID() (int64, error) {
+	q, err := db.NewQuery(r.Conn, &model.ClusterStatusEntity{}, r.Logger)
+	if err != nil {
+		return 0, err
+	}
+	entity, err := q.Select().
+		OrderBy(map[string]string{"ID": "DESC"}).
+		Limit(1).
+		GetOne()
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return entity.(*model.ClusterStatusEntity).ID, nil
+}
+
+func (r *PersistentRepository) Get(webhookID string) (*model.WebhookEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.WebhookEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{"ID": webhookID}
+	entity, err := q.Select().
+		Where(whereCond).
+		GetOne()
+	if err != nil {
+		return nil, r.MapError(err, entity, whereCond)
+	}
+	return entity.(*model.WebhookEntity), nil
+}
+
+func (r *PersistentRepository) GetAll() ([]*model.WebhookEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.WebhookEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		OrderBy(map[string]string{"Created": "ASC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.WebhookEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.WebhookEntity))
+	}
+	return result, nil
+}
+
+func (r *PersistentRepository) Delete(webhookID string) error {
+	if _, err := r.Get(webhookID); err != nil {
+		return err
+	}
+	dbOps := func(tx *db.TxConnection) error {
+		//deliveries are removed explicitly as not all databases cascade the deletion
+		qDeliveries, err := db.NewQuery(tx, &model.WebhookDeliveryEntity{}, r.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := qDeliveries.Delete().Where(map[string]interface{}{"WebhookID": webhookID}).Exec(); err != nil {
+			return err
+		}
+		qWebhook, err := db.NewQuery(tx, &model.WebhookEntity{}, r.Logger)
+		if err != nil {
+			return err
+		}
+		_, err = qWebhook.Delete().Where(map[string]interface{}{"ID": webhookID}).Exec()
+		return err
+	}
+	return r.Transactional(dbOps)
+}
+
+func (r *PersistentRepository) GetClusterStatuses(afterStatusID int64, limit int) ([]*model.ClusterStatusEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.ClusterStatusEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	colHandler, err := db.NewColumnHandler(&model.ClusterStatusEntity{}, r.Conn, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	columnName, err := colHandler.ColumnName("ID")
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		WhereRaw(fmt.Sprintf("%s>$1", columnName), afterStatusID).
+		OrderBy(map[string]string{"ID": "ASC"}).
+		Limit(limit).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.ClusterStatusEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.ClusterStatusEntity))
+	}
+	return result, nil
+}
+
+func (r *PersistentRepository) Enqueue(webhook *model.WebhookEntity, deliveries []*model.WebhookDeliveryEntity, lastStatusID int64) error {
+	dbOps := func(tx *db.TxConnection) error {
+		//the last checked status is compared to detect whether another dispatcher enqueued the statuses in the meantime
+		previousStatusID := webhook.LastStatusID
+		webhook.LastStatusID = lastStatusID
+		q, err := db.NewQuery(tx, webhook, r.Logger)
+		if err != nil {
+			return err
+		}
+		updated, err := q.Update().
+			Where(map[string]interface{}{"ID": webhook.ID, "LastStatusID": previousStatusID}).
+			ExecCount()
+		if err != nil {
+			return err
+		}
+		if updated != 1 {
+			r.Logger.Debugf("WebhookRepo lost race for cluster statuses of webhook '%s': another dispatcher "+
+				"enqueued them", webhook.ID)
+			return nil
+		}
+
+		for _, delivery := range deliveries {
+			delivery.ID = uuid.NewString()
+			delivery.WebhookID = webhook.ID
+			delivery.State = model.WebhookDeliveryStatePending
+			delivery.NextAttempt = time.Now().UTC()
+			delivery.Updated = time.Now().UTC()
+			q, err := db.NewQuery(tx, delivery, r.Logger)
+			if err != nil {
+				return err
+			}
+			if err := q.Insert().Exec(); err != nil {
+				r.Logger.Errorf("WebhookRepo failed to enqueue event of cluster status %d for webhook '%s': %s",
+					delivery.StatusID, webhook.ID, err)
+				return err
+			}
+		}
+		return nil
+	}
+	return r.Transactional(dbOps)
+}
+
+func (r *PersistentRepository) GetPendingDeliveries() ([]*model.WebhookDeliveryEntity, error) {
+	return r.getDeliveries(map[string]interface{}{"State": string(model.WebhookDeliveryStatePending)})
+}
+
+func (r *PersistentRepository) GetDeliveries(webhookID string, state model.WebhookDeliveryState) ([]*model.WebhookDeliveryEntity, error) {
+	return r.getDeliveries(map[string]interface{}{"WebhookID": webhookID, "State": string(state)})
+}
+
+func (r *PersistentRepository) getDeliveries(whereCond map[string]interface{}) ([]*model.WebhookDeliveryEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.WebhookDeliveryEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(whereCond).
+		OrderBy(map[string]string{"StatusID": "ASC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.WebhookDeliveryEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.WebhookDeliveryEntity))
+	}
+	return result, nil
+}
+
+func (r *PersistentRepository) ClaimDelivery(delivery *model.WebhookDeliveryEntity, lease time.Duration) (bool, error) {
+	//the attempts are compared to detect whether another dispatcher claimed the delivery in the meantime
+	previousAttempts := delivery.Attempts
+	delivery.Attempts++
+	delivery.NextAttempt = time.Now().UTC().Add(lease)
+	delivery.Updated = time.Now().UTC()
+	q, err := db.NewQuery(r.Conn, delivery, r.Logger)
+	if err != nil {
+		return false, err
+	}
+	updated, err := q.Update().
+		Where(map[string]interface{}{
+			"ID":       delivery.ID,
+			"State":    string(model.WebhookDeliveryStatePending),
+			"Attempts": previousAttempts,
+		}).
+		ExecCount()
+	if err != nil {
+		return false, err
+	}
+	if updated != 1 {
+		r.Logger.Debugf("WebhookRepo lost race for delivery '%s': another dispatcher claimed it", delivery.ID)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (r *PersistentRepository) UpdateDelivery(delivery *model.WebhookDeliveryEntity) error {
+	delivery.Updated = time.Now().UTC()
+	q, err := db.NewQuery(r.Conn, delivery, r.Logger)
+	if err != nil {
+		return err
+	}
+	return q.Update().
+		Where(map[string]interface{}{"ID": delivery.ID}).
+		Exec()
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb/test"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentRepository(t *testing.T) {
+	dbConn := db.NewTestConnection(t)
+	inventory, err := cluster.NewInventory(dbConn, true, cluster.MetricsCollectorMock{})
+	require.NoError(t, err)
+	repo, err := NewPersistentRepository(dbConn, true)
+	require.NoError(t, err)
+
+	newClusterStatus := func(status model.Status) *cluster.State {
+		state, err := inventory.CreateOrUpdate(1, test.NewCluster(t, uuid.NewString(), 1, false, test.OneComponentDummy))
+		require.NoError(t, err)
+		state, err = inventory.UpdateStatus(state, status)
+		require.NoError(t, err)
+		return state
+	}
+
+	//statuses recorded before the registration are not sent to the webhook
+	newClusterStatus(model.ClusterStatusReady)
+
+	webhook, err := repo.Create(&model.WebhookEntity{
+		URL:             "https://keb.local/events",
+		Secret:          "secret",
+		RuntimeIDFilter: []string{"runtime1"},
+		MetadataFilter:  map[string][]string{"region": {"westeurope"}},
+		StatusFilter:    []model.Status{model.ClusterStatusReady},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, webhook.ID)
+	require.Equal(t, "secret", webhook.Secret)
+	require.Equal(t, []string{"runtime1"}, webhook.RuntimeIDFilter)
+	require.Equal(t, map[string][]string{"region": {"westeurope"}}, webhook.MetadataFilter)
+	require.Equal(t, []model.Status{model.ClusterStatusReady}, webhook.StatusFilter)
+	defer func() {
+		require.NoError(t, repo.Delete(webhook.ID))
+		_, err := repo.Get(webhook.ID)
+		require.True(t, repository.IsNotFoundError(err))
+	}()
+
+	statuses, err := repo.GetClusterStatuses(webhook.LastStatusID, 10)
+	require.NoError(t, err)
+	require.Empty(t, statuses)
+
+	state := newClusterStatus(model.ClusterStatusReconcilePending)
+	statuses, err = repo.GetClusterStatuses(webhook.LastStatusID, 10)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	require.Equal(t, state.Status.ID, statuses[0].ID)
+
+	t.Run("Enqueue and update deliveries", func(t *testing.T) {
+		err := repo.Enqueue(webhook, []*model.WebhookDeliveryEntity{
+			{StatusID: statuses[0].ID, RuntimeID: state.Cluster.RuntimeID, Payload: "{}"},
+		}, statuses[0].ID)
+		require.NoError(t, err)
+
+		webhook, err := repo.Get(webhook.ID)
+		require.NoError(t, err)
+		require.Equal(t, statuses[0].ID, webhook.LastStatusID)
+
+		pending, err := repo.GetPendingDeliveries()
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		require.Equal(t, webhook.ID, pending[0].WebhookID)
+		require.Equal(t, model.WebhookDeliveryStatePending, pending[0].State)
+
+		pending[0].State = model.WebhookDeliveryStateDead
+		pending[0].Attempts = 3
+		pending[0].LastError = "timeout"
+		require.NoError(t, repo.UpdateDelivery(pending[0]))
+
+		pending, err = repo.GetPendingDeliveries()
+		require.NoError(t, err)
+		require.Empty(t, pending)
+
+		dead, err := repo.GetDeliveries(webhook.ID, model.WebhookDeliveryStateDead)
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+		require.Equal(t, int64(3), dead[0].Attempts)
+		require.Equal(t, "timeout", dead[0].LastError)
+	})
+
+	t.Run("Statuses and deliveries are claimed by one dispatcher", func(t *testing.T) {
+		state := newClusterStatus(model.ClusterStatusReady)
+		statuses, err := repo.GetClusterStatuses(state.Status.ID-1, 10)
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+
+		//two dispatchers loaded the same webhook: only the first enqueues the status
+		webhook1, err := repo.Get(webhook.ID)
+		require.NoError(t, err)
+		webhook2, err := repo.Get(webhook.ID)
+		require.NoError(t, err)
+		for _, wh := range []*model.WebhookEntity{webhook1, webhook2} {
+			require.NoError(t, repo.Enqueue(wh, []*model.WebhookDeliveryEntity{
+				{StatusID: statuses[0].ID, RuntimeID: state.Cluster.RuntimeID, Payload: "{}"},
+			}, statuses[0].ID))
+		}
+		pending, err := repo.GetPendingDeliveries()
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+
+		//two dispatchers loaded the same delivery: only the first claims it
+		pending2, err := repo.GetPendingDeliveries()
+		require.NoError(t, err)
+		claimed, err := repo.ClaimDelivery(pending[0], time.Minute)
+		require.NoError(t, err)
+		require.True(t, claimed)
+		require.Equal(t, int64(1), pending[0].Attempts)
+		require.True(t, pending[0].NextAttempt.After(time.Now().UTC()))
+		claimed, err = repo.ClaimDelivery(pending2[0], time.Minute)
+		require.NoError(t, err)
+		require.False(t, claimed)
+	})
+}
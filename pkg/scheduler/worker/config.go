@@ -3,6 +3,8 @@ package worker
 import (
 	"fmt"
 	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 )
 
 const (
@@ -11,6 +13,7 @@ const (
 	defaultOperationCheckInterval = 30 * time.Second
 	defaultInvokerMaxRetries      = 5
 	defaultInvokerRetryDelay      = 5 * time.Second
+	defaultStopTimeout            = 1 * time.Minute
 )
 
 type Config struct {
@@ -19,6 +22,8 @@ type Config struct {
 	OperationCheckInterval time.Duration
 	InvokerMaxRetries      int
 	InvokerRetryDelay      time.Duration
+	Scheduler              *config.SchedulerConfig //weights of clusters and limits of component reconcilers (nil = equal weights and no limits)
+	StopTimeout            time.Duration           //maximal time to wait for running workers when the pool is stopped
 }
 
 func (c *Config) validate() error {
@@ -52,5 +57,11 @@ func (c *Config) validate() error {
 	if c.InvokerRetryDelay == 0 {
 		c.InvokerRetryDelay = defaultInvokerRetryDelay
 	}
+	if c.StopTimeout < 0 {
+		return fmt.Errorf("stop timeout cannot be < 0 (was %.1f sec)", c.StopTimeout.Seconds())
+	}
+	if c.StopTimeout == 0 {
+		c.StopTimeout = defaultStopTimeout
+	}
 	return nil
 }
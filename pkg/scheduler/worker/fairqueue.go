@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"sort"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+)
+
+//fairQueue orders processable operations by weighted fair queuing: the reconciliation with the lowest number of
+//operations in progress relative to its weight is served first. Operations of component reconcilers which have
+//reached their limit of parallel operations are held back until the next check.
+type fairQueue struct {
+	config                  *config.SchedulerConfig
+	weights                 map[string]int //key: schedulingID
+	inProgressPerRecon      map[string]int //key: schedulingID
+	inProgressPerReconciler map[string]int //key: name of component reconciler
+}
+
+//newFairQueue expects all operations of the running reconciliations to determine the current load
+func newFairQueue(cfg *config.SchedulerConfig, reconcilingOps []*model.OperationEntity) *fairQueue {
+	queue := &fairQueue{
+		config:                  cfg,
+		weights:                 make(map[string]int),
+		inProgressPerRecon:      make(map[string]int),
+		inProgressPerReconciler: make(map[string]int),
+	}
+	for _, op := range reconcilingOps {
+		if op.State == model.OperationStateInProgress || op.State == model.OperationStateFailed {
+			queue.inProgressPerRecon[op.SchedulingID]++
+			queue.inProgressPerReconciler[queue.reconciler(op)]++
+		}
+	}
+	return queue
+}
+
+func (q *fairQueue) setWeight(schedulingID string, weight int) {
+	if weight > 0 {
+		q.weights[schedulingID] = weight
+	}
+}
+
+func (q *fairQueue) weight(schedulingID string) int {
+	if weight, ok := q.weights[schedulingID]; ok {
+		return weight
+	}
+	return 1
+}
+
+func (q *fairQueue) reconciler(op *model.OperationEntity) string {
+	if q.config == nil {
+		return config.FallbackComponentReconciler
+	}
+	return q.config.ReconcilerName(op.Component)
+}
+
+func (q *fairQueue) hasCapacity(op *model.OperationEntity) bool {
+	if q.config == nil {
+		return true
+	}
+	reconciler := q.reconciler(op)
+	limit := q.config.Reconcilers[reconciler].MaxParallelOperations
+	return limit == 0 || q.inProgressPerReconciler[reconciler] < limit
+}
+
+//next returns up to 'limit' operations in the order they have to be assigned to workers
+func (q *fairQueue) next(ops []*model.OperationEntity, limit int) []*model.OperationEntity {
+	var schedulingIDs []string
+	opsPerRecon := make(map[string][]*model.OperationEntity) //key: schedulingID
+	for _, op := range ops {
+		if _, ok := opsPerRecon[op.SchedulingID]; !ok {
+			schedulingIDs = append(schedulingIDs, op.SchedulingID)
+		}
+		opsPerRecon[op.SchedulingID] = append(opsPerRecon[op.SchedulingID], op)
+	}
+	//older reconciliations win if the load of reconciliations is equal
+	sort.SliceStable(schedulingIDs, func(i, j int) bool {
+		return opsPerRecon[schedulingIDs[i]][0].Created.Before(opsPerRecon[schedulingIDs[j]][0].Created)
+	})
+
+	var result []*model.OperationEntity
+	for len(result) < limit && len(schedulingIDs) > 0 {
+		idx := q.lowestLoad(schedulingIDs)
+		schedulingID := schedulingIDs[idx]
+
+		op := opsPerRecon[schedulingID][0]
+		opsPerRecon[schedulingID] = opsPerRecon[schedulingID][1:]
+		if q.hasCapacity(op) {
+			result = append(result, op)
+			q.inProgressPerRecon[schedulingID]++
+			q.inProgressPerReconciler[q.reconciler(op)]++
+		}
+
+		if len(opsPerRecon[schedulingID]) == 0 {
+			schedulingIDs = append(schedulingIDs[:idx], schedulingIDs[idx+1:]...)
+		}
+	}
+	return result
+}
+
+//lowestLoad returns the index of the reconciliation with the lowest ratio of operations in progress to its weight
+func (q *fairQueue) lowestLoad(schedulingIDs []string) int {
+	result := 0
+	for idx := 1; idx < len(schedulingIDs); idx++ {
+		candidate, current := schedulingIDs[idx], schedulingIDs[result]
+		//compare inProgress(candidate)/weight(candidate) < inProgress(current)/weight(current) without division
+		if q.inProgressPerRecon[candidate]*q.weight(current) < q.inProgressPerRecon[current]*q.weight(candidate) {
+			result = idx
+		}
+	}
+	return result
+}
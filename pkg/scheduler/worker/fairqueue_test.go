@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newFairQueueOps(schedulingID string, created time.Time, state model.OperationState, components ...string) []*model.OperationEntity {
+	var ops []*model.OperationEntity
+	for idx, component := range components {
+		ops = append(ops, &model.OperationEntity{
+			SchedulingID:  schedulingID,
+			CorrelationID: fmt.Sprintf("%s-%d", schedulingID, idx),
+			Component:     component,
+			State:         state,
+			Created:       created,
+		})
+	}
+	return ops
+}
+
+func schedulingIDs(ops []*model.OperationEntity) []string {
+	var result []string
+	for _, op := range ops {
+		result = append(result, op.SchedulingID)
+	}
+	return result
+}
+
+func TestFairQueue(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Equal weights", func(t *testing.T) {
+		var ops []*model.OperationEntity
+		ops = append(ops, newFairQueueOps("big", now.Add(-2*time.Minute), model.OperationStateNew, "a", "b", "c", "d")...)
+		ops = append(ops, newFairQueueOps("small", now.Add(-1*time.Minute), model.OperationStateNew, "a", "b")...)
+
+		queue := newFairQueue(nil, nil)
+		require.Equal(t, []string{"big", "small", "big", "small", "big"}, schedulingIDs(queue.next(ops, 5)))
+	})
+
+	t.Run("Operations in progress are considered", func(t *testing.T) {
+		var ops []*model.OperationEntity
+		ops = append(ops, newFairQueueOps("big", now.Add(-2*time.Minute), model.OperationStateNew, "a", "b", "c", "d")...)
+		ops = append(ops, newFairQueueOps("small", now.Add(-1*time.Minute), model.OperationStateNew, "a", "b")...)
+
+		queue := newFairQueue(nil, newFairQueueOps("big", now, model.OperationStateInProgress, "x", "y"))
+		require.Equal(t, []string{"small", "small", "big"}, schedulingIDs(queue.next(ops, 3)))
+	})
+
+	t.Run("Weighted reconciliations", func(t *testing.T) {
+		var ops []*model.OperationEntity
+		ops = append(ops, newFairQueueOps("heavy", now.Add(-1*time.Minute), model.OperationStateNew, "a", "b", "c", "d")...)
+		ops = append(ops, newFairQueueOps("light", now.Add(-2*time.Minute), model.OperationStateNew, "a", "b", "c", "d")...)
+
+		queue := newFairQueue(nil, nil)
+		queue.setWeight("heavy", 2)
+		result := queue.next(ops, 6)
+		require.Equal(t, []string{"light", "heavy", "heavy", "light", "heavy", "heavy"}, schedulingIDs(result))
+	})
+
+	t.Run("Limits of component reconcilers", func(t *testing.T) {
+		cfg := &config.SchedulerConfig{
+			Reconcilers: map[string]config.ComponentReconciler{
+				config.FallbackComponentReconciler: {URL: "http://base"},
+				"istio":                            {URL: "http://istio", MaxParallelOperations: 2},
+			},
+		}
+		var ops []*model.OperationEntity
+		ops = append(ops, newFairQueueOps("recon1", now.Add(-2*time.Minute), model.OperationStateNew, "istio", "serverless")...)
+		ops = append(ops, newFairQueueOps("recon2", now.Add(-1*time.Minute), model.OperationStateNew, "istio", "serverless")...)
+
+		queue := newFairQueue(cfg, newFairQueueOps("recon3", now, model.OperationStateInProgress, "istio"))
+		result := queue.next(ops, 10)
+		require.Len(t, result, 3)
+		var istioOps int
+		for _, op := range result {
+			if op.Component == "istio" {
+				istioOps++
+			}
+		}
+		require.Equal(t, 1, istioOps)
+	})
+}
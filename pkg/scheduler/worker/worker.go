@@ -51,13 +51,20 @@ func (w *worker) run(ctx context.Context, clusterState *cluster.State, op *model
 		})
 	}
 
-	//retry calling the invoker if error was returned
+	//retry calling the invoker if error was returned (retries are useless while the circuit of the reconciler is open)
 	err = retry.Do(retryable,
 		retry.Attempts(uint(w.maxRetries)),
 		retry.Delay(w.retryDelay),
 		retry.LastErrorOnly(false),
+		retry.RetryIf(func(err error) bool {
+			return !invoker.IsCircuitOpenError(err)
+		}),
 		retry.Context(ctx))
 
+	if circuitOpen(err) {
+		w.logger.Infof("Worker postpones processing of operation '%s': %s", op, err)
+		return nil
+	}
 	if err == nil {
 		w.logger.Debugf("Worker finished processing of operation '%s' successfully", op)
 	} else {
@@ -67,8 +74,20 @@ func (w *worker) run(ctx context.Context, clusterState *cluster.State, op *model
 	return err
 }
 
+//circuitOpen returns true if the last attempt was rejected because the circuit of the component reconciler is open
+func circuitOpen(err error) bool {
+	if errs, ok := err.(retry.Error); ok {
+		for idx := len(errs) - 1; idx >= 0; idx-- {
+			if errs[idx] != nil {
+				return invoker.IsCircuitOpenError(errs[idx])
+			}
+		}
+	}
+	return invoker.IsCircuitOpenError(err)
+}
+
 func (w *worker) componentsReady(op *model.OperationEntity) ([]string, error) {
-	opsReady, err := w.reconRepo.GetOperations(op.SchedulingID, model.OperationStateDone)
+	opsReady, err := w.reconRepo.GetOperations(op.SchedulingID, model.OperationStateDone, model.OperationStateSkipped)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +100,8 @@ func (w *worker) componentsReady(op *model.OperationEntity) ([]string, error) {
 
 func (w *worker) isProcessable(op *model.OperationEntity) bool {
 	return op.State != model.OperationStateDone &&
+		op.State != model.OperationStateSkipped &&
 		op.State != model.OperationStateError &&
+		op.State != model.OperationStateCancelled &&
 		op.State != model.OperationStateInProgress
 }
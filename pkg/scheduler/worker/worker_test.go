@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/invoker"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+)
+
+//circuitOpenInvoker simulates a component reconciler whose circuit is open
+type circuitOpenInvoker struct {
+	calls int
+}
+
+func (i *circuitOpenInvoker) Invoke(_ context.Context, _ *invoker.Params) error {
+	i.calls++
+	return &invoker.CircuitOpenError{Reconciler: "base", URL: "http://base:8080/v1/run"}
+}
+
+func TestWorkerDoesNotRetryOpenCircuit(t *testing.T) {
+	clusterState := &cluster.State{
+		Cluster: &model.ClusterEntity{
+			RuntimeID: "testCluster",
+			Metadata:  &keb.Metadata{},
+		},
+		Configuration: &model.ClusterConfigurationEntity{
+			RuntimeID:  "testCluster",
+			Components: []*keb.Component{{Component: "component1", Version: "1.2.3"}},
+		},
+		Status: &model.ClusterStatusEntity{
+			RuntimeID: "testCluster",
+			Status:    model.ClusterStatusReconcilePending,
+		},
+	}
+	reconRepo := reconciliation.NewInMemoryReconciliationRepository()
+	reconEntity, err := reconRepo.CreateReconciliation(clusterState, nil, nil)
+	require.NoError(t, err)
+	ops, err := reconRepo.GetOperations(reconEntity.SchedulingID)
+	require.NoError(t, err)
+	require.NotEmpty(t, ops)
+
+	circuitOpenInvoker := &circuitOpenInvoker{}
+	err = (&worker{
+		reconRepo:  reconRepo,
+		invoker:    circuitOpenInvoker,
+		logger:     logger.NewLogger(true),
+		maxRetries: 3,
+		retryDelay: time.Second,
+	}).run(context.Background(), clusterState, ops[0])
+	require.NoError(t, err) //operation is postponed
+	require.Equal(t, 1, circuitOpenInvoker.calls)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/model"
@@ -21,6 +22,9 @@ type Pool struct {
 	invoker   invoker.Invoker
 	config    *Config
 	logger    *zap.SugaredLogger
+	//operations assigned to a worker which didn't finish yet (key: correlationID): as the worker pool gets woken up
+	//by notifications, it could otherwise assign an operation again before the worker updated its state
+	assigned sync.Map
 }
 
 func NewWorkerPool(
@@ -63,6 +67,7 @@ func (w *Pool) run(ctx context.Context, runOnce bool) error {
 	defer func() {
 		w.logger.Info("Stopping worker pool")
 		workerPool.Release()
+		w.waitForWorkers(workerPool)
 	}()
 
 	if runOnce {
@@ -71,26 +76,35 @@ func (w *Pool) run(ctx context.Context, runOnce bool) error {
 	return w.invokeProcessableOpsWithInterval(ctx, workerPool)
 }
 
+//waitForWorkers waits until the running workers are stopped by the closed context: workers which are blocked
+//longer than the stop timeout are abandoned (otherwise a hanging worker would block e.g. a re-election forever)
+func (w *Pool) waitForWorkers(workerPool *ants.PoolWithFunc) {
+	deadline := time.Now().Add(w.config.StopTimeout)
+	for workerPool.Running() > 0 {
+		if time.Now().After(deadline) {
+			w.logger.Warnf("Stopped waiting for %d running workers after %.1f secs: "+
+				"their operations will be detected as orphans by the bookkeeper", workerPool.Running(), w.config.StopTimeout.Seconds())
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
 func (w *Pool) invokeProcessableOpsOnce(ctx context.Context, workerPool *ants.PoolWithFunc) error {
+	subscription := w.reconRepo.Notifier().Subscribe(reconciliation.NotificationOperations)
+	defer subscription.Close()
+
 	//wait until workers are ready
 	ticker := time.NewTicker(1 * time.Second)
 	for {
 		select {
 		case <-ticker.C:
-			runningWorkers := workerPool.Running()
-			if runningWorkers == 0 {
-				w.logger.Debug("Worker pool has no running workers")
-
-				invoked, err := w.invokeProcessableOps(workerPool)
-				if err != nil {
-					return errors.Wrap(err, "worker pool failed to assign processable operations to workers")
-				}
-				if invoked == 0 {
-					w.logger.Debug("Worker pool invoked all processable operations")
-					return nil
-				}
-			} else {
-				w.logger.Debugf("Worker pool is waiting for %d workers to be finished", runningWorkers)
+			if done, err := w.invokeProcessableOpsIfIdle(workerPool); done || err != nil {
+				return err
+			}
+		case <-subscription.C:
+			if done, err := w.invokeProcessableOpsIfIdle(workerPool); done || err != nil {
+				return err
 			}
 		case <-ctx.Done():
 			w.logger.Info("Stopping worker pool because parent context got closed")
@@ -100,6 +114,26 @@ func (w *Pool) invokeProcessableOpsOnce(ctx context.Context, workerPool *ants.Po
 	}
 }
 
+//invokeProcessableOpsIfIdle returns true if no workers are running and no processable operations are left
+func (w *Pool) invokeProcessableOpsIfIdle(workerPool *ants.PoolWithFunc) (bool, error) {
+	runningWorkers := workerPool.Running()
+	if runningWorkers > 0 {
+		w.logger.Debugf("Worker pool is waiting for %d workers to be finished", runningWorkers)
+		return false, nil
+	}
+	w.logger.Debug("Worker pool has no running workers")
+
+	invoked, err := w.invokeProcessableOps(workerPool)
+	if err != nil {
+		return false, errors.Wrap(err, "worker pool failed to assign processable operations to workers")
+	}
+	if invoked == 0 {
+		w.logger.Debug("Worker pool invoked all processable operations")
+		return true, nil
+	}
+	return false, nil
+}
+
 func (w *Pool) startWorkerPool(ctx context.Context) (*ants.PoolWithFunc, error) {
 	w.logger.Infof("Starting worker pool with capacity of %d workers", w.config.PoolSize)
 	return ants.NewPoolWithFunc(w.config.PoolSize, func(op interface{}) {
@@ -108,6 +142,8 @@ func (w *Pool) startWorkerPool(ctx context.Context) (*ants.PoolWithFunc, error)
 }
 
 func (w *Pool) assignWorker(ctx context.Context, opEntity *model.OperationEntity) {
+	defer w.assigned.Delete(opEntity.CorrelationID)
+
 	clusterState, err := w.retriever.Get(opEntity)
 	if err != nil {
 		if repository.IsNotFoundError(err) { // discard the orphaned operation, it will never succeed if the cluster is gone
@@ -145,6 +181,7 @@ func (w *Pool) invokeProcessableOps(workerPool *ants.PoolWithFunc) (int, error)
 		w.logger.Warnf("Worker pool failed to retrieve processable operations: %s", err)
 		return 0, err
 	}
+	ops = w.unassigned(ops)
 
 	opsCnt := len(ops)
 	w.logger.Debugf("Worker pool found %d processable operations: %s", opsCnt, func() string {
@@ -158,15 +195,28 @@ func (w *Pool) invokeProcessableOps(workerPool *ants.PoolWithFunc) (int, error)
 		return 0, nil
 	}
 
+	ops, err = w.queue(ops, workerPool.Free())
+	if err != nil {
+		w.logger.Warnf("Worker pool failed to queue processable operations: %s", err)
+		return 0, err
+	}
+	if len(ops) < opsCnt {
+		w.logger.Debugf("Worker pool postponed %d processable operations because of missing worker capacity "+
+			"or limits of component reconcilers", opsCnt-len(ops))
+		opsCnt = len(ops)
+	}
+
 	for idx, op := range ops {
 		if workerPool.Free() == 0 {
 			w.logger.Warnf("could not assign worker to operation '%s': workerpool capacity reached: capacity=%d", op, workerPool.Cap())
 			break
 		}
+		w.assigned.Store(op.CorrelationID, true)
 		if err := workerPool.Invoke(op); err == nil {
 			w.logger.Infof("Worker pool assigned worker to reconcile component '%s' on cluster '%s' (%s)",
 				op.Component, op.RuntimeID, op)
 		} else {
+			w.assigned.Delete(op.CorrelationID)
 			w.logger.Warnf("Worker pool failed to assign worker to operation '%s': %s", op, err)
 			return idx + 1, err
 		}
@@ -176,10 +226,54 @@ func (w *Pool) invokeProcessableOps(workerPool *ants.PoolWithFunc) (int, error)
 	return opsCnt, nil
 }
 
+//unassigned drops the operations which are still processed by a worker
+func (w *Pool) unassigned(ops []*model.OperationEntity) []*model.OperationEntity {
+	var result []*model.OperationEntity
+	for _, op := range ops {
+		if _, ok := w.assigned.Load(op.CorrelationID); ok {
+			w.logger.Debugf("Worker pool ignores operation '%s' because it is already assigned to a worker", op)
+			continue
+		}
+		result = append(result, op)
+	}
+	return result
+}
+
+//queue orders the processable operations by the weights of their clusters and drops operations which exceed
+//the free workers or the limits of the component reconcilers
+func (w *Pool) queue(ops []*model.OperationEntity, freeWorkers int) ([]*model.OperationEntity, error) {
+	reconcilingOps, err := w.reconRepo.GetReconcilingOperations()
+	if err != nil {
+		return nil, err
+	}
+	queue := newFairQueue(w.config.Scheduler, reconcilingOps)
+	if w.config.Scheduler != nil {
+		weighted := make(map[string]bool) //key: schedulingID
+		for _, op := range ops {
+			if weighted[op.SchedulingID] {
+				continue
+			}
+			weighted[op.SchedulingID] = true
+			clusterState, err := w.retriever.Get(op)
+			if err != nil { //cluster state is verified again when the worker gets assigned
+				w.logger.Debugf("Worker pool uses default weight for reconciliation '%s' because state "+
+					"of cluster '%s' could not be retrieved: %s", op.SchedulingID, op.RuntimeID, err)
+				continue
+			}
+			queue.setWeight(op.SchedulingID, w.config.Scheduler.FairQueuing.Weight(clusterState.Cluster.Metadata))
+		}
+	}
+	return queue.next(ops, freeWorkers), nil
+}
+
 func (w *Pool) invokeProcessableOpsWithInterval(ctx context.Context, workerPool *ants.PoolWithFunc) error {
 	w.logger.Debugf("Worker pool starts watching for processable operations each %.1f secs",
 		w.config.OperationCheckInterval.Seconds())
 
+	//operations become processable when they were created or other operations changed their state
+	subscription := w.reconRepo.Notifier().Subscribe(reconciliation.NotificationOperations)
+	defer subscription.Close()
+
 	//check now otherwise first check would happen by ticker (after the configured interval is over)
 	if _, err := w.invokeProcessableOps(workerPool); err != nil {
 		return err
@@ -194,6 +288,12 @@ func (w *Pool) invokeProcessableOpsWithInterval(ctx context.Context, workerPool
 					"but will retry after %.1f seconds again",
 					w.config.OperationCheckInterval.Seconds())
 			}
+		case <-subscription.C:
+			if _, err := w.invokeProcessableOps(workerPool); err != nil {
+				w.logger.Warnf("Worker pool failed to invoke processable operations after it was notified "+
+					"but will retry after %.1f seconds again",
+					w.config.OperationCheckInterval.Seconds())
+			}
 		case <-ctx.Done():
 			w.logger.Info("Worker pool is stopping interval checks of processable operations " +
 				"because parent context got closed")
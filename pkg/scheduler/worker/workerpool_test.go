@@ -16,6 +16,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/invoker"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/kyma-incubator/reconciler/pkg/test"
+	"github.com/panjf2000/ants/v2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -58,7 +59,7 @@ func TestWorkerPool(t *testing.T) {
 
 	//create reconciliation for cluster
 	testInvoker.reconRepo = reconciliation.NewInMemoryReconciliationRepository()
-	reconEntity, err := testInvoker.reconRepo.CreateReconciliation(clusterState, nil)
+	reconEntity, err := testInvoker.reconRepo.CreateReconciliation(clusterState, nil, nil)
 	require.NoError(t, err)
 	opsProcessable, err := testInvoker.reconRepo.GetProcessableOperations(0)
 	require.Len(t, opsProcessable, 1)
@@ -131,7 +132,7 @@ func TestWorkerPoolParallel(t *testing.T) {
 		testInvoker.reconRepo, err = reconciliation.NewPersistedReconciliationRepository(testDB, true)
 		require.NoError(t, err)
 		for i := range clusterStates {
-			_, err = testInvoker.reconRepo.CreateReconciliation(clusterStates[i], nil)
+			_, err = testInvoker.reconRepo.CreateReconciliation(clusterStates[i], nil, nil)
 		}
 		require.NoError(t, err)
 		opsProcessable, err := testInvoker.reconRepo.GetProcessableOperations(0)
@@ -173,3 +174,38 @@ func TestWorkerPoolParallel(t *testing.T) {
 		}
 	})
 }
+
+func TestWorkerPoolIgnoresAssignedOperations(t *testing.T) {
+	workerPool, err := NewWorkerPool(nil, &reconciliation.MockRepository{}, nil, nil, logger.NewLogger(true))
+	require.NoError(t, err)
+
+	ops := []*model.OperationEntity{
+		{CorrelationID: "correlation1"},
+		{CorrelationID: "correlation2"},
+	}
+	workerPool.assigned.Store("correlation1", true)
+	require.Equal(t, ops[1:], workerPool.unassigned(ops))
+
+	workerPool.assigned.Delete("correlation1")
+	require.Equal(t, ops, workerPool.unassigned(ops))
+}
+
+func TestWorkerPoolStopTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	//worker which ignores the closed context
+	antsPool, err := ants.NewPoolWithFunc(1, func(interface{}) {
+		<-release
+	})
+	require.NoError(t, err)
+	require.NoError(t, antsPool.Invoke(struct{}{}))
+
+	pool := &Pool{config: &Config{StopTimeout: 200 * time.Millisecond}, logger: logger.NewLogger(true)}
+	antsPool.Release()
+	start := time.Now()
+	pool.waitForWorkers(antsPool)
+	require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.Equal(t, 1, antsPool.Running())
+}
@@ -62,6 +62,12 @@ func (p *Params) StrSlice(name string) ([]string, error) {
 	return nil, p.newUndefinedErr(name)
 }
 
+//Exists returns true if the parameter is defined as path or query parameter
+func (p *Params) Exists(name string) bool {
+	_, ok := p.params[name]
+	return ok || p.queryParamExists(name)
+}
+
 func (p *Params) newUndefinedErr(name string) error {
 	return fmt.Errorf("parameter '%s' undefined", name)
 }
@@ -63,6 +63,9 @@ func TestParams(t *testing.T) {
 		i64, err := params.Int64("int64")
 		require.NoError(t, err)
 		require.Equal(t, int64(987), i64)
+		require.True(t, params.Exists("string"))
+		require.True(t, params.Exists("strSlice"))
+		require.False(t, params.Exists("undefined"))
 	})
 }
 